@@ -39,6 +39,7 @@ var App struct {
 	BizFile        string                     // name of csv file with new biz info
 	BldgFile       string                     // Buildings for this Business
 	BUD            string                     // business unit designator
+	BudgetFile     string                     // monthly GL Account budgets
 	CoaFile        string                     // chart of accounts
 	CustomFile     string                     // custom attributes
 	DBDir          string                     // phonebook database
@@ -48,6 +49,7 @@ var App struct {
 	DepositoryFile string                     // Depository
 	DMFile         string                     // Deposit Methods
 	InvoiceFile    string                     // Invoice
+	LeadSrcFile    string                     // LeadSources
 	NoteTypeFile   string                     // note types
 	PetFile        string                     // assign pets
 	PmtTypeFile    string                     // payment types
@@ -64,6 +66,7 @@ var App struct {
 	RPRSPRateFile  string                     // RatePlanRefSPRate
 	RSpFile        string                     // Rentable specialties
 	RspRefsFile    string                     // assign specialties to rentables
+	RTBudgetFile   string                     // monthly RentableType GSR and occupancy targets
 	RTFile         string                     // Rentable types csv file
 	SLFile         string                     // StringLists
 	SrcFile        string                     // Sources
@@ -91,6 +94,9 @@ func readCommandLineArgs() {
 	pBUD := flag.String("G", "", "BUD - business unit designator")
 	pAD := flag.String("H", "", "add Account Depositories via csv file")
 	invPtr := flag.String("i", "", "add Invoices via csv file")
+	budgetPtr := flag.String("J", "", "add GL Account budgets via csv file")
+	rtbudgetPtr := flag.String("j", "", "add RentableType GSR and occupancy targets via csv file")
+	leadsrc := flag.String("K", "", "add LeadSources via csv file")
 	lptr := flag.String("L", "", "Report: 1-jnl, 2-ldg, 3-biz, 4-asmtypes, 5-rtypes, 6-rentables, 7-people, 8-rat, 9-ra, 10-coa, 11-asm, 12-payment types, 13-receipts, 14-CustAttr, 15-CustAttrRef, 16-Pets, 17-NoteTypes, 18-Depositories, 19-Deposits, 20-Invoices, 21-Specialties, 22-Specialty Assignments, 23-Deposit Methods, 24-Sources, 25-StringList, 26-RatePlan, 27-RatePlanRef,BUD,RatePlanName, 28-BUD")
 	slPtr := flag.String("l", "", "add StringLists via csv file")
 	dbrrPtr := flag.String("M", "rentroll", "database name (rentroll)")
@@ -123,6 +129,7 @@ func readCommandLineArgs() {
 	App.AssignFile = *asgnPtr
 	App.BizFile = *bizPtr
 	App.BldgFile = *bldgPtr
+	App.BudgetFile = *budgetPtr
 	App.CoaFile = *coaPtr
 	App.CustomFile = *custPtr
 	App.DBDir = *dbnmPtr
@@ -132,6 +139,7 @@ func readCommandLineArgs() {
 	App.DepositoryFile = *depositoryPtr
 	App.DMFile = *dmPtr
 	App.InvoiceFile = *invPtr
+	App.LeadSrcFile = *leadsrc
 	App.NoteTypeFile = *ntPtr
 	App.PetFile = *petPtr
 	App.PmtTypeFile = *pmtPtr
@@ -147,6 +155,7 @@ func readCommandLineArgs() {
 	App.RPRSPRateFile = *rprsp
 	App.RSpFile = *rspPtr
 	App.RspRefsFile = *rsrefsPtr
+	App.RTBudgetFile = *rtbudgetPtr
 	App.RTFile = *rtPtr
 	App.SLFile = *slPtr
 	App.SrcFile = *src
@@ -278,6 +287,7 @@ func main() {
 		{Fname: App.PmtTypeFile, Handler: rcsv.LoadPaymentTypesCSV},
 		{Fname: App.DMFile, Handler: rcsv.LoadDepositMethodsCSV},
 		{Fname: App.SrcFile, Handler: rcsv.LoadSourcesCSV},
+		{Fname: App.LeadSrcFile, Handler: rcsv.LoadLeadSourcesCSV},
 		{Fname: App.RTFile, Handler: rcsv.LoadRentableTypesCSV},
 		{Fname: App.CustomFile, Handler: rcsv.LoadCustomAttributesCSV},
 		{Fname: App.DepositoryFile, Handler: rcsv.LoadDepositoryCSV},
@@ -292,6 +302,8 @@ func main() {
 		{Fname: App.PetFile, Handler: rcsv.LoadPetsCSV},
 		{Fname: App.CoaFile, Handler: rcsv.LoadChartOfAccountsCSV},
 		{Fname: App.ARFile, Handler: rcsv.LoadARCSV},
+		{Fname: App.BudgetFile, Handler: rcsv.LoadBudgetsCSV},
+		{Fname: App.RTBudgetFile, Handler: rcsv.LoadRentableTypeBudgetsCSV},
 		{Fname: App.RPFile, Handler: rcsv.LoadRatePlansCSV},
 		{Fname: App.RPRefFile, Handler: rcsv.LoadRatePlanRefsCSV},
 		{Fname: App.RPRRTRateFile, Handler: rcsv.LoadRatePlanRefRTRatesCSV},
@@ -0,0 +1,149 @@
+package bizlogic
+
+import (
+	"fmt"
+	"rentroll/rlib"
+	"time"
+)
+
+// Adjustment describes a correction to a Journal entry in a closed period.
+// The correcting entry is posted on Dt, which must be in an open period.
+type Adjustment struct {
+	BID     int64     // business
+	JID     int64     // the closed-period Journal entry being corrected, or...
+	ASMID   int64     // ...the closed-period Assessment being corrected
+	ARID    int64     // account rule for the correction. If 0, the original entry is reversed
+	Amount  float64   // amount of the correction, a negative amount swaps debit and credit
+	Dt      time.Time // date of the correcting entry
+	Comment string    // reason for the adjustment
+	UID     int64     // user posting the adjustment
+}
+
+// reverseAcctRule returns an account rule that undoes the supplied rule by
+// swapping its debits and credits.
+func reverseAcctRule(xbiz *rlib.XBusiness, ja *rlib.JournalAllocation, dt *time.Time) string {
+	s := ""
+	m := rlib.ParseAcctRule(xbiz, ja.RID, dt, dt, ja.AcctRule, ja.Amount, 1.0)
+	for i := 0; i < len(m); i++ {
+		action := "c"
+		if m[i].Action == "c" {
+			action = "d"
+		}
+		if len(s) > 0 {
+			s += ", "
+		}
+		s += fmt.Sprintf("%s %s %.2f", action, m[i].Account, m[i].Amount)
+	}
+	return s
+}
+
+// PostAdjustment posts a correcting Journal entry in the current open period
+// for a Journal entry or Assessment in a closed period. The new Journal has
+// Type JNLTYPEADJ and its ID is the JID of the entry it corrects. Ledger
+// entries are generated for it and all later LedgerMarkers are recomputed.
+//
+// INPUTS
+//    adj = the adjustment to post
+//
+// RETURNS
+//    the adjustment Journal entry
+//    a slice of BizErrors
+//-------------------------------------------------------------------------------------
+func PostAdjustment(adj *Adjustment) (rlib.Journal, []BizError) {
+	var jnl rlib.Journal
+	var errlist []BizError
+
+	if errlist = ValidatePeriodOpen(adj.BID, &adj.Dt); len(errlist) > 0 {
+		return jnl, errlist
+	}
+
+	//------------------------------------------------
+	// find the entry being corrected
+	//------------------------------------------------
+	var jorig rlib.Journal
+	if adj.JID > 0 {
+		jorig = rlib.GetJournal(adj.JID)
+	} else if adj.ASMID > 0 {
+		jorig = rlib.GetJournalByASMID(adj.ASMID)
+	}
+	if jorig.JID == 0 || jorig.BID != adj.BID {
+		msg := BizErrors[InvalidField].Message + "\nJournal entry or Assessment to adjust"
+		errlist = append(errlist, BizError{Errno: InvalidField, Message: msg})
+		return jnl, errlist
+	}
+	if !rlib.IsClosedPeriod(jorig.BID, &jorig.Dt) {
+		errlist = append(errlist, BizErrors[AdjustOpenPeriod])
+		return jnl, errlist
+	}
+	rlib.GetJournalAllocations(&jorig)
+
+	var xbiz rlib.XBusiness
+	rlib.InitBizInternals(adj.BID, &xbiz)
+
+	//------------------------------------------------
+	// build the correcting allocations
+	//------------------------------------------------
+	var ja []rlib.JournalAllocation
+	if adj.ARID > 0 {
+		ar, err := rlib.GetAR(adj.ARID)
+		if err != nil || ar.BID != adj.BID || adj.Amount == float64(0) {
+			msg := BizErrors[InvalidField].Message + "\nAccount Rule\nAmount"
+			errlist = append(errlist, BizError{Errno: InvalidField, Message: msg})
+			return jnl, errlist
+		}
+		d := rlib.RRdb.BizTypes[adj.BID].GLAccounts[ar.DebitLID]
+		c := rlib.RRdb.BizTypes[adj.BID].GLAccounts[ar.CreditLID]
+		amt := rlib.RoundToCent(adj.Amount)
+		if amt < 0 {
+			d, c = c, d
+			amt = -amt
+		}
+		a := rlib.JournalAllocation{BID: adj.BID, Amount: amt, AcctRule: fmt.Sprintf("d %s %.2f, c %s %.2f", d.GLNumber, amt, c.GLNumber, amt)}
+		if len(jorig.JA) > 0 {
+			a.RID = jorig.JA[0].RID
+			a.RAID = jorig.JA[0].RAID
+			a.TCID = jorig.JA[0].TCID
+			a.ASMID = jorig.JA[0].ASMID
+		}
+		ja = append(ja, a)
+	} else {
+		for i := 0; i < len(jorig.JA); i++ {
+			a := jorig.JA[i]
+			a.JAID = 0
+			a.AcctRule = reverseAcctRule(&xbiz, &jorig.JA[i], &jorig.Dt)
+			ja = append(ja, a)
+		}
+	}
+
+	//------------------------------------------------
+	// save the Journal entry
+	//------------------------------------------------
+	jnl = rlib.Journal{BID: adj.BID, Dt: adj.Dt, Type: rlib.JNLTYPEADJ, ID: jorig.JID, Comment: adj.Comment, CreateBy: adj.UID, LastModBy: adj.UID}
+	for i := 0; i < len(ja); i++ {
+		jnl.Amount += ja[i].Amount
+	}
+	_, err := rlib.InsertJournal(&jnl)
+	if err != nil {
+		return jnl, bizErrSys(&err)
+	}
+	for i := 0; i < len(ja); i++ {
+		ja[i].JID = jnl.JID
+		ja[i].CreateBy = adj.UID
+		if err = rlib.InsertJournalAllocationEntry(&ja[i]); err != nil {
+			return jnl, bizErrSys(&err)
+		}
+		jnl.JA = append(jnl.JA, ja[i])
+	}
+
+	//------------------------------------------------
+	// Add it to the Ledgers, then bring forward the
+	// balances of any later LedgerMarkers
+	//------------------------------------------------
+	d1, d2 := rlib.GetMonthPeriodForDate(&adj.Dt)
+	rlib.InitLedgerCache()
+	rlib.GenerateLedgerEntriesFromJournal(&xbiz, &jnl, &d1, &d2)
+	if err = rlib.UpdateLedgerMarkersAfter(&xbiz, &adj.Dt, adj.UID); err != nil {
+		return jnl, bizErrSys(&err)
+	}
+	return jnl, nil
+}
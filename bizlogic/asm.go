@@ -13,11 +13,12 @@ import (
 //    a = the assessment to insert
 //  exp = if it is a recurring assessment and the start date is in the past, should
 //        past entries be created?  true = yes
+//  uid = the user making the change
 //
 // RETURNS
 //    a slice of BizErrors
 //-------------------------------------------------------------------------------------
-func UpdateAssessment(anew *rlib.Assessment, mode int, dt *time.Time, exp int) []BizError {
+func UpdateAssessment(anew *rlib.Assessment, mode int, dt *time.Time, exp int, uid int64) []BizError {
 	var err error
 	var errlist []BizError
 
@@ -28,6 +29,9 @@ func UpdateAssessment(anew *rlib.Assessment, mode int, dt *time.Time, exp int) [
 		errlist = append(errlist, BizErrors[EditReversal])
 		return errlist
 	}
+	if errlist = ValidatePeriodOpen(anew.BID, &anew.Start); len(errlist) > 0 {
+		return errlist
+	}
 	//-------------------------------
 	// Load existing assessment...
 	//-------------------------------
@@ -61,11 +65,11 @@ func UpdateAssessment(anew *rlib.Assessment, mode int, dt *time.Time, exp int) [
 		(!aold.Start.Equal(anew.Start)) ||
 		(!aold.Stop.Equal(anew.Stop))
 	if reverse {
-		errlist = ReverseAssessment(&aold, mode, dt) // reverse the assessment itself
+		errlist = ReverseAssessment(&aold, mode, dt, uid) // reverse the assessment itself
 		if errlist != nil {
 			return errlist
 		}
-		errlist = InsertAssessment(anew, exp) // Finally, insert the new assessment...
+		errlist = InsertAssessment(anew, exp, uid) // Finally, insert the new assessment...
 		if err != nil {
 			return errlist
 		}
@@ -86,11 +90,13 @@ func UpdateAssessment(anew *rlib.Assessment, mode int, dt *time.Time, exp int) [
 //           0: just reverse this instance
 //           1: reverse this and future instances
 //           2: reverse all instances
+//    dt   = the time to mark for the reversal
+//    uid  = the user making the reversal
 //
 // RETURNS
 //    a slice of BizErrors
 //-------------------------------------------------------------------------------------
-func ReverseAssessment(aold *rlib.Assessment, mode int, dt *time.Time) []BizError {
+func ReverseAssessment(aold *rlib.Assessment, mode int, dt *time.Time, uid int64) []BizError {
 	funcname := "bizlogic.ReverseAssessment"
 	var errlist []BizError
 	fmt.Printf("Entered ReverseAssessment\n")
@@ -99,9 +105,9 @@ func ReverseAssessment(aold *rlib.Assessment, mode int, dt *time.Time) []BizErro
 	}
 	switch mode {
 	case 0:
-		errlist = ReverseAssessmentInstance(aold, dt)
+		errlist = ReverseAssessmentInstance(aold, dt, uid)
 	case 1:
-		errlist = ReverseAssessmentsGoingForward(aold, &aold.Start, dt)
+		errlist = ReverseAssessmentsGoingForward(aold, &aold.Start, dt, uid)
 	case 2:
 		var epoch, inst rlib.Assessment
 		var err error
@@ -122,7 +128,7 @@ func ReverseAssessment(aold *rlib.Assessment, mode int, dt *time.Time) []BizErro
 		// If it is not recurring then reverse it and we're done
 		//---------------------------------------------------------
 		if epoch.RentCycle == rlib.RECURNONE {
-			return ReverseAssessmentInstance(&epoch, dt)
+			return ReverseAssessmentInstance(&epoch, dt, uid)
 		}
 
 		//---------------------------------------------------------
@@ -132,11 +138,12 @@ func ReverseAssessment(aold *rlib.Assessment, mode int, dt *time.Time) []BizErro
 		if err != nil {
 			return bizErrSys(&err)
 		}
-		errlist = ReverseAssessmentsGoingForward(&inst, &inst.Start, dt) // reverse from start of recurring instances forward
+		errlist = ReverseAssessmentsGoingForward(&inst, &inst.Start, dt, uid) // reverse from start of recurring instances forward
 		if len(errlist) > 0 {
 			return errlist
 		}
 		epoch.FLAGS |= 0x4 // mark that this is void
+		epoch.LastModBy = uid
 		err = rlib.UpdateAssessment(&epoch)
 		if err != nil {
 			return bizErrSys(&err)
@@ -153,12 +160,15 @@ func ReverseAssessment(aold *rlib.Assessment, mode int, dt *time.Time) []BizErro
 // ReverseAssessmentsGoingForward reverses an existing assessment
 //
 // INPUTS
-//    aold = the first in a series of assessments to reverse
+//    aold    = the first in a series of assessments to reverse
+//    dtStart = reverse the instances from this date forward
+//    dt      = the time to mark for the reversal
+//    uid     = the user making the reversal
 //
 // RETURNS
 //    a slice of BizErrors
 //-------------------------------------------------------------------------------------
-func ReverseAssessmentsGoingForward(aold *rlib.Assessment, dtStart, dt *time.Time) []BizError {
+func ReverseAssessmentsGoingForward(aold *rlib.Assessment, dtStart, dt *time.Time, uid int64) []BizError {
 	var errlist []BizError
 
 	fmt.Printf("ENTERED: ReverseAssessmentsGoingForward\n")
@@ -169,7 +179,7 @@ func ReverseAssessmentsGoingForward(aold *rlib.Assessment, dtStart, dt *time.Tim
 	m := rlib.GetAssessmentInstancesByParent(aold.PASMID, dtStart, &d2)
 	fmt.Printf("Number of instances to reverse: %d\n", len(m))
 	for i := 0; i < len(m); i++ {
-		errlist = ReverseAssessmentInstance(&m[i], dt)
+		errlist = ReverseAssessmentInstance(&m[i], dt, uid)
 		if len(errlist) > 0 {
 			return errlist
 		}
@@ -183,11 +193,12 @@ func ReverseAssessmentsGoingForward(aold *rlib.Assessment, dtStart, dt *time.Tim
 // INPUTS
 //    aold = the assessment to reverse
 //      dt = the time to mark for the reversal (when it was made)
+//     uid = the user making the reversal
 //
 // RETURNS
 //    a slice of BizErrors
 //-------------------------------------------------------------------------------------
-func ReverseAssessmentInstance(aold *rlib.Assessment, dt *time.Time) []BizError {
+func ReverseAssessmentInstance(aold *rlib.Assessment, dt *time.Time, uid int64) []BizError {
 	if aold.FLAGS&0x4 != 0 {
 		return nil // it's already reversed
 	}
@@ -198,23 +209,36 @@ func ReverseAssessmentInstance(aold *rlib.Assessment, dt *time.Time) []BizError
 	anew.RPASMID = aold.ASMID
 	anew.FLAGS |= 0x4 // set bit 2 to mark that this assessment is void
 	anew.Comment = fmt.Sprintf("Reversal of %s", aold.IDtoString())
+	anew.CreateBy = uid
+	anew.LastModBy = uid
 
-	errlist := InsertAssessment(&anew, 1)
+	errlist := InsertAssessment(&anew, 1, uid)
 	if len(errlist) > 0 {
 		return errlist
 	}
 
 	aold.Comment = fmt.Sprintf("Reversed by %s", anew.IDtoString())
 	aold.FLAGS |= 0x4 // set bit 2 to mark that this assessment is void
+	aold.LastModBy = uid
 	err := rlib.UpdateAssessment(aold)
 	if err != nil {
 		return bizErrSys(&err)
 	}
 
-	err = DeallocateAppliedFunds(aold, anew.ASMID, dt)
+	err = DeallocateAppliedFunds(aold, anew.ASMID, dt, uid)
 	if err != nil {
 		return bizErrSys(&err)
 	}
+
+	//------------------------------------------------
+	// reverse the taxes that were charged on it
+	//------------------------------------------------
+	t := rlib.GetAssessmentTaxCharges(aold.ASMID)
+	for i := 0; i < len(t); i++ {
+		if errlist = ReverseAssessmentInstance(&t[i], dt, uid); len(errlist) > 0 {
+			return errlist
+		}
+	}
 	return nil
 }
 
@@ -223,11 +247,12 @@ func ReverseAssessmentInstance(aold *rlib.Assessment, dt *time.Time) []BizError
 //    a         = receipt to be voided
 //    asmtRevID = ASMID of the reversal assessment
 //    dt        = time we want the funds to be marked as deallocated
+//    uid       = the user deallocating the funds
 //
 // RETURNS
 //    any error that occurred, or nil if no error
 //-------------------------------------------------------------------------------
-func DeallocateAppliedFunds(a *rlib.Assessment, asmtRevID int64, dt *time.Time) error {
+func DeallocateAppliedFunds(a *rlib.Assessment, asmtRevID int64, dt *time.Time, uid int64) error {
 	funcname := "bizlogic.DeallocateAppliedFunds"
 	//--------------------------------------------------------------
 	// Find all JournalAllocations that reference Assessment a that
@@ -245,11 +270,13 @@ func DeallocateAppliedFunds(a *rlib.Assessment, asmtRevID int64, dt *time.Time)
 		// Reverse the Journal Entry...
 		//--------------------------------
 		var jnl = rlib.Journal{
-			BID:    rcpt.BID,
-			Amount: -JA[i].Amount, // reverse the amount
-			Type:   rlib.JNLTYPEASMT,
-			ID:     asmtRevID, // this is the rcptid of the reversal receipt
-			Dt:     *dt,       // reversal date
+			BID:       rcpt.BID,
+			Amount:    -JA[i].Amount, // reverse the amount
+			Type:      rlib.JNLTYPEASMT,
+			ID:        asmtRevID, // this is the rcptid of the reversal receipt
+			Dt:        *dt,       // reversal date
+			CreateBy:  uid,
+			LastModBy: uid,
 		}
 		_, err := rlib.InsertJournal(&jnl)
 		if err != nil {
@@ -295,6 +322,8 @@ func DeallocateAppliedFunds(a *rlib.Assessment, asmtRevID int64, dt *time.Time)
 			nle.JAID = ja.JAID       // our newly created reversing Journal Allocation
 			nle.JID = ja.JID         // which is tied to the reversing Journal entry
 			nle.Amount = -nle.Amount // this reverses the amount
+			nle.CreateBy = uid
+			nle.LastModBy = uid
 			_, err = rlib.InsertLedgerEntry(&nle)
 			if err != nil {
 				rlib.LogAndPrintError(funcname, err)
@@ -313,6 +342,8 @@ func DeallocateAppliedFunds(a *rlib.Assessment, asmtRevID int64, dt *time.Time)
 			vra.AcctRule = acctrule
 			vra.Dt = *dt
 			vra.RAID = ja.RAID
+			vra.CreateBy = uid
+			vra.LastModBy = uid
 			_, err = rlib.InsertReceiptAllocation(&vra)
 			if err != nil {
 				return err
@@ -354,6 +385,7 @@ func DeallocateAppliedFunds(a *rlib.Assessment, asmtRevID int64, dt *time.Time)
 		rcpt.FLAGS &= ^(uint64(0x3)) // remove whatever status was there before
 		rcpt.FLAGS |= f              // 0 = the entire amount is available, 1 = some is still available
 		rcpt.AcctRuleApply = rar
+		rcpt.LastModBy = uid
 		rlib.UpdateReceipt(&rcpt)
 
 		//-------------------------------------------------------------------------
@@ -384,11 +416,12 @@ func DeallocateAppliedFunds(a *rlib.Assessment, asmtRevID int64, dt *time.Time)
 //    a = the assessment to insert
 //  exp = if it is a recurring assessment and the start date is in the past, should
 //        past entries be created?  true = yes
+//  uid = the user inserting the assessment, 0 if it is a worker
 //
 // RETURNS
 //    a slice of BizErrors
 //-------------------------------------------------------------------------------------
-func InsertAssessment(a *rlib.Assessment, exp int) []BizError {
+func InsertAssessment(a *rlib.Assessment, exp int, uid int64) []BizError {
 	var errlist []BizError
 	errlist = ValidateAssessment(a) // Make sure there are no bizlogic errors before saving
 	if len(errlist) > 0 {
@@ -407,14 +440,17 @@ func InsertAssessment(a *rlib.Assessment, exp int) []BizError {
 	d1, d2 := rlib.GetMonthPeriodForDate(&a.Start) // TODO: probably needs to be more generalized
 	rlib.InitLedgerCache()
 	if a.RentCycle == rlib.RECURNONE { // for nonrecurring, use existng struct: a
-		rlib.ProcessJournalEntry(a, &xbiz, &d1, &d2, true)
+		err = rlib.ProcessJournalEntry(a, &xbiz, &d1, &d2, true, uid)
 	} else if exp != 0 && a.PASMID == 0 { // only expand if we're asked and if we're not an instance
 		now := rlib.DateAtTimeZero(time.Now())
 		dt := rlib.DateAtTimeZero(a.Start)
 		if !dt.After(now) {
-			createInstancesToDate(a, &xbiz)
+			err = createInstancesToDate(a, &xbiz, uid)
 		}
 	}
+	if err != nil {
+		return bizErrSys(&err)
+	}
 	return nil
 }
 
@@ -429,6 +465,9 @@ func InsertAssessment(a *rlib.Assessment, exp int) []BizError {
 //-------------------------------------------------------------------------------------
 func ValidateAssessment(a *rlib.Assessment) []BizError {
 	var e []BizError
+	if e = ValidatePeriodOpen(a.BID, &a.Start); len(e) > 0 {
+		return e
+	}
 	if a.RID > 0 {
 		//--------------------------------------------------------------------------
 		//  Check for assessment timeframe prior to or after Rentable's type being defined
@@ -469,16 +508,59 @@ func ValidateAssessment(a *rlib.Assessment) []BizError {
 // INPUTS
 //    a = the recurring assessment
 // xbiz = Business information
+//  uid = the user creating the instances
 //
 // RETURNS
-//
+//    any error encountered
 //-------------------------------------------------------------------------------------
-func createInstancesToDate(a *rlib.Assessment, xbiz *rlib.XBusiness) {
+func createInstancesToDate(a *rlib.Assessment, xbiz *rlib.XBusiness, uid int64) error {
 	now := time.Now()
 	as := time.Date(a.Start.Year(), a.Start.Month(), a.Start.Day(), 0, 0, 0, 0, time.UTC)
 	m := rlib.GetRecurrences(&a.Start, &a.Stop, &as, &now, a.RentCycle) // get all from the begining up to now
 	for i := 0; i < len(m); i++ {
 		dt1, dt2 := rlib.GetMonthPeriodForDate(&m[i])
-		rlib.ProcessJournalEntry(a, xbiz, &dt1, &dt2, true) // this generates the assessment instances
+		if err := rlib.ProcessJournalEntry(a, xbiz, &dt1, &dt2, true, uid); err != nil { // this generates the assessment instances
+			return err
+		}
+	}
+	return nil
+}
+
+// removeAssessmentJournals undoes the journaling of assessment a. The Journal
+// entries of a and of its instances are removed along with their allocations
+// and ledger entries, as are the instances and the tax charges booked with
+// them. It is used to back out an assessment that has just been made.
+//
+// INPUTS
+//    a   = the assessment
+//    uid = the user backing it out
+//-------------------------------------------------------------------------------------
+func removeAssessmentJournals(a *rlib.Assessment, uid int64) {
+	ids := []int64{a.ASMID}
+	d2 := a.Stop.AddDate(0, 0, 1)
+	m := rlib.GetAssessmentInstancesByParent(a.ASMID, &a.Start, &d2)
+	for i := 0; i < len(m); i++ {
+		ids = append(ids, m[i].ASMID)
+	}
+	for i := 0; i < len(ids); i++ {
+		j := rlib.GetJournalByASMID(ids[i])
+		if j.JID == 0 {
+			continue
+		}
+		rlib.GetJournalAllocations(&j)
+		for k := 0; k < len(j.JA); k++ {
+			l := rlib.GetLedgerEntriesByJAID(j.BID, j.JA[k].JAID)
+			for n := 0; n < len(l); n++ {
+				rlib.DeleteLedgerEntry(l[n].LEID, uid)
+			}
+			if j.JA[k].ASMID != 0 && j.JA[k].ASMID != ids[i] { // a tax charge
+				rlib.DeleteAssessment(j.JA[k].ASMID)
+			}
+		}
+		rlib.DeleteJournalAllocations(j.JID)
+		rlib.DeleteJournal(j.JID, uid)
+	}
+	for i := 1; i < len(ids); i++ {
+		rlib.DeleteAssessment(ids[i])
 	}
 }
@@ -0,0 +1,199 @@
+package bizlogic
+
+import (
+	"rentroll/rlib"
+	"time"
+)
+
+// AttributionEntry is the marketing performance of one DemandSource or
+// LeadSource over a period. The entry with ID 0 collects the people for
+// whom no source was recorded.
+type AttributionEntry struct {
+	ID         int64   // SourceSLSID or LSID
+	Name       string  // name of the source
+	Prospects  int64   // prospects created during the period
+	Applicants int64   // the prospects that went on to apply
+	Agreements int64   // rental agreements signed during the period
+	Conversion float64 // Agreements as a percentage of Prospects
+	Revenue    float64 // rent revenue of those agreements during the period
+}
+
+// Attribution is the marketing attribution of a business over a period,
+// broken out by DemandSource and by LeadSource.
+type Attribution struct {
+	DemandSources []AttributionEntry
+	LeadSources   []AttributionEntry
+}
+
+// attributionSet is the list of AttributionEntries for one kind of source
+// along with an index from source id to entry.
+type attributionSet struct {
+	m   []AttributionEntry
+	idx map[int64]int
+}
+
+func (s *attributionSet) add(id int64, name string) {
+	s.idx[id] = len(s.m)
+	s.m = append(s.m, AttributionEntry{ID: id, Name: name})
+}
+
+// entry returns the entry for source id. Unknown sources go to the
+// unattributed entry.
+func (s *attributionSet) entry(id int64) *AttributionEntry {
+	i, ok := s.idx[id]
+	if !ok {
+		i = s.idx[0]
+	}
+	return &s.m[i]
+}
+
+// list finishes the entries and returns them. The unattributed entry is
+// dropped if nothing was attributed to it.
+func (s *attributionSet) list() []AttributionEntry {
+	var m []AttributionEntry
+	for i := 0; i < len(s.m); i++ {
+		e := s.m[i]
+		if e.ID == 0 && e.Prospects == 0 && e.Agreements == 0 {
+			continue
+		}
+		if e.Prospects > 0 {
+			e.Conversion = float64(e.Agreements) * 100 / float64(e.Prospects)
+		}
+		e.Revenue = rlib.RoundToCent(e.Revenue)
+		m = append(m, e)
+	}
+	return m
+}
+
+// rentRevenue returns the rent revenue of Rental Agreement raid during
+// d1 - d2, that is the net credits to its Income accounts made by the
+// assessments of rent account rules. Fees and other charges are not rent.
+func rentRevenue(bid, raid int64, d1, d2 *time.Time) float64 {
+	amt := float64(0)
+	m, err := rlib.GetAllLedgerEntriesForRAID(d1, d2, raid)
+	if err != nil {
+		rlib.Ulog("rentRevenue: error getting ledger entries for RAID %d: %s\n", raid, err.Error())
+		return amt
+	}
+	rent := map[int64]bool{} // ARID to whether it assesses rent
+	for i := 0; i < len(m); i++ {
+		if rlib.RRdb.BizTypes[bid].GLAccounts[m[i].LID].AcctType != "Income" {
+			continue
+		}
+		a, _ := rlib.GetAssessment(rlib.GetJournalAllocation(m[i].JAID).ASMID)
+		isRent, ok := rent[a.ARID]
+		if !ok {
+			isRent = rlib.IsRentAR(a.ARID)
+			rent[a.ARID] = isRent
+		}
+		if isRent {
+			amt -= m[i].Amount // credits are negative
+		}
+	}
+	return rlib.RoundToCent(amt)
+}
+
+// GetAttribution computes the marketing attribution of business bid for
+// the period d1 - d2. A person's DemandSource is the SourceSLSID of their
+// User record and their LeadSource is the LSID of their Prospect record.
+// Prospects are counted if they were created during the period. Rental
+// Agreements are counted if they start during the period and are attributed
+// to the sources of their payors; the agreement that holds an unconverted
+// prospect's fees and floating deposit is not counted.
+//
+// INPUTS
+//    bid    = business id
+//    d1, d2 = the period
+//
+// RETURNS
+//    the attribution by DemandSource and by LeadSource
+//-------------------------------------------------------------------------------------
+func GetAttribution(bid int64, d1, d2 *time.Time) Attribution {
+	var a Attribution
+	ds := attributionSet{idx: map[int64]int{}}
+	ls := attributionSet{idx: map[int64]int{}}
+
+	m, _ := rlib.GetAllDemandSources(bid)
+	for i := 0; i < len(m); i++ {
+		ds.add(m[i].SourceSLSID, m[i].Name)
+	}
+	ds.add(0, "(unattributed)")
+	n, _ := rlib.GetAllLeadSources(bid)
+	for i := 0; i < len(n); i++ {
+		ls.add(n[i].LSID, n[i].Name)
+	}
+	ls.add(0, "(unattributed)")
+
+	//------------------------------------------------
+	// The sources of a person are looked up once
+	//------------------------------------------------
+	type sources struct {
+		SourceSLSID int64
+		LSID        int64
+	}
+	src := map[int64]sources{}
+	getSources := func(tcid int64) sources {
+		s, ok := src[tcid]
+		if !ok {
+			var u rlib.User
+			var p rlib.Prospect
+			rlib.GetUser(tcid, &u)
+			rlib.GetProspect(tcid, &p)
+			s = sources{SourceSLSID: u.SourceSLSID, LSID: p.LSID}
+			src[tcid] = s
+		}
+		return s
+	}
+
+	//------------------------------------------------
+	// Prospects and applicants
+	//------------------------------------------------
+	shell := map[int64]bool{} // agreements held by unconverted prospects
+	p := rlib.GetProspectsByBusiness(bid)
+	for i := 0; i < len(p); i++ {
+		stage := ProspectStage(&p[i])
+		if p[i].RAID > 0 && stage != PROSPECTCONVERTED {
+			shell[p[i].RAID] = true
+		}
+		if p[i].CreateTS.Before(*d1) || !p[i].CreateTS.Before(*d2) {
+			continue
+		}
+		s := getSources(p[i].TCID)
+		for _, e := range []*AttributionEntry{ds.entry(s.SourceSLSID), ls.entry(s.LSID)} {
+			e.Prospects++
+			if stage != PROSPECTLEAD {
+				e.Applicants++
+			}
+		}
+	}
+
+	//------------------------------------------------
+	// Signed agreements and their rent revenue
+	//------------------------------------------------
+	ra := rlib.GetAllRentalAgreementsByRange(bid, d1, d2)
+	for i := 0; i < len(ra); i++ {
+		if shell[ra[i].RAID] || ra[i].AgreementStart.Before(*d1) || !ra[i].AgreementStart.Before(*d2) {
+			continue
+		}
+		var s sources
+		rap := rlib.GetRentalAgreementPayorsInRange(ra[i].RAID, &ra[i].AgreementStart, &ra[i].AgreementStop)
+		for j := 0; j < len(rap); j++ {
+			t := getSources(rap[j].TCID)
+			if s.SourceSLSID == 0 {
+				s.SourceSLSID = t.SourceSLSID
+			}
+			if s.LSID == 0 {
+				s.LSID = t.LSID
+			}
+		}
+		rev := rentRevenue(bid, ra[i].RAID, d1, d2)
+		for _, e := range []*AttributionEntry{ds.entry(s.SourceSLSID), ls.entry(s.LSID)} {
+			e.Agreements++
+			e.Revenue += rev
+		}
+	}
+
+	a.DemandSources = ds.list()
+	a.LeadSources = ls.list()
+	return a
+}
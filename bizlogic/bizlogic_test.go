@@ -0,0 +1,15 @@
+package bizlogic
+
+import (
+	"os"
+	"rentroll/rlib"
+	"testing"
+	"time"
+)
+
+func TestMain(m *testing.M) {
+	loadBizErrors("bizerr.csv")
+	rlib.RRdb.Zone = time.UTC // no config is loaded for unit tests
+	rlib.RpnInit()
+	os.Exit(m.Run())
+}
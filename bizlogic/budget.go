@@ -0,0 +1,375 @@
+package bizlogic
+
+import (
+	"fmt"
+	"rentroll/rlib"
+	"sort"
+	"time"
+)
+
+// BudgetVariance compares the budget of one item, a GLAccount or a target of
+// a RentableType that is managed to budget, with its actual figures for a
+// period and for the year to date.
+type BudgetVariance struct {
+	LID         int64   // the GLAccount, 0 for RentableType targets
+	RTID        int64   // the RentableType, 0 for GLAccounts
+	Item        string  // what is compared
+	Budget      float64 // budget for the period
+	Actual      float64 // actual figure for the period
+	Variance    float64 // Actual - Budget
+	VariancePct float64 // Variance as a percent of Budget, 0 if there is no budget
+	YTDBudget   float64 // budget from the start of the year to the end of the period
+	YTDActual   float64 // actual figure from the start of the year to the end of the period
+	YTDVariance float64 // YTDActual - YTDBudget
+}
+
+// budgetMonth returns the first day of the month containing dt. Budgets are
+// always kept on the first of the month.
+func budgetMonth(dt *time.Time) time.Time {
+	return time.Date(dt.Year(), dt.Month(), 1, 0, 0, 0, 0, rlib.RRdb.Zone)
+}
+
+// budgetRange widens d1 - d2 to whole months
+func budgetRange(d1, d2 *time.Time) (time.Time, time.Time) {
+	m1 := budgetMonth(d1)
+	m2 := budgetMonth(d2)
+	if m2.Before(*d2) {
+		m2 = m2.AddDate(0, 1, 0)
+	}
+	return m1, m2
+}
+
+// setVariance computes the variances of v from its budget and actual figures
+func setVariance(v *BudgetVariance) {
+	v.Variance = v.Actual - v.Budget
+	v.YTDVariance = v.YTDActual - v.YTDBudget
+	if v.Budget != float64(0) {
+		v.VariancePct = v.Variance / v.Budget * 100
+	}
+}
+
+// SaveBudget validates and writes the budget of a GLAccount for a month.
+// The date is moved to the first of its month. There can only be one
+// budget for an account in a month. Amounts use the sign convention of
+// the ledger: debits are positive, credits are negative.
+//
+// INPUTS
+//    b   = the budget to save, inserted if BGID is 0
+//    uid = the user saving it
+//
+// RETURNS
+//    a slice of BizErrors
+//-------------------------------------------------------------------------------------
+func SaveBudget(b *rlib.Budget, uid int64) []BizError {
+	var errlist []BizError
+	bad := func(s string) {
+		errlist = append(errlist, BizError{Errno: InvalidField, Message: BizErrors[InvalidField].Message + "\n" + s})
+	}
+
+	b.Dt = budgetMonth(&b.Dt)
+	if l := rlib.GetLedger(b.LID); l.LID == 0 || l.BID != b.BID {
+		bad("GL Account")
+	}
+	if b.Dt.Year() <= 1970 {
+		bad("Dt")
+	}
+	if len(errlist) > 0 {
+		return errlist
+	}
+	if x, err := rlib.GetBudgetForMonth(b.LID, &b.Dt); err == nil && x.BGID != b.BGID {
+		bad(fmt.Sprintf("There is already a budget for this account for %s", b.Dt.Format("Jan 2006")))
+		return errlist
+	}
+
+	var err error
+	b.LastModBy = uid
+	if b.BGID > 0 {
+		err = rlib.UpdateBudget(b)
+	} else {
+		b.CreateBy = uid
+		_, err = rlib.InsertBudget(b)
+	}
+	if err != nil {
+		return bizErrSys(&err)
+	}
+	return nil
+}
+
+// SaveRentableTypeBudget validates and writes the GSR and occupancy targets
+// of a RentableType for a month. Only RentableTypes with ManageToBudget set
+// can have targets. The date is moved to the first of its month and there
+// can only be one set of targets for a type in a month.
+//
+// INPUTS
+//    b   = the targets to save, inserted if RTBGID is 0
+//    uid = the user saving them
+//
+// RETURNS
+//    a slice of BizErrors
+//-------------------------------------------------------------------------------------
+func SaveRentableTypeBudget(b *rlib.RentableTypeBudget, uid int64) []BizError {
+	var errlist []BizError
+	bad := func(s string) {
+		errlist = append(errlist, BizError{Errno: InvalidField, Message: BizErrors[InvalidField].Message + "\n" + s})
+	}
+
+	b.Dt = budgetMonth(&b.Dt)
+	var rt rlib.RentableType
+	if err := rlib.GetRentableType(b.RTID, &rt); err != nil || rt.BID != b.BID {
+		bad("Rentable Type")
+	} else if rt.ManageToBudget == 0 {
+		bad(fmt.Sprintf("Rentable Type %s is not managed to budget", rt.Style))
+	}
+	if b.Dt.Year() <= 1970 {
+		bad("Dt")
+	}
+	if b.GSR < 0 {
+		bad("GSR")
+	}
+	if b.Occupancy < 0 || b.Occupancy > 100 {
+		bad("Occupancy must be a percentage from 0 to 100")
+	}
+	if len(errlist) > 0 {
+		return errlist
+	}
+	if x, err := rlib.GetRentableTypeBudgetForMonth(b.RTID, &b.Dt); err == nil && x.RTBGID != b.RTBGID {
+		bad(fmt.Sprintf("There are already targets for Rentable Type %s for %s", rt.Style, b.Dt.Format("Jan 2006")))
+		return errlist
+	}
+
+	var err error
+	b.LastModBy = uid
+	if b.RTBGID > 0 {
+		err = rlib.UpdateRentableTypeBudget(b)
+	} else {
+		b.CreateBy = uid
+		_, err = rlib.InsertRentableTypeBudget(b)
+	}
+	if err != nil {
+		return bizErrSys(&err)
+	}
+	return nil
+}
+
+// GetAccountBudgetVariance compares the budget of each GLAccount of business
+// bid that has one with its ledger activity. The period d1 - d2 is widened
+// to whole months. The year to date figures run from January 1 of the year
+// of d1 to the end of the period.
+//
+// INPUTS
+//    bid = business id
+//    d1  = start of the period
+//    d2  = end of the period
+//
+// RETURNS
+//    the variances, sorted by GL number
+//    any error encountered
+//-------------------------------------------------------------------------------------
+func GetAccountBudgetVariance(bid int64, d1, d2 *time.Time) ([]BudgetVariance, error) {
+	var m []BudgetVariance
+	m1, m2 := budgetRange(d1, d2)
+	y1 := time.Date(m1.Year(), time.January, 1, 0, 0, 0, 0, rlib.RRdb.Zone)
+
+	idx := map[int64]int{}
+	b := rlib.GetBudgetsByRange(bid, &y1, &m2)
+	for i := 0; i < len(b); i++ {
+		j, ok := idx[b[i].LID]
+		if !ok {
+			j = len(m)
+			idx[b[i].LID] = j
+			m = append(m, BudgetVariance{LID: b[i].LID})
+		}
+		m[j].YTDBudget += b[i].Amount
+		if !b[i].Dt.Before(m1) {
+			m[j].Budget += b[i].Amount
+		}
+	}
+
+	var err error
+	for i := 0; i < len(m); i++ {
+		l := rlib.GetLedger(m[i].LID)
+		m[i].Item = l.GLNumber + " " + l.Name
+		if m[i].Actual, err = rlib.GetAccountActivity(bid, m[i].LID, &m1, &m2); err != nil {
+			return m, err
+		}
+		if m[i].YTDActual, err = rlib.GetAccountActivity(bid, m[i].LID, &y1, &m2); err != nil {
+			return m, err
+		}
+		setVariance(&m[i])
+	}
+	sort.Slice(m, func(i, j int) bool { return m[i].Item < m[j].Item })
+	return m, nil
+}
+
+// rtActuals is the GSR and occupancy of the Rentables of one RentableType
+// for one month
+type rtActuals struct {
+	GSR      float64
+	Occupied float64 // rentable-days rented
+	Days     float64 // rentable-days in the month
+}
+
+// rtMonthActuals returns the actual GSR and occupancy of each RentableType
+// that is managed to budget for the month starting on ms. A Rentable counts
+// towards the type it has on the first of the month. Its GSR is computed the
+// same way as in the GSR report, and a day is occupied if a Rental Agreement
+// rents the Rentable on that day.
+func rtMonthActuals(xbiz *rlib.XBusiness, r []rlib.Rentable, ms *time.Time) (map[int64]*rtActuals, error) {
+	m := map[int64]*rtActuals{}
+	me := ms.AddDate(0, 1, 0)
+	days := me.Sub(*ms).Hours() / 24
+	for i := 0; i < len(r); i++ {
+		rtid := rlib.GetRTIDForDate(r[i].RID, ms)
+		if rt, ok := xbiz.RT[rtid]; !ok || rt.ManageToBudget == 0 {
+			continue
+		}
+		a, ok := m[rtid]
+		if !ok {
+			a = &rtActuals{}
+			m[rtid] = a
+		}
+		gsr, _, _, err := rlib.CalculateLoadedGSR(&r[i], ms, &me, xbiz)
+		if err != nil {
+			return m, err
+		}
+		a.GSR += gsr
+		a.Days += days
+
+		occ := float64(0)
+		rar := rlib.GetAgreementsForRentable(r[i].RID, ms, &me)
+		for j := 0; j < len(rar); j++ {
+			start, stop := rar[j].RARDtStart, rar[j].RARDtStop
+			if start.Before(*ms) {
+				start = *ms
+			}
+			if stop.After(me) {
+				stop = me
+			}
+			if stop.After(start) {
+				occ += stop.Sub(start).Hours() / 24
+			}
+		}
+		if occ > days {
+			occ = days // overlapping agreements
+		}
+		a.Occupied += occ
+	}
+	return m, nil
+}
+
+// GetRentableTypeBudgetVariance compares the GSR and occupancy targets of
+// each RentableType of business bid that is managed to budget with its
+// actual figures. Each type gives two variances, one for GSR and one for
+// occupancy. Occupancy is the percent of rentable-days rented, its year to
+// date budget is the average of the monthly targets. The period d1 - d2 is
+// widened to whole months, the year to date figures run from January 1 of
+// the year of d1 to the end of the period.
+//
+// INPUTS
+//    bid = business id
+//    d1  = start of the period
+//    d2  = end of the period
+//
+// RETURNS
+//    the variances, sorted by RentableType style
+//    any error encountered
+//-------------------------------------------------------------------------------------
+func GetRentableTypeBudgetVariance(bid int64, d1, d2 *time.Time) ([]BudgetVariance, error) {
+	var m []BudgetVariance
+	var xbiz rlib.XBusiness
+	rlib.InitBizInternals(bid, &xbiz)
+	m1, m2 := budgetRange(d1, d2)
+	y1 := time.Date(m1.Year(), time.January, 1, 0, 0, 0, 0, rlib.RRdb.Zone)
+
+	//------------------------------------------------
+	// the types that are managed to budget
+	//------------------------------------------------
+	var rtids []int64
+	for k, v := range xbiz.RT {
+		if v.ManageToBudget != 0 {
+			rtids = append(rtids, k)
+		}
+	}
+	if len(rtids) == 0 {
+		return m, nil
+	}
+	sort.Slice(rtids, func(i, j int) bool { return xbiz.RT[rtids[i]].Style < xbiz.RT[rtids[j]].Style })
+
+	//------------------------------------------------
+	// targets
+	//------------------------------------------------
+	type target struct {
+		gsr, ytdgsr, occ, ytdocc float64
+		n, ytdn                  int
+	}
+	t := map[int64]*target{}
+	for _, rtid := range rtids {
+		t[rtid] = &target{}
+	}
+	b := rlib.GetRentableTypeBudgetsByRange(bid, &y1, &m2)
+	for i := 0; i < len(b); i++ {
+		x, ok := t[b[i].RTID]
+		if !ok {
+			continue
+		}
+		x.ytdgsr += b[i].GSR
+		x.ytdocc += b[i].Occupancy
+		x.ytdn++
+		if !b[i].Dt.Before(m1) {
+			x.gsr += b[i].GSR
+			x.occ += b[i].Occupancy
+			x.n++
+		}
+	}
+
+	//------------------------------------------------
+	// actuals, month by month
+	//------------------------------------------------
+	cur := map[int64]*rtActuals{}
+	ytd := map[int64]*rtActuals{}
+	for _, rtid := range rtids {
+		cur[rtid] = &rtActuals{}
+		ytd[rtid] = &rtActuals{}
+	}
+	r := rlib.GetAllRentablesByBusiness(bid)
+	for ms := y1; ms.Before(m2); ms = ms.AddDate(0, 1, 0) {
+		a, err := rtMonthActuals(&xbiz, r, &ms)
+		if err != nil {
+			return m, err
+		}
+		for rtid, v := range a {
+			ytd[rtid].GSR += v.GSR
+			ytd[rtid].Occupied += v.Occupied
+			ytd[rtid].Days += v.Days
+			if !ms.Before(m1) {
+				cur[rtid].GSR += v.GSR
+				cur[rtid].Occupied += v.Occupied
+				cur[rtid].Days += v.Days
+			}
+		}
+	}
+
+	pct := func(a *rtActuals) float64 {
+		if a.Days == 0 {
+			return float64(0)
+		}
+		return a.Occupied / a.Days * 100
+	}
+	avg := func(x float64, n int) float64 {
+		if n == 0 {
+			return float64(0)
+		}
+		return x / float64(n)
+	}
+	for _, rtid := range rtids {
+		style := xbiz.RT[rtid].Style
+		g := BudgetVariance{RTID: rtid, Item: "GSR " + style, Budget: t[rtid].gsr, Actual: cur[rtid].GSR,
+			YTDBudget: t[rtid].ytdgsr, YTDActual: ytd[rtid].GSR}
+		o := BudgetVariance{RTID: rtid, Item: "Occupancy % " + style, Budget: avg(t[rtid].occ, t[rtid].n), Actual: pct(cur[rtid]),
+			YTDBudget: avg(t[rtid].ytdocc, t[rtid].ytdn), YTDActual: pct(ytd[rtid])}
+		setVariance(&g)
+		setVariance(&o)
+		m = append(m, g, o)
+	}
+	return m, nil
+}
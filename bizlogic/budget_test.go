@@ -0,0 +1,51 @@
+package bizlogic
+
+import (
+	"rentroll/rlib"
+	"testing"
+)
+
+func TestSetVariance(t *testing.T) {
+	var m = []struct {
+		budget, actual       float64
+		ytdBudget, ytdActual float64
+		variance, pct        float64
+		ytdVariance          float64
+	}{
+		{1000, 1100, 3000, 2900, 100, 10, -100},
+		{1000, 750, 1000, 750, -250, -25, -250},
+		{-500, -600, -1500, -1500, -100, 20, 0}, // credits: more income than budgeted
+		{0, 200, 0, 200, 200, 0, 200},           // no budget, no percentage
+		{800, 800, 2400, 2400, 0, 0, 0},
+	}
+	for i := 0; i < len(m); i++ {
+		v := BudgetVariance{Budget: m[i].budget, Actual: m[i].actual, YTDBudget: m[i].ytdBudget, YTDActual: m[i].ytdActual}
+		setVariance(&v)
+		if v.Variance != m[i].variance || rlib.RoundToCent(v.VariancePct) != m[i].pct || v.YTDVariance != m[i].ytdVariance {
+			t.Errorf("%d: budget %.2f actual %.2f: expected variance %.2f (%.2f%%) ytd %.2f, got %.2f (%.2f%%) ytd %.2f",
+				i, m[i].budget, m[i].actual, m[i].variance, m[i].pct, m[i].ytdVariance, v.Variance, v.VariancePct, v.YTDVariance)
+		}
+	}
+}
+
+func TestBudgetRange(t *testing.T) {
+	var m = []struct {
+		d1, d2 string
+		m1, m2 string
+	}{
+		{"2017-06-01", "2017-07-01", "2017-06-01", "2017-07-01"},
+		{"2017-06-15", "2017-07-01", "2017-06-01", "2017-07-01"},
+		{"2017-06-01", "2017-06-20", "2017-06-01", "2017-07-01"},
+		{"2017-02-10", "2017-04-10", "2017-02-01", "2017-05-01"},
+		{"2017-12-05", "2018-01-02", "2017-12-01", "2018-02-01"},
+	}
+	for i := 0; i < len(m); i++ {
+		d1, _ := rlib.StringToDate(m[i].d1)
+		d2, _ := rlib.StringToDate(m[i].d2)
+		m1, m2 := budgetRange(&d1, &d2)
+		if m1.Format(rlib.RRDATEINPFMT) != m[i].m1 || m2.Format(rlib.RRDATEINPFMT) != m[i].m2 {
+			t.Errorf("%d: %s - %s: expected %s - %s, got %s - %s", i, m[i].d1, m[i].d2, m[i].m1, m[i].m2,
+				m1.Format(rlib.RRDATEINPFMT), m2.Format(rlib.RRDATEINPFMT))
+		}
+	}
+}
@@ -0,0 +1,285 @@
+package bizlogic
+
+import (
+	"fmt"
+	"rentroll/rlib"
+	"time"
+)
+
+// RentableTypeChangeAsm describes the effect of a change of Rentable type on
+// one recurring rent assessment in place on the date of the change.
+type RentableTypeChangeAsm struct {
+	ASM       rlib.Assessment // the recurring assessment
+	OldAmount float64         // its amount
+	NewAmount float64         // the market rate of the new type, the amount it is re-issued at
+}
+
+// RentableTypeChange describes the change of the type of a Rentable as of a
+// date and its effect on the Rentable's rent.
+type RentableTypeChange struct {
+	RID         int64
+	OldRTID     int64     // the type in effect on Dt
+	NewRTID     int64     // the type from Dt on
+	Dt          time.Time // date the change takes effect
+	OldGSR      float64   // loaded GSR for the month starting Dt, before the change
+	NewGSR      float64   // loaded GSR for the same month, after the change
+	OldMR       float64   // market rate of the old type on Dt
+	NewMR       float64   // market rate of the new type on Dt
+	Assessments []RentableTypeChangeAsm
+	Reissued    bool // true if the assessments were re-issued at NewMR
+}
+
+// rtMarketRate returns the market rate of rt in effect on dt
+func rtMarketRate(rt *rlib.RentableType, dt *time.Time) float64 {
+	for i := 0; i < len(rt.MR); i++ {
+		if rlib.DateInRange(dt, &rt.MR[i].DtStart, &rt.MR[i].DtStop) {
+			return rt.MR[i].MarketRate
+		}
+	}
+	return float64(0)
+}
+
+// changedRTRefs returns the RentableTypeRefs m as they will be once the type
+// of the Rentable is changed to rtid on dt. The ref in effect on dt is closed
+// on dt and a new ref for rtid runs from dt until the closed ref's DtStop, so
+// any later changes that are already scheduled are kept.
+//
+// RETURNS
+//    the refs after the change
+//    the index in the result of the ref closed on dt, -1 if the ref in effect
+//        on dt started on dt, in which case only its type is changed
+//    the index in the result of the ref for rtid, -1 if no ref was in effect on dt
+func changedRTRefs(m []rlib.RentableTypeRef, rtid int64, dt *time.Time) ([]rlib.RentableTypeRef, int, int) {
+	var n []rlib.RentableTypeRef
+	closed, opened := -1, -1
+	for i := 0; i < len(m); i++ {
+		if !rlib.DateInRange(dt, &m[i].DtStart, &m[i].DtStop) {
+			n = append(n, m[i])
+			continue
+		}
+		a := m[i]
+		if a.DtStart.Before(*dt) {
+			a.DtStop = *dt
+			closed = len(n)
+			n = append(n, a)
+			a = rlib.RentableTypeRef{RID: m[i].RID, BID: m[i].BID, DtStart: *dt, DtStop: m[i].DtStop}
+		}
+		a.RTID = rtid
+		a.OverrideRentCycle = 0 // the overrides were for the old type
+		a.OverrideProrationCycle = 0
+		opened = len(n)
+		n = append(n, a)
+	}
+	return n, closed, opened
+}
+
+// changeContractRent changes the ContractRent of rar to rent from dt on. The
+// current rent is kept for the time before dt: rar is ended on dt and a new
+// RentalAgreementRentable at rent runs from dt to rar's old RARDtStop. If rar
+// starts on or after dt its ContractRent is simply updated.
+//
+// INPUTS
+//    rar  = the RentalAgreementRentable in effect on dt
+//    dt   = date the new rent takes effect
+//    rent = the new ContractRent
+//    uid  = the user making the change
+//
+// RETURNS
+//    any error encountered
+//-------------------------------------------------------------------------------------
+func changeContractRent(rar *rlib.RentalAgreementRentable, dt *time.Time, rent float64, uid int64) error {
+	if !rar.RARDtStart.Before(*dt) {
+		rar.ContractRent = rent
+		return rlib.UpdateRentalAgreementRentable(rar)
+	}
+	r := *rar
+	r.RARID = 0
+	r.ContractRent = rent
+	r.RARDtStart = *dt
+	r.CreateBy = uid
+	rar.RARDtStop = *dt
+	if err := rlib.UpdateRentalAgreementRentable(rar); err != nil {
+		return err
+	}
+	_, err := rlib.InsertRentalAgreementRentable(&r)
+	return err
+}
+
+// rentableTypeChangeAsms returns the recurring rent assessments of Rentable
+// rid in place on dt. These are the recurring assessments of the Rentable,
+// made for a Rental Agreement with a rent account rule, that have not been
+// reversed.
+func rentableTypeChangeAsms(rid int64, dt *time.Time, newmr float64) []RentableTypeChangeAsm {
+	var m []RentableTypeChangeAsm
+	dt1 := dt.AddDate(0, 0, 1)
+	a := rlib.GetAllRentableAssessments(rid, dt, &dt1)
+	for i := 0; i < len(a); i++ {
+		if a[i].RAID == 0 || a[i].PASMID != 0 || a[i].RentCycle == rlib.RECURNONE || a[i].FLAGS&rlib.ASMREVERSED != 0 || !rlib.IsRentAR(a[i].ARID) {
+			continue
+		}
+		m = append(m, RentableTypeChangeAsm{ASM: a[i], OldAmount: a[i].Amount, NewAmount: newmr})
+	}
+	return m
+}
+
+// GetRentableTypeChange describes the change of the type of Rentable rid to
+// rtid on dt without making it. It reports the loaded GSR of the Rentable for
+// the month starting dt before and after the change, and the recurring rent
+// assessments in place on dt along with the market rate of the new type,
+// which is the amount they would be re-issued at. It is used for the dry run
+// as well as by ChangeRentableType.
+//
+// INPUTS
+//    bid  = business id
+//    rid  = the Rentable
+//    rtid = its new RentableType
+//    dt   = date the change takes effect
+//
+// RETURNS
+//    the change
+//    a slice of BizErrors
+//-------------------------------------------------------------------------------------
+func GetRentableTypeChange(bid, rid, rtid int64, dt *time.Time) (RentableTypeChange, []BizError) {
+	var (
+		errlist []BizError
+		c       = RentableTypeChange{RID: rid, NewRTID: rtid, Dt: *dt}
+	)
+	bad := func(s string) {
+		errlist = append(errlist, BizError{Errno: InvalidField, Message: BizErrors[InvalidField].Message + "\n" + s})
+	}
+
+	r := rlib.GetRentable(rid)
+	if r.RID == 0 || r.BID != bid {
+		bad("Rentable")
+	}
+	var xbiz rlib.XBusiness
+	rlib.InitBizInternals(bid, &xbiz)
+	newrt, ok := xbiz.RT[rtid]
+	if !ok {
+		bad("Rentable Type")
+	}
+	if dt.Year() <= 1970 {
+		bad("Dt")
+	}
+	if len(errlist) > 0 {
+		return c, errlist
+	}
+	c.OldRTID = rlib.GetRTIDForDate(rid, dt)
+	if c.OldRTID == 0 {
+		bad(fmt.Sprintf("Rentable %s has no type on %s", r.RentableName, dt.Format(rlib.RRDATEFMT4)))
+	} else if c.OldRTID == rtid {
+		bad(fmt.Sprintf("Rentable %s is already of type %s on %s", r.RentableName, newrt.Style, dt.Format(rlib.RRDATEFMT4)))
+	}
+	if len(errlist) > 0 {
+		return c, errlist
+	}
+
+	d2 := dt.AddDate(0, 1, 0)
+	oldrt := xbiz.RT[c.OldRTID]
+	c.OldMR = rtMarketRate(&oldrt, dt)
+	c.NewMR = rtMarketRate(&newrt, dt)
+	c.OldGSR, _, _, _ = rlib.CalculateLoadedGSR(&r, dt, &d2, &xbiz)
+	n, _, _ := changedRTRefs(rlib.GetRentableTypeRefsByRange(rid, dt, &d2), rtid, dt)
+	c.NewGSR, _, _, _ = rlib.CalculateLoadedGSRForRefs(&r, n, dt, &d2, &xbiz)
+	c.Assessments = rentableTypeChangeAsms(rid, dt, c.NewMR)
+	return c, nil
+}
+
+// ChangeRentableType changes the type of Rentable rid to rtid as of dt, for
+// example when a 1BR is converted to a 2BR after a renovation. The
+// RentableTypeRef in effect on dt is closed on dt and a new one for rtid is
+// opened. If reissue is true, each recurring rent assessment in place on dt
+// is stopped on dt, any instances of it already created from dt forward are
+// reversed, and a new recurring assessment is started at the market rate of
+// the new type. The ContractRent of the Rental Agreements renting the
+// Rentable is changed to the same rate from dt on.
+//
+// INPUTS
+//    bid     = business id
+//    rid     = the Rentable
+//    rtid    = its new RentableType
+//    dt      = date the change takes effect
+//    reissue = true to re-issue the recurring rent assessments at the new market rate
+//    uid     = the user making the change
+//
+// RETURNS
+//    the change
+//    a slice of BizErrors
+//-------------------------------------------------------------------------------------
+func ChangeRentableType(bid, rid, rtid int64, dt *time.Time, reissue bool, uid int64) (RentableTypeChange, []BizError) {
+	c, errlist := GetRentableTypeChange(bid, rid, rtid, dt)
+	if len(errlist) > 0 {
+		return c, errlist
+	}
+	if reissue && c.NewMR == float64(0) && len(c.Assessments) > 0 {
+		errlist = append(errlist, BizError{Errno: InvalidField, Message: BizErrors[InvalidField].Message + "\n" + "The new Rentable Type has no market rate on the date of the change"})
+		return c, errlist
+	}
+
+	//------------------------------------------------
+	// close the current type and open the new one
+	//------------------------------------------------
+	var err error
+	d2 := dt.AddDate(0, 0, 1)
+	n, closed, opened := changedRTRefs(rlib.GetRentableTypeRefsByRange(rid, dt, &d2), rtid, dt)
+	if closed >= 0 {
+		n[closed].LastModBy = uid
+		if err = rlib.UpdateRentableTypeRef(&n[closed]); err != nil {
+			return c, bizErrSys(&err)
+		}
+	}
+	if opened >= 0 {
+		n[opened].LastModBy = uid
+		if n[opened].RTRID > 0 {
+			err = rlib.UpdateRentableTypeRef(&n[opened])
+		} else {
+			n[opened].CreateBy = uid
+			err = rlib.InsertRentableTypeRef(&n[opened])
+		}
+		if err != nil {
+			return c, bizErrSys(&err)
+		}
+	}
+	if !reissue {
+		return c, nil
+	}
+
+	//------------------------------------------------
+	// re-issue the rent at the new market rate
+	//------------------------------------------------
+	now := time.Now()
+	for i := 0; i < len(c.Assessments); i++ {
+		old := c.Assessments[i].ASM
+		if errlist = ReverseAssessmentsGoingForward(&rlib.Assessment{PASMID: old.ASMID}, dt, &now, uid); len(errlist) > 0 {
+			return c, errlist
+		}
+		a := old
+		a.ASMID = 0
+		a.Start = *dt
+		a.Amount = c.NewMR
+		a.Comment = fmt.Sprintf("rentable type change from %s", old.IDtoString())
+		a.CreateBy = uid
+		a.LastModBy = uid
+
+		old.Stop = *dt
+		old.LastModBy = uid
+		if err = rlib.UpdateAssessment(&old); err != nil {
+			return c, bizErrSys(&err)
+		}
+		if errlist = InsertAssessment(&a, 1, uid); len(errlist) > 0 {
+			return c, errlist
+		}
+
+		rar := rlib.GetRentalAgreementRentables(old.RAID, dt, &d2)
+		for j := 0; j < len(rar); j++ {
+			if rar[j].RID != rid {
+				continue
+			}
+			if err = changeContractRent(&rar[j], dt, c.NewMR, uid); err != nil {
+				return c, bizErrSys(&err)
+			}
+		}
+	}
+	c.Reissued = true
+	return c, nil
+}
@@ -0,0 +1,86 @@
+package bizlogic
+
+import (
+	"fmt"
+	"rentroll/rlib"
+	"time"
+)
+
+// ValidatePeriodOpen checks whether the supplied date falls within a closed
+// accounting period of business bid.
+//
+// INPUTS
+//    bid = business id
+//     dt = date of the posting
+//
+// RETURNS
+//    a slice of BizErrors, nil if the period is open
+//-------------------------------------------------------------------------------------
+func ValidatePeriodOpen(bid int64, dt *time.Time) []BizError {
+	jm := rlib.GetClosedJournalMarkerForDate(bid, dt)
+	return checkPeriodOpen(&jm, dt)
+}
+
+// checkPeriodOpen returns the error for a posting dated dt when jm, the closed
+// JournalMarker whose period contains dt, exists.
+func checkPeriodOpen(jm *rlib.JournalMarker, dt *time.Time) []BizError {
+	var e []BizError
+	if jm.JMID > 0 {
+		msg := fmt.Sprintf("%s: %s is in the period %s - %s", BizErrors[PostToClosedPeriod].Message,
+			dt.Format(rlib.RRDATEFMT4), jm.DtStart.Format(rlib.RRDATEFMT4), jm.DtStop.Format(rlib.RRDATEFMT4))
+		e = append(e, BizError{Errno: PostToClosedPeriod, Message: msg})
+	}
+	return e
+}
+
+// ClosePeriod closes the accounting period d1 - d2 for business bid. The
+// trial balance must be zero on d2 and no part of the period may already be
+// closed. Once closed, Assessments and Receipts dated within the period are
+// rejected.
+//
+// INPUTS
+//    bid   = business id
+//    d1-d2 = the period to close, d2 is not included in the period
+//    uid   = the user closing the period
+//
+// RETURNS
+//    a slice of BizErrors
+//-------------------------------------------------------------------------------------
+func ClosePeriod(bid int64, d1, d2 *time.Time, uid int64) []BizError {
+	jm := rlib.GetClosedJournalMarkerInRange(bid, d1, d2)
+	bal := rlib.GetTrialBalance(bid, d2)
+	if errlist := checkClosePeriod(d1, d2, &jm, bal); len(errlist) > 0 {
+		return errlist
+	}
+
+	var xbiz rlib.XBusiness
+	rlib.GetXBusiness(bid, &xbiz)
+	rlib.InitLedgerCache()
+	if err := rlib.ClosePeriod(&xbiz, d1, d2, uid); err != nil {
+		return bizErrSys(&err)
+	}
+	return nil
+}
+
+// checkClosePeriod returns the reasons the period d1 - d2 cannot be closed. jm is
+// the first closed JournalMarker overlapping the period, if any, and bal is the
+// trial balance on d2.
+func checkClosePeriod(d1, d2 *time.Time, jm *rlib.JournalMarker, bal float64) []BizError {
+	var errlist []BizError
+	if !d2.After(*d1) {
+		msg := BizErrors[InvalidField].Message + "\nThe period end date must be after its start date"
+		errlist = append(errlist, BizError{Errno: InvalidField, Message: msg})
+		return errlist
+	}
+	if jm.JMID > 0 {
+		msg := fmt.Sprintf("%s: %s - %s", BizErrors[PeriodAlreadyClosed].Message,
+			jm.DtStart.Format(rlib.RRDATEFMT4), jm.DtStop.Format(rlib.RRDATEFMT4))
+		errlist = append(errlist, BizError{Errno: PeriodAlreadyClosed, Message: msg})
+		return errlist
+	}
+	if bal != float64(0) {
+		msg := fmt.Sprintf("%s. Out of balance by %.2f on %s", BizErrors[TrialBalanceNotZero].Message, bal, d2.Format(rlib.RRDATEFMT4))
+		errlist = append(errlist, BizError{Errno: TrialBalanceNotZero, Message: msg})
+	}
+	return errlist
+}
@@ -0,0 +1,49 @@
+package bizlogic
+
+import (
+	"rentroll/rlib"
+	"testing"
+)
+
+func TestCheckPeriodOpen(t *testing.T) {
+	dt, _ := rlib.StringToDate("2017-06-15")
+	d1, _ := rlib.StringToDate("2017-06-01")
+	d2, _ := rlib.StringToDate("2017-07-01")
+
+	var open rlib.JournalMarker
+	if e := checkPeriodOpen(&open, &dt); len(e) != 0 {
+		t.Errorf("open period: expected no errors, got %v", e)
+	}
+	closed := rlib.JournalMarker{JMID: 4, State: rlib.MARKERSTATECLOSED, DtStart: d1, DtStop: d2}
+	if e := checkPeriodOpen(&closed, &dt); len(e) != 1 || e[0].Errno != PostToClosedPeriod {
+		t.Errorf("closed period: expected PostToClosedPeriod, got %v", e)
+	}
+}
+
+func TestCheckClosePeriod(t *testing.T) {
+	var m = []struct {
+		d1, d2 string
+		jmid   int64
+		bal    float64
+		errno  int
+	}{
+		{"2017-06-01", "2017-07-01", 0, 0, -1},
+		{"2017-06-01", "2017-06-01", 0, 0, InvalidField},
+		{"2017-07-01", "2017-06-01", 0, 0, InvalidField},
+		{"2017-06-01", "2017-07-01", 3, 0, PeriodAlreadyClosed}, // a closed period overlaps
+		{"2017-06-01", "2017-07-01", 3, 12.5, PeriodAlreadyClosed},
+		{"2017-06-01", "2017-07-01", 0, 12.5, TrialBalanceNotZero},
+	}
+	for i := 0; i < len(m); i++ {
+		d1, _ := rlib.StringToDate(m[i].d1)
+		d2, _ := rlib.StringToDate(m[i].d2)
+		jm := rlib.JournalMarker{JMID: m[i].jmid, State: rlib.MARKERSTATECLOSED, DtStart: d1.AddDate(0, 0, 10), DtStop: d2.AddDate(0, 1, 0)}
+		e := checkClosePeriod(&d1, &d2, &jm, m[i].bal)
+		switch {
+		case m[i].errno < 0 && len(e) > 0:
+			t.Errorf("%d: expected no errors, got %v", i, e)
+		case m[i].errno >= 0 && (len(e) != 1 || e[0].Errno != m[i].errno):
+			t.Errorf("%d: expected error %d, got %v", i, m[i].errno, e)
+		}
+	}
+}
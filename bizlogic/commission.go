@@ -0,0 +1,163 @@
+package bizlogic
+
+import (
+	"fmt"
+	"rentroll/rlib"
+	"time"
+)
+
+// Commission describes a commission earned by a leasing agent or referrer
+// when a Rental Agreement is created. Exactly one of Percent and Amount is
+// used: Percent is a percentage of the rent for the agreement's first term,
+// Amount is a flat fee.
+type Commission struct {
+	Salesperson    string    // name of the agent or referrer
+	UID            int64     // the leasing agent (Accord Directory UserID), 0 if not an agent
+	TCID           int64     // the referrer, if they are a Transactant
+	Percent        float64   // percent of the first term's rent, 0 = flat Amount
+	Amount         float64   // flat fee, used when Percent is 0
+	PaymentDueDate time.Time // when the commission is due, defaults to the start of the term
+}
+
+// validateCommission checks commission c of business bid
+func validateCommission(bid int64, c *Commission) []BizError {
+	var errlist []BizError
+	bad := func(s string) {
+		errlist = append(errlist, BizError{Errno: InvalidField, Message: BizErrors[InvalidField].Message + "\n" + s})
+	}
+	if len(c.Salesperson) == 0 && c.UID == 0 && c.TCID == 0 {
+		bad("Commission Salesperson")
+	}
+	if c.TCID > 0 {
+		var t rlib.Transactant
+		if err := rlib.GetTransactant(c.TCID, &t); err != nil || t.BID != bid {
+			bad(fmt.Sprintf("Commission referrer %d", c.TCID))
+		}
+	}
+	if c.Percent < 0 || c.Amount < 0 || (c.Percent == 0) == (c.Amount == 0) {
+		bad("A commission must have either a Percent or an Amount")
+	}
+	return errlist
+}
+
+// commissionSalesperson returns the name to record for commission c
+func commissionSalesperson(c *Commission) string {
+	if len(c.Salesperson) > 0 {
+		return c.Salesperson
+	}
+	if c.TCID > 0 {
+		var t rlib.Transactant
+		rlib.GetTransactant(c.TCID, &t)
+		return t.GetFullTransactantName()
+	}
+	return fmt.Sprintf("UID %d", c.UID)
+}
+
+// insertCommission records commission c for Rentable r of Rental Agreement
+// ra. A percentage commission is computed from the loaded GSR of r over the
+// agreement's first term, d1 - d2.
+//
+// RETURNS
+//    the CommissionLedger record
+//    any error encountered
+//-------------------------------------------------------------------------------------
+func insertCommission(xbiz *rlib.XBusiness, ra *rlib.RentalAgreement, r *rlib.Rentable, d1, d2 *time.Time, c *Commission, uid int64) (rlib.CommissionLedger, error) {
+	cl := rlib.CommissionLedger{BID: ra.BID, RAID: ra.RAID, RID: r.RID, Salesperson: commissionSalesperson(c), UID: c.UID, TCID: c.TCID,
+		Percent: c.Percent, Amount: c.Amount, PaymentDueDate: c.PaymentDueDate, CreateBy: uid, LastModBy: uid}
+	if cl.PaymentDueDate.Year() <= 1970 {
+		cl.PaymentDueDate = *d1
+	}
+	if c.Percent > 0 {
+		gsr, _, _, err := rlib.CalculateLoadedGSR(r, d1, d2, xbiz)
+		if err != nil {
+			return cl, err
+		}
+		cl.Amount = gsr * c.Percent / 100
+	}
+	cl.Amount = rlib.RoundToCent(cl.Amount)
+	_, err := rlib.InsertCommissionLedger(&cl)
+	return cl, err
+}
+
+// PayCommissions marks the commissions in clids as paid on dt. A journal
+// entry is made for each one, debiting the commission expense account expLID
+// and crediting cashLID, the account the commission is paid from.
+//
+// INPUTS
+//    bid     = business id
+//    clids   = the commissions to pay
+//    dt      = date of payment
+//    expLID  = commission expense GL account
+//    cashLID = GL account the commission is paid from
+//    uid     = the user paying the commissions
+//
+// RETURNS
+//    a slice of BizErrors
+//-------------------------------------------------------------------------------------
+func PayCommissions(bid int64, clids []int64, dt *time.Time, expLID, cashLID, uid int64) []BizError {
+	var errlist []BizError
+	bad := func(s string) {
+		errlist = append(errlist, BizError{Errno: InvalidField, Message: BizErrors[InvalidField].Message + "\n" + s})
+	}
+	dacct, ok := rlib.RRdb.BizTypes[bid].GLAccounts[expLID]
+	if !ok {
+		bad("Commission Expense Account")
+	}
+	cacct, ok := rlib.RRdb.BizTypes[bid].GLAccounts[cashLID]
+	if !ok {
+		bad("Payment Account")
+	}
+	if len(clids) == 0 {
+		bad("No commissions were selected")
+	}
+	var m []rlib.CommissionLedger
+	for i := 0; i < len(clids); i++ {
+		cl, err := rlib.GetCommissionLedger(clids[i])
+		if err != nil || cl.BID != bid {
+			bad(fmt.Sprintf("Commission %d", clids[i]))
+			continue
+		}
+		if cl.FLAGS&rlib.CLPAID != 0 {
+			bad(fmt.Sprintf("Commission %s has already been paid", cl.IDtoString()))
+			continue
+		}
+		m = append(m, cl)
+	}
+	if len(errlist) > 0 {
+		return errlist
+	}
+	if errlist = ValidatePeriodOpen(bid, dt); len(errlist) > 0 {
+		return errlist
+	}
+
+	var xbiz rlib.XBusiness
+	rlib.InitBizInternals(bid, &xbiz)
+	d1, d2 := rlib.GetMonthPeriodForDate(dt)
+	rlib.InitLedgerCache()
+	for i := 0; i < len(m); i++ {
+		jnl := rlib.Journal{BID: bid, Dt: *dt, Type: rlib.JNLTYPECOMM, ID: m[i].CLID, Amount: m[i].Amount,
+			Comment: fmt.Sprintf("Commission %s to %s", m[i].IDtoString(), m[i].Salesperson), CreateBy: uid, LastModBy: uid}
+		if _, err := rlib.InsertJournal(&jnl); err != nil {
+			return bizErrSys(&err)
+		}
+		ja := rlib.JournalAllocation{JID: jnl.JID, BID: bid, RAID: m[i].RAID, RID: m[i].RID, Amount: m[i].Amount, CreateBy: uid,
+			AcctRule: fmt.Sprintf("d %s %.2f, c %s %.2f", dacct.GLNumber, m[i].Amount, cacct.GLNumber, m[i].Amount)}
+		if err := rlib.InsertJournalAllocationEntry(&ja); err != nil {
+			return bizErrSys(&err)
+		}
+		jnl.JA = append(jnl.JA, ja)
+		rlib.GenerateLedgerEntriesFromJournal(&xbiz, &jnl, &d1, &d2)
+
+		m[i].FLAGS |= rlib.CLPAID
+		m[i].DtPaid = *dt
+		m[i].JID = jnl.JID
+		m[i].LastModBy = uid
+		if err := rlib.UpdateCommissionLedger(&m[i]); err != nil {
+			return bizErrSys(&err)
+		}
+	}
+	if err := rlib.UpdateLedgerMarkersAfter(&xbiz, dt, uid); err != nil {
+		return bizErrSys(&err)
+	}
+	return nil
+}
@@ -0,0 +1,142 @@
+package bizlogic
+
+import (
+	"fmt"
+	"rentroll/rlib"
+)
+
+// MakeDeposit groups receipts into a Deposit to a Depository. The Deposit and
+// its DepositParts are saved, each receipt is marked with the DID, and a
+// Journal entry moves the funds from the account the receipts were received
+// into to the Depository's GL account.
+//
+// INPUTS
+//    d      = the deposit. BID, DEPID, DPMID and Dt must be set. Amount is
+//             computed from the receipts.
+//    rcpts  = RCPTIDs of the receipts to deposit
+//    uid    = the user making the deposit
+//
+// RETURNS
+//    a slice of BizErrors
+//-------------------------------------------------------------------------------------
+func MakeDeposit(d *rlib.Deposit, rcpts []int64, uid int64) []BizError {
+	var (
+		errlist []BizError
+		err     error
+		m       []rlib.Receipt
+	)
+	bad := func(s string) {
+		errlist = append(errlist, BizError{Errno: InvalidField, Message: BizErrors[InvalidField].Message + "\n" + s})
+	}
+
+	//------------------------------------------------
+	// validate
+	//------------------------------------------------
+	dep, err := rlib.GetDepository(d.DEPID)
+	if err != nil || dep.BID != d.BID {
+		bad("Depository")
+	}
+	if d.DPMID > 0 {
+		if dpm, err := rlib.GetDepositMethod(d.DPMID); err != nil || dpm.BID != d.BID {
+			bad("Deposit Method")
+		}
+	}
+	if len(rcpts) == 0 {
+		bad("At least one receipt is required")
+	}
+	for i := 0; i < len(rcpts); i++ {
+		r := rlib.GetReceiptNoAllocations(rcpts[i])
+		if r.RCPTID == 0 || r.BID != d.BID || r.DID != 0 || r.FLAGS&0x4 != 0 || (r.DEPID != 0 && r.DEPID != d.DEPID) {
+			bad(fmt.Sprintf("Receipt %s cannot be deposited", rlib.IDtoString("RCPT", rcpts[i])))
+			continue
+		}
+		m = append(m, r)
+	}
+	if len(errlist) > 0 {
+		return errlist
+	}
+	if errlist = ValidatePeriodOpen(d.BID, &d.Dt); len(errlist) > 0 {
+		return errlist
+	}
+
+	//------------------------------------------------
+	// save the Deposit and mark the receipts
+	//------------------------------------------------
+	d.Amount = float64(0)
+	for i := 0; i < len(m); i++ {
+		d.Amount += m[i].Amount
+	}
+	d.Amount = rlib.RoundToCent(d.Amount)
+	d.CreateBy = uid
+	d.LastModBy = uid
+	if d.DID, err = rlib.InsertDeposit(d); err != nil {
+		return bizErrSys(&err)
+	}
+	d.DP = nil
+	for i := 0; i < len(m); i++ {
+		dp := rlib.DepositPart{DID: d.DID, BID: d.BID, RCPTID: m[i].RCPTID, CreateBy: uid}
+		if err = rlib.InsertDepositPart(&dp); err != nil {
+			return bizErrSys(&err)
+		}
+		d.DP = append(d.DP, dp)
+		m[i].DID = d.DID
+		m[i].DEPID = d.DEPID
+		m[i].LastModBy = uid
+		if err = rlib.UpdateReceipt(&m[i]); err != nil {
+			return bizErrSys(&err)
+		}
+	}
+
+	//------------------------------------------------
+	// move the funds to the Depository's account
+	//------------------------------------------------
+	dacct := rlib.RRdb.BizTypes[d.BID].GLAccounts[dep.LID]
+	jnl := rlib.Journal{BID: d.BID, Dt: d.Dt, Type: rlib.JNLTYPEDEP, ID: d.DID, Comment: fmt.Sprintf("Deposit %s to %s", d.IDtoString(), dep.Name), CreateBy: uid, LastModBy: uid}
+	var ja []rlib.JournalAllocation
+	for i := 0; i < len(m); i++ {
+		ar := rlib.RRdb.BizTypes[d.BID].AR[m[i].ARID]
+		if ar.DebitLID == dep.LID || m[i].Amount == float64(0) {
+			continue // the funds were received directly into the depository's account
+		}
+		cacct := rlib.RRdb.BizTypes[d.BID].GLAccounts[ar.DebitLID]
+		amt := rlib.RoundToCent(m[i].Amount)
+		ja = append(ja, rlib.JournalAllocation{BID: d.BID, Amount: amt, TCID: m[i].TCID, RCPTID: m[i].RCPTID, CreateBy: uid,
+			AcctRule: fmt.Sprintf("d %s %.2f, c %s %.2f", dacct.GLNumber, amt, cacct.GLNumber, amt)})
+		jnl.Amount += amt
+	}
+	if len(ja) == 0 {
+		return nil
+	}
+	if _, err = rlib.InsertJournal(&jnl); err != nil {
+		return bizErrSys(&err)
+	}
+	for i := 0; i < len(ja); i++ {
+		ja[i].JID = jnl.JID
+		if err = rlib.InsertJournalAllocationEntry(&ja[i]); err != nil {
+			return bizErrSys(&err)
+		}
+		jnl.JA = append(jnl.JA, ja[i])
+	}
+	var xbiz rlib.XBusiness
+	rlib.InitBizInternals(d.BID, &xbiz)
+	d1, d2 := rlib.GetMonthPeriodForDate(&d.Dt)
+	rlib.InitLedgerCache()
+	rlib.GenerateLedgerEntriesFromJournal(&xbiz, &jnl, &d1, &d2)
+	if err = rlib.UpdateLedgerMarkersAfter(&xbiz, &d.Dt, uid); err != nil {
+		return bizErrSys(&err)
+	}
+	return nil
+}
+
+// GetDepositReceipts returns the receipts in deposit d
+func GetDepositReceipts(d *rlib.Deposit) []rlib.Receipt {
+	var m []rlib.Receipt
+	dp, err := rlib.GetDepositParts(d.DID)
+	if err != nil {
+		return m
+	}
+	for i := 0; i < len(dp); i++ {
+		m = append(m, rlib.GetReceiptNoAllocations(dp[i].RCPTID))
+	}
+	return m
+}
@@ -0,0 +1,227 @@
+package bizlogic
+
+import (
+	"fmt"
+	"rentroll/rlib"
+	"time"
+)
+
+// A floating deposit is money received from a prospect before a lease exists.
+// It is received into the Rental Agreement that holds the prospect's charges
+// (see prospectRentalAgreement), typically with an account rule like
+// d $(GLCASH) _, c $(GLGENRCV) _, and it stays unallocated until the prospect
+// is either converted into a Rental Agreement or declined. Prospect.FloatingDeposit
+// holds the amount that has not yet been applied or refunded.
+
+// AcceptFloatingDeposit records receipt r as a floating deposit from prospect
+// r.TCID. The receipt is journaled like any other receipt and assigned to the
+// prospect's Rental Agreement, which is created if needed.
+//
+// INPUTS
+//    r   = the receipt. BID, TCID, ARID, Dt and Amount are required.
+//    uid = the user accepting the deposit
+//
+// RETURNS
+//    a slice of BizErrors
+//-------------------------------------------------------------------------------------
+func AcceptFloatingDeposit(r *rlib.Receipt, uid int64) []BizError {
+	var errlist []BizError
+	bad := func(s string) {
+		errlist = append(errlist, BizError{Errno: InvalidField, Message: BizErrors[InvalidField].Message + "\n" + s})
+	}
+	p, errlist := getProspect(r.BID, r.TCID)
+	if len(errlist) > 0 {
+		return errlist
+	}
+	if s := ProspectStage(&p); s == PROSPECTDECLINED || s == PROSPECTCONVERTED {
+		bad(fmt.Sprintf("A floating deposit cannot be accepted from a %s prospect", ProspectStageNames[s]))
+	}
+	if r.Amount <= 0 {
+		bad("Amount")
+	}
+	if ar, err := rlib.GetAR(r.ARID); err != nil || ar.BID != r.BID || ar.ARType != rlib.ARRECEIPT {
+		bad("Account Rule")
+	}
+	if len(errlist) > 0 {
+		return errlist
+	}
+	if errlist = ValidatePeriodOpen(r.BID, &r.Dt); len(errlist) > 0 {
+		return errlist
+	}
+
+	err := prospectRentalAgreement(&p, &r.Dt, uid)
+	if err != nil {
+		return bizErrSys(&err)
+	}
+	r.Amount = rlib.RoundToCent(r.Amount)
+	if len(r.Comment) == 0 {
+		r.Comment = "floating deposit"
+	}
+	r.CreateBy = uid
+	r.LastModBy = uid
+	if err = InsertReceipt(r); err != nil {
+		return bizErrSys(&err)
+	}
+	if err = assignReceiptToRA(r, p.RAID); err != nil {
+		return bizErrSys(&err)
+	}
+	p.FloatingDeposit = rlib.RoundToCent(p.FloatingDeposit + r.Amount)
+	p.LastModBy = uid
+	if err = rlib.UpdateProspect(&p); err != nil {
+		return bizErrSys(&err)
+	}
+	return nil
+}
+
+// assignReceiptToRA marks the receipt allocations made when r was received as
+// belonging to Rental Agreement raid.
+func assignReceiptToRA(r *rlib.Receipt, raid int64) error {
+	for i := 0; i < len(r.RA); i++ {
+		if r.RA[i].ASMID > 0 {
+			continue
+		}
+		r.RA[i].RAID = raid
+		if err := rlib.UpdateReceiptAllocation(&r.RA[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// floatingDepositReceipts returns the unallocated receipts of prospect p
+// that were received as its floating deposit, that is, the ones assigned to
+// the prospect's Rental Agreement. Other receipts of the payor are left alone.
+func floatingDepositReceipts(p *rlib.Prospect) []rlib.Receipt {
+	var m []rlib.Receipt
+	if p.RAID == 0 {
+		return m
+	}
+	n := rlib.GetUnallocatedReceiptsByPayor(p.BID, p.TCID)
+	for i := 0; i < len(n); i++ {
+		for j := 0; j < len(n[i].RA); j++ {
+			if n[i].RA[j].ASMID == 0 && n[i].RA[j].RAID == p.RAID {
+				m = append(m, n[i])
+				break
+			}
+		}
+	}
+	return m
+}
+
+// applyFloatingDeposit pays the unpaid assessments of the prospect's Rental
+// Agreement, oldest first, from the prospect's floating deposit receipts.
+// p.FloatingDeposit is reduced by the amount applied, but p is not saved. The
+// payments are made by user uid.
+//
+// RETURNS
+//    the amount applied
+//    any error encountered
+//-------------------------------------------------------------------------------------
+func applyFloatingDeposit(p *rlib.Prospect, dt *time.Time, uid int64) (float64, error) {
+	tot := float64(0)
+	if p.RAID == 0 {
+		return tot, nil
+	}
+	n := floatingDepositReceipts(p)
+	m := rlib.GetUnpaidAssessmentsByRAID(p.RAID)
+	for i := 0; i < len(m); i++ {
+		needed := AssessmentUnpaidPortion(&m[i])
+		for j := 0; j < len(n) && needed > ROUNDINGERR; j++ {
+			if n[j].FLAGS&3 == 2 {
+				continue // no funds left in this receipt
+			}
+			amt := needed
+			owed := needed
+			if err := PayAssessment(&m[i], &n[j], &needed, &amt, dt, uid); err != nil {
+				return tot, err
+			}
+			tot += owed - needed
+		}
+	}
+	tot = rlib.RoundToCent(tot)
+	p.FloatingDeposit = rlib.RoundToCent(p.FloatingDeposit - tot)
+	if p.FloatingDeposit < 0 {
+		p.FloatingDeposit = 0
+	}
+	return tot, nil
+}
+
+// FloatingDepositRefund describes what happened to a declined prospect's
+// floating deposit.
+type FloatingDepositRefund struct {
+	Applied float64 // amount applied to the prospect's unpaid charges
+	Refund  float64 // amount refunded
+	RCPTIDs []int64 // the refund receipts
+}
+
+// RefundFloatingDeposit refunds the floating deposit of declined prospect tcid
+// on dt. Any unpaid charges of the prospect, such as the application fee, are
+// paid from the deposit first. Each receipt with funds remaining is then
+// refunded by a receipt for the negative of those funds, linked to it through
+// PRCPTID and dated dt, which reverses the journal and ledger entries made
+// when the deposit was received.
+//
+// INPUTS
+//    bid  = business id
+//    tcid = the prospect
+//    dt   = date of the refund
+//    uid  = the user processing the refund
+//
+// RETURNS
+//    what was applied and refunded
+//    a slice of BizErrors
+//-------------------------------------------------------------------------------------
+func RefundFloatingDeposit(bid, tcid int64, dt *time.Time, uid int64) (FloatingDepositRefund, []BizError) {
+	var fr FloatingDepositRefund
+	p, errlist := getProspect(bid, tcid)
+	if len(errlist) > 0 {
+		return fr, errlist
+	}
+	if ProspectStage(&p) != PROSPECTDECLINED {
+		return fr, []BizError{{Errno: InvalidField, Message: BizErrors[InvalidField].Message + "\nOnly the floating deposit of a declined prospect can be refunded"}}
+	}
+	if errlist = ValidatePeriodOpen(bid, dt); len(errlist) > 0 {
+		return fr, errlist
+	}
+
+	var err error
+	if fr.Applied, err = applyFloatingDeposit(&p, dt, uid); err != nil {
+		return fr, bizErrSys(&err)
+	}
+	n := floatingDepositReceipts(&p)
+	for i := 0; i < len(n); i++ {
+		amt := rlib.RoundToCent(RemainingReceiptFunds(&n[i]))
+		if amt < ROUNDINGERR {
+			continue
+		}
+		rr := rlib.Receipt{BID: bid, TCID: tcid, PRCPTID: n[i].RCPTID, PMTID: n[i].PMTID, DEPID: n[i].DEPID,
+			Dt: *dt, DocNo: "REFUND", Amount: -amt, ARID: n[i].ARID, FLAGS: 2,
+			Comment:  fmt.Sprintf("Refund of floating deposit receipt %s", n[i].IDtoString()),
+			CreateBy: uid, LastModBy: uid}
+		if err = InsertReceipt(&rr); err != nil {
+			return fr, bizErrSys(&err)
+		}
+		if err = assignReceiptToRA(&rr, p.RAID); err != nil {
+			return fr, bizErrSys(&err)
+		}
+		n[i].FLAGS = (n[i].FLAGS &^ 3) | 2
+		if len(n[i].Comment) > 0 {
+			n[i].Comment += ", "
+		}
+		n[i].Comment += fmt.Sprintf("Refunded by receipt %s", rr.IDtoString())
+		n[i].LastModBy = uid
+		if err = rlib.UpdateReceipt(&n[i]); err != nil {
+			return fr, bizErrSys(&err)
+		}
+		fr.Refund += amt
+		fr.RCPTIDs = append(fr.RCPTIDs, rr.RCPTID)
+	}
+	fr.Refund = rlib.RoundToCent(fr.Refund)
+
+	p.FloatingDeposit = 0
+	p.LastModBy = uid
+	if err = rlib.UpdateProspect(&p); err != nil {
+		return fr, bizErrSys(&err)
+	}
+	return fr, nil
+}
@@ -0,0 +1,249 @@
+package bizlogic
+
+import (
+	"fmt"
+	"rentroll/rlib"
+	"strings"
+	"time"
+)
+
+// SaveGoodsService validates and writes an item of the catalog of goods and
+// services of a business. Names are unique within a business. The account
+// rule must be one for assessments and the tax, if any, must belong to the
+// business.
+//
+// INPUTS
+//    g   = the item to save, inserted if GSID is 0
+//    uid = the user saving it
+//
+// RETURNS
+//    a slice of BizErrors
+//-------------------------------------------------------------------------------------
+func SaveGoodsService(g *rlib.GoodsService, uid int64) []BizError {
+	var errlist []BizError
+	bad := func(s string) {
+		errlist = append(errlist, BizError{Errno: InvalidField, Message: BizErrors[InvalidField].Message + "\n" + s})
+	}
+
+	g.Name = strings.TrimSpace(g.Name)
+	if len(g.Name) == 0 {
+		bad("Name")
+	} else if x, err := rlib.GetGoodsServiceByName(g.BID, g.Name); err == nil && x.GSID != g.GSID {
+		bad(fmt.Sprintf("There is already an item named %s", g.Name))
+	}
+	if g.Price < 0 {
+		bad("Price")
+	}
+	if ar, err := rlib.GetAR(g.ARID); err != nil || ar.BID != g.BID || ar.ARType != rlib.ARASSESSMENT {
+		bad("Account Rule")
+	}
+	if g.TAXID > 0 {
+		if t, err := rlib.GetTax(g.TAXID); err != nil || t.BID != g.BID {
+			bad("Tax")
+		}
+	}
+	if len(errlist) > 0 {
+		return errlist
+	}
+
+	var err error
+	g.Price = rlib.RoundToCent(g.Price)
+	g.LastModBy = uid
+	if g.GSID > 0 {
+		err = rlib.UpdateGoodsService(g)
+	} else {
+		g.CreateBy = uid
+		_, err = rlib.InsertGoodsService(g)
+	}
+	if err != nil {
+		return bizErrSys(&err)
+	}
+	return nil
+}
+
+// Sale describes a sale of goods or services to a Rental Agreement or to a
+// walk-in Transactant. A walk-in sale must be paid when it is made.
+type Sale struct {
+	BID      int64     // business
+	GSID     int64     // the item sold
+	Quantity int64     // number of items sold
+	Price    float64   // unit price, 0 = the catalog price
+	RAID     int64     // Rental Agreement charged, 0 for a walk-in sale
+	TCID     int64     // payor, required for a walk-in sale. For a Rental Agreement it defaults to its payor on Dt
+	Dt       time.Time // date of the sale
+	Paid     bool      // true if the sale is paid now, in which case a receipt is made
+	PMTID    int64     // payment type of the receipt
+	RcptARID int64     // account rule of the receipt
+	DocNo    string    // check number or other document of the payment
+	UID      int64     // user making the sale
+}
+
+// SaleResult is the outcome of a sale
+type SaleResult struct {
+	Assessments []rlib.Assessment // the charge for the items followed by the tax, if any
+	Receipt     rlib.Receipt      // the receipt, if the sale was paid
+	Total       float64           // total charged, tax included
+}
+
+// validateSale checks the sale for business logic errors before anything is
+// written. It returns the item being sold.
+func validateSale(s *Sale) (rlib.GoodsService, []BizError) {
+	var errlist []BizError
+	bad := func(m string) {
+		errlist = append(errlist, BizError{Errno: InvalidField, Message: BizErrors[InvalidField].Message + "\n" + m})
+	}
+
+	g, err := rlib.GetGoodsService(s.GSID)
+	if err != nil || g.BID != s.BID {
+		bad("Goods or Service")
+	} else if g.FLAGS&rlib.GSINACTIVE != 0 {
+		bad(fmt.Sprintf("%s is no longer sold", g.Name))
+	}
+	if s.Quantity <= 0 {
+		bad("Quantity")
+	}
+	if s.Price < 0 {
+		bad("Price")
+	}
+	if s.RAID > 0 {
+		ra, err := rlib.GetRentalAgreement(s.RAID)
+		if err != nil || ra.BID != s.BID {
+			bad("Rental Agreement")
+		} else {
+			dt1 := s.Dt.AddDate(0, 0, 1)
+			p := rlib.GetRentalAgreementPayorsInRange(s.RAID, &s.Dt, &dt1)
+			found := s.TCID == 0
+			for i := 0; i < len(p) && !found; i++ {
+				found = p[i].TCID == s.TCID
+			}
+			if len(p) == 0 || !found {
+				bad("Payor")
+			} else if s.TCID == 0 {
+				s.TCID = p[0].TCID
+			}
+		}
+	} else {
+		var t rlib.Transactant
+		if err := rlib.GetTransactant(s.TCID, &t); err != nil || t.TCID == 0 || t.BID != s.BID {
+			bad("Payor")
+		}
+		if !s.Paid {
+			bad("A walk-in sale must be paid when it is made")
+		}
+	}
+	if s.Paid {
+		var pt rlib.PaymentType
+		rlib.GetPaymentType(s.PMTID, &pt)
+		if pt.PMTID == 0 || pt.BID != s.BID {
+			bad("Payment Type")
+		}
+		if ar, err := rlib.GetAR(s.RcptARID); err != nil || ar.BID != s.BID || ar.ARType != rlib.ARRECEIPT {
+			bad("Receipt Account Rule")
+		}
+	}
+	if len(errlist) > 0 {
+		return g, errlist
+	}
+	return g, ValidatePeriodOpen(s.BID, &s.Dt)
+}
+
+// ProcessSale rings up a sale of goods or services. It makes a one-time
+// assessment for the items using the item's account rule. If the item is
+// taxed and the Rental Agreement, if any, is taxable, the tax is computed
+// from the TaxRate in effect on the date of the sale and assessed using the
+// Tax's account rule. Both are journaled and posted to the ledgers through
+// the usual assessment path. If the sale is paid, a receipt for the total is
+// made and applied to the assessments.
+//
+// INPUTS
+//    s = the sale
+//
+// RETURNS
+//    the assessments and the receipt that were made
+//    a slice of BizErrors
+//-------------------------------------------------------------------------------------
+func ProcessSale(s *Sale) (SaleResult, []BizError) {
+	var res SaleResult
+	g, errlist := validateSale(s)
+	if len(errlist) > 0 {
+		return res, errlist
+	}
+	price := g.Price
+	if s.Price > 0 {
+		price = s.Price
+	}
+
+	//------------------------------------------------
+	// the charge for the items
+	//------------------------------------------------
+	ar, _ := rlib.GetAR(g.ARID)
+	a := rlib.Assessment{BID: s.BID, RAID: s.RAID, ATypeLID: ar.CreditLID, ARID: g.ARID,
+		Amount: rlib.RoundToCent(price * float64(s.Quantity)), Start: s.Dt, Stop: s.Dt,
+		RentCycle: rlib.CYCLENORECUR, ProrationCycle: rlib.CYCLENORECUR,
+		Comment: fmt.Sprintf("%d x %s", s.Quantity, g.Name), CreateBy: s.UID, LastModBy: s.UID}
+	if errlist = InsertAssessment(&a, 0, s.UID); len(errlist) > 0 {
+		return res, errlist
+	}
+	res.Assessments = append(res.Assessments, a)
+
+	//------------------------------------------------
+	// the tax
+	//------------------------------------------------
+	if g.TAXID > 0 && rlib.IsRentalAgreementTaxable(s.RAID, &s.Dt) {
+		tax, err := rlib.GetTax(g.TAXID)
+		if err != nil {
+			return res, bizErrSys(&err)
+		}
+		tr, err := rlib.GetTaxRateForDate(tax.TAXID, &s.Dt)
+		if err != nil && !rlib.IsSQLNoResultsError(err) {
+			return res, bizErrSys(&err)
+		}
+		if tr.TRID == 0 {
+			return res, []BizError{{Errno: InvalidField, Message: BizErrors[InvalidField].Message +
+				fmt.Sprintf("\nTax %s has no rate in effect on %s", tax.Name, s.Dt.Format(rlib.RRDATEFMT4))}}
+		}
+		var xbiz rlib.XBusiness
+		rlib.InitBizInternals(s.BID, &xbiz)
+		d1, d2 := rlib.GetMonthPeriodForDate(&s.Dt)
+		amt := rlib.CalculateTax(&xbiz, &a, &tr, a.Amount, &d1, &d2)
+		if amt != float64(0) {
+			tar, _ := rlib.GetAR(tax.ARID)
+			t := rlib.Assessment{BID: s.BID, RAID: s.RAID, ATypeLID: tar.CreditLID, ARID: tax.ARID,
+				Amount: amt, Start: s.Dt, Stop: s.Dt, RentCycle: rlib.CYCLENORECUR, ProrationCycle: rlib.CYCLENORECUR,
+				Comment: fmt.Sprintf("%s on %s", tax.Name, a.IDtoString()), CreateBy: s.UID, LastModBy: s.UID}
+			if errlist = InsertAssessment(&t, 0, s.UID); len(errlist) > 0 {
+				return res, errlist
+			}
+			res.Assessments = append(res.Assessments, t)
+		}
+	}
+	for i := 0; i < len(res.Assessments); i++ {
+		res.Total += res.Assessments[i].Amount
+	}
+	res.Total = rlib.RoundToCent(res.Total)
+	if !s.Paid || res.Total == float64(0) {
+		return res, nil
+	}
+
+	//------------------------------------------------
+	// the receipt
+	//------------------------------------------------
+	res.Receipt = rlib.Receipt{BID: s.BID, TCID: s.TCID, PMTID: s.PMTID, Dt: s.Dt, DocNo: s.DocNo, Amount: res.Total,
+		ARID: s.RcptARID, Comment: fmt.Sprintf("payment for %s", a.Comment), CreateBy: s.UID, LastModBy: s.UID}
+	if err := InsertReceipt(&res.Receipt); err != nil {
+		return res, bizErrSys(&err)
+	}
+	if s.RAID > 0 {
+		if err := assignReceiptToRA(&res.Receipt, s.RAID); err != nil {
+			return res, bizErrSys(&err)
+		}
+	}
+	for i := 0; i < len(res.Assessments); i++ {
+		needed := res.Assessments[i].Amount
+		amt := needed
+		if err := PayAssessment(&res.Assessments[i], &res.Receipt, &needed, &amt, &s.Dt, s.UID); err != nil {
+			return res, bizErrSys(&err)
+		}
+	}
+	return res, nil
+}
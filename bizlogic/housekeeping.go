@@ -0,0 +1,254 @@
+package bizlogic
+
+import (
+	"rentroll/rlib"
+	"sort"
+	"strings"
+	"time"
+)
+
+// HKStatusNames maps the housekeeping states to their names
+var HKStatusNames = map[int64]string{
+	rlib.HKUNKNOWN:    "unknown",
+	rlib.HKDIRTY:      "dirty",
+	rlib.HKCLEAN:      "clean",
+	rlib.HKINSPECTED:  "inspected",
+	rlib.HKOUTOFORDER: "out of order",
+}
+
+// HKStatusFromName returns the housekeeping state named s, or -1 if there is
+// no such state.
+func HKStatusFromName(s string) int64 {
+	s = strings.ToLower(strings.TrimSpace(s))
+	for k, v := range HKStatusNames {
+		if v == s {
+			return k
+		}
+	}
+	return -1
+}
+
+// The occupancy of a room on a given day, as shown on the housekeeping board
+const (
+	HKOCCVACANT    = "vacant"    // nobody stayed last night or arrives today
+	HKOCCARRIVAL   = "arrival"   // a guest arrives today
+	HKOCCSTAYOVER  = "stay-over" // the guest who stayed last night stays tonight
+	HKOCCDEPARTURE = "departure" // the guest checks out today
+	HKOCCTURNOVER  = "turnover"  // a guest checks out and another arrives today
+)
+
+// hkDay returns the start and end of the day containing dt in the
+// business's time zone.
+func hkDay(dt *time.Time) (time.Time, time.Time) {
+	t := dt.In(rlib.RRdb.Zone)
+	d0 := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, rlib.RRdb.Zone)
+	return d0, d0.AddDate(0, 0, 1)
+}
+
+// roomOccupancy returns the occupancy of Rentable rid on the day d0 - d1
+func roomOccupancy(rid int64, d0, d1 *time.Time) string {
+	var arrives, departs, stays bool
+	prev := d0.AddDate(0, 0, -1)
+	m := rlib.GetAgreementsForRentable(rid, &prev, d1)
+	for i := 0; i < len(m); i++ {
+		if rlib.DateInRange(&m[i].RARDtStart, d0, d1) {
+			arrives = true
+		}
+		if rlib.DateInRange(&m[i].RARDtStop, d0, d1) {
+			departs = true
+		}
+		if m[i].RARDtStart.Before(*d0) && !m[i].RARDtStop.Before(*d1) {
+			stays = true
+		}
+	}
+	switch {
+	case departs && arrives:
+		return HKOCCTURNOVER
+	case departs:
+		return HKOCCDEPARTURE
+	case arrives:
+		return HKOCCARRIVAL
+	case stays:
+		return HKOCCSTAYOVER
+	}
+	return HKOCCVACANT
+}
+
+// hotelRentables returns the Rentables of business bid that rent by the
+// night on dt, that is those whose RentableType has a daily rent cycle.
+func hotelRentables(bid int64, dt *time.Time) []rlib.Rentable {
+	var m []rlib.Rentable
+	rt := rlib.GetBusinessRentableTypes(bid)
+	r := rlib.GetAllRentablesByBusiness(bid)
+	for i := 0; i < len(r); i++ {
+		if rt[rlib.GetRTIDForDate(r[i].RID, dt)].RentCycle == rlib.RECURDAILY {
+			m = append(m, r[i])
+		}
+	}
+	return m
+}
+
+// markRoomDirty marks Rentable rid dirty unless it already is dirty or it is
+// out of order.
+//
+// RETURNS
+//    true if the state was changed
+//    any error encountered
+func markRoomDirty(bid, rid int64, dt *time.Time, comment string, uid int64) (bool, error) {
+	switch rlib.GetHousekeepingStatus(rid).Status {
+	case rlib.HKDIRTY, rlib.HKOUTOFORDER:
+		return false, nil
+	}
+	a := rlib.HousekeepingStatus{BID: bid, RID: rid, Status: rlib.HKDIRTY, Dt: *dt, Comment: comment, CreateBy: uid}
+	_, err := rlib.InsertHousekeepingStatus(&a)
+	return err == nil, err
+}
+
+// SetHousekeepingStatus changes the housekeeping state of a Rentable and
+// records the change in its history. A room must be clean before it can be
+// inspected.
+//
+// INPUTS
+//    bid     = business id
+//    rid     = the Rentable
+//    status  = the new state, HKDIRTY ... HKOUTOFORDER
+//    dt      = when the state changed, defaults to now
+//    comment = reason for the change
+//    uid     = the user making the change
+//
+// RETURNS
+//    a slice of BizErrors
+//-------------------------------------------------------------------------------------
+func SetHousekeepingStatus(bid, rid, status int64, dt *time.Time, comment string, uid int64) []BizError {
+	var errlist []BizError
+	bad := func(s string) {
+		errlist = append(errlist, BizError{Errno: InvalidField, Message: BizErrors[InvalidField].Message + "\n" + s})
+	}
+	if r := rlib.GetRentable(rid); r.RID == 0 || r.BID != bid {
+		bad("Rentable")
+	}
+	if _, ok := HKStatusNames[status]; !ok || status == rlib.HKUNKNOWN {
+		bad("Status")
+	}
+	if status == rlib.HKINSPECTED && rlib.GetHousekeepingStatus(rid).Status != rlib.HKCLEAN {
+		bad("A room must be clean before it can be inspected")
+	}
+	if len(errlist) > 0 {
+		return errlist
+	}
+	if dt.Year() <= 1970 {
+		*dt = time.Now()
+	}
+	a := rlib.HousekeepingStatus{BID: bid, RID: rid, Status: status, Dt: *dt, Comment: comment, CreateBy: uid}
+	if _, err := rlib.InsertHousekeepingStatus(&a); err != nil {
+		return bizErrSys(&err)
+	}
+	return nil
+}
+
+// HousekeepingCheckout marks the Rentable of rar dirty because its guest
+// checked out on dt. It is called when a Rentable is moved out of a Rental
+// Agreement.
+//
+// INPUTS
+//    rar = the RentalAgreementRentable that ended
+//    dt  = the checkout date
+//    uid = the user checking the guest out
+//
+// RETURNS
+//    a slice of BizErrors
+//-------------------------------------------------------------------------------------
+func HousekeepingCheckout(rar *rlib.RentalAgreementRentable, dt *time.Time, uid int64) []BizError {
+	ra := rlib.RentalAgreement{RAID: rar.RAID}
+	if _, err := markRoomDirty(rar.BID, rar.RID, dt, "checkout "+ra.IDtoString(), uid); err != nil {
+		return bizErrSys(&err)
+	}
+	return nil
+}
+
+// ResetHousekeeping starts the housekeeping day dt for business bid. Every
+// nightly Rentable whose guest stays over or checks out that day is marked
+// dirty. Rooms that are out of order are left alone.
+//
+// INPUTS
+//    bid = business id
+//    dt  = the day
+//    uid = the user resetting the rooms, 0 for the system
+//
+// RETURNS
+//    the number of rooms marked dirty
+//    a slice of BizErrors
+//-------------------------------------------------------------------------------------
+func ResetHousekeeping(bid int64, dt *time.Time, uid int64) (int, []BizError) {
+	var errlist []BizError
+	n := 0
+	d0, d1 := hkDay(dt)
+	r := hotelRentables(bid, &d0)
+	for i := 0; i < len(r); i++ {
+		var comment string
+		switch roomOccupancy(r[i].RID, &d0, &d1) {
+		case HKOCCSTAYOVER:
+			comment = "stay-over"
+		case HKOCCDEPARTURE, HKOCCTURNOVER:
+			comment = "checkout"
+		default:
+			continue
+		}
+		changed, err := markRoomDirty(bid, r[i].RID, dt, comment, uid)
+		if err != nil {
+			errlist = append(errlist, bizErrSys(&err)...)
+			continue
+		}
+		if changed {
+			n++
+		}
+	}
+	return n, errlist
+}
+
+// HousekeepingBoardEntry is the housekeeping state of one room on the board
+type HousekeepingBoardEntry struct {
+	BLDGID       int64  // Building of the room
+	Building     string // its address
+	RID          int64
+	RentableName string
+	Status       int64     // housekeeping state
+	Dt           time.Time // when the state last changed
+	Comment      string    // reason for the last change
+	Occupancy    string    // HKOCCVACANT ... HKOCCTURNOVER
+}
+
+// GetHousekeepingBoard returns the housekeeping board of business bid for the
+// day dt. It lists every nightly Rentable sorted by Building and Rentable name.
+//
+// INPUTS
+//    bid = business id
+//    dt  = the day
+//
+// RETURNS
+//    the board
+//-------------------------------------------------------------------------------------
+func GetHousekeepingBoard(bid int64, dt *time.Time) []HousekeepingBoardEntry {
+	var m []HousekeepingBoardEntry
+	d0, d1 := hkDay(dt)
+	bldg := map[int64]string{}
+	b := rlib.GetAllBuildings(bid)
+	for i := 0; i < len(b); i++ {
+		bldg[b[i].BLDGID] = b[i].Address
+	}
+	cur := rlib.GetCurrentHousekeepingStatuses(bid)
+	r := hotelRentables(bid, &d0)
+	for i := 0; i < len(r); i++ {
+		s := cur[r[i].RID]
+		m = append(m, HousekeepingBoardEntry{BLDGID: r[i].BLDGID, Building: bldg[r[i].BLDGID], RID: r[i].RID,
+			RentableName: r[i].RentableName, Status: s.Status, Dt: s.Dt, Comment: s.Comment,
+			Occupancy: roomOccupancy(r[i].RID, &d0, &d1)})
+	}
+	sort.Slice(m, func(i, j int) bool {
+		if m[i].BLDGID != m[j].BLDGID {
+			return m[i].BLDGID < m[j].BLDGID
+		}
+		return m[i].RentableName < m[j].RentableName
+	})
+	return m
+}
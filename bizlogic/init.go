@@ -27,6 +27,15 @@ const (
 	EditReversal          = 3
 	PostToSummaryAcct     = 4
 	RuleUsesAcct          = 5
+	PostToClosedPeriod    = 6
+	TrialBalanceNotZero   = 7
+	PeriodAlreadyClosed   = 8
+	AdjustOpenPeriod      = 9
+	RentableNotVacant     = 10
+	RentableNotRented     = 11
+	NoInvoiceAssessments  = 12
+	InvoiceIsVoid         = 13
+	ServiceRequestClosed  = 14
 )
 
 // InitBizLogic loads the error messages needed for validation errors
@@ -35,7 +44,11 @@ func InitBizLogic() {
 	if err != nil {
 		log.Fatal(err)
 	}
-	fname := folderPath + "/bizerr.csv"
+	loadBizErrors(folderPath + "/bizerr.csv")
+}
+
+// loadBizErrors reads the error messages from the csv file fname
+func loadBizErrors(fname string) {
 	t := rlib.LoadCSV(fname)
 	for i := 0; i < len(t); i++ {
 		n := strings.TrimSpace(t[i][0])
@@ -0,0 +1,245 @@
+package bizlogic
+
+import (
+	"rentroll/rlib"
+	"time"
+)
+
+// InvoiceRequest describes an invoice to be generated. The invoice bills the
+// unbilled assessments of Rental Agreement RAID, or of every Rental Agreement
+// on which TCID is a payor, that fall in the period DtStart - DtStop.
+type InvoiceRequest struct {
+	BID         int64     // business
+	RAID        int64     // bill this Rental Agreement, or
+	TCID        int64     // bill this payor
+	DtStart     time.Time // first day of the billing period
+	DtStop      time.Time // day after the last day of the billing period
+	Dt          time.Time // invoice date
+	DtDue       time.Time // date the invoice is due
+	DeliveredBy string    // mail, email, ...
+	UID         int64     // user generating the invoice
+}
+
+// invoiceableAssessment returns true if assessment a can be billed on a new
+// invoice: it is not already on an invoice, it is not a recurring definition
+// and it has not been reversed or paid.
+func invoiceableAssessment(a *rlib.Assessment) bool {
+	if a.InvoiceNo != 0 || a.FLAGS&0x4 != 0 || a.FLAGS&3 == 2 {
+		return false
+	}
+	return a.PASMID != 0 || a.RentCycle == rlib.RECURNONE
+}
+
+// GenerateInvoice creates an invoice for the request. Each assessment billed
+// is stamped with the InvoiceNo so that it is not billed again.
+//
+// INPUTS
+//    req = the invoice to generate
+//
+// RETURNS
+//    the invoice
+//    a slice of BizErrors
+//-------------------------------------------------------------------------------------
+func GenerateInvoice(req *InvoiceRequest) (rlib.Invoice, []BizError) {
+	var (
+		inv     rlib.Invoice
+		err     error
+		errlist []BizError
+		raids   []int64
+		payors  []int64
+		asms    []rlib.Assessment
+	)
+	bad := func(s string) {
+		errlist = append(errlist, BizError{Errno: InvalidField, Message: BizErrors[InvalidField].Message + "\n" + s})
+	}
+
+	//------------------------------------------------
+	// the Rental Agreements and payors being billed
+	//------------------------------------------------
+	if !req.DtStop.After(req.DtStart) {
+		bad("The billing period end must be after its start")
+	}
+	switch {
+	case req.RAID > 0:
+		ra, err := rlib.GetRentalAgreement(req.RAID)
+		if err != nil || ra.BID != req.BID {
+			bad("Rental Agreement")
+			break
+		}
+		raids = append(raids, ra.RAID)
+		m := rlib.GetRentalAgreementPayorsInRange(ra.RAID, &req.DtStart, &req.DtStop)
+		for i := 0; i < len(m); i++ {
+			payors = append(payors, m[i].TCID)
+		}
+	case req.TCID > 0:
+		var t rlib.Transactant
+		if err = rlib.GetTransactant(req.TCID, &t); err != nil || t.BID != req.BID {
+			bad("Payor")
+			break
+		}
+		payors = append(payors, req.TCID)
+		seen := map[int64]bool{}
+		last := req.DtStop.AddDate(0, 0, -1)
+		for _, dt := range []*time.Time{&req.DtStart, &last} {
+			m := rlib.GetRentalAgreementsByPayor(req.BID, req.TCID, dt)
+			for i := 0; i < len(m); i++ {
+				if !seen[m[i].RAID] {
+					seen[m[i].RAID] = true
+					raids = append(raids, m[i].RAID)
+				}
+			}
+		}
+	default:
+		bad("A Rental Agreement or a payor is required")
+	}
+	if len(errlist) > 0 {
+		return inv, errlist
+	}
+
+	//------------------------------------------------
+	// the assessments being billed
+	//------------------------------------------------
+	for i := 0; i < len(raids); i++ {
+		m := rlib.GetAssessmentsByRAIDRange(raids[i], &req.DtStart, &req.DtStop)
+		for j := 0; j < len(m); j++ {
+			if invoiceableAssessment(&m[j]) && m[j].Start.Before(req.DtStop) && !m[j].Start.Before(req.DtStart) {
+				asms = append(asms, m[j])
+				inv.Amount += m[j].Amount
+			}
+		}
+	}
+	if len(asms) == 0 {
+		return inv, []BizError{BizErrors[NoInvoiceAssessments]}
+	}
+
+	//------------------------------------------------
+	// save the invoice
+	//------------------------------------------------
+	inv.BID = req.BID
+	inv.Dt = req.Dt
+	inv.DtDue = req.DtDue
+	inv.Amount = rlib.RoundToCent(inv.Amount)
+	inv.DeliveredBy = req.DeliveredBy
+	inv.CreateBy = req.UID
+	inv.LastModBy = req.UID
+	if inv.InvoiceNo, err = rlib.InsertInvoice(&inv); err != nil {
+		return inv, bizErrSys(&err)
+	}
+	for i := 0; i < len(payors); i++ {
+		p := rlib.InvoicePayor{InvoiceNo: inv.InvoiceNo, BID: inv.BID, PID: payors[i], CreateBy: req.UID}
+		if err = rlib.InsertInvoicePayor(&p); err != nil {
+			return inv, bizErrSys(&err)
+		}
+		inv.P = append(inv.P, p)
+	}
+	for i := 0; i < len(asms); i++ {
+		a := rlib.InvoiceAssessment{InvoiceNo: inv.InvoiceNo, BID: inv.BID, ASMID: asms[i].ASMID, CreateBy: req.UID}
+		if err = rlib.InsertInvoiceAssessment(&a); err != nil {
+			return inv, bizErrSys(&err)
+		}
+		inv.A = append(inv.A, a)
+		asms[i].InvoiceNo = inv.InvoiceNo
+		asms[i].LastModBy = req.UID
+		if err = rlib.UpdateAssessment(&asms[i]); err != nil {
+			return inv, bizErrSys(&err)
+		}
+	}
+	return inv, nil
+}
+
+// InvoiceBalance returns the amount still unpaid on the assessments of
+// invoice inv.
+func InvoiceBalance(inv *rlib.Invoice) float64 {
+	var bal float64
+	for i := 0; i < len(inv.A); i++ {
+		a, err := rlib.GetAssessment(inv.A[i].ASMID)
+		if err != nil || a.FLAGS&0x4 != 0 {
+			continue
+		}
+		bal += AssessmentUnpaidPortion(&a)
+	}
+	return rlib.RoundToCent(bal)
+}
+
+// InvoiceStatus returns a description of the payment status of invoice inv
+func InvoiceStatus(inv *rlib.Invoice) string {
+	switch {
+	case inv.FLAGS&rlib.INVOICEVOID != 0:
+		return "Void"
+	case inv.FLAGS&rlib.INVOICEPAID != 0:
+		return "Paid"
+	}
+	return "Unpaid"
+}
+
+// UpdateInvoicePaidStatus sets or clears the paid flag of invoice id
+// depending on whether all of its assessments have been paid in full.
+//
+// INPUTS
+//    id  = InvoiceNo
+//
+// RETURNS
+//    any error encountered
+//-------------------------------------------------------------------------------------
+func UpdateInvoicePaidStatus(id int64) error {
+	inv, err := rlib.GetInvoice(id)
+	if err != nil {
+		return err
+	}
+	if inv.FLAGS&rlib.INVOICEVOID != 0 {
+		return nil
+	}
+	flags := inv.FLAGS &^ rlib.INVOICEPAID
+	if InvoiceBalance(&inv) < ROUNDINGERR {
+		flags |= rlib.INVOICEPAID
+	}
+	if flags == inv.FLAGS {
+		return nil
+	}
+	inv.FLAGS = flags
+	return rlib.UpdateInvoice(&inv)
+}
+
+// VoidInvoice marks invoice id as void. The invoice is kept for reference,
+// but its assessments are released so that they can be billed again.
+//
+// INPUTS
+//    bid = business id
+//    id  = InvoiceNo
+//    uid = the user voiding the invoice
+//
+// RETURNS
+//    a slice of BizErrors
+//-------------------------------------------------------------------------------------
+func VoidInvoice(bid, id, uid int64) []BizError {
+	inv, err := rlib.GetInvoice(id)
+	if err != nil {
+		return bizErrSys(&err)
+	}
+	if inv.InvoiceNo == 0 || inv.BID != bid {
+		return []BizError{{Errno: InvalidField, Message: BizErrors[InvalidField].Message + "\nInvoice"}}
+	}
+	if inv.FLAGS&rlib.INVOICEVOID != 0 {
+		return []BizError{BizErrors[InvoiceIsVoid]}
+	}
+	for i := 0; i < len(inv.A); i++ {
+		a, err := rlib.GetAssessment(inv.A[i].ASMID)
+		if err != nil {
+			return bizErrSys(&err)
+		}
+		if a.InvoiceNo != inv.InvoiceNo {
+			continue
+		}
+		a.InvoiceNo = 0
+		a.LastModBy = uid
+		if err = rlib.UpdateAssessment(&a); err != nil {
+			return bizErrSys(&err)
+		}
+	}
+	inv.FLAGS |= rlib.INVOICEVOID
+	inv.LastModBy = uid
+	if err = rlib.UpdateInvoice(&inv); err != nil {
+		return bizErrSys(&err)
+	}
+	return nil
+}
@@ -0,0 +1,153 @@
+package bizlogic
+
+import (
+	"fmt"
+	"rentroll/rlib"
+	"time"
+)
+
+// AGINGCURRENT and the others are the aging buckets for unpaid assessments.
+// An assessment is current on the day it is due. After that it falls into
+// the bucket for the number of days it is past due: 1-30, 31-60, 61-90, or
+// more than 90.
+const (
+	AGINGCURRENT = 0
+	AGING30      = 1
+	AGING60      = 2
+	AGING90      = 3
+	AGING90PLUS  = 4
+	AGINGBUCKETS = 5 // the number of buckets
+)
+
+// Aging is the unpaid balance of a Rental Agreement on a date, broken out
+// by how long the assessments making it up have been past due.
+type Aging struct {
+	RAID   int64
+	Bucket [AGINGBUCKETS]float64 // unpaid amounts indexed by AGINGCURRENT ... AGING90PLUS
+	Total  float64               // total unpaid
+}
+
+// AgingBucket returns the aging bucket for an assessment due on due that is
+// unpaid on dt.
+func AgingBucket(due, dt *time.Time) int {
+	days := int(rlib.DateAtTimeZero(*dt).Sub(rlib.DateAtTimeZero(*due)).Hours() / 24)
+	switch {
+	case days <= 0:
+		return AGINGCURRENT
+	case days <= 30:
+		return AGING30
+	case days <= 60:
+		return AGING60
+	case days <= 90:
+		return AGING90
+	}
+	return AGING90PLUS
+}
+
+// GetAging computes the aging of the unpaid assessments for each Rental
+// Agreement in business bid that has a balance due on dt.
+//
+// INPUTS
+//    bid = business id
+//    dt  = the date for which aging is computed
+//
+// RETURNS
+//    the aging for each Rental Agreement, in RAID order
+//-------------------------------------------------------------------------------------
+func GetAging(bid int64, dt *time.Time) []Aging {
+	var m []Aging
+	dt1 := dt.AddDate(0, 0, 1)
+	a := rlib.GetUnpaidAssessmentsByBusiness(bid, &dt1)
+	for i := 0; i < len(a); i++ {
+		if len(m) == 0 || m[len(m)-1].RAID != a[i].RAID {
+			m = append(m, Aging{RAID: a[i].RAID})
+		}
+		amt := AssessmentUnpaidPortion(&a[i])
+		g := &m[len(m)-1]
+		g.Bucket[AgingBucket(&a[i].Start, dt)] += amt
+		g.Total += amt
+	}
+	return m
+}
+
+// lateFeeCutoff returns the date before which an assessment must have been due
+// for a late fee to be charged on dt under late fee policy p.
+func lateFeeCutoff(p *rlib.LateFeePolicy, dt *time.Time) time.Time {
+	return rlib.DateAtTimeZero(*dt).AddDate(0, 0, -int(p.GraceDays))
+}
+
+// CalculateLateFee returns the late fee for assessment a, which has unpaid
+// amount unpaid, according to late fee policy p. The fee is limited to
+// p.MaxAmount when it is set.
+func CalculateLateFee(xbiz *rlib.XBusiness, p *rlib.LateFeePolicy, a *rlib.Assessment, unpaid float64) float64 {
+	fee := p.Amount
+	if len(p.Formula) > 0 {
+		var m []rlib.AcctRule
+		d1, d2 := rlib.GetMonthPeriodForDate(&a.Start)
+		ctx := rlib.RpnCreateCtx(xbiz, a.RID, &d1, &d2, &m, unpaid, float64(1))
+		fee = rlib.RpnCalculateEquation(&ctx, p.Formula)
+	}
+	if p.MaxAmount > 0 && fee > p.MaxAmount {
+		fee = p.MaxAmount
+	}
+	return rlib.RoundToCent(fee)
+}
+
+// AssessLateFees applies the late fee policy of business bid. A late fee is
+// assessed for every assessment that is still unpaid more than GraceDays after
+// it was due and that has not already been charged a late fee. No fee is more
+// than the policy's MaxAmount. Late fees are not charged on late fees.
+//
+// INPUTS
+//    bid = business id
+//    dt  = the date on which the late fees are assessed
+//    uid = the user assessing the late fees, 0 for the automatic worker
+//
+// RETURNS
+//    the number of late fees assessed
+//    a slice of BizErrors
+//-------------------------------------------------------------------------------------
+func AssessLateFees(bid int64, dt *time.Time, uid int64) (int, []BizError) {
+	var (
+		n       int
+		errlist []BizError
+	)
+	p, err := rlib.GetLateFeePolicy(bid)
+	if err != nil && !rlib.IsSQLNoResultsError(err) {
+		return n, bizErrSys(&err)
+	}
+	if p.LFPID == 0 {
+		return n, nil // no policy, no late fees
+	}
+	ar, err := rlib.GetAR(p.ARID)
+	if err != nil {
+		return n, bizErrSys(&err)
+	}
+	var xbiz rlib.XBusiness
+	rlib.GetXBusiness(bid, &xbiz)
+
+	due := lateFeeCutoff(&p, dt) // assessments due before this are late
+	m := rlib.GetUnpaidAssessmentsByBusiness(bid, &due)
+	for i := 0; i < len(m); i++ {
+		if m[i].FLAGS&rlib.ASMLATEFEE != 0 || m[i].ARID == p.ARID {
+			continue
+		}
+		fee := CalculateLateFee(&xbiz, &p, &m[i], AssessmentUnpaidPortion(&m[i]))
+		if fee <= 0 {
+			continue
+		}
+		a := rlib.Assessment{BID: bid, RID: m[i].RID, RAID: m[i].RAID, ATypeLID: ar.CreditLID, ARID: p.ARID,
+			Amount: fee, Start: *dt, Stop: *dt, RentCycle: rlib.CYCLENORECUR, ProrationCycle: rlib.CYCLENORECUR,
+			Comment: fmt.Sprintf("late fee for %s", m[i].IDtoString()), CreateBy: uid, LastModBy: uid}
+		if errlist = InsertAssessment(&a, 0, uid); len(errlist) > 0 {
+			return n, errlist
+		}
+		m[i].FLAGS |= rlib.ASMLATEFEE
+		m[i].LastModBy = uid
+		if err = rlib.UpdateAssessment(&m[i]); err != nil {
+			return n, bizErrSys(&err)
+		}
+		n++
+	}
+	return n, nil
+}
@@ -0,0 +1,82 @@
+package bizlogic
+
+import (
+	"rentroll/rlib"
+	"testing"
+)
+
+func TestAgingBucket(t *testing.T) {
+	due, _ := rlib.StringToDate("2017-06-01")
+	var m = []struct {
+		dt     string
+		bucket int
+	}{
+		{"2017-05-15", AGINGCURRENT}, // not yet due
+		{"2017-06-01", AGINGCURRENT},
+		{"2017-06-02", AGING30},
+		{"2017-07-01", AGING30}, // 30 days
+		{"2017-07-02", AGING60},
+		{"2017-07-31", AGING60}, // 60 days
+		{"2017-08-30", AGING90}, // 90 days
+		{"2017-08-31", AGING90PLUS},
+	}
+	for i := 0; i < len(m); i++ {
+		dt, _ := rlib.StringToDate(m[i].dt)
+		if b := AgingBucket(&due, &dt); b != m[i].bucket {
+			t.Errorf("%d: due %s, unpaid on %s: expected bucket %d, got %d", i, due.Format(rlib.RRDATEINPFMT), m[i].dt, m[i].bucket, b)
+		}
+	}
+}
+
+func TestLateFeeCutoff(t *testing.T) {
+	dt, _ := rlib.StringToDate("2017-06-10")
+	var m = []struct {
+		grace  int64
+		due    string
+		cutoff string
+		late   bool
+	}{
+		{0, "2017-06-09", "2017-06-10", true},
+		{0, "2017-06-10", "2017-06-10", false}, // due today is not late
+		{5, "2017-06-04", "2017-06-05", true},
+		{5, "2017-06-05", "2017-06-05", false}, // still in the grace period
+		{15, "2017-05-25", "2017-05-26", true}, // grace period spans the month boundary
+	}
+	for i := 0; i < len(m); i++ {
+		p := rlib.LateFeePolicy{GraceDays: m[i].grace}
+		cutoff := lateFeeCutoff(&p, &dt)
+		due, _ := rlib.StringToDate(m[i].due)
+		if cutoff.Format(rlib.RRDATEINPFMT) != m[i].cutoff || due.Before(cutoff) != m[i].late {
+			t.Errorf("%d: grace %d days: expected cutoff %s late = %v, got cutoff %s late = %v",
+				i, m[i].grace, m[i].cutoff, m[i].late, cutoff.Format(rlib.RRDATEINPFMT), due.Before(cutoff))
+		}
+	}
+}
+
+func TestCalculateLateFee(t *testing.T) {
+	var xbiz rlib.XBusiness
+	dt, _ := rlib.StringToDate("2017-06-01")
+	a := rlib.Assessment{ASMID: 1, BID: 1, RID: 1, Amount: 1000, Start: dt, Stop: dt}
+	var m = []struct {
+		amount  float64
+		formula string
+		max     float64
+		unpaid  float64
+		fee     float64
+	}{
+		{50, "", 0, 1000, 50},
+		{50, "", 25, 1000, 25}, // capped
+		{0, "_ 0.05 *", 0, 1000, 50},
+		{0, "_ 0.05 *", 0, 333.33, 16.67},
+		{0, "_ 0.05 *", 40, 1000, 40}, // formula capped
+		{0, "_ 0.05 *", 40, 500, 25},  // under the cap
+		{10, "_ 0.05 *", 0, 100, 5},   // the formula overrides the amount
+	}
+	for i := 0; i < len(m); i++ {
+		p := rlib.LateFeePolicy{Amount: m[i].amount, Formula: m[i].formula, MaxAmount: m[i].max}
+		if fee := CalculateLateFee(&xbiz, &p, &a, m[i].unpaid); fee != m[i].fee {
+			t.Errorf("%d: amount %.2f formula %q max %.2f on %.2f unpaid: expected %.2f, got %.2f",
+				i, m[i].amount, m[i].formula, m[i].max, m[i].unpaid, m[i].fee, fee)
+		}
+	}
+}
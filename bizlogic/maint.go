@@ -0,0 +1,313 @@
+package bizlogic
+
+import (
+	"fmt"
+	"rentroll/rlib"
+	"sort"
+	"strings"
+	"time"
+)
+
+// maintenanceCycles are the recurrences a MaintenanceSchedule may use
+var maintenanceCycles = map[int64]bool{
+	rlib.RECURNONE:      true,
+	rlib.RECURDAILY:     true,
+	rlib.RECURWEEKLY:    true,
+	rlib.RECURMONTHLY:   true,
+	rlib.RECURQUARTERLY: true,
+	rlib.RECURYEARLY:    true,
+}
+
+// maintenanceDueDates returns the due dates of the instances of schedule ms
+// that fall before dt. It uses the same recurrence rules as assessments. The
+// sequence is always generated from DtStart so that the instances land on
+// the same days no matter when it is called.
+func maintenanceDueDates(ms *rlib.MaintenanceSchedule, dt *time.Time) []time.Time {
+	var m []time.Time
+	d := rlib.GetRecurrences(&ms.DtStart, dt, &ms.DtStart, &ms.DtStop, ms.Cycle)
+	for i := 0; i < len(d); i++ {
+		if d[i].Before(ms.DtStop) {
+			m = append(m, d[i])
+		}
+	}
+	return m
+}
+
+// maintenanceRentables returns the Rentables that schedule ms applies to on
+// date dt. For a RentableType this is every Rentable of the business that
+// is of that type on dt.
+func maintenanceRentables(ms *rlib.MaintenanceSchedule, dt *time.Time) []rlib.Rentable {
+	var m []rlib.Rentable
+	switch {
+	case ms.RID > 0:
+		if r := rlib.GetRentable(ms.RID); r.RID > 0 {
+			m = append(m, r)
+		}
+	case ms.BLDGID > 0:
+		m = rlib.GetRentablesByBuilding(ms.BLDGID)
+	case ms.RTID > 0:
+		r := rlib.GetAllRentablesByBusiness(ms.BID)
+		for i := 0; i < len(r); i++ {
+			if rlib.GetRTIDForDate(r[i].RID, dt) == ms.RTID {
+				m = append(m, r[i])
+			}
+		}
+	}
+	return m
+}
+
+// SaveMaintenanceSchedule creates a new maintenance schedule if ms.MSID is 0,
+// otherwise it updates an existing one. Exactly one of RID, RTID and BLDGID
+// must be set. DtStop defaults to the end of time. The due date of the last
+// instance generated is maintained by GenerateMaintenanceRequests and is not
+// changed by this call.
+//
+// INPUTS
+//    ms  = the maintenance schedule
+//    uid = the user making the change
+//
+// RETURNS
+//    a slice of BizErrors
+//-------------------------------------------------------------------------------------
+func SaveMaintenanceSchedule(ms *rlib.MaintenanceSchedule, uid int64) []BizError {
+	var errlist []BizError
+	bad := func(s string) {
+		errlist = append(errlist, BizError{Errno: InvalidField, Message: BizErrors[InvalidField].Message + "\n" + s})
+	}
+
+	if len(strings.TrimSpace(ms.Name)) == 0 {
+		bad("Name")
+	}
+	n := 0
+	if ms.RID > 0 {
+		n++
+		if r := rlib.GetRentable(ms.RID); r.RID == 0 || r.BID != ms.BID {
+			bad("Rentable")
+		}
+	}
+	if ms.RTID > 0 {
+		n++
+		var rt rlib.RentableType
+		if err := rlib.GetRentableType(ms.RTID, &rt); err != nil || rt.BID != ms.BID {
+			bad("Rentable Type")
+		}
+	}
+	if ms.BLDGID > 0 {
+		n++
+		if b := rlib.GetBuilding(ms.BLDGID); b.BLDGID == 0 || b.BID != ms.BID {
+			bad("Building")
+		}
+	}
+	if n != 1 {
+		bad("Exactly one of Rentable, Rentable Type and Building must be supplied")
+	}
+	if ms.CategorySLSID > 0 {
+		var s rlib.SLString
+		rlib.GetSLString(ms.CategorySLSID, &s)
+		if s.SLSID == 0 || s.BID != ms.BID {
+			bad("Category")
+		}
+	}
+	if _, ok := SRPriorityNames[ms.Priority]; !ok {
+		bad("Priority")
+	}
+	if !maintenanceCycles[ms.Cycle] {
+		bad("Cycle")
+	}
+	if ms.DtStop.Year() <= 1970 {
+		ms.DtStop = time.Date(9999, time.December, 31, 0, 0, 0, 0, time.UTC)
+	}
+	if ms.DtStart.Year() <= 1970 || !ms.DtStop.After(ms.DtStart) {
+		bad("DtStart must be set and must be before DtStop")
+	}
+	if ms.LeadDays < 0 {
+		bad("Lead Days")
+	}
+	if len(errlist) > 0 {
+		return errlist
+	}
+	ms.LastModBy = uid
+
+	if ms.MSID > 0 {
+		old, err := rlib.GetMaintenanceSchedule(ms.MSID)
+		if err != nil && !rlib.IsSQLNoResultsError(err) {
+			return bizErrSys(&err)
+		}
+		if old.MSID == 0 || old.BID != ms.BID {
+			bad(fmt.Sprintf("Maintenance schedule %d", ms.MSID))
+			return errlist
+		}
+		ms.DtLastDue = old.DtLastDue
+		if err = rlib.UpdateMaintenanceSchedule(ms); err != nil {
+			return bizErrSys(&err)
+		}
+		return nil
+	}
+
+	ms.DtLastDue = srNoDate
+	ms.CreateBy = uid
+	if _, err := rlib.InsertMaintenanceSchedule(ms); err != nil {
+		return bizErrSys(&err)
+	}
+	return nil
+}
+
+// maintenanceInstance identifies the instance of a maintenance schedule for
+// one Rentable
+type maintenanceInstance struct {
+	RID int64
+	Due int64 // Unix time of the due date
+}
+
+// maintenanceGenerated returns the instances of the maintenance schedule msid
+// for which service requests have already been generated.
+func maintenanceGenerated(msid int64) map[maintenanceInstance]bool {
+	m := map[maintenanceInstance]bool{}
+	sr := rlib.GetServiceRequestsBySchedule(msid)
+	for i := 0; i < len(sr); i++ {
+		m[maintenanceInstance{sr[i].RID, sr[i].DtDue.Unix()}] = true
+	}
+	return m
+}
+
+// GenerateMaintenanceRequests creates a service request for each Rentable
+// covered by an instance of a maintenance schedule of business bid once the
+// instance is within its schedule's LeadDays of dt. Each instance is
+// generated only once, even if an earlier run stopped part way through an
+// instance. If instances were missed, for example because the schedule starts
+// in the past, they are all generated.
+//
+// INPUTS
+//    bid = business id
+//    dt  = the current date
+//    uid = the user generating the requests, 0 for the system
+//
+// RETURNS
+//    the number of service requests created
+//    a slice of BizErrors
+//-------------------------------------------------------------------------------------
+func GenerateMaintenanceRequests(bid int64, dt *time.Time, uid int64) (int, []BizError) {
+	var errlist []BizError
+	n := 0
+	m := rlib.GetAllMaintenanceSchedules(bid)
+	for i := 0; i < len(m); i++ {
+		ms := m[i]
+		horizon := dt.AddDate(0, 0, int(ms.LeadDays))
+		d := maintenanceDueDates(&ms, &horizon)
+		done := maintenanceGenerated(ms.MSID)
+		changed := false
+		for j := 0; j < len(d); j++ {
+			if !d[j].After(ms.DtLastDue) {
+				continue
+			}
+			descr := ms.Name
+			if len(ms.Description) > 0 {
+				descr += ": " + ms.Description
+			}
+			var el []BizError
+			r := maintenanceRentables(&ms, &d[j])
+			for k := 0; k < len(r) && len(el) == 0; k++ {
+				if done[maintenanceInstance{r[k].RID, d[j].Unix()}] {
+					continue
+				}
+				sr := rlib.ServiceRequest{BID: bid, RID: r[k].RID, CategorySLSID: ms.CategorySLSID, Priority: ms.Priority,
+					Description: descr, Assignee: ms.Assignee, DtReported: *dt, MSID: ms.MSID, DtDue: d[j]}
+				if el = SaveServiceRequest(&sr, uid); len(el) == 0 {
+					n++
+				}
+			}
+			if len(el) > 0 {
+				errlist = append(errlist, el...)
+				break
+			}
+			ms.DtLastDue = d[j]
+			changed = true
+		}
+		if changed {
+			ms.LastModBy = uid
+			if err := rlib.UpdateMaintenanceSchedule(&ms); err != nil {
+				errlist = append(errlist, bizErrSys(&err)...)
+			}
+		}
+	}
+	return n, errlist
+}
+
+// MaintenanceDue is one instance of scheduled maintenance for one Rentable
+type MaintenanceDue struct {
+	BLDGID       int64 // Building of the Rentable
+	RID          int64
+	RentableName string
+	MSID         int64
+	Name         string // name of the schedule
+	DtDue        time.Time
+	SRID         int64 // service request for the instance, 0 if it has not been generated yet
+	Status       int64 // status of the service request
+	Overdue      bool  // true if the instance was due before the period
+}
+
+// GetMaintenanceDue returns the outstanding maintenance of business bid that
+// is due before the end of the period d1 - d2. It includes the open service
+// requests generated by the maintenance schedules and the instances that have
+// not been generated yet. Anything due before d1 is overdue. The list is
+// sorted by Building, due date and Rentable name.
+//
+// INPUTS
+//    bid    = business id
+//    d1, d2 = the period
+//
+// RETURNS
+//    the outstanding maintenance
+//-------------------------------------------------------------------------------------
+func GetMaintenanceDue(bid int64, d1, d2 *time.Time) []MaintenanceDue {
+	var m []MaintenanceDue
+	rentables := map[int64]rlib.Rentable{}
+	getRentable := func(rid int64) rlib.Rentable {
+		r, ok := rentables[rid]
+		if !ok {
+			r = rlib.GetRentable(rid)
+			rentables[rid] = r
+		}
+		return r
+	}
+
+	s := rlib.GetAllMaintenanceSchedules(bid)
+	for i := 0; i < len(s); i++ {
+		done := map[maintenanceInstance]bool{}
+		sr := rlib.GetServiceRequestsBySchedule(s[i].MSID)
+		for j := 0; j < len(sr); j++ {
+			done[maintenanceInstance{sr[j].RID, sr[j].DtDue.Unix()}] = true
+			if serviceRequestClosed(&sr[j]) || !sr[j].DtDue.Before(*d2) {
+				continue
+			}
+			r := getRentable(sr[j].RID)
+			m = append(m, MaintenanceDue{BLDGID: r.BLDGID, RID: r.RID, RentableName: r.RentableName, MSID: s[i].MSID,
+				Name: s[i].Name, DtDue: sr[j].DtDue, SRID: sr[j].SRID, Status: sr[j].Status, Overdue: sr[j].DtDue.Before(*d1)})
+		}
+		d := maintenanceDueDates(&s[i], d2)
+		for j := 0; j < len(d); j++ {
+			if !d[j].After(s[i].DtLastDue) {
+				continue
+			}
+			r := maintenanceRentables(&s[i], &d[j])
+			for k := 0; k < len(r); k++ {
+				if done[maintenanceInstance{r[k].RID, d[j].Unix()}] {
+					continue
+				}
+				m = append(m, MaintenanceDue{BLDGID: r[k].BLDGID, RID: r[k].RID, RentableName: r[k].RentableName, MSID: s[i].MSID,
+					Name: s[i].Name, DtDue: d[j], Overdue: d[j].Before(*d1)})
+			}
+		}
+	}
+
+	sort.Slice(m, func(i, j int) bool {
+		if m[i].BLDGID != m[j].BLDGID {
+			return m[i].BLDGID < m[j].BLDGID
+		}
+		if !m[i].DtDue.Equal(m[j].DtDue) {
+			return m[i].DtDue.Before(m[j].DtDue)
+		}
+		return m[i].RentableName < m[j].RentableName
+	})
+	return m
+}
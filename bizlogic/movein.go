@@ -0,0 +1,276 @@
+package bizlogic
+
+import (
+	"fmt"
+	"rentroll/rlib"
+	"time"
+)
+
+// MoveIn describes a resident arrival. The Rentable is added to a new or an
+// existing Rental Agreement along with its payors and users, and the first
+// month's prorated rent, the recurring rent and the security deposit are
+// assessed.
+type MoveIn struct {
+	BID          int64        // business
+	RAID         int64        // existing Rental Agreement to attach to, 0 = create a new one
+	RATID        int64        // Rental Agreement template for a new Rental Agreement
+	RID          int64        // the Rentable being occupied
+	Payors       []int64      // TCIDs of the payors
+	Users        []int64      // TCIDs of the users
+	DtStart      time.Time    // move-in date
+	DtStop       time.Time    // end of the agreement
+	ContractRent float64      // monthly rent, 0 = use the market rate
+	RentARID     int64        // account rule for the rent assessment
+	Deposit      float64      // security deposit, 0 = no deposit
+	DepositARID  int64        // account rule for the security deposit assessment
+	Commissions  []Commission // commissions earned by leasing agents and referrers
+	UID          int64        // user processing the move-in
+}
+
+// validateMoveIn checks the move-in for business logic errors before anything
+// is written.
+//-------------------------------------------------------------------------------------
+func validateMoveIn(mi *MoveIn, r *rlib.Rentable) []BizError {
+	var errlist []BizError
+	bad := func(s string) {
+		errlist = append(errlist, BizError{Errno: InvalidField, Message: BizErrors[InvalidField].Message + "\n" + s})
+	}
+	if r.RID == 0 || r.BID != mi.BID {
+		bad("Rentable")
+	}
+	if !mi.DtStop.After(mi.DtStart) {
+		bad("The end date must be after the move-in date")
+	}
+	if len(mi.Payors) == 0 {
+		bad("At least one payor is required")
+	}
+	for _, l := range [][]int64{mi.Payors, mi.Users} {
+		for i := 0; i < len(l); i++ {
+			var t rlib.Transactant
+			if err := rlib.GetTransactant(l[i], &t); err != nil || t.BID != mi.BID {
+				bad(fmt.Sprintf("Transactant %d", l[i]))
+			}
+		}
+	}
+	if ar, err := rlib.GetAR(mi.RentARID); err != nil || ar.BID != mi.BID || ar.ARType != rlib.ARASSESSMENT {
+		bad("Rent Account Rule")
+	}
+	if mi.Deposit < 0 {
+		bad("Security Deposit")
+	}
+	if mi.Deposit > 0 {
+		if ar, err := rlib.GetAR(mi.DepositARID); err != nil || ar.BID != mi.BID || ar.ARType != rlib.ARASSESSMENT {
+			bad("Security Deposit Account Rule")
+		}
+	}
+	if mi.RAID > 0 {
+		ra, err := rlib.GetRentalAgreement(mi.RAID)
+		if err != nil || ra.BID != mi.BID {
+			bad("Rental Agreement")
+		}
+	}
+	for i := 0; i < len(mi.Commissions); i++ {
+		errlist = append(errlist, validateCommission(mi.BID, &mi.Commissions[i])...)
+	}
+	if len(errlist) > 0 {
+		return errlist
+	}
+	if errlist = ValidatePeriodOpen(mi.BID, &mi.DtStart); len(errlist) > 0 {
+		return errlist
+	}
+	if len(rlib.GetAgreementsForRentable(mi.RID, &mi.DtStart, &mi.DtStop)) > 0 {
+		errlist = append(errlist, BizErrors[RentableNotVacant])
+	}
+	return errlist
+}
+
+// moveInProration returns the month d1 - d2 in which a resident moves in on
+// dtStart under an agreement that ends on dtStop, and the part of that month
+// that is charged. Recurring rent starts with the following month.
+func moveInProration(dtStart, dtStop time.Time) (time.Time, time.Time, float64) {
+	d1 := time.Date(dtStart.Year(), dtStart.Month(), 1, 0, 0, 0, 0, rlib.RRdb.Zone)
+	d2 := d1.AddDate(0, 1, 0)
+	pf, _, _, _, _ := rlib.CalcProrationInfo(&dtStart, &dtStop, &d1, &d2, rlib.CYCLEMONTHLY, rlib.CYCLEDAILY)
+	return d1, d2, pf
+}
+
+// ProcessMoveIn moves a resident into a Rentable in one step. It creates or
+// attaches the Rental Agreement, adds the RentalAgreementRentable, payors and
+// users, marks the Rentable occupied, and assesses the prorated rent for the
+// first month, the monthly rent from the next full rent cycle on, and the
+// security deposit. Any commissions earned by leasing
+// agents or referrers are recorded in the CommissionLedger. If any step fails,
+// everything written before it is removed.
+//
+// INPUTS
+//    mi = the move-in to process
+//
+// RETURNS
+//    the Rental Agreement
+//    a slice of BizErrors
+//-------------------------------------------------------------------------------------
+func ProcessMoveIn(mi *MoveIn) (rlib.RentalAgreement, []BizError) {
+	var (
+		ra      rlib.RentalAgreement
+		err     error
+		undo    []func() // rollback steps, run in reverse order on failure
+		errlist []BizError
+	)
+	undoAll := func() {
+		for i := len(undo) - 1; i >= 0; i-- {
+			undo[i]()
+		}
+	}
+	rollback := func() []BizError {
+		undoAll()
+		return bizErrSys(&err)
+	}
+
+	r := rlib.GetRentable(mi.RID)
+	if errlist = validateMoveIn(mi, &r); len(errlist) > 0 {
+		return ra, errlist
+	}
+	var xbiz rlib.XBusiness
+	rlib.InitBizInternals(mi.BID, &xbiz)
+
+	//------------------------------------------------
+	// the first month's prorated rent
+	//------------------------------------------------
+	d1, d2, pf := moveInProration(mi.DtStart, mi.DtStop)
+	rent := mi.ContractRent
+	if rent == float64(0) {
+		rent = rlib.GetRentableMarketRate(&xbiz, &r, &d1, &d2)
+	}
+	rentAR, _ := rlib.GetAR(mi.RentARID)
+	asmRent := rlib.Assessment{BID: mi.BID, RID: mi.RID, ATypeLID: rentAR.CreditLID, Amount: rlib.RoundToCent(rent * pf),
+		Start: mi.DtStart, Stop: mi.DtStart, RentCycle: rlib.CYCLENORECUR, ProrationCycle: rlib.CYCLENORECUR,
+		ARID: mi.RentARID, Comment: "first month rent, prorated", CreateBy: mi.UID, LastModBy: mi.UID}
+	asms := []*rlib.Assessment{&asmRent}
+
+	//------------------------------------------------
+	// the recurring rent, from the next full cycle on
+	//------------------------------------------------
+	var asmRecur rlib.Assessment
+	if mi.DtStop.After(d2) {
+		asmRecur = rlib.Assessment{BID: mi.BID, RID: mi.RID, ATypeLID: rentAR.CreditLID, Amount: rlib.RoundToCent(rent),
+			Start: d2, Stop: mi.DtStop, RentCycle: rlib.CYCLEMONTHLY, ProrationCycle: rlib.CYCLEDAILY,
+			ARID: mi.RentARID, Comment: "rent", CreateBy: mi.UID, LastModBy: mi.UID}
+		asms = append(asms, &asmRecur)
+	}
+	var asmDep rlib.Assessment
+	if mi.Deposit > 0 {
+		depAR, _ := rlib.GetAR(mi.DepositARID)
+		asmDep = rlib.Assessment{BID: mi.BID, RID: mi.RID, ATypeLID: depAR.CreditLID, Amount: rlib.RoundToCent(mi.Deposit),
+			Start: mi.DtStart, Stop: mi.DtStart, RentCycle: rlib.CYCLENORECUR, ProrationCycle: rlib.CYCLENORECUR,
+			ARID: mi.DepositARID, Comment: "security deposit", CreateBy: mi.UID, LastModBy: mi.UID}
+		asms = append(asms, &asmDep)
+	}
+
+	//------------------------------------------------
+	// Rental Agreement
+	//------------------------------------------------
+	if mi.RAID > 0 {
+		ra, _ = rlib.GetRentalAgreement(mi.RAID)
+	} else {
+		ra = rlib.RentalAgreement{RATID: mi.RATID, BID: mi.BID,
+			AgreementStart: mi.DtStart, AgreementStop: mi.DtStop,
+			PossessionStart: mi.DtStart, PossessionStop: mi.DtStop,
+			RentStart: mi.DtStart, RentStop: mi.DtStop, RentCycleEpoch: mi.DtStart,
+			CreateBy: mi.UID, LastModBy: mi.UID}
+		if _, err = rlib.InsertRentalAgreement(&ra); err != nil {
+			return ra, rollback()
+		}
+		raid := ra.RAID
+		undo = append(undo, func() { rlib.DeleteRentalAgreement(raid) })
+	}
+
+	//------------------------------------------------
+	// Rentable, payors, and users
+	//------------------------------------------------
+	rar := rlib.RentalAgreementRentable{RAID: ra.RAID, BID: mi.BID, RID: mi.RID, ContractRent: rent,
+		RARDtStart: mi.DtStart, RARDtStop: mi.DtStop, CreateBy: mi.UID}
+	if _, err = rlib.InsertRentalAgreementRentable(&rar); err != nil {
+		return ra, rollback()
+	}
+	undo = append(undo, func() { rlib.DeleteRentalAgreementRentable(rar.RARID) })
+
+	current := map[int64]bool{}
+	m := rlib.GetRentalAgreementPayorsInRange(ra.RAID, &mi.DtStart, &mi.DtStop)
+	for i := 0; i < len(m); i++ {
+		current[m[i].TCID] = true
+	}
+	for i := 0; i < len(mi.Payors); i++ {
+		if current[mi.Payors[i]] {
+			continue // already a payor on this agreement
+		}
+		p := rlib.RentalAgreementPayor{RAID: ra.RAID, BID: mi.BID, TCID: mi.Payors[i], DtStart: mi.DtStart, DtStop: mi.DtStop, CreateBy: mi.UID}
+		if _, err = rlib.InsertRentalAgreementPayor(&p); err != nil {
+			return ra, rollback()
+		}
+		undo = append(undo, func() { rlib.DeleteRentalAgreementPayor(p.RAPID) })
+	}
+	for i := 0; i < len(mi.Users); i++ {
+		u := rlib.RentableUser{RID: mi.RID, BID: mi.BID, TCID: mi.Users[i], DtStart: mi.DtStart, DtStop: mi.DtStop, CreateBy: mi.UID}
+		if err = rlib.InsertRentableUser(&u); err != nil {
+			return ra, rollback()
+		}
+		undo = append(undo, func() { rlib.DeleteRentableUser(u.RUID) })
+	}
+
+	//------------------------------------------------
+	// mark the Rentable occupied
+	//------------------------------------------------
+	removed, added, err := setRentableStatus(mi.RID, mi.BID, rlib.RENTABLESTATUSOCCUPIED, &mi.DtStart, &mi.DtStop, mi.UID)
+	undo = append(undo, func() { restoreRentableStatus(removed, added) })
+	if err != nil {
+		return ra, rollback()
+	}
+
+	//------------------------------------------------
+	// Assessments. Save them all before any journal
+	// entries are made so that they can be removed
+	// if one of them fails.
+	//------------------------------------------------
+	for i := 0; i < len(asms); i++ {
+		asms[i].RAID = ra.RAID
+		if errlist = ValidateAssessment(asms[i]); len(errlist) > 0 {
+			undoAll()
+			return ra, errlist
+		}
+		if _, err = rlib.InsertAssessment(asms[i]); err != nil {
+			return ra, rollback()
+		}
+		asmid := asms[i].ASMID
+		undo = append(undo, func() { rlib.DeleteAssessment(asmid) })
+	}
+
+	//------------------------------------------------
+	// commissions
+	//------------------------------------------------
+	for i := 0; i < len(mi.Commissions); i++ {
+		var cl rlib.CommissionLedger
+		if cl, err = insertCommission(&xbiz, &ra, &r, &mi.DtStart, &mi.DtStop, &mi.Commissions[i], mi.UID); err != nil {
+			return ra, rollback()
+		}
+		undo = append(undo, func() { rlib.DeleteCommissionLedger(cl.CLID) })
+	}
+
+	//------------------------------------------------
+	// journal the one-time charges and create the
+	// instances of the recurring rent that are due
+	//------------------------------------------------
+	rlib.InitLedgerCache()
+	for i := 0; i < len(asms); i++ {
+		a := asms[i]
+		undo = append(undo, func() { removeAssessmentJournals(a, mi.UID) })
+		if asms[i].RentCycle != rlib.CYCLENORECUR {
+			err = createInstancesToDate(asms[i], &xbiz, mi.UID)
+		} else {
+			err = rlib.ProcessJournalEntry(asms[i], &xbiz, &d1, &d2, true, mi.UID)
+		}
+		if err != nil {
+			return ra, rollback()
+		}
+	}
+	return ra, nil
+}
@@ -0,0 +1,33 @@
+package bizlogic
+
+import (
+	"rentroll/rlib"
+	"testing"
+)
+
+func TestMoveInProration(t *testing.T) {
+	const rent = float64(900) // $30 a day in June
+	var m = []struct {
+		start, stop string
+		d2          string
+		amount      float64
+	}{
+		{"2017-06-01", "2018-06-01", "2017-07-01", 900},
+		{"2017-06-16", "2018-06-01", "2017-07-01", 450},
+		{"2017-06-30", "2018-06-01", "2017-07-01", 30},
+		{"2017-06-16", "2017-06-25", "2017-07-01", 270}, // the agreement ends in the move-in month
+		{"2017-02-15", "2018-02-15", "2017-03-01", 450}, // 14 of 28 days
+	}
+	for i := 0; i < len(m); i++ {
+		start, _ := rlib.StringToDate(m[i].start)
+		stop, _ := rlib.StringToDate(m[i].stop)
+		d1, d2, pf := moveInProration(start, stop)
+		if d1.Day() != 1 || d1.Month() != start.Month() || d2.Format(rlib.RRDATEINPFMT) != m[i].d2 {
+			t.Errorf("%d: move-in %s: expected the month ending %s, got %s - %s", i, m[i].start, m[i].d2,
+				d1.Format(rlib.RRDATEINPFMT), d2.Format(rlib.RRDATEINPFMT))
+		}
+		if amt := rlib.RoundToCent(rent * pf); amt != rlib.RoundToCent(m[i].amount) {
+			t.Errorf("%d: move-in %s - %s: expected %.2f, got %.2f", i, m[i].start, m[i].stop, m[i].amount, amt)
+		}
+	}
+}
@@ -0,0 +1,377 @@
+package bizlogic
+
+import (
+	"fmt"
+	"rentroll/rlib"
+	"time"
+)
+
+// MoveOutCharge is a charge assessed when a resident leaves, typically for
+// damages to the Rentable.
+type MoveOutCharge struct {
+	ARID    int64   // account rule for the assessment
+	Amount  float64 // amount of the charge
+	Comment string  // description of the charge
+}
+
+// MoveOut describes a resident departure from a Rentable on a Rental Agreement.
+type MoveOut struct {
+	BID         int64           // business
+	RAID        int64           // the Rental Agreement
+	RID         int64           // the Rentable being vacated
+	Dt          time.Time       // move-out date, the Rentable is vacant from this date forward
+	Charges     []MoveOutCharge // damages and other charges to assess on Dt
+	DepositARID int64           // receipt account rule that applies the security deposit to unpaid assessments
+	UID         int64           // user processing the move-out
+}
+
+// MoveOutItem is one line of a move-out statement
+type MoveOutItem struct {
+	ASMID   int64     // the assessment
+	Dt      time.Time // date of the assessment
+	Descr   string    // what it is for
+	Amount  float64   // amount of the assessment, or the part of it that was paid
+	Applied float64   // the part of the amount paid from the security deposit
+}
+
+// MoveOutDisposition is the outcome of a move-out: the final charges and what
+// became of the security deposit.
+type MoveOutDisposition struct {
+	BID         int64
+	RAID        int64
+	RID         int64
+	Dt          time.Time     // move-out date
+	RCPTID      int64         // receipt recording the application of the deposit, 0 if none was applied
+	DepositHeld float64       // security deposit held on Dt
+	Charges     []MoveOutItem // final rent and damage charges assessed at move-out
+	Applied     []MoveOutItem // unpaid assessments paid from the security deposit
+	TotApplied  float64       // total of the security deposit applied to unpaid assessments
+	BalanceDue  float64       // amount still owed by the resident after the deposit is applied
+	Refund      float64       // security deposit to be refunded to the resident
+}
+
+// moveOutItemDescr returns the description of assessment a for a move-out
+// statement, its comment or the name of its account rule.
+func moveOutItemDescr(a *rlib.Assessment) string {
+	if len(a.Comment) > 0 {
+		return a.Comment
+	}
+	return rlib.RRdb.BizTypes[a.BID].AR[a.ARID].Name
+}
+
+// validateMoveOut checks the move-out for business logic errors before anything
+// is written. It returns the RentalAgreementRentable for the Rentable.
+//-------------------------------------------------------------------------------------
+func validateMoveOut(mo *MoveOut, ra *rlib.RentalAgreement) (rlib.RentalAgreementRentable, []BizError) {
+	var (
+		rar     rlib.RentalAgreementRentable
+		errlist []BizError
+	)
+	bad := func(s string) {
+		errlist = append(errlist, BizError{Errno: InvalidField, Message: BizErrors[InvalidField].Message + "\n" + s})
+	}
+	if ra.RAID == 0 || ra.BID != mo.BID {
+		bad("Rental Agreement")
+	}
+	for i := 0; i < len(mo.Charges); i++ {
+		if mo.Charges[i].Amount <= 0 {
+			bad(fmt.Sprintf("Charge %d amount", i+1))
+		}
+		if ar, err := rlib.GetAR(mo.Charges[i].ARID); err != nil || ar.BID != mo.BID || ar.ARType != rlib.ARASSESSMENT {
+			bad(fmt.Sprintf("Charge %d Account Rule", i+1))
+		}
+	}
+	if mo.DepositARID > 0 {
+		if ar, err := rlib.GetAR(mo.DepositARID); err != nil || ar.BID != mo.BID || ar.ARType != rlib.ARRECEIPT || !isSecurityDepositAcct(mo.BID, ar.DebitLID) {
+			bad("Security Deposit Account Rule")
+		}
+	}
+	if len(errlist) > 0 {
+		return rar, errlist
+	}
+	if errlist = ValidatePeriodOpen(mo.BID, &mo.Dt); len(errlist) > 0 {
+		return rar, errlist
+	}
+	dt1 := mo.Dt.AddDate(0, 0, 1)
+	m := rlib.GetRentalAgreementRentables(mo.RAID, &mo.Dt, &dt1)
+	for i := 0; i < len(m); i++ {
+		if m[i].RID == mo.RID && !mo.Dt.Before(m[i].RARDtStart) && mo.Dt.Before(m[i].RARDtStop) {
+			return m[i], nil
+		}
+	}
+	errlist = append(errlist, BizErrors[RentableNotRented])
+	return rar, errlist
+}
+
+// ProcessMoveOut moves a resident out of a Rentable. The Rentable, its users,
+// and, if it is the last Rentable on the agreement, the Rental Agreement and its
+// payors are ended on mo.Dt. Recurring rent from the start of the move-out month
+// forward is reversed and replaced by the rent prorated to mo.Dt. The Rentable
+// is put back online and marked dirty for housekeeping, any charges are assessed,
+// and the security deposit is applied to the unpaid assessments on the agreement.
+//
+// INPUTS
+//    mo = the move-out to process
+//
+// RETURNS
+//    the disposition of the security deposit
+//    a slice of BizErrors
+//-------------------------------------------------------------------------------------
+func ProcessMoveOut(mo *MoveOut) (MoveOutDisposition, []BizError) {
+	var (
+		err     error
+		errlist []BizError
+		d       = MoveOutDisposition{BID: mo.BID, RAID: mo.RAID, RID: mo.RID, Dt: mo.Dt}
+		now     = time.Now()
+		future  = time.Date(9999, time.December, 31, 0, 0, 0, 0, time.UTC)
+	)
+
+	ra, _ := rlib.GetRentalAgreement(mo.RAID)
+	rar, errlist := validateMoveOut(mo, &ra)
+	if len(errlist) > 0 {
+		return d, errlist
+	}
+	if mo.DepositARID == 0 && securityDepositHeld(mo) > 0 {
+		return d, []BizError{{Errno: InvalidField, Message: BizErrors[InvalidField].Message + "\nSecurity Deposit Account Rule"}}
+	}
+	var xbiz rlib.XBusiness
+	rlib.InitBizInternals(mo.BID, &xbiz)
+	d1 := time.Date(mo.Dt.Year(), mo.Dt.Month(), 1, 0, 0, 0, 0, rlib.RRdb.Zone)
+	d2 := d1.AddDate(0, 1, 0)
+	dt1 := mo.Dt.AddDate(0, 0, 1)
+
+	//------------------------------------------------
+	// end the Rentable and its users
+	//------------------------------------------------
+	occupiedStop := rar.RARDtStop
+	rar.RARDtStop = mo.Dt
+	if err = rlib.UpdateRentalAgreementRentable(&rar); err != nil {
+		return d, bizErrSys(&err)
+	}
+	u := rlib.GetRentableUsersInRange(mo.RID, &mo.Dt, &future)
+	for i := 0; i < len(u); i++ {
+		if u[i].DtStop.After(mo.Dt) {
+			u[i].DtStop = mo.Dt
+			if err = rlib.UpdateRentableUser(&u[i]); err != nil {
+				return d, bizErrSys(&err)
+			}
+		}
+	}
+	if errlist = HousekeepingCheckout(&rar, &mo.Dt, mo.UID); len(errlist) > 0 {
+		return d, errlist
+	}
+
+	//------------------------------------------------
+	// if nothing else is rented, end the agreement
+	//------------------------------------------------
+	if len(rlib.GetRentalAgreementRentables(mo.RAID, &dt1, &future)) == 0 {
+		if ra.PossessionStop.After(mo.Dt) {
+			ra.PossessionStop = mo.Dt
+		}
+		if ra.RentStop.After(mo.Dt) {
+			ra.RentStop = mo.Dt
+		}
+		if ra.AgreementStop.After(mo.Dt) {
+			ra.AgreementStop = mo.Dt
+		}
+		ra.Renewal = 0 // an ended agreement does not renew
+		ra.LastModBy = mo.UID
+		if err = rlib.UpdateRentalAgreement(&ra); err != nil {
+			return d, bizErrSys(&err)
+		}
+		p := rlib.GetRentalAgreementPayorsInRange(mo.RAID, &mo.Dt, &future)
+		for i := 0; i < len(p); i++ {
+			if p[i].DtStop.After(mo.Dt) {
+				p[i].DtStop = mo.Dt
+				if err = rlib.UpdateRentalAgreementPayor(&p[i]); err != nil {
+					return d, bizErrSys(&err)
+				}
+			}
+		}
+	}
+
+	//------------------------------------------------
+	// put the Rentable back online
+	//------------------------------------------------
+	if occupiedStop.After(mo.Dt) {
+		if _, _, err = setRentableStatus(mo.RID, mo.BID, rlib.RENTABLESTATUSONLINE, &mo.Dt, &occupiedStop, mo.UID); err != nil {
+			return d, bizErrSys(&err)
+		}
+	}
+
+	//------------------------------------------------
+	// Stop the recurring rent. Reverse the instances
+	// from the start of the move-out month forward and
+	// assess the rent for that month prorated to Dt.
+	//------------------------------------------------
+	var asms []rlib.Assessment
+	m := rlib.GetAllRentableAssessments(mo.RID, &d1, &future)
+	for i := 0; i < len(m); i++ {
+		if m[i].RAID != mo.RAID || m[i].PASMID != 0 || m[i].RentCycle == rlib.RECURNONE || m[i].FLAGS&rlib.ASMREVERSED != 0 {
+			continue
+		}
+		if errlist = ReverseAssessmentsGoingForward(&rlib.Assessment{PASMID: m[i].ASMID}, &d1, &now, mo.UID); len(errlist) > 0 {
+			return d, errlist
+		}
+		if m[i].Stop.After(mo.Dt) {
+			m[i].Stop = mo.Dt
+			m[i].LastModBy = mo.UID
+			if err = rlib.UpdateAssessment(&m[i]); err != nil {
+				return d, bizErrSys(&err)
+			}
+		}
+		if m[i].Start.After(mo.Dt) {
+			continue
+		}
+		start := d1 // the rent is charged from the later of the month, the Rentable's and the assessment's start
+		if rar.RARDtStart.After(start) {
+			start = rar.RARDtStart
+		}
+		if m[i].Start.After(start) {
+			start = m[i].Start
+		}
+		_, _, pf := rlib.Prorate(start, mo.Dt, d1, d2, rlib.CYCLEMONTHLY, rlib.CYCLEDAILY)
+		if pf <= 0 {
+			continue
+		}
+		a := rlib.Assessment{BID: mo.BID, RID: mo.RID, RAID: mo.RAID, ATypeLID: m[i].ATypeLID, ARID: m[i].ARID,
+			Amount: rlib.RoundToCent(m[i].Amount * pf), Start: d1, Stop: d1,
+			RentCycle: rlib.CYCLENORECUR, ProrationCycle: rlib.CYCLENORECUR,
+			Comment: "final month rent, prorated", CreateBy: mo.UID, LastModBy: mo.UID}
+		asms = append(asms, a)
+	}
+
+	//------------------------------------------------
+	// damages and other charges
+	//------------------------------------------------
+	for i := 0; i < len(mo.Charges); i++ {
+		ar, _ := rlib.GetAR(mo.Charges[i].ARID)
+		a := rlib.Assessment{BID: mo.BID, RID: mo.RID, RAID: mo.RAID, ATypeLID: ar.CreditLID, ARID: ar.ARID,
+			Amount: rlib.RoundToCent(mo.Charges[i].Amount), Start: mo.Dt, Stop: mo.Dt,
+			RentCycle: rlib.CYCLENORECUR, ProrationCycle: rlib.CYCLENORECUR,
+			Comment: mo.Charges[i].Comment, CreateBy: mo.UID, LastModBy: mo.UID}
+		asms = append(asms, a)
+	}
+	rlib.InitLedgerCache()
+	for i := 0; i < len(asms); i++ {
+		if errlist = ValidateAssessment(&asms[i]); len(errlist) > 0 {
+			return d, errlist
+		}
+		if _, err = rlib.InsertAssessment(&asms[i]); err != nil {
+			return d, bizErrSys(&err)
+		}
+		if err = rlib.ProcessJournalEntry(&asms[i], &xbiz, &d1, &d2, true, mo.UID); err != nil {
+			return d, bizErrSys(&err)
+		}
+		d.Charges = append(d.Charges, MoveOutItem{ASMID: asms[i].ASMID, Dt: asms[i].Start, Descr: moveOutItemDescr(&asms[i]), Amount: asms[i].Amount})
+	}
+
+	//------------------------------------------------
+	// the security deposit disposition
+	//------------------------------------------------
+	if errlist = applySecurityDeposit(mo, &d); len(errlist) > 0 {
+		return d, errlist
+	}
+	return d, nil
+}
+
+// isSecurityDepositAcct returns true if lid is one of the security deposit
+// accounts of business bid.
+func isSecurityDepositAcct(bid, lid int64) bool {
+	m := rlib.GetSecurityDepositsAccounts(bid)
+	for i := 0; i < len(m); i++ {
+		if m[i] == lid {
+			return true
+		}
+	}
+	return false
+}
+
+// securityDepositHeld returns the security deposit held for the Rental
+// Agreement on the move-out date in the account debited by mo.DepositARID, or
+// in all the security deposit accounts if no account rule was supplied.
+func securityDepositHeld(mo *MoveOut) float64 {
+	dt1 := mo.Dt.AddDate(0, 0, 1)
+	held := float64(0)
+	secdep := rlib.GetSecurityDepositsAccounts(mo.BID)
+	if mo.DepositARID > 0 {
+		secdep = []int64{rlib.RRdb.BizTypes[mo.BID].AR[mo.DepositARID].DebitLID}
+	}
+	for i := 0; i < len(secdep); i++ {
+		held -= rlib.GetRAAccountBalance(mo.BID, secdep[i], mo.RAID, &dt1) // credit balance, it's a liability
+	}
+	if held < 0 {
+		held = 0
+	}
+	return held
+}
+
+// applySecurityDeposit applies the security deposit held for the Rental
+// Agreement to its unpaid assessments, oldest first, and fills in the rest of
+// the disposition. The application is recorded as a Receipt for the amount
+// applied under account rule mo.DepositARID, which takes the funds out of the
+// security deposit account, and the Receipt pays the assessments.
+//-------------------------------------------------------------------------------------
+func applySecurityDeposit(mo *MoveOut, d *MoveOutDisposition) []BizError {
+	var err error
+	dt1 := mo.Dt.AddDate(0, 0, 1)
+	d.DepositHeld = securityDepositHeld(mo)
+
+	//------------------------------------------------
+	// how much of the deposit will be applied
+	//------------------------------------------------
+	m := rlib.GetUnpaidAssessmentsByRAID(mo.RAID)
+	owed := make([]float64, len(m))
+	tot := float64(0)
+	for i := 0; i < len(m); i++ {
+		owed[i] = AssessmentUnpaidPortion(&m[i])
+		tot += owed[i]
+	}
+	apply := rlib.RoundToCent(tot)
+	if apply > d.DepositHeld {
+		apply = d.DepositHeld
+	}
+	d.Refund = rlib.RoundToCent(d.DepositHeld)
+	d.BalanceDue = rlib.RoundToCent(tot)
+	if apply < ROUNDINGERR {
+		return nil
+	}
+
+	rcpt := rlib.Receipt{BID: mo.BID, ARID: mo.DepositARID, Dt: mo.Dt, DocNo: "SECDEP", Amount: apply,
+		Comment:  fmt.Sprintf("security deposit applied at move-out of %s", rlib.IDtoString("RA", mo.RAID)),
+		CreateBy: mo.UID, LastModBy: mo.UID}
+	p := rlib.GetRentalAgreementPayorsInRange(mo.RAID, &mo.Dt, &dt1)
+	if len(p) > 0 {
+		rcpt.TCID = p[0].TCID
+	}
+	if err = InsertReceipt(&rcpt); err != nil {
+		return bizErrSys(&err)
+	}
+	if err = assignReceiptToRA(&rcpt, mo.RAID); err != nil {
+		return bizErrSys(&err)
+	}
+	d.RCPTID = rcpt.RCPTID
+
+	avail := apply
+	d.BalanceDue = 0
+	for i := 0; i < len(m); i++ {
+		needed := owed[i]
+		if avail < ROUNDINGERR {
+			d.BalanceDue += needed
+			continue
+		}
+		amt := needed
+		if err = PayAssessment(&m[i], &rcpt, &needed, &amt, &mo.Dt, mo.UID); err != nil {
+			return bizErrSys(&err)
+		}
+		paid := owed[i] - needed
+		avail -= paid
+		d.TotApplied += paid
+		d.BalanceDue += needed
+		d.Applied = append(d.Applied, MoveOutItem{ASMID: m[i].ASMID, Dt: m[i].Start, Descr: moveOutItemDescr(&m[i]), Amount: owed[i], Applied: paid})
+	}
+	d.BalanceDue = rlib.RoundToCent(d.BalanceDue)
+	d.TotApplied = rlib.RoundToCent(d.TotApplied)
+	d.Refund = rlib.RoundToCent(d.DepositHeld - d.TotApplied)
+	return nil
+}
@@ -0,0 +1,152 @@
+package bizlogic
+
+import (
+	"fmt"
+	"rentroll/rlib"
+	"time"
+)
+
+// NOTICERENTINCREASE and the others are the types of notices sent to the
+// payors of a Rental Agreement
+const (
+	NOTICERENTINCREASE    = 1 // RateChange goes into effect on NextRateChange
+	NOTICELATEPAYMENT     = 2 // assessments are past due
+	NOTICELEASEEXPIRATION = 3 // the agreement ends on AgreementStop
+	NOTICEEXTENSIONOPTION = 4 // the last day to exercise the ExtensionOption is ExtensionOptionNotice
+	NOTICEEXPANSIONOPTION = 5 // the last day to exercise the ExpansionOption is ExpansionOptionNotice
+)
+
+// NoticeNames are the names of the notice types. They are also the names of
+// the templates used to render the notices.
+var NoticeNames = map[int64]string{
+	NOTICERENTINCREASE:    "rentincrease",
+	NOTICELATEPAYMENT:     "latepayment",
+	NOTICELEASEEXPIRATION: "leaseexpiration",
+	NOTICEEXTENSIONOPTION: "extensionoption",
+	NOTICEEXPANSIONOPTION: "expansionoption",
+}
+
+// NoticeNoteType is the name of the NoteType used to record notices
+const NoticeNoteType = "Notice"
+
+// Notice describes a notice that is due to be sent for a Rental Agreement
+type Notice struct {
+	Type   int64                // NOTICERENTINCREASE, ...
+	RA     rlib.RentalAgreement // the agreement
+	Dt     time.Time            // the date the notice is about: rate change, agreement end, option deadline
+	Amount float64              // rent increase percentage, or the past due balance
+	Option string               // extension or expansion option text
+	Aging  Aging                // for late payment notices, the aging of the balance
+}
+
+// GetNoticesDue returns the notices that are due for the Rental Agreements of
+// business bid during the period d1 - d2. A notice is due when the date it is
+// about falls within the period. Late payment notices are due for every
+// agreement with a balance that is past due on d1.
+//
+// INPUTS
+//    bid   = business id
+//    d1-d2 = the notice window
+//
+// RETURNS
+//    the notices, grouped by Rental Agreement
+//-------------------------------------------------------------------------------------
+func GetNoticesDue(bid int64, d1, d2 *time.Time) []Notice {
+	var m []Notice
+	in := func(dt *time.Time) bool {
+		return !dt.Before(*d1) && dt.Before(*d2)
+	}
+	late := map[int64]Aging{}
+	g := GetAging(bid, d1)
+	for i := 0; i < len(g); i++ {
+		if g[i].Total-g[i].Bucket[AGINGCURRENT] > ROUNDINGERR {
+			late[g[i].RAID] = g[i]
+		}
+	}
+
+	ra := rlib.GetAllRentalAgreementsByRange(bid, d1, d2)
+	for i := 0; i < len(ra); i++ {
+		if in(&ra[i].NextRateChange) && ra[i].RateChange != float64(0) {
+			m = append(m, Notice{Type: NOTICERENTINCREASE, RA: ra[i], Dt: ra[i].NextRateChange, Amount: ra[i].RateChange})
+		}
+		if in(&ra[i].AgreementStop) {
+			m = append(m, Notice{Type: NOTICELEASEEXPIRATION, RA: ra[i], Dt: ra[i].AgreementStop})
+		}
+		if in(&ra[i].ExtensionOptionNotice) && len(ra[i].ExtensionOption) > 0 {
+			m = append(m, Notice{Type: NOTICEEXTENSIONOPTION, RA: ra[i], Dt: ra[i].ExtensionOptionNotice, Option: ra[i].ExtensionOption})
+		}
+		if in(&ra[i].ExpansionOptionNotice) && len(ra[i].ExpansionOption) > 0 {
+			m = append(m, Notice{Type: NOTICEEXPANSIONOPTION, RA: ra[i], Dt: ra[i].ExpansionOptionNotice, Option: ra[i].ExpansionOption})
+		}
+		if a, ok := late[ra[i].RAID]; ok {
+			m = append(m, Notice{Type: NOTICELATEPAYMENT, RA: ra[i], Dt: *d1, Amount: rlib.RoundToCent(a.Total - a.Bucket[AGINGCURRENT]), Aging: a})
+		}
+	}
+	return m
+}
+
+// getNoticeNoteType returns the NTID of the NoteType used for notices in
+// business bid, creating it if necessary.
+func getNoticeNoteType(bid, uid int64) (int64, error) {
+	m := rlib.GetAllNoteTypes(bid)
+	for i := 0; i < len(m); i++ {
+		if m[i].Name == NoticeNoteType {
+			return m[i].NTID, nil
+		}
+	}
+	nt := rlib.NoteType{BID: bid, Name: NoticeNoteType, CreateBy: uid, LastModBy: uid}
+	return rlib.InsertNoteType(&nt)
+}
+
+// RecordNotice records a generated notice as a Note on the Rental Agreement's
+// NoteList. A NoteList is created for the agreement if it does not have one.
+// If the same notice has already been recorded it is not recorded again.
+//
+// INPUTS
+//    n    = the notice
+//    text = the notice as it was rendered
+//    uid  = the user generating the notice
+//
+// RETURNS
+//    any error encountered
+//-------------------------------------------------------------------------------------
+func RecordNotice(n *Notice, text string, uid int64) error {
+	comment := fmt.Sprintf("%s notice for %s, %s\n%s", NoticeNames[n.Type], n.RA.IDtoString(), n.Dt.Format(rlib.RRDATEFMT4), text)
+	if len(comment) > 1024 {
+		comment = comment[:1024] // the size of Notes.Comment
+	}
+
+	// another notice for the same agreement may have created its NoteList
+	ra, err := rlib.GetRentalAgreement(n.RA.RAID)
+	if err != nil {
+		return err
+	}
+	n.RA.NLID = ra.NLID
+	if n.RA.NLID == 0 {
+		nl := rlib.NoteList{BID: ra.BID, CreateBy: uid, LastModBy: uid}
+		if nl.NLID, err = rlib.InsertNoteList(&nl); err != nil {
+			return err
+		}
+		ra.NLID = nl.NLID
+		ra.LastModBy = uid
+		if err = rlib.UpdateRentalAgreement(&ra); err != nil {
+			return err
+		}
+		n.RA.NLID = ra.NLID
+	} else {
+		nl := rlib.GetNoteList(n.RA.NLID)
+		for i := 0; i < len(nl.N); i++ {
+			if nl.N[i].Comment == comment {
+				return nil // already recorded
+			}
+		}
+	}
+
+	ntid, err := getNoticeNoteType(n.RA.BID, uid)
+	if err != nil {
+		return err
+	}
+	note := rlib.Note{BID: n.RA.BID, NLID: n.RA.NLID, NTID: ntid, RAID: n.RA.RAID, Comment: comment, CreateBy: uid, LastModBy: uid}
+	_, err = rlib.InsertNote(&note)
+	return err
+}
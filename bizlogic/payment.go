@@ -105,7 +105,8 @@ func AssessmentUnpaidPortion(a *rlib.Assessment) float64 {
 //           cover *amt, then upon return *amt will be 0.00.  If there were not enough funds, *amt will
 //           contain the amount still needed to be paid by another receipt.
 //  dt     - timestamp to mark on the allocation for this payment
-func PayAssessment(a *rlib.Assessment, rcpt *rlib.Receipt, needed *float64, amt *float64, dt *time.Time) error {
+//  uid    - the user making the payment, 0 if it is a worker
+func PayAssessment(a *rlib.Assessment, rcpt *rlib.Receipt, needed *float64, amt *float64, dt *time.Time, uid int64) error {
 	funcname := "PayAssessment"
 
 	amtToUse := *amt
@@ -129,6 +130,8 @@ func PayAssessment(a *rlib.Assessment, rcpt *rlib.Receipt, needed *float64, amt
 	ra.RCPTID = rcpt.RCPTID                        // bind this allocation the the receipt
 	ra.Dt = *dt                                    // the date is the one supplied to this routine, may be different than the Receipt's date
 	ra.RAID = a.RAID                               // this Rental Agreement
+	ra.CreateBy = uid                              // the user making the payment
+	ra.LastModBy = uid                             // and the last to modify it
 	car := rlib.RRdb.BizTypes[a.BID].AR[a.ARID]    // this is the assessment's Account Rule
 	dar := rlib.RRdb.BizTypes[a.BID].AR[rcpt.ARID] // debit -- this is the receipt's Account Rule, credit account
 
@@ -160,6 +163,7 @@ func PayAssessment(a *rlib.Assessment, rcpt *rlib.Receipt, needed *float64, amt
 		a.FLAGS |= 1 // 1 = partially paid
 		fmt.Printf("Partially paid assessment %d\n", a.ASMID)
 	}
+	a.LastModBy = uid
 	err = rlib.UpdateAssessment(a)
 	if err != nil {
 		rlib.LogAndPrintError(funcname, err)
@@ -167,6 +171,12 @@ func PayAssessment(a *rlib.Assessment, rcpt *rlib.Receipt, needed *float64, amt
 	}
 	(*needed) -= ra.Amount
 	fmt.Printf("Amount still owed on assessment %d:  %.2f\n", a.ASMID, *needed)
+	if a.InvoiceNo > 0 {
+		if err = UpdateInvoicePaidStatus(a.InvoiceNo); err != nil {
+			rlib.LogAndPrintError(funcname, err)
+			return err
+		}
+	}
 
 	//------------------------------------------------------------------
 	// update the receipt as partially or fully allocated as needed...
@@ -187,6 +197,7 @@ func PayAssessment(a *rlib.Assessment, rcpt *rlib.Receipt, needed *float64, amt
 	} else {
 		rcpt.AcctRuleApply = ra.AcctRule
 	}
+	rcpt.LastModBy = uid
 	err = rlib.UpdateReceipt(rcpt)
 	if err != nil {
 		rlib.LogAndPrintError(funcname, err)
@@ -202,11 +213,13 @@ func PayAssessment(a *rlib.Assessment, rcpt *rlib.Receipt, needed *float64, amt
 
 	// New
 	var jnl = rlib.Journal{
-		BID:    a.BID,
-		Amount: amtToUse,
-		Dt:     *dt,
-		Type:   rlib.JNLTYPERCPT,
-		ID:     rcpt.RCPTID,
+		BID:       a.BID,
+		Amount:    amtToUse,
+		Dt:        *dt,
+		Type:      rlib.JNLTYPERCPT,
+		ID:        rcpt.RCPTID,
+		CreateBy:  uid,
+		LastModBy: uid,
 	}
 	_, err = rlib.InsertJournal(&jnl)
 	if err != nil {
@@ -233,15 +246,17 @@ func PayAssessment(a *rlib.Assessment, rcpt *rlib.Receipt, needed *float64, amt
 	// Update ledgers based on journal entry
 	//-------------------------------------------------------------------------
 	var l = rlib.LedgerEntry{
-		BID:    jnl.BID,
-		JID:    jnl.JID,
-		RID:    ja.RID,
-		JAID:   ja.JAID,
-		RAID:   ja.RAID,
-		TCID:   ja.TCID,
-		Dt:     jnl.Dt,
-		LID:    dacct.LID,
-		Amount: amtToUse,
+		BID:       jnl.BID,
+		JID:       jnl.JID,
+		RID:       ja.RID,
+		JAID:      ja.JAID,
+		RAID:      ja.RAID,
+		TCID:      ja.TCID,
+		Dt:        jnl.Dt,
+		LID:       dacct.LID,
+		Amount:    amtToUse,
+		CreateBy:  uid,
+		LastModBy: uid,
 	}
 	_, err = rlib.InsertLedgerEntry(&l)
 	if err != nil {
@@ -266,7 +281,8 @@ func PayAssessment(a *rlib.Assessment, rcpt *rlib.Receipt, needed *float64, amt
 // @params:
 //	tcid = TCID of payor
 //  dt   = date to be used for allocations
-func AutoAllocatePayorReceipts(tcid int64, dt *time.Time) error {
+//  uid  = the user allocating the receipts
+func AutoAllocatePayorReceipts(tcid int64, dt *time.Time, uid int64) error {
 	funcname := "AutoAllocatePayorReceipts"
 	fmt.Printf("Entered %s\n", funcname)
 	var t rlib.Transactant
@@ -295,7 +311,7 @@ func AutoAllocatePayorReceipts(tcid int64, dt *time.Time) error {
 			amt := RemainingReceiptFunds(&n[j])
 			fmt.Printf("Needed for ASMID %d :  %.2f\n", m[i].ASMID, needed)
 			fmt.Printf("Funds remaining in receipt %d:  %.2f\n", n[j].RCPTID, amt)
-			err := PayAssessment(&m[i], &n[j], &needed, &amt, dt)
+			err := PayAssessment(&m[i], &n[j], &needed, &amt, dt, uid)
 			fmt.Printf("\n")
 			if err != nil {
 				return err
@@ -0,0 +1,168 @@
+package bizlogic
+
+import (
+	"fmt"
+	"rentroll/rlib"
+	"strings"
+)
+
+// PermAny matches every Business (as a BID of 0), every service and every
+// command in a RolePermission
+const PermAny = "*"
+
+// permMatch returns true if grant p of a RolePermission matches s
+func permMatch(p, s string) bool {
+	return p == PermAny || p == s
+}
+
+// HasPermission decides whether phonebook user uid may run command cmd of web
+// service svc for business bid. It is allowed if one of the user's Roles has
+// a RolePermission for the business (or for every business) that names the
+// service and the command, either of which may be PermAny. For example, a
+// Leasing Agent role with the right ("receipt", "get") may view receipts but
+// not reverse them, which is the "delete" command.
+//
+// Permissions are not enforced until a Role has been given to at least one
+// user. This lets a new installation be set up before anyone is locked out.
+//
+// INPUTS
+//    uid = phonebook UID of the requester
+//    bid = business id, 0 if the request is not for a business
+//    svc = web service, such as "receipt"
+//    cmd = command, such as "get"
+//
+// RETURNS
+//    true if the request is allowed
+//-------------------------------------------------------------------------------------
+func HasPermission(uid, bid int64, svc, cmd string) bool {
+	if rlib.GetCountUserRoles() == 0 {
+		return true
+	}
+	m := rlib.GetUserPermissions(uid)
+	for i := 0; i < len(m); i++ {
+		if (m[i].BID == 0 || m[i].BID == bid) && permMatch(m[i].Service, svc) && permMatch(m[i].Cmd, cmd) {
+			return true
+		}
+	}
+	return false
+}
+
+// SaveRole creates a new Role if role.RoleID is 0, otherwise it updates an
+// existing one. The Role's permissions are replaced by perms.
+//
+// INPUTS
+//    role  = the Role
+//    perms = its permissions, the RoleID of each is set by this call
+//    uid   = the user making the change
+//
+// RETURNS
+//    a slice of BizErrors
+//-------------------------------------------------------------------------------------
+func SaveRole(role *rlib.Role, perms []rlib.RolePermission, uid int64) []BizError {
+	var errlist []BizError
+	bad := func(s string) {
+		errlist = append(errlist, BizError{Errno: InvalidField, Message: BizErrors[InvalidField].Message + "\n" + s})
+	}
+
+	role.Name = strings.TrimSpace(role.Name)
+	if len(role.Name) == 0 {
+		bad("Name")
+	} else if r, err := rlib.GetRoleByName(role.Name); err == nil && r.RoleID != role.RoleID {
+		bad(fmt.Sprintf("There is already a Role named %s", role.Name))
+	}
+	for i := 0; i < len(perms); i++ {
+		perms[i].Service = strings.TrimSpace(perms[i].Service)
+		perms[i].Cmd = strings.TrimSpace(perms[i].Cmd)
+		if len(perms[i].Service) == 0 || len(perms[i].Cmd) == 0 {
+			bad(fmt.Sprintf("Permission %d: Service and Cmd must be supplied", i+1))
+		}
+		if perms[i].BID != 0 {
+			var b rlib.Business
+			rlib.GetBusiness(perms[i].BID, &b)
+			if b.BID == 0 {
+				bad(fmt.Sprintf("Permission %d: Business", i+1))
+			}
+		}
+	}
+	if len(errlist) > 0 {
+		return errlist
+	}
+	role.LastModBy = uid
+
+	if role.RoleID > 0 {
+		old, err := rlib.GetRole(role.RoleID)
+		if err != nil && !rlib.IsSQLNoResultsError(err) {
+			return bizErrSys(&err)
+		}
+		if old.RoleID == 0 {
+			bad(fmt.Sprintf("Role %d", role.RoleID))
+			return errlist
+		}
+		if err = rlib.UpdateRole(role); err != nil {
+			return bizErrSys(&err)
+		}
+		if err = rlib.DeleteRolePermissions(role.RoleID); err != nil {
+			return bizErrSys(&err)
+		}
+	} else {
+		role.CreateBy = uid
+		if _, err := rlib.InsertRole(role); err != nil {
+			return bizErrSys(&err)
+		}
+	}
+
+	for i := 0; i < len(perms); i++ {
+		perms[i].RoleID = role.RoleID
+		perms[i].CreateBy = uid
+		if _, err := rlib.InsertRolePermission(&perms[i]); err != nil {
+			return bizErrSys(&err)
+		}
+	}
+	return nil
+}
+
+// SetUserRoles replaces the Roles of phonebook user uid with roleids. An
+// empty list takes every Role away from the user.
+//
+// INPUTS
+//    uid     = phonebook UID of the user
+//    roleids = the Roles the user is to have
+//    by      = the user making the change
+//
+// RETURNS
+//    a slice of BizErrors
+//-------------------------------------------------------------------------------------
+func SetUserRoles(uid int64, roleids []int64, by int64) []BizError {
+	var errlist []BizError
+	bad := func(s string) {
+		errlist = append(errlist, BizError{Errno: InvalidField, Message: BizErrors[InvalidField].Message + "\n" + s})
+	}
+
+	if uid <= 0 {
+		bad("UID")
+	}
+	for i := 0; i < len(roleids); i++ {
+		if r, err := rlib.GetRole(roleids[i]); err != nil || r.RoleID == 0 {
+			bad(fmt.Sprintf("Role %d", roleids[i]))
+		}
+	}
+	if len(errlist) > 0 {
+		return errlist
+	}
+
+	if err := rlib.DeleteUserRoles(uid); err != nil {
+		return bizErrSys(&err)
+	}
+	seen := map[int64]bool{}
+	for i := 0; i < len(roleids); i++ {
+		if seen[roleids[i]] {
+			continue
+		}
+		seen[roleids[i]] = true
+		a := rlib.UserRole{UID: uid, RoleID: roleids[i], CreateBy: by}
+		if _, err := rlib.InsertUserRole(&a); err != nil {
+			return bizErrSys(&err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,368 @@
+package bizlogic
+
+import (
+	"rentroll/rlib"
+	"time"
+)
+
+// PROSPECTLEAD and the others are the stages of the prospect pipeline. A lead
+// becomes an applicant when it has an ApplicationFee. An applicant is then
+// approved or declined, and an approved applicant is converted into a Rental
+// Agreement.
+const (
+	PROSPECTLEAD      = 0
+	PROSPECTAPPLICANT = 1
+	PROSPECTAPPROVED  = 2
+	PROSPECTDECLINED  = 3
+	PROSPECTCONVERTED = 4
+)
+
+// ProspectStageNames maps the prospect stages to their names
+var ProspectStageNames = map[int]string{
+	PROSPECTLEAD:      "lead",
+	PROSPECTAPPLICANT: "applicant",
+	PROSPECTAPPROVED:  "approved",
+	PROSPECTDECLINED:  "declined",
+	PROSPECTCONVERTED: "converted",
+}
+
+// ProspectStage returns the pipeline stage of prospect p
+func ProspectStage(p *rlib.Prospect) int {
+	switch {
+	case p.FLAGS&rlib.FlProspectConverted != 0:
+		return PROSPECTCONVERTED
+	case p.FLAGS&rlib.FlProspectDeclined != 0:
+		return PROSPECTDECLINED
+	case p.FLAGS&rlib.FlProspectApproved != 0:
+		return PROSPECTAPPROVED
+	case p.ApplicationFee > 0:
+		return PROSPECTAPPLICANT
+	}
+	return PROSPECTLEAD
+}
+
+// ProspectEntry is a prospect in the pipeline
+type ProspectEntry struct {
+	P     rlib.Prospect
+	Name  string // the prospect's full name
+	Stage int    // PROSPECTLEAD ... PROSPECTCONVERTED
+}
+
+// GetProspectPipeline returns the prospects of business bid that are in stage
+// stage, or all of them if stage is less than 0.
+func GetProspectPipeline(bid int64, stage int) []ProspectEntry {
+	var m []ProspectEntry
+	n := rlib.GetProspectsByBusiness(bid)
+	for i := 0; i < len(n); i++ {
+		s := ProspectStage(&n[i])
+		if stage >= 0 && s != stage {
+			continue
+		}
+		var t rlib.Transactant
+		rlib.GetTransactant(n[i].TCID, &t)
+		m = append(m, ProspectEntry{P: n[i], Name: t.GetFullTransactantName(), Stage: s})
+	}
+	return m
+}
+
+// GetProspectFollowUps returns the prospects of business bid that are due for
+// a follow-up on or before dt, oldest FollowUpDate first. Prospects that have
+// been declined or converted are not included.
+func GetProspectFollowUps(bid int64, dt *time.Time) []ProspectEntry {
+	var m []ProspectEntry
+	n := GetProspectPipeline(bid, -1)
+	for i := 0; i < len(n); i++ {
+		if n[i].Stage == PROSPECTDECLINED || n[i].Stage == PROSPECTCONVERTED {
+			continue
+		}
+		if n[i].P.FollowUpDate.Year() <= 1969 || n[i].P.FollowUpDate.After(*dt) {
+			continue
+		}
+		j := len(m)
+		m = append(m, n[i])
+		for ; j > 0 && m[j-1].P.FollowUpDate.After(m[j].P.FollowUpDate); j-- {
+			m[j-1], m[j] = m[j], m[j-1]
+		}
+	}
+	return m
+}
+
+// getProspect reads prospect tcid and makes sure it belongs to business bid
+func getProspect(bid, tcid int64) (rlib.Prospect, []BizError) {
+	var p rlib.Prospect
+	rlib.GetProspect(tcid, &p)
+	if p.TCID == 0 || p.BID != bid {
+		return p, []BizError{{Errno: InvalidField, Message: BizErrors[InvalidField].Message + "\nProspect"}}
+	}
+	return p, nil
+}
+
+// prospectRentalAgreement returns the Rental Agreement that holds the
+// prospect's charges and payments before it is converted. If the prospect does
+// not have one yet, an agreement with the prospect as its payor and no Rentables
+// is created starting on dt and saved in p.RAID.
+//-------------------------------------------------------------------------------------
+func prospectRentalAgreement(p *rlib.Prospect, dt *time.Time, uid int64) error {
+	if p.RAID > 0 {
+		return nil
+	}
+	future := time.Date(9999, time.December, 31, 0, 0, 0, 0, time.UTC)
+	ra := rlib.RentalAgreement{BID: p.BID, AgreementStart: *dt, AgreementStop: future,
+		PossessionStart: *dt, PossessionStop: *dt, RentStart: *dt, RentStop: *dt, RentCycleEpoch: *dt,
+		CreateBy: uid, LastModBy: uid}
+	if _, err := rlib.InsertRentalAgreement(&ra); err != nil {
+		return err
+	}
+	rap := rlib.RentalAgreementPayor{RAID: ra.RAID, BID: p.BID, TCID: p.TCID, DtStart: *dt, DtStop: future, CreateBy: uid}
+	if _, err := rlib.InsertRentalAgreementPayor(&rap); err != nil {
+		return err
+	}
+	p.RAID = ra.RAID
+	p.LastModBy = uid
+	return rlib.UpdateProspect(p)
+}
+
+// ProspectDecision approves or declines an applicant
+type ProspectDecision struct {
+	BID          int64
+	TCID         int64     // the prospect
+	Approve      bool      // true = approve, false = decline
+	SLSID        int64     // reason: the outcome if approved, required decline reason if declined
+	FollowUpDate time.Time // next follow-up, if not zero
+	UID          int64     // the user making the decision
+}
+
+// DecideProspect records the approval or decline of a prospect. The user making
+// the decision is recorded as the Approver. The reason must be a string from one
+// of the business's StringLists.
+//
+// INPUTS
+//    d = the decision
+//
+// RETURNS
+//    a slice of BizErrors
+//-------------------------------------------------------------------------------------
+func DecideProspect(d *ProspectDecision) []BizError {
+	var errlist []BizError
+	bad := func(s string) {
+		errlist = append(errlist, BizError{Errno: InvalidField, Message: BizErrors[InvalidField].Message + "\n" + s})
+	}
+	p, errlist := getProspect(d.BID, d.TCID)
+	if len(errlist) > 0 {
+		return errlist
+	}
+	if s := ProspectStage(&p); s == PROSPECTCONVERTED {
+		bad("The prospect has already been converted to a Rental Agreement")
+	}
+	if d.SLSID > 0 {
+		var sls rlib.SLString
+		rlib.GetSLString(d.SLSID, &sls)
+		if sls.SLSID == 0 || sls.BID != d.BID {
+			bad("Reason")
+		}
+	} else if !d.Approve {
+		bad("A reason is required to decline a prospect")
+	}
+	if len(errlist) > 0 {
+		return errlist
+	}
+
+	p.FLAGS &^= rlib.FlProspectApproved | rlib.FlProspectDeclined
+	if d.Approve {
+		p.FLAGS |= rlib.FlProspectApproved
+		p.OutcomeSLSID = d.SLSID
+		p.DeclineReasonSLSID = 0
+	} else {
+		p.FLAGS |= rlib.FlProspectDeclined
+		p.DeclineReasonSLSID = d.SLSID
+	}
+	p.Approver = d.UID
+	if !d.FollowUpDate.IsZero() {
+		p.FollowUpDate = d.FollowUpDate
+	}
+	p.LastModBy = d.UID
+	if err := rlib.UpdateProspect(&p); err != nil {
+		return bizErrSys(&err)
+	}
+	return nil
+}
+
+// AssessApplicationFee assesses the ApplicationFee of prospect tcid on dt using
+// account rule arid. The fee is assessed on the prospect's Rental Agreement,
+// which is created if needed, and can only be assessed once.
+//
+// INPUTS
+//    bid  = business id
+//    tcid = the prospect
+//    arid = account rule for the assessment
+//    dt   = date of the assessment
+//    uid  = the user assessing the fee
+//
+// RETURNS
+//    the assessment
+//    a slice of BizErrors
+//-------------------------------------------------------------------------------------
+func AssessApplicationFee(bid, tcid, arid int64, dt *time.Time, uid int64) (rlib.Assessment, []BizError) {
+	var a rlib.Assessment
+	var errlist []BizError
+	bad := func(s string) {
+		errlist = append(errlist, BizError{Errno: InvalidField, Message: BizErrors[InvalidField].Message + "\n" + s})
+	}
+	p, errlist := getProspect(bid, tcid)
+	if len(errlist) > 0 {
+		return a, errlist
+	}
+	if p.ApplicationFee <= 0 {
+		bad("The prospect has no application fee")
+	}
+	if p.FLAGS&rlib.FlProspectFeeAssessed != 0 {
+		bad("The application fee has already been assessed")
+	}
+	ar, err := rlib.GetAR(arid)
+	if err != nil || ar.BID != bid || ar.ARType != rlib.ARASSESSMENT {
+		bad("Account Rule")
+	}
+	if len(errlist) > 0 {
+		return a, errlist
+	}
+	if errlist = ValidatePeriodOpen(bid, dt); len(errlist) > 0 {
+		return a, errlist
+	}
+
+	if err = prospectRentalAgreement(&p, dt, uid); err != nil {
+		return a, bizErrSys(&err)
+	}
+	a = rlib.Assessment{BID: bid, RAID: p.RAID, ATypeLID: ar.CreditLID, ARID: arid, Amount: rlib.RoundToCent(p.ApplicationFee),
+		Start: *dt, Stop: *dt, RentCycle: rlib.CYCLENORECUR, ProrationCycle: rlib.CYCLENORECUR,
+		Comment: "application fee", CreateBy: uid, LastModBy: uid}
+	if errlist = InsertAssessment(&a, 0, uid); len(errlist) > 0 {
+		return a, errlist
+	}
+	p.FLAGS |= rlib.FlProspectFeeAssessed
+	p.LastModBy = uid
+	if err = rlib.UpdateProspect(&p); err != nil {
+		return a, bizErrSys(&err)
+	}
+	return a, nil
+}
+
+// ProspectConversion describes the conversion of an approved applicant into a
+// Rental Agreement.
+type ProspectConversion struct {
+	BID          int64        // business
+	TCID         int64        // the prospect, who becomes the payor
+	RATID        int64        // Rental Agreement template
+	RID          int64        // the Rentable being rented
+	Users        []int64      // TCIDs of the users
+	DtStart      time.Time    // move-in date
+	DtStop       time.Time    // end of the agreement
+	ContractRent float64      // monthly rent, 0 = use the market rate
+	RentARID     int64        // account rule for the rent assessment
+	Deposit      float64      // security deposit, 0 = no deposit
+	DepositARID  int64        // account rule for the security deposit assessment
+	Commissions  []Commission // commissions earned, one with no salesperson goes to the prospect's CSAgent
+	UID          int64        // user processing the conversion
+}
+
+// ConvertProspect converts an approved applicant into a Rental Agreement and
+// moves them into the Rentable. If the prospect already has a Rental Agreement
+// holding its application fee or other charges, that agreement becomes the
+// real one so that the charges and payments carry over. Otherwise a new one is
+// created. Any floating deposit the prospect has made is applied to the unpaid
+// charges of the agreement, and whatever is left remains on account.
+//
+// INPUTS
+//    c = the conversion to process
+//
+// RETURNS
+//    the Rental Agreement
+//    a slice of BizErrors
+//-------------------------------------------------------------------------------------
+func ConvertProspect(c *ProspectConversion) (rlib.RentalAgreement, []BizError) {
+	var (
+		ra      rlib.RentalAgreement
+		err     error
+		errlist []BizError
+	)
+	p, errlist := getProspect(c.BID, c.TCID)
+	if len(errlist) > 0 {
+		return ra, errlist
+	}
+	if ProspectStage(&p) != PROSPECTAPPROVED {
+		return ra, []BizError{{Errno: InvalidField, Message: BizErrors[InvalidField].Message + "\nOnly an approved applicant can be converted"}}
+	}
+	mi := MoveIn{BID: c.BID, RAID: p.RAID, RATID: c.RATID, RID: c.RID, Payors: []int64{c.TCID}, Users: c.Users,
+		DtStart: c.DtStart, DtStop: c.DtStop, ContractRent: c.ContractRent, RentARID: c.RentARID,
+		Deposit: c.Deposit, DepositARID: c.DepositARID, Commissions: c.Commissions, UID: c.UID}
+	for i := 0; i < len(mi.Commissions); i++ {
+		cm := &mi.Commissions[i]
+		if len(cm.Salesperson) == 0 && cm.UID == 0 && cm.TCID == 0 {
+			cm.UID = p.CSAgent
+		}
+	}
+
+	//------------------------------------------------
+	// Turn the prospect's agreement into the real
+	// one. Its payor stays on for the new term.
+	//------------------------------------------------
+	var raOld rlib.RentalAgreement
+	var rapOld []rlib.RentalAgreementPayor
+	if p.RAID > 0 {
+		if ra, err = rlib.GetRentalAgreement(p.RAID); err != nil {
+			return ra, bizErrSys(&err)
+		}
+		raOld = ra
+		ra.RATID = c.RATID
+		ra.AgreementStop = c.DtStop
+		ra.PossessionStart = c.DtStart
+		ra.PossessionStop = c.DtStop
+		ra.RentStart = c.DtStart
+		ra.RentStop = c.DtStop
+		ra.RentCycleEpoch = c.DtStart
+		ra.LastModBy = c.UID
+		if err = rlib.UpdateRentalAgreement(&ra); err != nil {
+			return ra, bizErrSys(&err)
+		}
+		rapOld = rlib.GetRentalAgreementPayorsInRange(ra.RAID, &c.DtStart, &c.DtStop)
+		for i := 0; i < len(rapOld); i++ {
+			rap := rapOld[i]
+			rap.DtStop = c.DtStop
+			if err = rlib.UpdateRentalAgreementPayor(&rap); err != nil {
+				return ra, bizErrSys(&err)
+			}
+		}
+	}
+
+	ra, errlist = ProcessMoveIn(&mi)
+	if len(errlist) > 0 {
+		if raOld.RAID > 0 { // put the prospect's agreement back the way it was
+			rlib.UpdateRentalAgreement(&raOld)
+			for i := 0; i < len(rapOld); i++ {
+				rlib.UpdateRentalAgreementPayor(&rapOld[i])
+			}
+		}
+		return ra, errlist
+	}
+
+	p.RAID = ra.RAID
+	if _, err = applyFloatingDeposit(&p, &c.DtStart, c.UID); err != nil {
+		return ra, bizErrSys(&err)
+	}
+	p.FLAGS |= rlib.FlProspectConverted
+	p.LastModBy = c.UID
+	if err = rlib.UpdateProspect(&p); err != nil {
+		return ra, bizErrSys(&err)
+	}
+	return ra, nil
+}
+
+// ProspectStageFromName returns the stage with name s, or -1 if there is none
+func ProspectStageFromName(s string) int {
+	for k, v := range ProspectStageNames {
+		if v == s {
+			return k
+		}
+	}
+	return -1
+}
+
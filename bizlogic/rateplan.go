@@ -0,0 +1,331 @@
+package bizlogic
+
+import (
+	"fmt"
+	"rentroll/rlib"
+	"strings"
+	"time"
+)
+
+// SaveRatePlan validates and saves RatePlan a. If a.RPID is 0 a new RatePlan
+// is created, otherwise the existing one is updated.
+func SaveRatePlan(a *rlib.RatePlan) []BizError {
+	var err error
+	a.Name = strings.TrimSpace(a.Name)
+	if len(a.Name) == 0 {
+		return []BizError{{Errno: InvalidField, Message: BizErrors[InvalidField].Message + "\nName"}}
+	}
+	var b rlib.RatePlan
+	rlib.GetRatePlanByName(a.BID, a.Name, &b)
+	if b.RPID > 0 && b.RPID != a.RPID {
+		return []BizError{{Errno: InvalidField, Message: BizErrors[InvalidField].Message + "\nA rate plan named " + a.Name + " already exists"}}
+	}
+	if a.RPID == 0 {
+		_, err = rlib.InsertRatePlan(a)
+	} else {
+		err = rlib.UpdateRatePlan(a)
+	}
+	if err != nil {
+		return bizErrSys(&err)
+	}
+	return nil
+}
+
+// DeleteRatePlan deletes RatePlan rpid along with all of its RatePlanRefs and
+// their rates.
+func DeleteRatePlan(rpid int64) []BizError {
+	m := rlib.GetAllRatePlanRefs(rpid)
+	for i := 0; i < len(m); i++ {
+		if errlist := DeleteRatePlanRef(m[i].RPRID); len(errlist) > 0 {
+			return errlist
+		}
+	}
+	if err := rlib.DeleteRatePlan(rpid); err != nil {
+		return bizErrSys(&err)
+	}
+	return nil
+}
+
+// validateRatePlanRef checks RatePlanRef a and its rates for business logic
+// errors before anything is written.
+//-------------------------------------------------------------------------------------
+func validateRatePlanRef(a *rlib.RatePlanRef) []BizError {
+	var errlist []BizError
+	bad := func(s string) {
+		errlist = append(errlist, BizError{Errno: InvalidField, Message: BizErrors[InvalidField].Message + "\n" + s})
+	}
+	var rp rlib.RatePlan
+	rlib.GetRatePlan(a.RPID, &rp)
+	if rp.RPID == 0 || rp.BID != a.BID {
+		bad("Rate Plan")
+	}
+	if !a.DtStop.After(a.DtStart) {
+		bad("The stop date must be after the start date")
+	}
+	if a.MaxNoFeeUsers < 0 || a.AdditionalUserFee < 0 || a.FeeAppliesAge < 0 || a.CancellationFee < 0 {
+		bad("Fees, ages and user counts cannot be negative")
+	}
+	var xbiz rlib.XBusiness
+	rlib.GetXBusiness(a.BID, &xbiz)
+	rts := map[int64]bool{}
+	for i := 0; i < len(a.RT); i++ {
+		if _, ok := xbiz.RT[a.RT[i].RTID]; !ok || rts[a.RT[i].RTID] {
+			bad(fmt.Sprintf("Rentable Type %d", a.RT[i].RTID))
+		}
+		rts[a.RT[i].RTID] = true
+	}
+	for i := 0; i < len(a.SP); i++ {
+		if _, ok := xbiz.US[a.SP[i].RSPID]; !ok || !rts[a.SP[i].RTID] {
+			bad(fmt.Sprintf("Specialty %d for Rentable Type %d", a.SP[i].RSPID, a.SP[i].RTID))
+		}
+	}
+	return errlist
+}
+
+// SaveRatePlanRef validates and saves RatePlanRef a along with its RentableType
+// rates a.RT and Specialty rates a.SP. If a.RPRID is 0 a new RatePlanRef is
+// created, otherwise the existing one is updated and its rates are replaced.
+func SaveRatePlanRef(a *rlib.RatePlanRef) []BizError {
+	var err error
+	if errlist := validateRatePlanRef(a); len(errlist) > 0 {
+		return errlist
+	}
+	if a.RPRID == 0 {
+		_, err = rlib.InsertRatePlanRef(a)
+	} else {
+		var old rlib.RatePlanRef
+		rlib.GetRatePlanRefFull(a.RPRID, &old)
+		if err = deleteRatePlanRefRates(&old); err == nil {
+			err = rlib.UpdateRatePlanRef(a)
+		}
+	}
+	if err != nil {
+		return bizErrSys(&err)
+	}
+	for i := 0; i < len(a.RT); i++ {
+		a.RT[i].RPRID = a.RPRID
+		a.RT[i].BID = a.BID
+		a.RT[i].CreateBy = a.LastModBy
+		if err = rlib.InsertRatePlanRefRTRate(&a.RT[i]); err != nil {
+			return bizErrSys(&err)
+		}
+	}
+	for i := 0; i < len(a.SP); i++ {
+		a.SP[i].RPRID = a.RPRID
+		a.SP[i].BID = a.BID
+		a.SP[i].CreateBy = a.LastModBy
+		if err = rlib.InsertRatePlanRefSPRate(&a.SP[i]); err != nil {
+			return bizErrSys(&err)
+		}
+	}
+	return nil
+}
+
+// deleteRatePlanRefRates deletes the RentableType and Specialty rates of a
+func deleteRatePlanRefRates(a *rlib.RatePlanRef) error {
+	for i := 0; i < len(a.SP); i++ {
+		if err := rlib.DeleteRatePlanRefSPRate(a.RPRID, a.SP[i].RSPID); err != nil {
+			return err
+		}
+	}
+	for i := 0; i < len(a.RT); i++ {
+		if err := rlib.DeleteRatePlanRefRTRate(a.RPRID, a.RT[i].RTID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DeleteRatePlanRef deletes RatePlanRef rprid and its rates
+func DeleteRatePlanRef(rprid int64) []BizError {
+	var a rlib.RatePlanRef
+	rlib.GetRatePlanRefFull(rprid, &a)
+	err := deleteRatePlanRefRates(&a)
+	if err == nil {
+		err = rlib.DeleteRatePlanRef(rprid)
+	}
+	if err != nil {
+		return bizErrSys(&err)
+	}
+	return nil
+}
+
+// RateQuoteRequest describes what is to be priced
+type RateQuoteRequest struct {
+	BID       int64     // business
+	RPID      int64     // the RatePlan
+	RTID      int64     // the RentableType
+	RSPIDs    []int64   // Specialties
+	DtStart   time.Time // start of the rental
+	DtStop    time.Time // end of the rental
+	Headcount int64     // number of users who are at least the RatePlanRef's FeeAppliesAge
+	PromoCode string    // promotion code, if any
+}
+
+// RateQuoteItem is one line of a RateQuote
+type RateQuoteItem struct {
+	Descr  string  // what is being charged
+	Rate   float64 // amount per rent cycle
+	Amount float64 // amount for the quoted period
+}
+
+// RateQuote is the itemized price of a RateQuoteRequest
+type RateQuote struct {
+	BID        int64
+	RPID       int64           // the RatePlan
+	RPRID      int64           // the RatePlanRef in effect
+	RTID       int64           // the RentableType
+	DtStart    time.Time       // start of the rental
+	DtStop     time.Time       // end of the rental
+	RentCycle  int64           // the RentableType's rent cycle
+	Cycles     float64         // number of rent cycles in the period, including any prorated cycle
+	MarketRate float64         // the RentableType's market rate per rent cycle
+	Items      []RateQuoteItem // the charges
+	Rate       float64         // total per rent cycle
+	Total      float64         // total for the period
+}
+
+// GetEffectiveRatePlanRef returns the RatePlanRef of RatePlan rpid in effect on
+// dt. A RatePlanRef with a PromoCode applies only when that code is supplied,
+// and it is chosen over a RatePlanRef without one. The returned RatePlanRef
+// has RPRID 0 if none is in effect.
+func GetEffectiveRatePlanRef(rpid int64, promo string, dt *time.Time) rlib.RatePlanRef {
+	var rpr rlib.RatePlanRef
+	promo = strings.TrimSpace(promo)
+	m := rlib.GetRatePlanRefsInRange(rpid, dt, dt)
+	for i := 0; i < len(m); i++ {
+		if len(m[i].PromoCode) == 0 {
+			if rpr.RPRID == 0 {
+				rpr = m[i]
+			}
+		} else if len(promo) > 0 && strings.EqualFold(m[i].PromoCode, promo) {
+			rpr = m[i]
+			break
+		}
+	}
+	return rpr
+}
+
+// quoteCycles returns the number of rent cycles between d1 and d2. A partial
+// cycle at the end is prorated.
+func quoteCycles(d1, d2 time.Time, cycle, proration int64) float64 {
+	if cycle == rlib.CYCLENORECUR {
+		return float64(1)
+	}
+	n := float64(0)
+	for dt := d1; dt.Before(d2); {
+		next := dt.Add(rlib.CycleDuration(cycle, dt))
+		if next.After(d2) && proration != rlib.CYCLENORECUR {
+			_, _, pf := rlib.Prorate(dt, d2, dt, next, cycle, proration)
+			return n + pf
+		}
+		n++
+		dt = next
+	}
+	return n
+}
+
+// GetRateQuote prices a rental using the RatePlanRef of RatePlan q.RPID in
+// effect on q.DtStart. The RentableType is priced at the RatePlanRef's rate for
+// it, which is either an absolute amount or a percentage of the RentableType's
+// market rate. If the RatePlanRef does not affect the RentableType the market
+// rate is used. Each Specialty is priced the same way, falling back to the
+// Specialty's fee. Users beyond MaxNoFeeUsers are charged AdditionalUserFee
+// each. Every charge is per rent cycle and is multiplied by the number of
+// rent cycles in the period.
+//
+// INPUTS
+//    q = what is to be priced
+//
+// RETURNS
+//    the itemized quote
+//    a slice of BizErrors
+//-------------------------------------------------------------------------------------
+func GetRateQuote(q *RateQuoteRequest) (RateQuote, []BizError) {
+	var errlist []BizError
+	bad := func(s string) {
+		errlist = append(errlist, BizError{Errno: InvalidField, Message: BizErrors[InvalidField].Message + "\n" + s})
+	}
+	rq := RateQuote{BID: q.BID, RPID: q.RPID, RTID: q.RTID, DtStart: q.DtStart, DtStop: q.DtStop}
+
+	var rp rlib.RatePlan
+	rlib.GetRatePlan(q.RPID, &rp)
+	if rp.RPID == 0 || rp.BID != q.BID {
+		bad("Rate Plan")
+	}
+	var xbiz rlib.XBusiness
+	rlib.GetXBusiness(q.BID, &xbiz)
+	rt, ok := xbiz.RT[q.RTID]
+	if !ok {
+		bad("Rentable Type")
+	}
+	for i := 0; i < len(q.RSPIDs); i++ {
+		if _, ok := xbiz.US[q.RSPIDs[i]]; !ok {
+			bad(fmt.Sprintf("Specialty %d", q.RSPIDs[i]))
+		}
+	}
+	if !q.DtStop.After(q.DtStart) {
+		bad("The stop date must be after the start date")
+	}
+	if len(errlist) > 0 {
+		return rq, errlist
+	}
+	rpr := GetEffectiveRatePlanRef(q.RPID, q.PromoCode, &q.DtStart)
+	if rpr.RPRID == 0 {
+		bad(fmt.Sprintf("Rate plan %s has no rates in effect on %s", rp.Name, q.DtStart.Format(rlib.RRDATEFMT4)))
+		return rq, errlist
+	}
+	rq.RPRID = rpr.RPRID
+	rq.RentCycle = rt.RentCycle
+	rq.Cycles = quoteCycles(q.DtStart, q.DtStop, rt.RentCycle, rt.Proration)
+	rq.MarketRate = rlib.FindApplicableMarketRate(q.DtStart, q.DtStart, q.DtStop, rt.MR)
+
+	add := func(descr string, rate float64) {
+		rate = rlib.RoundToCent(rate)
+		amt := rlib.RoundToCent(rate * rq.Cycles)
+		rq.Items = append(rq.Items, RateQuoteItem{Descr: descr, Rate: rate, Amount: amt})
+		rq.Rate += rate
+		rq.Total += amt
+	}
+
+	//------------------------------------------------
+	// the RentableType
+	//------------------------------------------------
+	var rtr rlib.RatePlanRefRTRate
+	rlib.GetRatePlanRefRTRate(rpr.RPRID, q.RTID, &rtr)
+	switch {
+	case rtr.RPRID == 0 || rtr.FLAGS&rlib.FlRTRna != 0:
+		add(rt.Name+" (market rate)", rq.MarketRate)
+	case rtr.FLAGS&rlib.FlRTRpct != 0:
+		add(fmt.Sprintf("%s (%.2f%% of market rate)", rt.Name, rtr.Val*100), rq.MarketRate*rtr.Val)
+	default:
+		add(rt.Name, rtr.Val)
+	}
+
+	//------------------------------------------------
+	// Specialties
+	//------------------------------------------------
+	for i := 0; i < len(q.RSPIDs); i++ {
+		sp := xbiz.US[q.RSPIDs[i]]
+		var spr rlib.RatePlanRefSPRate
+		rlib.GetRatePlanRefSPRate(rpr.RPRID, q.RTID, sp.RSPID, &spr)
+		switch {
+		case spr.RPRID == 0 || spr.FLAGS&rlib.FlSPRna != 0:
+			add(sp.Name, sp.Fee)
+		case spr.FLAGS&rlib.FlSPRpct != 0:
+			add(fmt.Sprintf("%s (%.2f%% of market rate)", sp.Name, spr.Val*100), rq.MarketRate*spr.Val)
+		default:
+			add(sp.Name, spr.Val)
+		}
+	}
+
+	//------------------------------------------------
+	// additional users
+	//------------------------------------------------
+	if n := q.Headcount - rpr.MaxNoFeeUsers; n > 0 && rpr.AdditionalUserFee > 0 {
+		add(fmt.Sprintf("%d additional user(s) at %.2f", n, rpr.AdditionalUserFee), float64(n)*rpr.AdditionalUserFee)
+	}
+	rq.Rate = rlib.RoundToCent(rq.Rate)
+	rq.Total = rlib.RoundToCent(rq.Total)
+	return rq, nil
+}
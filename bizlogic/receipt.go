@@ -341,6 +341,9 @@ func InsertReceipt(a *rlib.Receipt) error {
 //-------------------------------------------------------------------------------------
 func ValidateReceipt(r *rlib.Receipt) []BizError {
 	var e []BizError
+	if e = ValidatePeriodOpen(r.BID, &r.Dt); len(e) > 0 {
+		return e
+	}
 	fields := []string{}
 	if r.TCID == 0 {
 		fields = append(fields, "Payor")
@@ -0,0 +1,264 @@
+package bizlogic
+
+import (
+	"fmt"
+	"rentroll/rlib"
+	"time"
+)
+
+// RAExtension describes the extension or renewal of a Rental Agreement. An
+// extension pushes out the stop dates of the agreement. A renewal starts a new
+// agreement term on the day the current one ends. Either way the Rentables,
+// payors, users and open-ended recurring assessments are carried forward to
+// DtStop.
+type RAExtension struct {
+	BID        int64     // business
+	RAID       int64     // the agreement being extended or renewed
+	NewTerm    bool      // true = renew as a new agreement, false = extend the existing one
+	DtStop     time.Time // end of the extended or new term
+	RateChange float64   // change to the rent for the new term, as a percentage, 0 = no change
+	UID        int64     // user processing the extension
+}
+
+// validateRAExtension checks the extension for business logic errors before
+// anything is written.
+//-------------------------------------------------------------------------------------
+func validateRAExtension(x *RAExtension, ra *rlib.RentalAgreement) []BizError {
+	var errlist []BizError
+	bad := func(s string) {
+		errlist = append(errlist, BizError{Errno: InvalidField, Message: BizErrors[InvalidField].Message + "\n" + s})
+	}
+	if ra.RAID == 0 || ra.BID != x.BID {
+		bad("Rental Agreement")
+		return errlist
+	}
+	if !x.DtStop.After(ra.AgreementStop) {
+		bad("The new stop date must be after the current agreement stop date")
+	}
+	if x.RateChange <= -100 {
+		bad("Rate Change")
+	}
+	if len(errlist) > 0 {
+		return errlist
+	}
+	return ValidatePeriodOpen(x.BID, &ra.AgreementStop)
+}
+
+// ExtendRentalAgreement extends or renews a Rental Agreement as described by x.
+// The Rentables and payors in effect when the agreement ends are carried forward
+// to x.DtStop, either on the same agreement or on a new one, and the users of
+// those Rentables are extended. The Rentables remain occupied. The recurring
+// assessments that run to the end of the agreement are extended to x.DtStop.
+// A rate change applies to the rent only: the contract rent and the recurring
+// rent assessments are stopped when the current term ends and replaced by new
+// ones at the changed amount. A renewal replaces all the recurring assessments
+// so that they belong to the new agreement.
+//
+// INPUTS
+//    x = the extension to process
+//
+// RETURNS
+//    the Rental Agreement for the new term, which is the original agreement
+//    for an extension
+//    a slice of BizErrors
+//-------------------------------------------------------------------------------------
+func ExtendRentalAgreement(x *RAExtension) (rlib.RentalAgreement, []BizError) {
+	var (
+		err     error
+		errlist []BizError
+		now     = time.Now()
+		future  = time.Date(9999, time.December, 31, 0, 0, 0, 0, time.UTC)
+	)
+
+	ra, _ := rlib.GetRentalAgreement(x.RAID)
+	if errlist = validateRAExtension(x, &ra); len(errlist) > 0 {
+		return ra, errlist
+	}
+	dt := ra.AgreementStop // the current term ends and the new one begins
+	d0 := dt.AddDate(0, 0, -1)
+	rate := 1 + x.RateChange/100
+	rar := rlib.GetRentalAgreementRentables(ra.RAID, &d0, &dt)
+	p := rlib.GetRentalAgreementPayorsInRange(ra.RAID, &d0, &dt)
+
+	//------------------------------------------------
+	// the agreement for the new term
+	//------------------------------------------------
+	nra := ra
+	if x.NewTerm {
+		nra.RAID = 0
+		nra.NLID = 0
+		nra.AgreementStart = dt
+		nra.PossessionStart = dt
+		nra.RentStart = dt
+		nra.RentCycleEpoch = dt
+	}
+	for _, d := range []*time.Time{&nra.AgreementStop, &nra.PossessionStop, &nra.RentStop} {
+		if d.Before(x.DtStop) {
+			*d = x.DtStop
+		}
+	}
+	nra.LastModBy = x.UID
+	if x.NewTerm {
+		nra.CreateBy = x.UID
+		if _, err = rlib.InsertRentalAgreement(&nra); err != nil {
+			return nra, bizErrSys(&err)
+		}
+		ra.Renewal = 0 // the new term carries the renewal setting from here on
+		ra.LastModBy = x.UID
+		if err = rlib.UpdateRentalAgreement(&ra); err != nil {
+			return nra, bizErrSys(&err)
+		}
+	} else if err = rlib.UpdateRentalAgreement(&nra); err != nil {
+		return nra, bizErrSys(&err)
+	}
+
+	//------------------------------------------------
+	// Rentables, their users, and the payors
+	//------------------------------------------------
+	for i := 0; i < len(rar); i++ {
+		if x.NewTerm {
+			r := rlib.RentalAgreementRentable{RAID: nra.RAID, BID: x.BID, RID: rar[i].RID, CLID: rar[i].CLID,
+				ContractRent: rlib.RoundToCent(rar[i].ContractRent * rate), RARDtStart: dt, RARDtStop: x.DtStop, CreateBy: x.UID}
+			if _, err = rlib.InsertRentalAgreementRentable(&r); err != nil {
+				return nra, bizErrSys(&err)
+			}
+		} else if x.RateChange != float64(0) {
+			r := rar[i]
+			r.RARID = 0
+			r.ContractRent = rlib.RoundToCent(rar[i].ContractRent * rate)
+			r.RARDtStart = dt
+			if r.RARDtStop.Before(x.DtStop) {
+				r.RARDtStop = x.DtStop
+			}
+			r.CreateBy = x.UID
+			rar[i].RARDtStop = dt // the current rent ends with the current term
+			if err = rlib.UpdateRentalAgreementRentable(&rar[i]); err != nil {
+				return nra, bizErrSys(&err)
+			}
+			if _, err = rlib.InsertRentalAgreementRentable(&r); err != nil {
+				return nra, bizErrSys(&err)
+			}
+		} else {
+			if rar[i].RARDtStop.Before(x.DtStop) {
+				rar[i].RARDtStop = x.DtStop
+			}
+			if err = rlib.UpdateRentalAgreementRentable(&rar[i]); err != nil {
+				return nra, bizErrSys(&err)
+			}
+		}
+		u := rlib.GetRentableUsersInRange(rar[i].RID, &d0, &dt)
+		for j := 0; j < len(u); j++ {
+			if u[j].DtStop.Before(x.DtStop) {
+				u[j].DtStop = x.DtStop
+				if err = rlib.UpdateRentableUser(&u[j]); err != nil {
+					return nra, bizErrSys(&err)
+				}
+			}
+		}
+		if _, _, err = setRentableStatus(rar[i].RID, x.BID, rlib.RENTABLESTATUSOCCUPIED, &dt, &x.DtStop, x.UID); err != nil {
+			return nra, bizErrSys(&err)
+		}
+	}
+	for i := 0; i < len(p); i++ {
+		if x.NewTerm {
+			np := rlib.RentalAgreementPayor{RAID: nra.RAID, BID: x.BID, TCID: p[i].TCID, DtStart: dt, DtStop: x.DtStop, CreateBy: x.UID}
+			if _, err = rlib.InsertRentalAgreementPayor(&np); err != nil {
+				return nra, bizErrSys(&err)
+			}
+		} else if p[i].DtStop.Before(x.DtStop) {
+			p[i].DtStop = x.DtStop
+			if err = rlib.UpdateRentalAgreementPayor(&p[i]); err != nil {
+				return nra, bizErrSys(&err)
+			}
+		}
+	}
+
+	//------------------------------------------------
+	// Recurring assessments that run to the end of
+	// the agreement. If nothing about them changes
+	// they are simply extended. Otherwise they stop
+	// when the current term ends and new ones start.
+	// Only the rent changes by the rate.
+	//------------------------------------------------
+	for i := 0; i < len(rar); i++ {
+		m := rlib.GetAllRentableAssessments(rar[i].RID, &d0, &future)
+		for j := 0; j < len(m); j++ {
+			if m[j].RAID != ra.RAID || m[j].PASMID != 0 || m[j].RentCycle == rlib.RECURNONE || m[j].FLAGS&rlib.ASMREVERSED != 0 || m[j].Stop.Before(dt) {
+				continue
+			}
+			rent := rlib.IsRentAR(m[j].ARID)
+			if !x.NewTerm && (x.RateChange == float64(0) || !rent) {
+				if m[j].Stop.Before(x.DtStop) {
+					m[j].Stop = x.DtStop
+					m[j].LastModBy = x.UID
+					if err = rlib.UpdateAssessment(&m[j]); err != nil {
+						return nra, bizErrSys(&err)
+					}
+				}
+				continue
+			}
+			if errlist = ReverseAssessmentsGoingForward(&rlib.Assessment{PASMID: m[j].ASMID}, &dt, &now, x.UID); len(errlist) > 0 {
+				return nra, errlist
+			}
+			a := m[j]
+			a.ASMID = 0
+			a.RAID = nra.RAID
+			a.Start = dt
+			if a.Stop.Before(x.DtStop) {
+				a.Stop = x.DtStop
+			}
+			if rent {
+				a.Amount = rlib.RoundToCent(m[j].Amount * rate)
+			}
+			a.Comment = fmt.Sprintf("continues %s for the term ending %s", m[j].IDtoString(), x.DtStop.Format(rlib.RRDATEFMT4))
+			a.CreateBy = x.UID
+			a.LastModBy = x.UID
+
+			m[j].Stop = dt
+			m[j].LastModBy = x.UID
+			if err = rlib.UpdateAssessment(&m[j]); err != nil {
+				return nra, bizErrSys(&err)
+			}
+			if errlist = InsertAssessment(&a, 1, x.UID); len(errlist) > 0 {
+				return nra, errlist
+			}
+		}
+	}
+	return nra, nil
+}
+
+// RenewMonthToMonth extends, by one month, each Rental Agreement of business
+// bid that is set for month to month automatic renewal (Renewal = 1) and that
+// ends on or before dt. Agreements that ended more than a month before dt are
+// left alone.
+//
+// INPUTS
+//    bid = business id
+//    dt  = the date on which the renewals are processed
+//    uid = the user processing the renewals, 0 for the automatic worker
+//
+// RETURNS
+//    the number of agreements renewed
+//    a slice of BizErrors
+//-------------------------------------------------------------------------------------
+func RenewMonthToMonth(bid int64, dt *time.Time, uid int64) (int, []BizError) {
+	var (
+		n       int
+		errlist []BizError
+	)
+	d1 := rlib.DateAtTimeZero(*dt).AddDate(0, -1, 0)
+	d2 := rlib.DateAtTimeZero(*dt).AddDate(0, 0, 1)
+	m := rlib.GetAllRentalAgreementsByRange(bid, &d1, &d2)
+	for i := 0; i < len(m); i++ {
+		if m[i].Renewal != 1 || !m[i].AgreementStop.Before(d2) {
+			continue
+		}
+		x := RAExtension{BID: bid, RAID: m[i].RAID, DtStop: m[i].AgreementStop.AddDate(0, 1, 0), UID: uid}
+		if _, e := ExtendRentalAgreement(&x); len(e) > 0 {
+			errlist = append(errlist, e...)
+			continue
+		}
+		n++
+	}
+	return n, errlist
+}
@@ -0,0 +1,72 @@
+package bizlogic
+
+import (
+	"rentroll/rlib"
+	"time"
+)
+
+// setRentableStatus sets the status of Rentable rid to status for the period
+// d1 - d2. Existing RentableStatus records that overlap the period are removed
+// and the parts of them that fall outside the period are written back.
+//
+// INPUTS
+//    rid    = the Rentable
+//    bid    = business id
+//    status = the new status, RENTABLESTATUSONLINE, RENTABLESTATUSOCCUPIED, ...
+//    d1-d2  = the period
+//    uid    = the user making the change
+//
+// RETURNS
+//    the RentableStatus records that were removed
+//    the RentableStatus records that were added
+//    any error encountered
+//-------------------------------------------------------------------------------------
+func setRentableStatus(rid, bid, status int64, d1, d2 *time.Time, uid int64) ([]rlib.RentableStatus, []rlib.RentableStatus, error) {
+	var removed, added []rlib.RentableStatus
+	m := rlib.GetRentableStatusByRange(rid, d1, d2)
+	for i := 0; i < len(m); i++ {
+		if !rlib.DateRangeOverlap(d1, d2, &m[i].DtStart, &m[i].DtStop) {
+			continue
+		}
+		if err := rlib.DeleteRentableStatus(m[i].RSID); err != nil {
+			return removed, added, err
+		}
+		removed = append(removed, m[i])
+		if m[i].DtStart.Before(*d1) { // keep the part before d1
+			rs := m[i]
+			rs.DtStop = *d1
+			rs.LastModBy = uid
+			if err := rlib.InsertRentableStatus(&rs); err != nil {
+				return removed, added, err
+			}
+			added = append(added, rs)
+		}
+		if m[i].DtStop.After(*d2) { // keep the part after d2
+			rs := m[i]
+			rs.DtStart = *d2
+			rs.LastModBy = uid
+			if err := rlib.InsertRentableStatus(&rs); err != nil {
+				return removed, added, err
+			}
+			added = append(added, rs)
+		}
+	}
+	rs := rlib.RentableStatus{RID: rid, BID: bid, Status: status, DtStart: *d1, DtStop: *d2, CreateBy: uid, LastModBy: uid}
+	if err := rlib.InsertRentableStatus(&rs); err != nil {
+		return removed, added, err
+	}
+	added = append(added, rs)
+	return removed, added, nil
+}
+
+// restoreRentableStatus undoes setRentableStatus. The added records are deleted
+// and the removed records are written back.
+//-------------------------------------------------------------------------------------
+func restoreRentableStatus(removed, added []rlib.RentableStatus) {
+	for i := 0; i < len(added); i++ {
+		rlib.DeleteRentableStatus(added[i].RSID)
+	}
+	for i := 0; i < len(removed); i++ {
+		rlib.InsertRentableStatus(&removed[i])
+	}
+}
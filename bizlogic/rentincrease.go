@@ -0,0 +1,161 @@
+package bizlogic
+
+import (
+	"fmt"
+	"rentroll/rlib"
+	"time"
+)
+
+// RentIncrease describes the increase of one recurring rent assessment of a
+// Rental Agreement on the agreement's NextRateChange date.
+type RentIncrease struct {
+	RA        rlib.RentalAgreement // the agreement
+	ASM       rlib.Assessment      // the recurring assessment being increased
+	Dt        time.Time            // date the increase takes effect
+	Rate      float64              // the increase, as a percentage
+	OldAmount float64              // amount before the increase
+	NewAmount float64              // amount after the increase
+}
+
+// GetRentIncreasesDue returns the rent increases that take effect during the
+// period d1 - d2 for the Rental Agreements of business bid. An increase is due
+// for an agreement with a non-zero RateChange whose NextRateChange falls within
+// the period while the agreement is in effect. Each recurring rent assessment
+// of the agreement in effect on NextRateChange is increased by RateChange
+// percent. Other recurring assessments, such as pet fees, are not changed.
+// Nothing is written; this is used for the dry run as well as by
+// ApplyRentIncreases.
+//
+// INPUTS
+//    bid   = business id
+//    d1-d2 = the period
+//
+// RETURNS
+//    the rent increases
+//-------------------------------------------------------------------------------------
+func GetRentIncreasesDue(bid int64, d1, d2 *time.Time) []RentIncrease {
+	var m []RentIncrease
+	ra := rlib.GetAllRentalAgreementsByRange(bid, d1, d2)
+	for i := 0; i < len(ra); i++ {
+		dt := ra[i].NextRateChange
+		if ra[i].RateChange == float64(0) || dt.Before(*d1) || !dt.Before(*d2) {
+			continue
+		}
+		if dt.Before(ra[i].AgreementStart) || !dt.Before(ra[i].AgreementStop) {
+			continue
+		}
+		dt1 := dt.AddDate(0, 0, 1)
+		rar := rlib.GetRentalAgreementRentables(ra[i].RAID, &dt, &dt1)
+		for j := 0; j < len(rar); j++ {
+			a := rlib.GetAllRentableAssessments(rar[j].RID, &dt, &dt1)
+			for k := 0; k < len(a); k++ {
+				if a[k].RAID != ra[i].RAID || a[k].PASMID != 0 || a[k].RentCycle == rlib.RECURNONE || a[k].FLAGS&rlib.ASMREVERSED != 0 || !rlib.IsRentAR(a[k].ARID) {
+					continue
+				}
+				m = append(m, RentIncrease{RA: ra[i], ASM: a[k], Dt: dt, Rate: ra[i].RateChange, OldAmount: a[k].Amount,
+					NewAmount: rlib.RoundToCent(a[k].Amount * (1 + ra[i].RateChange/100))})
+			}
+		}
+	}
+	return m
+}
+
+// rentIncreaseAssessment returns the recurring assessment that replaces the
+// one increased by r, starting on the increase date at the new amount.
+func rentIncreaseAssessment(r *RentIncrease, uid int64) rlib.Assessment {
+	a := r.ASM
+	a.ASMID = 0
+	a.FLAGS = 0
+	a.Start = r.Dt
+	a.Amount = r.NewAmount
+	a.Comment = fmt.Sprintf("rent increase of %.2f%% from %s", r.Rate, r.ASM.IDtoString())
+	a.CreateBy = uid
+	a.LastModBy = uid
+	return a
+}
+
+// ApplyRentIncreases applies the rent increases of business bid that are due
+// on or before dt. The recurring assessment being increased is stopped on the
+// increase date, any instances of it already created from that date forward
+// are reversed, and a new recurring assessment is started at the increased
+// amount. The ContractRent of the agreement's Rentables is increased by the
+// same rate from the increase date on, the rent before it is kept, and
+// NextRateChange is advanced by a year. Increases that were
+// missed, for up to a month before dt, are applied as well. All the increases
+// are validated before anything is written, and processing stops at the first
+// error.
+//
+// INPUTS
+//    bid = business id
+//    dt  = the date the increases are being applied
+//    uid = the user applying the increases, 0 for the automatic worker
+//
+// RETURNS
+//    the rent increases that were applied
+//    a slice of BizErrors
+//-------------------------------------------------------------------------------------
+func ApplyRentIncreases(bid int64, dt *time.Time, uid int64) ([]RentIncrease, []BizError) {
+	var (
+		done    []RentIncrease
+		errlist []BizError
+		err     error
+	)
+	now := time.Now()
+	d1 := rlib.DateAtTimeZero(*dt).AddDate(0, -1, 0)
+	d2 := rlib.DateAtTimeZero(*dt).AddDate(0, 0, 1)
+	m := GetRentIncreasesDue(bid, &d1, &d2)
+
+	//------------------------------------------------
+	// validate every increase before writing any
+	//------------------------------------------------
+	asms := make([]rlib.Assessment, len(m))
+	for i := 0; i < len(m); i++ {
+		asms[i] = rentIncreaseAssessment(&m[i], uid)
+		if errlist = ValidateAssessment(&asms[i]); len(errlist) > 0 {
+			return done, errlist
+		}
+	}
+
+	for i := 0; i < len(m); i++ {
+		r := &m[i]
+		a := asms[i]
+		if errlist = ReverseAssessmentsGoingForward(&rlib.Assessment{PASMID: r.ASM.ASMID}, &r.Dt, &now, uid); len(errlist) > 0 {
+			return done, errlist
+		}
+		r.ASM.Stop = r.Dt
+		r.ASM.LastModBy = uid
+		if err = rlib.UpdateAssessment(&r.ASM); err != nil {
+			return done, bizErrSys(&err)
+		}
+		if errlist = InsertAssessment(&a, 1, uid); len(errlist) > 0 {
+			return done, errlist
+		}
+		done = append(done, *r)
+
+		//------------------------------------------------
+		// once the agreement's last assessment is done,
+		// update its rent and its next rate change
+		//------------------------------------------------
+		if i+1 < len(m) && m[i+1].RA.RAID == r.RA.RAID {
+			continue
+		}
+		dt1 := r.Dt.AddDate(0, 0, 1)
+		rar := rlib.GetRentalAgreementRentables(r.RA.RAID, &r.Dt, &dt1)
+		for j := 0; j < len(rar); j++ {
+			rent := rlib.RoundToCent(rar[j].ContractRent * (1 + r.Rate/100))
+			if err = changeContractRent(&rar[j], &r.Dt, rent, uid); err != nil {
+				return done, bizErrSys(&err)
+			}
+		}
+		ra, err := rlib.GetRentalAgreement(r.RA.RAID)
+		if err != nil {
+			return done, bizErrSys(&err)
+		}
+		ra.NextRateChange = ra.NextRateChange.AddDate(1, 0, 0)
+		ra.LastModBy = uid
+		if err = rlib.UpdateRentalAgreement(&ra); err != nil {
+			return done, bizErrSys(&err)
+		}
+	}
+	return done, nil
+}
@@ -0,0 +1,309 @@
+package bizlogic
+
+import (
+	"fmt"
+	"rentroll/rlib"
+	"strings"
+	"time"
+)
+
+// SRPriorityNames maps the service request priorities to their names
+var SRPriorityNames = map[int64]string{
+	rlib.SRPRIORITYLOW:       "low",
+	rlib.SRPRIORITYNORMAL:    "normal",
+	rlib.SRPRIORITYHIGH:      "high",
+	rlib.SRPRIORITYEMERGENCY: "emergency",
+}
+
+// SRStatusNames maps the service request states to their names
+var SRStatusNames = map[int64]string{
+	rlib.SROPEN:       "open",
+	rlib.SRASSIGNED:   "assigned",
+	rlib.SRINPROGRESS: "in progress",
+	rlib.SRONHOLD:     "on hold",
+	rlib.SRCOMPLETED:  "completed",
+	rlib.SRCANCELLED:  "cancelled",
+}
+
+// SRStatusFromName returns the service request status named s, or -1 if
+// there is no such status.
+func SRStatusFromName(s string) int64 {
+	s = strings.ToLower(strings.TrimSpace(s))
+	for k, v := range SRStatusNames {
+		if v == s {
+			return k
+		}
+	}
+	return -1
+}
+
+// srNoDate is stored in the date fields of a service request that do not
+// apply yet, such as DtCompleted of an open request.
+var srNoDate = time.Date(1970, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+// serviceRequestClosed returns true if service request sr has been
+// completed or cancelled.
+func serviceRequestClosed(sr *rlib.ServiceRequest) bool {
+	return sr.Status == rlib.SRCOMPLETED || sr.Status == rlib.SRCANCELLED
+}
+
+// getServiceRequest reads service request srid and makes sure it belongs to
+// business bid.
+func getServiceRequest(bid, srid int64) (rlib.ServiceRequest, []BizError) {
+	sr, err := rlib.GetServiceRequest(srid)
+	if err != nil && !rlib.IsSQLNoResultsError(err) {
+		return sr, bizErrSys(&err)
+	}
+	if sr.SRID == 0 || sr.BID != bid {
+		return sr, []BizError{{Errno: InvalidField, Message: BizErrors[InvalidField].Message + "\n" + fmt.Sprintf("Service request %d", srid)}}
+	}
+	return sr, nil
+}
+
+// addServiceRequestStatus records the current status of sr in its history
+func addServiceRequestStatus(sr *rlib.ServiceRequest, dt *time.Time, comment string, uid int64) error {
+	h := rlib.ServiceRequestStatus{SRID: sr.SRID, BID: sr.BID, Status: sr.Status, Assignee: sr.Assignee, Dt: *dt,
+		Comment: comment, CreateBy: uid}
+	_, err := rlib.InsertServiceRequestStatus(&h)
+	return err
+}
+
+// validateServiceRequest checks the fields of sr that the requester can set
+func validateServiceRequest(sr *rlib.ServiceRequest) []BizError {
+	var errlist []BizError
+	bad := func(s string) {
+		errlist = append(errlist, BizError{Errno: InvalidField, Message: BizErrors[InvalidField].Message + "\n" + s})
+	}
+	if r := rlib.GetRentable(sr.RID); r.RID == 0 || r.BID != sr.BID {
+		bad("Rentable")
+	}
+	if sr.TCID > 0 {
+		var t rlib.Transactant
+		if err := rlib.GetTransactant(sr.TCID, &t); err != nil || t.BID != sr.BID {
+			bad("Reported by")
+		}
+	}
+	if sr.CategorySLSID > 0 {
+		var s rlib.SLString
+		rlib.GetSLString(sr.CategorySLSID, &s)
+		if s.SLSID == 0 || s.BID != sr.BID {
+			bad("Category")
+		}
+	}
+	if _, ok := SRPriorityNames[sr.Priority]; !ok {
+		bad("Priority")
+	}
+	if len(strings.TrimSpace(sr.Description)) == 0 {
+		bad("Description")
+	}
+	return errlist
+}
+
+// SaveServiceRequest creates a new service request if sr.SRID is 0,
+// otherwise it updates the description fields of an existing one. The
+// status of an existing request is changed by ChangeServiceRequestStatus
+// and CompleteServiceRequest, not by this call. A new request is open, or
+// assigned if it has an Assignee, and is reported now if DtReported is not
+// set.
+//
+// INPUTS
+//    sr  = the service request
+//    uid = the user making the change
+//
+// RETURNS
+//    a slice of BizErrors
+//-------------------------------------------------------------------------------------
+func SaveServiceRequest(sr *rlib.ServiceRequest, uid int64) []BizError {
+	var err error
+	if errlist := validateServiceRequest(sr); len(errlist) > 0 {
+		return errlist
+	}
+	sr.LastModBy = uid
+
+	if sr.SRID > 0 {
+		old, errlist := getServiceRequest(sr.BID, sr.SRID)
+		if len(errlist) > 0 {
+			return errlist
+		}
+		if serviceRequestClosed(&old) {
+			return []BizError{{Errno: ServiceRequestClosed, Message: BizErrors[ServiceRequestClosed].Message}}
+		}
+		sr.Assignee = old.Assignee
+		sr.Status = old.Status
+		sr.DtCompleted = old.DtCompleted
+		sr.CompletionNotes = old.CompletionNotes
+		sr.ASMID = old.ASMID
+		sr.MSID = old.MSID
+		sr.DtDue = old.DtDue
+		if err = rlib.UpdateServiceRequest(sr); err != nil {
+			return bizErrSys(&err)
+		}
+		return nil
+	}
+
+	if sr.DtReported.Year() <= 1970 {
+		sr.DtReported = time.Now()
+	}
+	sr.Status = rlib.SROPEN
+	if sr.Assignee > 0 {
+		sr.Status = rlib.SRASSIGNED
+	}
+	sr.DtCompleted = srNoDate
+	if sr.DtDue.Year() <= 1970 {
+		sr.DtDue = srNoDate
+	}
+	sr.CompletionNotes = ""
+	sr.ASMID = 0
+	sr.CreateBy = uid
+	if _, err = rlib.InsertServiceRequest(sr); err != nil {
+		return bizErrSys(&err)
+	}
+	if err = addServiceRequestStatus(sr, &sr.DtReported, "reported", uid); err != nil {
+		return bizErrSys(&err)
+	}
+	return nil
+}
+
+// ServiceRequestStatusChange describes a change in the status of a service
+// request short of completing it.
+type ServiceRequestStatusChange struct {
+	BID      int64
+	SRID     int64
+	Status   int64     // SROPEN, SRASSIGNED, SRINPROGRESS, SRONHOLD or SRCANCELLED
+	Assignee int64     // the new assignee, 0 = leave unchanged
+	Dt       time.Time // when the change happened, defaults to now
+	Comment  string
+	UID      int64 // user making the change
+}
+
+// ChangeServiceRequestStatus changes the status and possibly the assignee
+// of a service request and records the change in its history. Closed
+// requests cannot be changed and a request can only be assigned to
+// someone.
+//
+// INPUTS
+//    c = the status change
+//
+// RETURNS
+//    a slice of BizErrors
+//-------------------------------------------------------------------------------------
+func ChangeServiceRequestStatus(c *ServiceRequestStatusChange) []BizError {
+	var errlist []BizError
+	bad := func(s string) {
+		errlist = append(errlist, BizError{Errno: InvalidField, Message: BizErrors[InvalidField].Message + "\n" + s})
+	}
+	sr, errlist := getServiceRequest(c.BID, c.SRID)
+	if len(errlist) > 0 {
+		return errlist
+	}
+	if serviceRequestClosed(&sr) {
+		return []BizError{{Errno: ServiceRequestClosed, Message: BizErrors[ServiceRequestClosed].Message}}
+	}
+	if _, ok := SRStatusNames[c.Status]; !ok || c.Status == rlib.SRCOMPLETED {
+		bad("Status")
+	}
+	if c.Assignee > 0 {
+		sr.Assignee = c.Assignee
+	}
+	if c.Status == rlib.SRASSIGNED && sr.Assignee == 0 {
+		bad("Assignee")
+	}
+	if len(errlist) > 0 {
+		return errlist
+	}
+	if c.Dt.Year() <= 1970 {
+		c.Dt = time.Now()
+	}
+
+	sr.Status = c.Status
+	sr.LastModBy = c.UID
+	if err := rlib.UpdateServiceRequest(&sr); err != nil {
+		return bizErrSys(&err)
+	}
+	if err := addServiceRequestStatus(&sr, &c.Dt, c.Comment, c.UID); err != nil {
+		return bizErrSys(&err)
+	}
+	return nil
+}
+
+// ServiceRequestCompletion describes the completion of a service request
+type ServiceRequestCompletion struct {
+	BID        int64
+	SRID       int64
+	Dt         time.Time // when the work was completed, defaults to now
+	Notes      string    // what was done
+	BillAmount float64   // amount to bill the tenant, 0 = do not bill
+	ARID       int64     // account rule for the assessment, required if BillAmount > 0
+	UID        int64     // user completing the request
+}
+
+// CompleteServiceRequest marks a service request completed. If BillAmount
+// is greater than 0 the tenant is billed with an assessment on the Rental
+// Agreement that holds the Rentable on the completion date.
+//
+// INPUTS
+//    c = the completion
+//
+// RETURNS
+//    the completed service request
+//    a slice of BizErrors
+//-------------------------------------------------------------------------------------
+func CompleteServiceRequest(c *ServiceRequestCompletion) (rlib.ServiceRequest, []BizError) {
+	var errlist []BizError
+	bad := func(s string) {
+		errlist = append(errlist, BizError{Errno: InvalidField, Message: BizErrors[InvalidField].Message + "\n" + s})
+	}
+	sr, errlist := getServiceRequest(c.BID, c.SRID)
+	if len(errlist) > 0 {
+		return sr, errlist
+	}
+	if serviceRequestClosed(&sr) {
+		return sr, []BizError{{Errno: ServiceRequestClosed, Message: BizErrors[ServiceRequestClosed].Message}}
+	}
+	if c.Dt.Year() <= 1970 {
+		c.Dt = time.Now()
+	}
+
+	var a rlib.Assessment
+	if c.BillAmount < 0 {
+		bad("Bill Amount")
+	}
+	if c.BillAmount > 0 {
+		ar, err := rlib.GetAR(c.ARID)
+		if err != nil || ar.BID != c.BID || ar.ARType != rlib.ARASSESSMENT {
+			bad("Account Rule")
+		}
+		rar, err := rlib.FindAgreementByRentable(sr.RID, &c.Dt, &c.Dt)
+		if err != nil || rar.RAID == 0 {
+			bad("There is no Rental Agreement for the Rentable on the completion date to bill")
+		}
+		if len(errlist) > 0 {
+			return sr, errlist
+		}
+		if errlist = ValidatePeriodOpen(c.BID, &c.Dt); len(errlist) > 0 {
+			return sr, errlist
+		}
+		a = rlib.Assessment{BID: c.BID, RID: sr.RID, RAID: rar.RAID, ATypeLID: ar.CreditLID, ARID: c.ARID,
+			Amount: rlib.RoundToCent(c.BillAmount), Start: c.Dt, Stop: c.Dt, RentCycle: rlib.CYCLENORECUR,
+			ProrationCycle: rlib.CYCLENORECUR, Comment: "service request " + sr.IDtoString(), CreateBy: c.UID, LastModBy: c.UID}
+		if errlist = InsertAssessment(&a, 0, c.UID); len(errlist) > 0 {
+			return sr, errlist
+		}
+	}
+	if len(errlist) > 0 {
+		return sr, errlist
+	}
+
+	sr.Status = rlib.SRCOMPLETED
+	sr.DtCompleted = c.Dt
+	sr.CompletionNotes = c.Notes
+	sr.ASMID = a.ASMID
+	sr.LastModBy = c.UID
+	if err := rlib.UpdateServiceRequest(&sr); err != nil {
+		return sr, bizErrSys(&err)
+	}
+	if err := addServiceRequestStatus(&sr, &c.Dt, "completed", c.UID); err != nil {
+		return sr, bizErrSys(&err)
+	}
+	return sr, nil
+}
@@ -0,0 +1,255 @@
+package bizlogic
+
+import (
+	"fmt"
+	"rentroll/rlib"
+	"time"
+)
+
+// Transfer describes moving a resident from one Rentable to another on the
+// same Rental Agreement in the middle of the lease, for example from unit A
+// to unit B.
+type Transfer struct {
+	BID          int64     // business
+	RAID         int64     // the Rental Agreement
+	RID          int64     // the Rentable being vacated
+	ToRID        int64     // the Rentable being occupied
+	Dt           time.Time // transfer date, the resident occupies ToRID from this date forward
+	ContractRent float64   // rent for ToRID, 0 = keep the current rent
+	RentARID     int64     // account rule of the recurring rent, its assessments are charged at ContractRent on ToRID
+	UID          int64     // user processing the transfer
+}
+
+// TransferResult is the outcome of a transfer
+type TransferResult struct {
+	RAR         rlib.RentalAgreementRentable // the new RentalAgreementRentable for ToRID
+	Assessments []rlib.Assessment            // the prorated rent for both Rentables and the recurring assessments moved to ToRID
+	Vacancy     int                          // number of vacancy journal entries made for RID
+}
+
+// validateTransfer checks the transfer for business logic errors before
+// anything is written. It returns the RentalAgreementRentable for RID.
+//-------------------------------------------------------------------------------------
+func validateTransfer(t *Transfer, ra *rlib.RentalAgreement, to *rlib.Rentable) (rlib.RentalAgreementRentable, []BizError) {
+	var errlist []BizError
+	bad := func(s string) {
+		errlist = append(errlist, BizError{Errno: InvalidField, Message: BizErrors[InvalidField].Message + "\n" + s})
+	}
+	if to.RID == 0 || to.BID != t.BID || to.RID == t.RID {
+		bad("Rentable to transfer to")
+	}
+	if t.ContractRent < 0 {
+		bad("Contract Rent")
+	}
+	if t.ContractRent > 0 {
+		if ar, err := rlib.GetAR(t.RentARID); err != nil || ar.BID != t.BID || ar.ARType != rlib.ARASSESSMENT {
+			bad("Rent Account Rule")
+		}
+	}
+	if len(errlist) > 0 {
+		return rlib.RentalAgreementRentable{}, errlist
+	}
+	rar, errlist := validateMoveOut(&MoveOut{BID: t.BID, RAID: t.RAID, RID: t.RID, Dt: t.Dt}, ra)
+	if len(errlist) > 0 {
+		return rar, errlist
+	}
+	if len(rlib.GetAgreementsForRentable(t.ToRID, &t.Dt, &rar.RARDtStop)) > 0 {
+		errlist = append(errlist, BizErrors[RentableNotVacant])
+	}
+	return rar, errlist
+}
+
+// transferProration returns the parts of the month d1 - d2 that are charged
+// on the old Rentable and on the new one when a recurring assessment that runs
+// from start to stop is transferred on dt. The old Rentable is charged through
+// the day before dt and the new one from dt on.
+func transferProration(start, stop, dt, d1, d2 time.Time) (float64, float64) {
+	var pfOld, pfNew float64
+	if start.Before(dt) {
+		_, _, pfOld = rlib.Prorate(start, dt.AddDate(0, 0, -1), d1, d2, rlib.CYCLEMONTHLY, rlib.CYCLEDAILY)
+	}
+	nstart := dt
+	if start.After(nstart) {
+		nstart = start
+	}
+	if stop.After(nstart) && nstart.Before(d2) {
+		pfNew, _, _, _, _ = rlib.CalcProrationInfo(&nstart, &stop, &d1, &d2, rlib.CYCLEMONTHLY, rlib.CYCLEDAILY)
+	}
+	return pfOld, pfNew
+}
+
+// ProcessTransfer moves a resident from Rentable t.RID to t.ToRID on t.Dt.
+// The RentalAgreementRentable for RID is ended on Dt and one for ToRID is
+// started on Dt and runs until the old one would have ended. The Rentable
+// users are moved to ToRID, RID is put back online and marked dirty for
+// housekeeping, and ToRID is marked occupied.
+//
+// Recurring assessments of RID on the agreement are stopped on Dt and any
+// instances of them from the start of the transfer month forward are
+// reversed. The rent for the transfer month is prorated on both Rentables:
+// RID is charged up to Dt and ToRID from Dt to the end of the month. The
+// recurring assessments that continue past the transfer month are re-issued
+// for ToRID from the start of the next month. Those with account rule
+// RentARID are charged at ContractRent when it is set.
+//
+// Finally, the vacancy of RID for the transfer month is journaled.
+//
+// INPUTS
+//    t = the transfer to process
+//
+// RETURNS
+//    the new RentalAgreementRentable and the assessments that were made
+//    a slice of BizErrors
+//-------------------------------------------------------------------------------------
+func ProcessTransfer(t *Transfer) (TransferResult, []BizError) {
+	var (
+		err     error
+		errlist []BizError
+		res     TransferResult
+		now     = time.Now()
+		future  = time.Date(9999, time.December, 31, 0, 0, 0, 0, time.UTC)
+	)
+
+	ra, _ := rlib.GetRentalAgreement(t.RAID)
+	to := rlib.GetRentable(t.ToRID)
+	rar, errlist := validateTransfer(t, &ra, &to)
+	if len(errlist) > 0 {
+		return res, errlist
+	}
+	var xbiz rlib.XBusiness
+	rlib.InitBizInternals(t.BID, &xbiz)
+	d1 := time.Date(t.Dt.Year(), t.Dt.Month(), 1, 0, 0, 0, 0, rlib.RRdb.Zone)
+	d2 := d1.AddDate(0, 1, 0)
+	occupiedStop := rar.RARDtStop
+
+	//------------------------------------------------
+	// end the old Rentable and start the new one
+	//------------------------------------------------
+	res.RAR = rlib.RentalAgreementRentable{RAID: t.RAID, BID: t.BID, RID: t.ToRID, CLID: rar.CLID, ContractRent: rar.ContractRent,
+		RARDtStart: t.Dt, RARDtStop: occupiedStop, CreateBy: t.UID}
+	if t.ContractRent > 0 {
+		res.RAR.ContractRent = t.ContractRent
+	}
+	rar.RARDtStop = t.Dt
+	if err = rlib.UpdateRentalAgreementRentable(&rar); err != nil {
+		return res, bizErrSys(&err)
+	}
+	if _, err = rlib.InsertRentalAgreementRentable(&res.RAR); err != nil {
+		return res, bizErrSys(&err)
+	}
+
+	//------------------------------------------------
+	// move the users
+	//------------------------------------------------
+	u := rlib.GetRentableUsersInRange(t.RID, &t.Dt, &future)
+	for i := 0; i < len(u); i++ {
+		if !u[i].DtStop.After(t.Dt) {
+			continue
+		}
+		nu := u[i]
+		nu.RID = t.ToRID
+		nu.CreateBy = t.UID
+		if nu.DtStart.Before(t.Dt) {
+			nu.DtStart = t.Dt
+			u[i].DtStop = t.Dt
+			err = rlib.UpdateRentableUser(&u[i])
+		} else {
+			err = rlib.DeleteRentableUser(u[i].RUID) // they had not started in the old Rentable yet
+		}
+		if err != nil {
+			return res, bizErrSys(&err)
+		}
+		if err = rlib.InsertRentableUser(&nu); err != nil {
+			return res, bizErrSys(&err)
+		}
+	}
+
+	//------------------------------------------------
+	// the old Rentable is back online, the new one
+	// is occupied
+	//------------------------------------------------
+	if occupiedStop.After(t.Dt) {
+		if _, _, err = setRentableStatus(t.RID, t.BID, rlib.RENTABLESTATUSONLINE, &t.Dt, &occupiedStop, t.UID); err != nil {
+			return res, bizErrSys(&err)
+		}
+		if _, _, err = setRentableStatus(t.ToRID, t.BID, rlib.RENTABLESTATUSOCCUPIED, &t.Dt, &occupiedStop, t.UID); err != nil {
+			return res, bizErrSys(&err)
+		}
+	}
+	if errlist = HousekeepingCheckout(&rar, &t.Dt, t.UID); len(errlist) > 0 {
+		return res, errlist
+	}
+
+	//------------------------------------------------
+	// Stop the recurring assessments of the old
+	// Rentable, prorate the transfer month on both
+	// Rentables and move the rest to the new one.
+	//------------------------------------------------
+	var asms []rlib.Assessment
+	m := rlib.GetAllRentableAssessments(t.RID, &d1, &future)
+	for i := 0; i < len(m); i++ {
+		if m[i].RAID != t.RAID || m[i].PASMID != 0 || m[i].RentCycle == rlib.RECURNONE || m[i].FLAGS&rlib.ASMREVERSED != 0 {
+			continue
+		}
+		if errlist = ReverseAssessmentsGoingForward(&rlib.Assessment{PASMID: m[i].ASMID}, &d1, &now, t.UID); len(errlist) > 0 {
+			return res, errlist
+		}
+		old := m[i]
+		amount := old.Amount
+		if t.ContractRent > 0 && old.ARID == t.RentARID {
+			amount = t.ContractRent
+		}
+		if old.Stop.After(t.Dt) {
+			m[i].Stop = t.Dt
+			if old.Start.After(t.Dt) {
+				m[i].Stop = old.Start // it had not started yet
+			}
+			m[i].LastModBy = t.UID
+			if err = rlib.UpdateAssessment(&m[i]); err != nil {
+				return res, bizErrSys(&err)
+			}
+		}
+		mk := func(rid int64, amt float64, comment string) rlib.Assessment {
+			return rlib.Assessment{BID: t.BID, RID: rid, RAID: t.RAID, ATypeLID: old.ATypeLID, ARID: old.ARID,
+				Amount: rlib.RoundToCent(amt), Start: d1, Stop: d1, RentCycle: rlib.CYCLENORECUR, ProrationCycle: rlib.CYCLENORECUR,
+				Comment: comment, CreateBy: t.UID, LastModBy: t.UID}
+		}
+		start := rar.RARDtStart
+		if old.Start.After(start) {
+			start = old.Start
+		}
+		pfOld, pfNew := transferProration(start, old.Stop, t.Dt, d1, d2)
+		if pfOld > 0 {
+			asms = append(asms, mk(t.RID, old.Amount*pfOld, "rent prorated to transfer"))
+		}
+		if pfNew > 0 {
+			asms = append(asms, mk(t.ToRID, amount*pfNew, "rent prorated from transfer"))
+		}
+		if old.Stop.After(d2) {
+			a := old
+			a.ASMID = 0
+			a.RID = t.ToRID
+			if a.Start.Before(d2) {
+				a.Start = d2
+			}
+			a.Amount = amount
+			a.Comment = fmt.Sprintf("transferred from %s", old.IDtoString())
+			a.CreateBy = t.UID
+			a.LastModBy = t.UID
+			asms = append(asms, a)
+		}
+	}
+	for i := 0; i < len(asms); i++ {
+		if errlist = InsertAssessment(&asms[i], 1, t.UID); len(errlist) > 0 {
+			return res, errlist
+		}
+		res.Assessments = append(res.Assessments, asms[i])
+	}
+
+	//------------------------------------------------
+	// vacancy of the old Rentable
+	//------------------------------------------------
+	r := rlib.GetRentable(t.RID)
+	res.Vacancy = rlib.ProcessRentable(&xbiz, &d1, &d2, &r)
+	return res, nil
+}
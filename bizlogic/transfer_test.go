@@ -0,0 +1,35 @@
+package bizlogic
+
+import (
+	"rentroll/rlib"
+	"testing"
+)
+
+func TestTransferProration(t *testing.T) {
+	const rent = float64(900) // $30 a day in June
+	d1, _ := rlib.StringToDate("2017-06-01")
+	d2, _ := rlib.StringToDate("2017-07-01")
+	var m = []struct {
+		start, stop, dt string
+		amtOld, amtNew  float64
+	}{
+		{"2017-01-01", "2018-01-01", "2017-06-16", 450, 450},
+		{"2017-01-01", "2018-01-01", "2017-06-01", 0, 900},   // transferred on the first of the month
+		{"2017-01-01", "2018-01-01", "2017-06-30", 870, 30},  // transferred on the last day
+		{"2017-06-10", "2018-01-01", "2017-06-20", 300, 330}, // moved in mid-month
+		{"2017-01-01", "2017-06-25", "2017-06-16", 450, 270}, // ends mid-month
+		{"2017-07-05", "2018-01-01", "2017-06-16", 0, 0},     // starts after the transfer month
+	}
+	for i := 0; i < len(m); i++ {
+		start, _ := rlib.StringToDate(m[i].start)
+		stop, _ := rlib.StringToDate(m[i].stop)
+		dt, _ := rlib.StringToDate(m[i].dt)
+		pfOld, pfNew := transferProration(start, stop, dt, d1, d2)
+		amtOld := rlib.RoundToCent(rent * pfOld)
+		amtNew := rlib.RoundToCent(rent * pfNew)
+		if amtOld != m[i].amtOld || amtNew != m[i].amtNew {
+			t.Errorf("%d: %s - %s transferred %s: expected %.2f old, %.2f new, got %.2f old, %.2f new",
+				i, m[i].start, m[i].stop, m[i].dt, m[i].amtOld, m[i].amtNew, amtOld, amtNew)
+		}
+	}
+}
@@ -28,7 +28,9 @@
 -- RID = Rentable id
 -- RSPID = unit specialty id
 -- RTID = Rentable type id
+-- TAXID = Tax id
 -- TCID = Transactant id
+-- TRID = TaxRate id
 -- USERID = User id
 
 DROP DATABASE IF EXISTS rentroll;
@@ -52,6 +54,7 @@ CREATE TABLE Tax (
     TAXID BIGINT NOT NULL AUTO_INCREMENT,                   -- unique identifier for this tax
     BID BIGINT NOT NULL DEFAULT 0,                          -- what business is this tax associated with
     Name VARCHAR(50),                                       -- a name for this tax
+    ARID BIGINT NOT NULL DEFAULT 0,                         -- account rule used to book the tax: debit receivable, credit tax liability
     TaxingAuthority VARCHAR(100),                           -- name of the Taxing Authority
     TaxingAuthorityAddress VARCHAR(256),                    -- where these taxes are sent
     FilingDate DATE NOT NULL DEFAULT '1970-01-01',          -- date on which taxes need to be filed
@@ -65,6 +68,7 @@ CREATE TABLE Tax (
 );
 
 CREATE TABLE TaxRate (
+    TRID BIGINT NOT NULL AUTO_INCREMENT,                    -- unique identifier for this tax rate
     TAXID BIGINT NOT NULL DEFAULT 0,                        -- reference to which tax this table represents
     BID BIGINT NOT NULL DEFAULT 0,                          -- what business is this tax associated with
     DtStart DATE NOT NULL DEFAULT '1970-01-01 00:00:00',    -- date when this tax rate goes into effect
@@ -75,7 +79,8 @@ CREATE TABLE TaxRate (
     LastModTime TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,                                  -- when was this record last written
     LastModBy BIGINT NOT NULL DEFAULT 0,                 -- employee UID (from phonebook) that modified it
     CreateTS TIMESTAMP DEFAULT CURRENT_TIMESTAMP,    -- when was this record created
-    CreateBy BIGINT NOT NULL DEFAULT 0                     -- employee UID (from phonebook) that created this record
+    CreateBy BIGINT NOT NULL DEFAULT 0,                    -- employee UID (from phonebook) that created this record
+    PRIMARY KEY(TRID)
 );
 
 CREATE TABLE StringList (
@@ -299,9 +304,16 @@ CREATE TABLE CommissionLedger (
     RAID BIGINT NOT NULL DEFAULT 0,                 -- associated with this RAID
     RID BIGINT NOT NULL DEFAULT 0,                  -- associated with this rentable??????
     Salesperson  VARCHAR(100) NOT NULL DEFAULT '',  -- who referred
-    Percent DECIMAL(19,4) NOT NULL DEFAULT 0,       -- what percent are we paying them. If 0 then we're paying a specific Amount
-    Amount DECIMAL(19,4) NOT NULL DEFAULT 0,        -- what amount are we paying them. If 0 then we're paying a percentage
+    UID BIGINT NOT NULL DEFAULT 0,                  -- the leasing agent (Accord Directory UserID), 0 if the Salesperson is not an agent
+    TCID BIGINT NOT NULL DEFAULT 0,                 -- the referrer, if they are a Transactant
+    Percent DECIMAL(19,4) NOT NULL DEFAULT 0,       -- what percent of the first term's rent are we paying them. If 0 then we're paying a specific Amount
+    Amount DECIMAL(19,4) NOT NULL DEFAULT 0,        -- the commission owed. If Percent is not 0 it is computed from the first term's rent
     PaymentDueDate DATE NOT NULL DEFAULT '1970-01-01 00:00:00',     -- enterer will fill it out
+    FLAGS BIGINT NOT NULL DEFAULT 0,                -- bit 0 = paid
+    DtPaid DATE NOT NULL DEFAULT '1970-01-01 00:00:00',             -- date the commission was paid
+    JID BIGINT NOT NULL DEFAULT 0,                  -- Journal entry for the payment
+    LastModTime TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,   -- when was this record last written
+    LastModBy BIGINT NOT NULL DEFAULT 0,            -- employee UID (from phonebook) that modified it
     CreateTS TIMESTAMP DEFAULT CURRENT_TIMESTAMP,    -- when was this record created
     CreateBy BIGINT NOT NULL DEFAULT 0,                    -- employee UID (from phonebook) that created this record
     PRIMARY KEY(CLID)
@@ -417,6 +429,22 @@ CREATE TABLE Business (
 );
 --    ParkingPermitInUse SMALLINT NOT NULL DEFAULT 0,     -- yes/no  0 = no, 1 = yes
 
+CREATE TABLE LateFeePolicy (
+    LFPID BIGINT NOT NULL AUTO_INCREMENT,                   -- unique id for this policy
+    BID BIGINT NOT NULL DEFAULT 0,                          -- Business, at most one policy per business
+    ARID BIGINT NOT NULL DEFAULT 0,                         -- account rule for the late fee assessment
+    GraceDays BIGINT NOT NULL DEFAULT 0,                    -- days after an assessment is due before the late fee is charged
+    Amount DECIMAL(19,4) NOT NULL DEFAULT 0.0,              -- flat late fee
+    Formula VARCHAR(256) NOT NULL DEFAULT '',               -- RPN formula, overrides Amount when present. _ is the unpaid amount
+    MaxAmount DECIMAL(19,4) NOT NULL DEFAULT 0.0,           -- the most that is charged for one late fee, 0 = no limit
+    LastModTime TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,   -- when was this record last written
+    LastModBy BIGINT NOT NULL DEFAULT 0,                    -- employee UID (from phonebook) that modified it
+    CreateTS TIMESTAMP DEFAULT CURRENT_TIMESTAMP,           -- when was this record created
+    CreateBy BIGINT NOT NULL DEFAULT 0,                     -- employee UID (from phonebook) that created this record
+    PRIMARY KEY (LFPID),
+    UNIQUE KEY (BID)
+);
+
 -- ===========================================
 --   RENTABLE TYPES
 -- ===========================================
@@ -565,6 +593,7 @@ CREATE TABLE Rentable (
     BID BIGINT NOT NULL DEFAULT 0,                                 -- Business associated with this Rentable
     RentableName VARCHAR(100) NOT NULL DEFAULT '',                 -- must be unique, name for this instance, "101" for a room number, CP744 carport number, etc
     AssignmentTime SMALLINT NOT NULL DEFAULT 0,                    -- Unknown = 0, OK to pre-assign = 1, assign at occupancy commencement = 2
+    BLDGID BIGINT NOT NULL DEFAULT 0,                              -- Building where this Rentable is located, 0 = none
     LastModTime TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,                                         -- when was this record last written
     LastModBy BIGINT NOT NULL DEFAULT 0,                           -- employee UID (from phonebook) that modified it
     CreateTS TIMESTAMP DEFAULT CURRENT_TIMESTAMP,                  -- when was this record created
@@ -594,7 +623,7 @@ CREATE TABLE RentableStatus (
     RSID BIGINT NOT NULL AUTO_INCREMENT,                            -- unique id for Rentable Status
     RID BIGINT NOT NULL DEFAULT 0,                                  -- associated Rentable
     BID BIGINT NOT NULL DEFAULT 0,                                  -- Business
-    Status SMALLINT NOT NULL DEFAULT 0,                             -- 0 = UNKNOWN -- 1 = ONLINE, 2 = ADMIN, 3 = EMPLOYEE, 4 = OWNEROCC, 5 = OFFLINE,
+    Status SMALLINT NOT NULL DEFAULT 0,                             -- 0 = UNKNOWN -- 1 = ONLINE, 2 = ADMIN, 3 = EMPLOYEE, 4 = OWNEROCC, 5 = OFFLINE, 6 = OCCUPIED
     DtStart DATETIME NOT NULL DEFAULT '1970-01-01 00:00:00',        -- start time for this state
     DtStop DATETIME NOT NULL DEFAULT '1970-01-01 00:00:00',         -- stop time for this state
     DtNoticeToVacate DATE NOT NULL DEFAULT '1970-01-01 00:00:00',   -- user has indicated they will vacate on this date
@@ -618,6 +647,198 @@ CREATE TABLE RentableSpecialtyRef (
 );
 
 
+-- **************************************
+-- ****                              ****
+-- ****       SERVICE REQUESTS       ****
+-- ****                              ****
+-- **************************************
+-- work orders against a Rentable
+CREATE TABLE ServiceRequest (
+    SRID BIGINT NOT NULL AUTO_INCREMENT,                            -- unique id for this service request
+    BID BIGINT NOT NULL DEFAULT 0,                                  -- the Business
+    RID BIGINT NOT NULL DEFAULT 0,                                  -- the Rentable needing service
+    TCID BIGINT NOT NULL DEFAULT 0,                                 -- Transactant who reported it, 0 if reported by staff
+    CategorySLSID BIGINT NOT NULL DEFAULT 0,                        -- category, id of a string in a StringList
+    Priority SMALLINT NOT NULL DEFAULT 0,                           -- 0 = low, 1 = normal, 2 = high, 3 = emergency
+    Description VARCHAR(1024) NOT NULL DEFAULT '',                  -- what needs to be done
+    Assignee BIGINT NOT NULL DEFAULT 0,                             -- Accord Directory UserID of the person doing the work
+    Status SMALLINT NOT NULL DEFAULT 0,                             -- 0 = open, 1 = assigned, 2 = in progress, 3 = on hold, 4 = completed, 5 = cancelled
+    DtReported DATETIME NOT NULL DEFAULT '1970-01-01 00:00:00',     -- when the request was made
+    DtCompleted DATETIME NOT NULL DEFAULT '1970-01-01 00:00:00',    -- when the work was completed
+    CompletionNotes VARCHAR(2048) NOT NULL DEFAULT '',              -- what was done
+    ASMID BIGINT NOT NULL DEFAULT 0,                                -- Assessment billing the tenant for the work, 0 if not billed
+    MSID BIGINT NOT NULL DEFAULT 0,                                 -- MaintenanceSchedule that generated it, 0 if it was reported
+    DtDue DATETIME NOT NULL DEFAULT '1970-01-01 00:00:00',          -- when scheduled maintenance is due
+    LastModTime TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,   -- when was this record last written
+    LastModBy BIGINT NOT NULL DEFAULT 0,                            -- employee UID (from phonebook) that modified it
+    CreateTS TIMESTAMP DEFAULT CURRENT_TIMESTAMP,                   -- when was this record created
+    CreateBy BIGINT NOT NULL DEFAULT 0,                             -- employee UID (from phonebook) that created this record
+    PRIMARY KEY (SRID)
+);
+
+-- status history of a ServiceRequest, one row per change
+CREATE TABLE ServiceRequestStatus (
+    SRSID BIGINT NOT NULL AUTO_INCREMENT,                           -- unique id for this status change
+    SRID BIGINT NOT NULL DEFAULT 0,                                 -- the service request
+    BID BIGINT NOT NULL DEFAULT 0,                                  -- the Business
+    Status SMALLINT NOT NULL DEFAULT 0,                             -- the new status, same values as ServiceRequest.Status
+    Assignee BIGINT NOT NULL DEFAULT 0,                             -- the assignee as of this change
+    Dt DATETIME NOT NULL DEFAULT '1970-01-01 00:00:00',             -- when the status changed
+    Comment VARCHAR(1024) NOT NULL DEFAULT '',                      -- reason for the change
+    CreateTS TIMESTAMP DEFAULT CURRENT_TIMESTAMP,                   -- when was this record created
+    CreateBy BIGINT NOT NULL DEFAULT 0,                             -- employee UID (from phonebook) that created this record
+    PRIMARY KEY (SRSID)
+);
+
+-- **************************************
+-- ****                              ****
+-- ****  PREVENTATIVE MAINTENANCE    ****
+-- ****                              ****
+-- **************************************
+-- recurring maintenance. Exactly one of RID, RTID, BLDGID is set and selects
+-- the Rentables that get a ServiceRequest for each instance.
+CREATE TABLE MaintenanceSchedule (
+    MSID BIGINT NOT NULL AUTO_INCREMENT,                            -- unique id for this schedule
+    BID BIGINT NOT NULL DEFAULT 0,                                  -- the Business
+    Name VARCHAR(100) NOT NULL DEFAULT '',                          -- "HVAC filter change"
+    Description VARCHAR(1024) NOT NULL DEFAULT '',                  -- what needs to be done, copied to each ServiceRequest
+    CategorySLSID BIGINT NOT NULL DEFAULT 0,                        -- category of the ServiceRequests, id of a string in a StringList
+    Priority SMALLINT NOT NULL DEFAULT 1,                           -- priority of the ServiceRequests
+    Assignee BIGINT NOT NULL DEFAULT 0,                             -- Accord Directory UserID who is assigned the ServiceRequests, 0 = unassigned
+    RID BIGINT NOT NULL DEFAULT 0,                                  -- a single Rentable
+    RTID BIGINT NOT NULL DEFAULT 0,                                 -- every Rentable of this RentableType
+    BLDGID BIGINT NOT NULL DEFAULT 0,                               -- every Rentable in this Building
+    Cycle SMALLINT NOT NULL DEFAULT 0,                              -- 4 = daily, 5 = weekly, 6 = monthly, 7 = quarterly, 8 = yearly
+    DtStart DATETIME NOT NULL DEFAULT '1970-01-01 00:00:00',        -- first instance, the day and time of later instances follow it
+    DtStop DATETIME NOT NULL DEFAULT '9999-12-31 00:00:00',         -- no instances on or after this date
+    LeadDays SMALLINT NOT NULL DEFAULT 0,                           -- ServiceRequests are generated this many days before an instance is due
+    DtLastDue DATETIME NOT NULL DEFAULT '1970-01-01 00:00:00',      -- due date of the last instance generated
+    LastModTime TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,   -- when was this record last written
+    LastModBy BIGINT NOT NULL DEFAULT 0,                            -- employee UID (from phonebook) that modified it
+    CreateTS TIMESTAMP DEFAULT CURRENT_TIMESTAMP,                   -- when was this record created
+    CreateBy BIGINT NOT NULL DEFAULT 0,                             -- employee UID (from phonebook) that created this record
+    PRIMARY KEY (MSID)
+);
+
+-- **************************************
+-- ****                              ****
+-- ****        HOUSEKEEPING          ****
+-- ****                              ****
+-- **************************************
+-- housekeeping state history of a Rentable, one row per change. The
+-- current state is the most recent row.
+CREATE TABLE HousekeepingStatus (
+    HKSID BIGINT NOT NULL AUTO_INCREMENT,                           -- unique id for this state change
+    BID BIGINT NOT NULL DEFAULT 0,                                  -- the Business
+    RID BIGINT NOT NULL DEFAULT 0,                                  -- the Rentable
+    Status SMALLINT NOT NULL DEFAULT 0,                             -- 1 = dirty, 2 = clean, 3 = inspected, 4 = out of order
+    Dt DATETIME NOT NULL DEFAULT '1970-01-01 00:00:00',             -- when the state changed
+    Comment VARCHAR(256) NOT NULL DEFAULT '',                       -- reason for the change, such as "checkout"
+    CreateTS TIMESTAMP DEFAULT CURRENT_TIMESTAMP,                   -- when was this record created
+    CreateBy BIGINT NOT NULL DEFAULT 0,                             -- employee UID (from phonebook) that created this record
+    PRIMARY KEY (HKSID)
+);
+
+-- **************************************
+-- ****                              ****
+-- ****    ROLES AND PERMISSIONS     ****
+-- ****                              ****
+-- **************************************
+-- Web service rights. Phonebook users are given Roles and Roles are given
+-- RolePermissions. A request is allowed if one of the requester's Roles has
+-- a matching RolePermission. Nothing is enforced until the first UserRole
+-- is created.
+CREATE TABLE Role (
+    RoleID BIGINT NOT NULL AUTO_INCREMENT,                          -- unique id for this Role
+    Name VARCHAR(100) NOT NULL DEFAULT '',                          -- "Leasing Agent", "Controller"
+    Description VARCHAR(1024) NOT NULL DEFAULT '',
+    LastModTime TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,   -- when was this record last written
+    LastModBy BIGINT NOT NULL DEFAULT 0,                            -- employee UID (from phonebook) that modified it
+    CreateTS TIMESTAMP DEFAULT CURRENT_TIMESTAMP,                   -- when was this record created
+    CreateBy BIGINT NOT NULL DEFAULT 0,                             -- employee UID (from phonebook) that created this record
+    PRIMARY KEY (RoleID)
+);
+
+CREATE TABLE RolePermission (
+    RPID BIGINT NOT NULL AUTO_INCREMENT,                            -- unique id for this right
+    RoleID BIGINT NOT NULL DEFAULT 0,                               -- the Role that has it
+    BID BIGINT NOT NULL DEFAULT 0,                                  -- the Business it applies to, 0 = every Business
+    Service VARCHAR(50) NOT NULL DEFAULT '',                        -- web service, such as "receipt", * = every service
+    Cmd VARCHAR(25) NOT NULL DEFAULT '',                            -- command, such as "get", * = every command
+    CreateTS TIMESTAMP DEFAULT CURRENT_TIMESTAMP,                   -- when was this record created
+    CreateBy BIGINT NOT NULL DEFAULT 0,                             -- employee UID (from phonebook) that created this record
+    PRIMARY KEY (RPID)
+);
+
+CREATE TABLE UserRole (
+    URID BIGINT NOT NULL AUTO_INCREMENT,                            -- unique id for this assignment
+    UID BIGINT NOT NULL DEFAULT 0,                                  -- phonebook UID of the user
+    RoleID BIGINT NOT NULL DEFAULT 0,                               -- the Role the user has
+    CreateTS TIMESTAMP DEFAULT CURRENT_TIMESTAMP,                   -- when was this record created
+    CreateBy BIGINT NOT NULL DEFAULT 0,                             -- employee UID (from phonebook) that created this record
+    PRIMARY KEY (URID)
+);
+
+-- **************************************
+-- ****                              ****
+-- ****           BUDGETS            ****
+-- ****                              ****
+-- **************************************
+-- The budgeted activity of a GL account for a month. Amounts use the sign
+-- convention of the ledger: debits are positive, credits are negative.
+CREATE TABLE Budget (
+    BGID BIGINT NOT NULL AUTO_INCREMENT,                            -- unique id for this budget entry
+    BID BIGINT NOT NULL DEFAULT 0,                                  -- Business
+    LID BIGINT NOT NULL DEFAULT 0,                                  -- the GLAccount
+    Dt DATE NOT NULL DEFAULT '1970-01-01',                          -- first day of the budgeted month
+    Amount DECIMAL(19,4) NOT NULL DEFAULT 0.0,                      -- budgeted activity for the month
+    LastModTime TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,   -- when was this record last written
+    LastModBy BIGINT NOT NULL DEFAULT 0,                            -- employee UID (from phonebook) that modified it
+    CreateTS TIMESTAMP DEFAULT CURRENT_TIMESTAMP,                   -- when was this record created
+    CreateBy BIGINT NOT NULL DEFAULT 0,                             -- employee UID (from phonebook) that created this record
+    PRIMARY KEY (BGID)
+);
+
+-- The GSR and occupancy targets of a RentableType for a month. Only
+-- RentableTypes with ManageToBudget = 1 have targets.
+CREATE TABLE RentableTypeBudget (
+    RTBGID BIGINT NOT NULL AUTO_INCREMENT,                          -- unique id for this target
+    BID BIGINT NOT NULL DEFAULT 0,                                  -- Business
+    RTID BIGINT NOT NULL DEFAULT 0,                                 -- the RentableType
+    Dt DATE NOT NULL DEFAULT '1970-01-01',                          -- first day of the budgeted month
+    GSR DECIMAL(19,4) NOT NULL DEFAULT 0.0,                         -- target gross scheduled rent of all Rentables of the type
+    Occupancy DECIMAL(19,4) NOT NULL DEFAULT 0.0,                   -- target occupancy, percent
+    LastModTime TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,   -- when was this record last written
+    LastModBy BIGINT NOT NULL DEFAULT 0,                            -- employee UID (from phonebook) that modified it
+    CreateTS TIMESTAMP DEFAULT CURRENT_TIMESTAMP,                   -- when was this record created
+    CreateBy BIGINT NOT NULL DEFAULT 0,                             -- employee UID (from phonebook) that created this record
+    PRIMARY KEY (RTBGID)
+);
+
+-- **************************************
+-- ****                              ****
+-- ****      GOODS AND SERVICES      ****
+-- ****                              ****
+-- **************************************
+-- An item in a business's catalog of goods and services, such as a parking
+-- pass, a laundry card or an amenity fee. A sale is charged as a one-time
+-- assessment using ARID, and taxed using TAXID.
+CREATE TABLE GoodsService (
+    GSID BIGINT NOT NULL AUTO_INCREMENT,                            -- unique id for this item
+    BID BIGINT NOT NULL DEFAULT 0,                                  -- Business
+    Name VARCHAR(100) NOT NULL DEFAULT '',                          -- name of the item
+    Description VARCHAR(1024) NOT NULL DEFAULT '',                  -- description of the item
+    Price DECIMAL(19,4) NOT NULL DEFAULT 0.0,                       -- unit price
+    ARID BIGINT NOT NULL DEFAULT 0,                                 -- account rule used to assess a sale
+    TAXID BIGINT NOT NULL DEFAULT 0,                                -- tax charged on a sale, 0 = not taxed
+    FLAGS BIGINT NOT NULL DEFAULT 0,                                -- bit 0: 1 = no longer sold
+    LastModTime TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,   -- when was this record last written
+    LastModBy BIGINT NOT NULL DEFAULT 0,                            -- employee UID (from phonebook) that modified it
+    CreateTS TIMESTAMP DEFAULT CURRENT_TIMESTAMP,                   -- when was this record created
+    CreateBy BIGINT NOT NULL DEFAULT 0,                             -- employee UID (from phonebook) that created this record
+    PRIMARY KEY (GSID)
+);
+
 -- **************************************
 -- ****                              ****
 -- ****        ASSESSMENTS           ****
@@ -642,6 +863,8 @@ CREATE TABLE Assessments (
     AcctRule VARCHAR(200) NOT NULL DEFAULT '',              -- Accounting rule override- which acct debited, which credited
     ARID BIGINT NOT NULL DEFAULT 0,                         -- The accounting rule to apply
     FLAGS BIGINT NOT NULL DEFAULT 0,                        -- Bits 0-1:  0 = unpaid, 1 = partially paid, 2 = fully paid, 3 is undefined.  Bit 2: 1 = this assmt has been reversed.
+                                                            --     Bit 3: 1 = a late fee has been assessed for this assmt (ASMLATEFEE).
+                                                            --     Bit 4: 1 = this assmt is the tax charge of another assmt (ASMTAXCHARGE).
     Comment VARCHAR(256) NOT NULL DEFAULT '',               -- for comments such as "Prior period adjustment"
     LastModTime TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,   -- when was this record last written
     LastModBy BIGINT NOT NULL DEFAULT 0,                    -- employee UID (from phonebook) that modified it
@@ -680,6 +903,7 @@ CREATE TABLE AR (
     Description VARCHAR(1024) NOT NULL DEFAULT '',
     DtStart DATETIME NOT NULL DEFAULT '1970-01-01 00:00:00',  -- epoch date for recurring assessments; the date/time of the assessment for instances
     DtStop DATETIME NOT NULL DEFAULT '2066-01-01 00:00:00',   -- stop date for recurrent assessments; the date/time of the assessment for instances
+    FLAGS BIGINT NOT NULL DEFAULT 0,                        -- bit 0 = this rule assesses rent
     LastModTime TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,                                  -- when was this record last written
     LastModBy BIGINT NOT NULL DEFAULT 0,                 -- employee UID (from phonebook) that modified it
     CreateTS TIMESTAMP DEFAULT CURRENT_TIMESTAMP,    -- when was this record created
@@ -706,7 +930,7 @@ CREATE TABLE DemandSource (
 );
 
 CREATE TABLE LeadSource (
-    LSID BIGINT NOT NULL AUTO_INCREMENT,                    -- DemandSource ID - unique id for this source
+    LSID BIGINT NOT NULL AUTO_INCREMENT,                    -- LeadSource ID - unique id for this source
     BID BIGINT NOT NULL DEFAULT 0,                          -- What business is this
     Name VARCHAR(100),                                      -- Name of the source
     IndustrySLID BIGINT NOT NULL DEFAULT 0,                 -- What industry -- THIS BECOMES A REFERENCE TO "Industry" StringList
@@ -775,7 +999,8 @@ CREATE TABLE Prospect (
     ApplicationFee DECIMAL(19,4) NOT NULL DEFAULT 0.0,      -- if non-zero this Prospect is an applicant
     DesiredUsageStartDate DATE NOT NULL DEFAULT '1970-01-01 00:00:00',   -- User's initial indication of move in date, actual move in date is in Rental Agreement
     RentableTypePreference BIGINT NOT NULL DEFAULT 0,       -- This would be "model" preference  (Rentable Type name) for room or residence, but could apply to all rentables
-    FLAGS BIGINT NOT NULL DEFAULT 0,                        -- 1<<0 did they fill out an appl.  1<<1 - approved/not approved
+    FLAGS BIGINT NOT NULL DEFAULT 0,                        -- 1<<0 application approved, 1<<1 application declined, 1<<2 converted to a Rental Agreement,
+                                                            --     1<<3 ApplicationFee has been assessed
     Approver BIGINT NOT NULL DEFAULT 0,                     -- who approved or declined
     DeclineReasonSLSID BIGINT NOT NULL DEFAULT 0,           -- ID to string in list of choices, Melissa will provide the list.
     OtherPreferences VARCHAR(1024) NOT NULL DEFAULT '',     -- Arbitrary text, anything else they might request
@@ -784,6 +1009,7 @@ CREATE TABLE Prospect (
     OutcomeSLSID BIGINT NOT NULL DEFAULT 0,                 -- id of string from a list of outcomes.
     FloatingDeposit DECIMAL (19,4) NOT NULL DEFAULT 0.0,    --  d $(GLCASH) _, c $(GLGENRCV) _; assign to a shell of a Rental Agreement
     RAID BIGINT NOT NULL DEFAULT 0,                         -- created to hold On Account amount of Floating Deposit  -- Make this 0 after Prospect becomes Transactant
+    LSID BIGINT NOT NULL DEFAULT 0,                         -- LeadSource that produced this Prospect
     LastModTime TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,    -- when was this record last written
     LastModBy BIGINT NOT NULL DEFAULT 0,                    -- employee UID (from phonebook) that modified it
     CreateTS TIMESTAMP DEFAULT CURRENT_TIMESTAMP,           -- when was this record created
@@ -970,6 +1196,7 @@ CREATE TABLE Invoice (
     DtDue DATE NOT NULL DEFAULT '1970-01-01 00:00:00',          -- Date when the invoice is due
     Amount DECIMAL(19,4) NOT NULL DEFAULT 0.0,                  -- total amount of all assessments in this invoice
     DeliveredBy VARCHAR(256) NOT NULL DEFAULT '',               -- mail, FedEx, UPS, ...
+    FLAGS BIGINT NOT NULL DEFAULT 0,                            -- bit 0 = paid, bit 1 = void
     LastModTime TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,                                      -- when was this record last written
     LastModBy BIGINT NOT NULL DEFAULT 0,                     -- employee UID (from phonebook) that modified it
     CreateTS TIMESTAMP DEFAULT CURRENT_TIMESTAMP,    -- when was this record created
@@ -1005,10 +1232,13 @@ CREATE TABLE Journal (
     -- RAID BIGINT NOT NULL DEFAULT 0,                                -- associated rental agreement
     Dt DATETIME NOT NULL DEFAULT '1970-01-01 00:00:00',            -- date when it occurred
     Amount DECIMAL(19,4) NOT NULL DEFAULT 0.0,                     -- how much
-    Type SMALLINT NOT NULL DEFAULT 0,                              -- 0 = unassociated with RA, 1 = assessment, 2 = payment/Receipt
+    Type SMALLINT NOT NULL DEFAULT 0,                              -- 0 = unassociated with RA, 1 = assessment, 2 = payment/Receipt, 3 = adjustment, 4 = deposit, 5 = commission
     ID BIGINT NOT NULL DEFAULT 0,                                  -- if Type == 0 then it is the RentableID,
                                                                    -- if Type == 1 then it is the ASMID that caused this entry,
                                                                    -- if Type == 2 then it is the RCPTID
+                                                                   -- if Type == 3 then it is the JID of the closed-period entry being adjusted
+                                                                   -- if Type == 4 then it is the DID of the Deposit
+                                                                   -- if Type == 5 then it is the CLID of the CommissionLedger entry paid
     Comment VARCHAR(256) NOT NULL DEFAULT '',                      -- for notes like "prior period adjustment"
     LastModTime TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,                                         -- when was this record last written
     LastModBy BIGINT NOT NULL DEFAULT 0,                        -- employee UID (from phonebook) that modified it
@@ -1050,14 +1280,18 @@ CREATE TABLE JournalAudit (
     JID BIGINT NOT NULL DEFAULT 0,          -- what JID was affected
     BID BIGINT NOT NULL DEFAULT 0,          -- Business id
     UID MEDIUMINT NOT NULL DEFAULT 0,       -- UID of person making the change
-    ModTime TIMESTAMP                       -- timestamp of change
+    Action SMALLINT NOT NULL DEFAULT 0,     -- 1 = create, 2 = update, 3 = delete
+    Comment VARCHAR(256) NOT NULL DEFAULT '', -- values of the record at the time of the change
+    ModTime TIMESTAMP DEFAULT CURRENT_TIMESTAMP -- timestamp of change
 );
 
 CREATE TABLE JournalMarkerAudit (
     JMID BIGINT NOT NULL DEFAULT 0,         -- what JMID was affected
     BID BIGINT NOT NULL DEFAULT 0,          -- Business id
     UID MEDIUMINT NOT NULL DEFAULT 0,       -- UID of person making the change
-    ModTime TIMESTAMP                       -- timestamp of change
+    Action SMALLINT NOT NULL DEFAULT 0,     -- 1 = create, 2 = update, 3 = delete
+    Comment VARCHAR(256) NOT NULL DEFAULT '', -- values of the record at the time of the change
+    ModTime TIMESTAMP DEFAULT CURRENT_TIMESTAMP -- timestamp of change
 );
 
 -- **************************************
@@ -1135,13 +1369,17 @@ CREATE TABLE LedgerAudit (
     LEID BIGINT NOT NULL DEFAULT 0,             -- what LEID was affected
     BID BIGINT NOT NULL DEFAULT 0,              -- Business id
     UID MEDIUMINT NOT NULL DEFAULT 0,           -- UID of person making the change
-    ModTime TIMESTAMP                           -- timestamp of change
+    Action SMALLINT NOT NULL DEFAULT 0,         -- 1 = create, 2 = update, 3 = delete
+    Comment VARCHAR(256) NOT NULL DEFAULT '',   -- values of the record at the time of the change
+    ModTime TIMESTAMP DEFAULT CURRENT_TIMESTAMP -- timestamp of change
 );
 
 CREATE TABLE LedgerMarkerAudit (
     LMID BIGINT NOT NULL DEFAULT 0,             -- what LMID was affected
     BID BIGINT NOT NULL DEFAULT 0,              -- Business id
     UID MEDIUMINT NOT NULL DEFAULT 0,           -- UID of person making the change
-    ModTime TIMESTAMP                           -- timestamp of change
+    Action SMALLINT NOT NULL DEFAULT 0,         -- 1 = create, 2 = update, 3 = delete
+    Comment VARCHAR(256) NOT NULL DEFAULT '',   -- values of the record at the time of the change
+    ModTime TIMESTAMP DEFAULT CURRENT_TIMESTAMP -- timestamp of change
 );
 
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"gotable"
 	"os"
+	"rentroll/bizlogic"
 	"rentroll/rcsv"
 	"rentroll/rlib"
 	"rentroll/rrpt"
@@ -131,6 +132,15 @@ func RunCommandLine(ctx *DispatchCtx) {
 		rrpt.RRreportBusiness(&ri)
 		fmt.Printf("Deleting business: %d\n", ctx.xbiz.P.BID)
 		rlib.DeleteBusinessFromDB(ctx.xbiz.P.BID)
+	case 23: // close period -j to -k
+		errlist := bizlogic.ClosePeriod(ctx.xbiz.P.BID, &ctx.DtStart, &ctx.DtStop, 0)
+		if len(errlist) > 0 {
+			fmt.Printf("Could not close period %s - %s:\n%s", ctx.DtStart.Format(rlib.RRDATEFMT4), ctx.DtStop.Format(rlib.RRDATEFMT4), bizlogic.BizErrorListToError(errlist).Error())
+			os.Exit(1)
+		}
+		fmt.Printf("Closed period %s - %s\n", ctx.DtStart.Format(rlib.RRDATEFMT4), ctx.DtStop.Format(rlib.RRDATEFMT4))
+	case 24: // NOTICES due -j to -k
+		fmt.Print(rrpt.NoticesTextReport(&ri))
 
 	default:
 		rlib.GenerateJournalRecords(&ctx.xbiz, &ctx.DtStart, &ctx.DtStop, App.SkipVacCheck)
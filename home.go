@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"net/url"
 	"rentroll/rlib"
+	"rentroll/ws"
 	"strings"
 )
 
@@ -17,6 +18,8 @@ import (
 //
 // <lang> specifies the language.  The default is en-us
 // <tmpl> specifies which template to use. The default is "dflt"
+//
+// Users who are not logged in are sent to the login page.
 //------------------------------------------------------------------
 func HomeUIHandler(w http.ResponseWriter, r *http.Request) {
 	var ui RRuiSupport
@@ -26,6 +29,11 @@ func HomeUIHandler(w http.ResponseWriter, r *http.Request) {
 	lang := "en-us"
 	tmpl := "default"
 
+	if ws.SessionGet(r) == nil {
+		http.Redirect(w, r, "/html/login.html", http.StatusFound)
+		return
+	}
+
 	path := "/home/"                // this is the part of the URL that got us into this handler
 	uri := r.RequestURI[len(path):] // this pulls off the specific request
 
@@ -17,7 +17,8 @@ function getARRulesInitRecord(BID, BUD, post_accounts_pre_selected){
         DtStart: w2uiDateControlString(y),
         DtStop: w2uiDateControlString(ny),
         PriorToRAStart: true,
-        PriorToRAStop: true
+        PriorToRAStop: true,
+        IsRent: false
     };
 }
 
@@ -208,6 +209,7 @@ $().w2grid({
             { field: 'DtStop', type: 'date', required: true, html: { page: 0, column: 0 } },
             { field: 'PriorToRAStart', type: 'checkbox', required: true, html: { page: 0, column: 0 } },
             { field: 'PriorToRAStop', type: 'checkbox', required: true, html: { page: 0, column: 0 } },
+            { field: 'IsRent', type: 'checkbox', required: false, html: { page: 0, column: 0 } },
             { field: "LastModTime", required: false, type: 'time', html: { caption: "LastModTime", page: 0, column: 0 } },
             { field: "LastModBy", required: false, type: 'int', html: { caption: "LastModBy", page: 0, column: 0 } },
             { field: "CreateTS", required: false, type: 'time', html: { caption: "CreateTS", page: 0, column: 0 } },
@@ -347,6 +349,7 @@ $().w2grid({
             // object to value before submit to server
             data.postData.record.PriorToRAStart = int_to_bool(data.postData.record.PriorToRAStart);
             data.postData.record.PriorToRAStop = int_to_bool(data.postData.record.PriorToRAStop);
+            data.postData.record.IsRent = int_to_bool(data.postData.record.IsRent);
             console.log(data.postData.record);
         },
         onRefresh: function(event) {
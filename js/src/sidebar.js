@@ -52,14 +52,19 @@ function buildSidebar() {
                 nodes: [
                        //{ id: 'RPTasmrpt',     text: 'Assessments',                     icon: 'fa fa-file-text-o' },
                        //{ id: 'RPTb',          text: 'Business Units',                  icon: 'fa fa-file-text-o' },
+                       { id: 'RPTbudget',       text: 'Budget vs Actual',                icon: 'fa fa-file-text-o' },
                        { id: 'RPTcoa',          text: 'Chart Of Accounts',               icon: 'fa fa-file-text-o' },
+                       { id: 'RPTcommdue',      text: 'Commissions Due',                 icon: 'fa fa-file-text-o' },
                        //{ id: 'RPTdpm',        text: 'Deposit Methods',                 icon: 'fa fa-file-text-o' },
                        //{ id: 'RPTdep',        text: 'Depositories',                    icon: 'fa fa-file-text-o' },
                        { id: 'RPTdelinq',       text: 'Delinquency',                     icon: 'fa fa-file-text-o' },
+                       { id: 'RPTdepslip',      text: 'Deposit Slips',                   icon: 'fa fa-file-text-o' },
                        { id: 'RPTgsr',          text: 'GSR',                             icon: 'fa fa-file-text-o' },
                        { id: 'RPTj',            text: 'Journal',                         icon: 'fa fa-file-text-o' },
                        { id: 'RPTl',            text: 'Ledger',                          icon: 'fa fa-file-text-o' },
                        { id: 'RPTla',           text: 'Ledger Activity',                 icon: 'fa fa-file-text-o' },
+                       { id: 'RPTmaint',        text: 'Maintenance Due',                 icon: 'fa fa-file-text-o' },
+                       { id: 'RPTmktattr',      text: 'Marketing Attribution',           icon: 'fa fa-file-text-o' },
                        { id: 'RPTpeople',       text: app.sTransactant,                  icon: 'fa fa-file-text-o' },
                        //{ id: 'RPTpmt',        text: 'Payment Types',                   icon: 'fa fa-file-text-o' },
                        //{ id: 'RPTrcpt',       text: 'Receipts',                        icon: 'fa fa-file-text-o' },
@@ -68,6 +73,7 @@ function buildSidebar() {
                        { id: 'RPTra',           text: plural(app.sRentalAgreement),      icon: 'fa fa-file-text-o' },
                        { id: 'RPTrat',          text: app.sRentalAgreement+' Templates', icon: 'fa fa-file-text-o' },
                        { id: 'RPTrt',           text: app.sRentable+' Types',            icon: 'fa fa-file-text-o' },
+                       { id: 'RPTri',           text: 'Rent Increases',                  icon: 'fa fa-file-text-o' },
                        { id: 'RPTrr',           text: 'RentRoll',                        icon: 'fa fa-file-text-o' },
                        //{ id: 'RPTstatements', text: 'Statements',                      icon: 'fa fa-file-text-o' },
                        //{ id: 'RPTsl',         text: 'String Lists',                    icon: 'fa fa-file-text-o' },
@@ -188,8 +194,8 @@ function buildSidebar() {
                         break;
                     case 'prepnotice':
                         w2ui.sidebarL1.collapse('reports'); // close reports when jumping to a main view
-                        w2ui.toplayout.load('main', '/html/formprepnotice.html');
-                        w2ui.toplayout.hide('right',true);
+                        showReport('RPTnotices');
+                        app.last.report = 'RPTnotices';
                         break;
                     case 'close':
                         w2ui.sidebarL1.collapse('reports'); // close reports when jumping to a main view
@@ -238,20 +244,26 @@ function buildSidebar() {
                         break;
                     case 'RPTasmrpt':
                     case 'RPTb':
+                    case 'RPTbudget':
                     case 'RPTcoa':
+                    case 'RPTcommdue':
                     case 'RPTdelinq':
                     case 'RPTdep':
+                    case 'RPTdepslip':
                     case 'RPTdpm':
                     case 'RPTgsr':
                     case 'RPTj':
                     case 'RPTl':
                     case 'RPTla':
+                    case 'RPTmaint':
+                    case 'RPTmktattr':
                     case 'RPTpeople':
                     case 'RPTpmt':
                     case 'RPTr':
                     case 'RPTra':
                     case 'RPTrat':
                     case 'RPTrcbt':  // rentable count by type
+                    case 'RPTri':
                     case 'RPTrcpt':
                     case 'RPTrr':
                     case 'RPTrt':
@@ -6,10 +6,12 @@ import (
 	"strings"
 )
 
-// 0   1   2
-// Bud,Name,ARType,    DebitLID,CreditLID,Description
-// REX,Rent,Assessment,2,       8,        Rent assessment, accrual based, manage to budget
+// 0   1   2                                                                                               6
+// Bud,Name,ARType,    DebitLID,CreditLID,Description,                                                      Rent
+// REX,Rent,Assessment,2,       8,        "Rent assessment, accrual based, manage to budget",               yes
 // REX,FNB, Receipt,   3,       7,        payments that are deposited in First National Bank
+//
+// Rent is optional. It is "yes" for an assessment rule that assesses rent.
 
 // CreateAR creates AR database records from the supplied CSV file lines
 func CreateAR(sa []string, lineno int) (int, error) {
@@ -24,6 +26,7 @@ func CreateAR(sa []string, lineno int) (int, error) {
 		DebitLID    = iota
 		CreditLID   = iota
 		Description = iota
+		Rent        = iota
 	)
 
 	// csvCols is an array that defines all the columns that should be in this csv file
@@ -124,6 +127,22 @@ func CreateAR(sa []string, lineno int) (int, error) {
 	//----------------------------------------------------------------
 	b.Description = sa[Description]
 
+	//----------------------------------------------------------------
+	// Is it rent?
+	//----------------------------------------------------------------
+	if len(sa) > Rent {
+		switch strings.TrimSpace(strings.ToLower(sa[Rent])) {
+		case "", "no", "false", "0":
+		case "yes", "true", "1":
+			if b.ARType != rlib.ARASSESSMENT {
+				return CsvErrorSensitivity, fmt.Errorf("%s: line %d - only an Assessment rule can assess rent", funcname, lineno)
+			}
+			b.FLAGS |= rlib.ARRENTASSESSMENT
+		default:
+			return CsvErrorSensitivity, fmt.Errorf("%s: line %d - Rent must be yes or no.  Found: %s", funcname, lineno, sa[Rent])
+		}
+	}
+
 	_, err = rlib.InsertAR(&b)
 	if err != nil {
 		return CsvErrorSensitivity, fmt.Errorf("%s: error inserting AR = %v", funcname, err)
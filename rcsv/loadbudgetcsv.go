@@ -0,0 +1,102 @@
+package rcsv
+
+import (
+	"fmt"
+	"rentroll/rlib"
+	"strings"
+	"time"
+)
+
+// CSV FIELDS FOR THIS MODULE
+//    0    1         2          3
+//    BUD, GLNumber, Month,     Amount
+//    REX, 40001,    1/1/2018,  -42000.00
+//    REX, 60100,    2/1/2018,  1200.00
+//
+// Month can be any date in the budgeted month. Amounts use the sign
+// convention of the ledger: debits are positive, credits are negative.
+
+// CreateBudgetCSV reads a Budget string array and creates a database record for it.
+func CreateBudgetCSV(sa []string, lineno int) (int, error) {
+	funcname := "CreateBudgetCSV"
+	var a rlib.Budget
+	var errmsg string
+
+	const (
+		BUD      = 0
+		GLNumber = iota
+		Month    = iota
+		Amount   = iota
+	)
+
+	// csvCols is an array that defines all the columns that should be in this csv file
+	var csvCols = []CSVColumn{
+		{"BUD", BUD},
+		{"GLNumber", GLNumber},
+		{"Month", Month},
+		{"Amount", Amount},
+	}
+
+	y, err := ValidateCSVColumnsErr(csvCols, sa, funcname, lineno)
+	if y {
+		return 1, err
+	}
+	if lineno == 1 {
+		return 0, nil // we've validated the col headings, all is good, send the next line
+	}
+
+	des := strings.ToLower(strings.TrimSpace(sa[BUD]))
+
+	//-------------------------------------------------------------------
+	// Business
+	//-------------------------------------------------------------------
+	var b rlib.Business
+	if len(des) > 0 {
+		b = rlib.GetBusinessByDesignation(des)
+		if b.BID < 1 {
+			return CsvErrorSensitivity, fmt.Errorf("%s: line %d - rlib.Business named %s not found", funcname, lineno, sa[BUD])
+		}
+	}
+	a.BID = b.BID
+
+	//-------------------------------------------------------------------
+	// GL Account
+	//-------------------------------------------------------------------
+	g := strings.TrimSpace(sa[GLNumber])
+	l := rlib.GetLedgerByGLNo(a.BID, g)
+	if l.LID == 0 {
+		return CsvErrorSensitivity, fmt.Errorf("%s: line %d - GL Account %s not found", funcname, lineno, g)
+	}
+	a.LID = l.LID
+
+	//-------------------------------------------------------------------
+	// Month
+	//-------------------------------------------------------------------
+	dt, err := rlib.StringToDate(sa[Month])
+	if err != nil {
+		return CsvErrorSensitivity, fmt.Errorf("%s: line %d - invalid Month: %s", funcname, lineno, sa[Month])
+	}
+	a.Dt = time.Date(dt.Year(), dt.Month(), 1, 0, 0, 0, 0, rlib.RRdb.Zone)
+	if x, err := rlib.GetBudgetForMonth(a.LID, &a.Dt); err == nil && x.BGID > 0 {
+		return CsvErrorSensitivity, fmt.Errorf("%s: line %d - GL Account %s already has a budget for %s", funcname, lineno, g, a.Dt.Format("Jan 2006"))
+	}
+
+	//-------------------------------------------------------------------
+	// Amount
+	//-------------------------------------------------------------------
+	a.Amount, errmsg = rlib.FloatFromString(sa[Amount], "Amount is invalid")
+	if len(errmsg) > 0 {
+		return CsvErrorSensitivity, fmt.Errorf("%s: line %d - %s", funcname, lineno, errmsg)
+	}
+
+	_, err = rlib.InsertBudget(&a)
+	if err != nil {
+		return CsvErrorSensitivity, fmt.Errorf("%s: line %d - error inserting Budget: %v", funcname, lineno, err)
+	}
+	return 0, nil
+}
+
+// LoadBudgetsCSV loads a csv file with monthly GL Account budgets
+func LoadBudgetsCSV(fname string) []error {
+	return LoadRentRollCSV(fname, CreateBudgetCSV)
+}
@@ -0,0 +1,99 @@
+package rcsv
+
+import (
+	"fmt"
+	"rentroll/rlib"
+	"strings"
+)
+
+// CSV FIELDS FOR THIS MODULE
+//    0    1               2
+//    BUD, Name,           Industry
+//    REX, Apartments.com, Real Estate
+//    REX, Craigslist,     Classifieds
+
+// CreateLeadSourceCSV reads a LeadSource string array and creates a database record for it.
+// Industry, if supplied, must be a string in the business's "Industry" StringList.
+func CreateLeadSourceCSV(sa []string, lineno int) (int, error) {
+	funcname := "CreateLeadSourceCSV"
+	var a rlib.LeadSource
+	var err error
+
+	const (
+		BUD     = 0
+		Name    = iota
+		Industy = iota
+	)
+
+	// csvCols is an array that defines all the columns that should be in this csv file
+	var csvCols = []CSVColumn{
+		{"BUD", BUD},
+		{"Name", Name},
+		{"Industry", Industy},
+	}
+
+	y, err := ValidateCSVColumnsErr(csvCols, sa, funcname, lineno)
+	if y {
+		return 1, err
+	}
+	if lineno == 1 {
+		return 0, nil // we've validated the col headings, all is good, send the next line
+	}
+
+	des := strings.ToLower(strings.TrimSpace(sa[BUD]))
+
+	//-------------------------------------------------------------------
+	// Business
+	//-------------------------------------------------------------------
+	var b rlib.Business
+	if len(des) > 0 {
+		b = rlib.GetBusinessByDesignation(des)
+		if b.BID < 1 {
+			return CsvErrorSensitivity, fmt.Errorf("%s: line %d - rlib.Business named %s not found", funcname, lineno, sa[BUD])
+		}
+	}
+	a.BID = b.BID
+
+	//-------------------------------------------------------------------
+	// Name
+	//-------------------------------------------------------------------
+	s := strings.TrimSpace(sa[Name])
+	if len(s) > 0 {
+		var src rlib.LeadSource
+		rlib.GetLeadSourceByName(b.BID, s, &src)
+		if len(src.Name) > 0 {
+			return CsvErrorSensitivity, fmt.Errorf("%s: line %d - LeadSource named %s already exists", funcname, lineno, s)
+		}
+	}
+	a.Name = s
+
+	//-------------------------------------------------------------------
+	// Industry
+	//-------------------------------------------------------------------
+	s = strings.TrimSpace(sa[Industy])
+	if len(s) > 0 {
+		var sl rlib.StringList
+		rlib.GetStringListByName(b.BID, "Industry", &sl)
+		for i := 0; i < len(sl.S); i++ {
+			if strings.ToLower(sl.S[i].Value) == strings.ToLower(s) {
+				a.IndustrySLID = sl.S[i].SLSID
+				break
+			}
+		}
+		if a.IndustrySLID == 0 {
+			return CsvErrorSensitivity, fmt.Errorf("%s: line %d - Industry %s not found in StringList Industry", funcname, lineno, s)
+		}
+	}
+
+	_, err = rlib.InsertLeadSource(&a)
+	if err != nil {
+		return CsvErrorSensitivity, fmt.Errorf("%s: line %d - error inserting LeadSource: %v", funcname, lineno, err)
+	}
+
+	return 0, nil
+}
+
+// LoadLeadSourcesCSV loads a csv file with LeadSources
+func LoadLeadSourcesCSV(fname string) []error {
+	return LoadRentRollCSV(fname, CreateLeadSourceCSV)
+}
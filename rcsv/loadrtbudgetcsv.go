@@ -0,0 +1,117 @@
+package rcsv
+
+import (
+	"fmt"
+	"rentroll/rlib"
+	"strings"
+	"time"
+)
+
+// CSV FIELDS FOR THIS MODULE
+//    0    1             2          3          4
+//    BUD, RentableType, Month,     GSR,       Occupancy
+//    REX, GM,           1/1/2018,  12000.00,  95%
+//    REX, Flat Studio,  1/1/2018,  8400.00,   90
+//
+// RentableType is the style of a RentableType with ManageToBudget set.
+// Month can be any date in the budgeted month. Occupancy is a percentage,
+// with or without the %.
+
+// CreateRentableTypeBudgetCSV reads a RentableTypeBudget string array and creates a database record for it.
+func CreateRentableTypeBudgetCSV(sa []string, lineno int) (int, error) {
+	funcname := "CreateRentableTypeBudgetCSV"
+	var a rlib.RentableTypeBudget
+	var errmsg string
+
+	const (
+		BUD          = 0
+		RentableType = iota
+		Month        = iota
+		GSR          = iota
+		Occupancy    = iota
+	)
+
+	// csvCols is an array that defines all the columns that should be in this csv file
+	var csvCols = []CSVColumn{
+		{"BUD", BUD},
+		{"RentableType", RentableType},
+		{"Month", Month},
+		{"GSR", GSR},
+		{"Occupancy", Occupancy},
+	}
+
+	y, err := ValidateCSVColumnsErr(csvCols, sa, funcname, lineno)
+	if y {
+		return 1, err
+	}
+	if lineno == 1 {
+		return 0, nil // we've validated the col headings, all is good, send the next line
+	}
+
+	des := strings.ToLower(strings.TrimSpace(sa[BUD]))
+
+	//-------------------------------------------------------------------
+	// Business
+	//-------------------------------------------------------------------
+	var b rlib.Business
+	if len(des) > 0 {
+		b = rlib.GetBusinessByDesignation(des)
+		if b.BID < 1 {
+			return CsvErrorSensitivity, fmt.Errorf("%s: line %d - rlib.Business named %s not found", funcname, lineno, sa[BUD])
+		}
+	}
+	a.BID = b.BID
+
+	//-------------------------------------------------------------------
+	// RT Style
+	// identifies the RentableType
+	//-------------------------------------------------------------------
+	name := strings.TrimSpace(sa[RentableType])
+	rt, err := rlib.GetRentableTypeByStyle(name, a.BID)
+	if err != nil {
+		return CsvErrorSensitivity, fmt.Errorf("%s: line %d - could not load RentableType with Style = %s,  err:  %s", funcname, lineno, sa[RentableType], err.Error())
+	}
+	if rt.ManageToBudget == 0 {
+		return CsvErrorSensitivity, fmt.Errorf("%s: line %d - RentableType %s is not managed to budget", funcname, lineno, name)
+	}
+	a.RTID = rt.RTID
+
+	//-------------------------------------------------------------------
+	// Month
+	//-------------------------------------------------------------------
+	dt, err := rlib.StringToDate(sa[Month])
+	if err != nil {
+		return CsvErrorSensitivity, fmt.Errorf("%s: line %d - invalid Month: %s", funcname, lineno, sa[Month])
+	}
+	a.Dt = time.Date(dt.Year(), dt.Month(), 1, 0, 0, 0, 0, rlib.RRdb.Zone)
+	if x, err := rlib.GetRentableTypeBudgetForMonth(a.RTID, &a.Dt); err == nil && x.RTBGID > 0 {
+		return CsvErrorSensitivity, fmt.Errorf("%s: line %d - RentableType %s already has targets for %s", funcname, lineno, name, a.Dt.Format("Jan 2006"))
+	}
+
+	//-------------------------------------------------------------------
+	// GSR and Occupancy
+	//-------------------------------------------------------------------
+	a.GSR, errmsg = rlib.FloatFromString(sa[GSR], "GSR is invalid")
+	if len(errmsg) > 0 {
+		return CsvErrorSensitivity, fmt.Errorf("%s: line %d - %s", funcname, lineno, errmsg)
+	}
+	occ := strings.Replace(sa[Occupancy], "%", "", -1) // FloatFromString would make 95% into 0.95
+	a.Occupancy, errmsg = rlib.FloatFromString(occ, "Occupancy is invalid")
+	if len(errmsg) > 0 {
+		return CsvErrorSensitivity, fmt.Errorf("%s: line %d - %s", funcname, lineno, errmsg)
+	}
+	if a.Occupancy < 0 || a.Occupancy > 100 {
+		return CsvErrorSensitivity, fmt.Errorf("%s: line %d - Occupancy must be a percentage from 0 to 100", funcname, lineno)
+	}
+
+	_, err = rlib.InsertRentableTypeBudget(&a)
+	if err != nil {
+		return CsvErrorSensitivity, fmt.Errorf("%s: line %d - error inserting RentableTypeBudget: %v", funcname, lineno, err)
+	}
+	return 0, nil
+}
+
+// LoadRentableTypeBudgetsCSV loads a csv file with monthly GSR and occupancy targets of RentableTypes
+func LoadRentableTypeBudgetsCSV(fname string) []error {
+	return LoadRentRollCSV(fname, CreateRentableTypeBudgetCSV)
+}
@@ -0,0 +1,118 @@
+package rlib
+
+import (
+	"database/sql"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// Audit tables record every create, update and delete of the financial records:
+// Journal, JournalMarker, LedgerEntry and LedgerMarker. The audit record holds the
+// id of the changed record, the UID of the person who made the change, the action,
+// and the values of the record at the time of the change. Batch processes that run
+// without a user are recorded with UID 0.
+//
+// Audit failures are logged but they never fail the change being audited.
+
+// auditJournal writes a JournalAudit record for j
+func auditJournal(j *Journal, uid, action int64) {
+	c := fmt.Sprintf("Dt=%s Amount=%.2f Type=%d ID=%d", j.Dt.Format(RRDATEFMT4), j.Amount, j.Type, j.ID)
+	if _, err := RRdb.Prepstmt.InsertJournalAudit.Exec(j.JID, j.BID, uid, action, c); err != nil {
+		Ulog("auditJournal: error writing JournalAudit for JID = %d, error: %v\n", j.JID, err)
+	}
+}
+
+// auditJournalMarker writes a JournalMarkerAudit record for jm
+func auditJournalMarker(jm *JournalMarker, uid, action int64) {
+	c := fmt.Sprintf("DtStart=%s DtStop=%s State=%d", jm.DtStart.Format(RRDATEFMT4), jm.DtStop.Format(RRDATEFMT4), jm.State)
+	if _, err := RRdb.Prepstmt.InsertJournalMarkerAudit.Exec(jm.JMID, jm.BID, uid, action, c); err != nil {
+		Ulog("auditJournalMarker: error writing JournalMarkerAudit for JMID = %d, error: %v\n", jm.JMID, err)
+	}
+}
+
+// auditLedgerEntry writes a LedgerAudit record for l
+func auditLedgerEntry(l *LedgerEntry, uid, action int64) {
+	c := fmt.Sprintf("LID=%d JID=%d Dt=%s Amount=%.2f", l.LID, l.JID, l.Dt.Format(RRDATEFMT4), l.Amount)
+	if _, err := RRdb.Prepstmt.InsertLedgerAudit.Exec(l.LEID, l.BID, uid, action, c); err != nil {
+		Ulog("auditLedgerEntry: error writing LedgerAudit for LEID = %d, error: %v\n", l.LEID, err)
+	}
+}
+
+// auditLedgerMarker writes a LedgerMarkerAudit record for l
+func auditLedgerMarker(l *LedgerMarker, uid, action int64) {
+	c := fmt.Sprintf("LID=%d RAID=%d Dt=%s Balance=%.2f State=%d", l.LID, l.RAID, l.Dt.Format(RRDATEFMT4), l.Balance, l.State)
+	if _, err := RRdb.Prepstmt.InsertLedgerMarkerAudit.Exec(l.LMID, l.BID, uid, action, c); err != nil {
+		Ulog("auditLedgerMarker: error writing LedgerMarkerAudit for LMID = %d, error: %v\n", l.LMID, err)
+	}
+}
+
+// AuditList is a sortable list of AuditRecords, oldest change first
+type AuditList []AuditRecord
+
+// Len returns the number of AuditRecords in the list
+func (a AuditList) Len() int { return len(a) }
+
+// Swap exchanges the AuditRecords at i and j
+func (a AuditList) Swap(i, j int) { a[i], a[j] = a[j], a[i] }
+
+// Less orders the AuditRecords by ModTime
+func (a AuditList) Less(i, j int) bool { return a[i].ModTime.Before(a[j].ModTime) }
+
+// readAuditRecords reads the rows of an audit table query. table is the name of the
+// audited table.
+func readAuditRecords(rows *sql.Rows, err error, table string) []AuditRecord {
+	var m []AuditRecord
+	if err != nil {
+		Ulog("readAuditRecords: error reading %s audit: %v\n", table, err)
+		return m
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var a = AuditRecord{Table: table}
+		Errcheck(rows.Scan(&a.ID, &a.BID, &a.UID, &a.Action, &a.Comment, &a.ModTime))
+		m = append(m, a)
+	}
+	Errcheck(rows.Err())
+	return m
+}
+
+// GetJournalAudit returns the change history of the Journal entry jid, oldest change first
+func GetJournalAudit(jid int64) []AuditRecord {
+	rows, err := RRdb.Prepstmt.GetJournalAudit.Query(jid)
+	return readAuditRecords(rows, err, "Journal")
+}
+
+// GetJournalMarkerAudit returns the change history of the JournalMarker jmid, oldest change first
+func GetJournalMarkerAudit(jmid int64) []AuditRecord {
+	rows, err := RRdb.Prepstmt.GetJournalMarkerAudit.Query(jmid)
+	return readAuditRecords(rows, err, "JournalMarker")
+}
+
+// GetLedgerAudit returns the change history of the LedgerEntry leid, oldest change first
+func GetLedgerAudit(leid int64) []AuditRecord {
+	rows, err := RRdb.Prepstmt.GetLedgerAudit.Query(leid)
+	return readAuditRecords(rows, err, "LedgerEntry")
+}
+
+// GetLedgerMarkerAudit returns the change history of the LedgerMarker lmid, oldest change first
+func GetLedgerMarkerAudit(lmid int64) []AuditRecord {
+	rows, err := RRdb.Prepstmt.GetLedgerMarkerAudit.Query(lmid)
+	return readAuditRecords(rows, err, "LedgerMarker")
+}
+
+// GetAuditTrail returns every change made to the Journal, JournalMarker, LedgerEntry and
+// LedgerMarker records of business bid during the period d1 - d2, oldest change first
+func GetAuditTrail(bid int64, d1, d2 *time.Time) []AuditRecord {
+	var m AuditList
+	rows, err := RRdb.Prepstmt.GetJournalAuditInRange.Query(bid, d1, d2)
+	m = append(m, readAuditRecords(rows, err, "Journal")...)
+	rows, err = RRdb.Prepstmt.GetJournalMarkerAuditInRange.Query(bid, d1, d2)
+	m = append(m, readAuditRecords(rows, err, "JournalMarker")...)
+	rows, err = RRdb.Prepstmt.GetLedgerAuditInRange.Query(bid, d1, d2)
+	m = append(m, readAuditRecords(rows, err, "LedgerEntry")...)
+	rows, err = RRdb.Prepstmt.GetLedgerMarkerAuditInRange.Query(bid, d1, d2)
+	m = append(m, readAuditRecords(rows, err, "LedgerMarker")...)
+	sort.Stable(m)
+	return m
+}
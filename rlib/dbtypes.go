@@ -92,7 +92,8 @@ const (
 	RENTABLESTATUSEMPLOYEE = 3
 	RENTABLESTATUSOWNEROCC = 4
 	RENTABLESTATUSOFFLINE  = 5
-	RENTABLESTATUSLAST     = 5 // keep in sync with last
+	RENTABLESTATUSOCCUPIED = 6 // online and occupied by a resident, set by move-in
+	RENTABLESTATUSLAST     = 6 // keep in sync with last
 
 	CREDIT = 0
 	DEBIT  = 1
@@ -107,12 +108,19 @@ const (
 	JNLTYPEUNAS = 0 // record is unassociated with any assessment or Receipt
 	JNLTYPEASMT = 1 // record is the result of an assessment
 	JNLTYPERCPT = 2 // record is the result of a Receipt
+	JNLTYPEADJ  = 3 // record is an adjustment to a Journal entry in a closed period
+	JNLTYPEDEP  = 4 // record is the Deposit of receipts into a Depository
+	JNLTYPECOMM = 5 // record is the payment of a commission from the CommissionLedger
 
 	MARKERSTATEOPEN   = 0 // Journal/LedgerMarker state
 	MARKERSTATECLOSED = 1
 	MARKERSTATELOCKED = 2
 	MARKERSTATEORIGIN = 3
 
+	AUDITCREATE = 1 // JournalAudit, LedgerAudit, and marker audit actions
+	AUDITUPDATE = 2
+	AUDITDELETE = 3
+
 	JOURNALTYPEASMID  = 1
 	JOURNALTYPERCPTID = 2
 
@@ -511,6 +519,19 @@ type DemandSource struct {
 	CreateBy    int64     // employee UID (from phonebook) that created it
 }
 
+// LeadSource is a marketing channel, such as a listing site or an ad
+// campaign, that produces Prospects
+type LeadSource struct {
+	LSID         int64     // LeadSource ID
+	BID          int64     // Business unit
+	Name         string    // name of source
+	IndustrySLID int64     // SLSID of the industry this source is in
+	LastModTime  time.Time // when was this record last written
+	LastModBy    int64     // employee UID (from phonebook) that modified it
+	CreateTS     time.Time // when was this record created
+	CreateBy     int64     // employee UID (from phonebook) that created it
+}
+
 // Transactant is the basic structure of information
 // about a person who is a Prospect, applicant, User, or Payor
 type Transactant struct {
@@ -557,7 +578,7 @@ type Prospect struct {
 	ApplicationFee         float64   // if non-zero this Prospect is an applicant
 	DesiredUsageStartDate  time.Time // predicted rent start date
 	RentableTypePreference int64     // RentableType
-	FLAGS                  uint64    // 0 = Approved/NotApproved,
+	FLAGS                  uint64    // see FlProspectApproved and the others
 	Approver               int64     // UID from Directory
 	DeclineReasonSLSID     int64     // SLSid of reason
 	OtherPreferences       string    // arbitrary text
@@ -566,12 +587,21 @@ type Prospect struct {
 	OutcomeSLSID           int64     // id of string from a list of outcomes. Melissa to provide reasons
 	FloatingDeposit        float64   // d $(GLCASH) _, c $(GLGENRCV) _; assign to a shell of a Rental Agreement
 	RAID                   int64     // created to hold On Account amount of Floating Deposit
+	LSID                   int64     // LeadSource that produced this Prospect
 	LastModTime            time.Time
 	LastModBy              int64
 	CreateTS               time.Time // when was this record created
 	CreateBy               int64     // employee UID (from phonebook) that created it
 }
 
+// FlProspectApproved and the others are bit flags for the Prospect FLAGS
+const (
+	FlProspectApproved    = 1 << 0 // bit 0 = application approved
+	FlProspectDeclined    = 1 << 1 // bit 1 = application declined
+	FlProspectConverted   = 1 << 2 // bit 2 = converted to a Rental Agreement
+	FlProspectFeeAssessed = 1 << 3 // bit 3 = ApplicationFee has been assessed
+)
+
 // User contains all info common to a person
 type User struct {
 	// USERID                    int64
@@ -673,7 +703,7 @@ type Assessment struct {
 	InvoiceNo      int64     // A uniqueID for the invoice number
 	AcctRule       string    // override ARID with this account rule
 	ARID           int64     // reference to the account rule to use
-	FLAGS          uint64    // bit flags.  bits 0-1: 0 = unpaid, 1 = partially paid, 2 = fully paid
+	FLAGS          uint64    // bit flags.  bits 0-1: 0 = unpaid, 1 = partially paid, 2 = fully paid, bit 2: reversed, bit 3: late fee assessed
 	Comment        string
 	LastModTime    time.Time
 	LastModBy      int64
@@ -681,6 +711,124 @@ type Assessment struct {
 	CreateBy       int64     // employee UID (from phonebook) that created it
 }
 
+// Tax describes a tax levied by a taxing authority. The amount is computed
+// from the TaxRate in effect on the date of the assessment being taxed.
+type Tax struct {
+	TAXID                  int64     // unique id for this tax
+	BID                    int64     // Business
+	Name                   string    // name of this tax
+	ARID                   int64     // account rule used to book the tax: debit receivable, credit tax liability
+	TaxingAuthority        string    // name of the taxing authority
+	TaxingAuthorityAddress string    // where these taxes are sent
+	FilingDate             time.Time // date on which taxes need to be filed
+	FilingCycle            int64     // epoch date for recurrence calculation
+	Instructions           string    // filing instructions
+	LastModTime            time.Time // when was this record last written
+	LastModBy              int64     // employee UID (from phonebook) that modified it
+	CreateTS               time.Time // when was this record created
+	CreateBy               int64     // employee UID (from phonebook) that created it
+}
+
+// TaxRate is the rate for tax TAXID during the period DtStart - DtStop. If Formula
+// is set, it is evaluated by the RPN calculator with the taxable amount as "_".
+// Otherwise, the tax is Rate percent of the taxable amount plus Fee.
+type TaxRate struct {
+	TRID        int64     // unique id for this tax rate
+	TAXID       int64     // associated tax
+	BID         int64     // Business
+	DtStart     time.Time // date when this rate goes into effect
+	DtStop      time.Time // date when this rate is no longer applicable
+	Rate        float64   // percentage of the taxable amount, 0 if not applicable
+	Fee         float64   // flat amount, 0 if not applicable
+	Formula     string    // RPN formula, overrides Rate and Fee when present
+	LastModTime time.Time // when was this record last written
+	LastModBy   int64     // employee UID (from phonebook) that modified it
+	CreateTS    time.Time // when was this record created
+	CreateBy    int64     // employee UID (from phonebook) that created it
+}
+
+// AssessmentTax overrides the way tax TAXID is applied to assessment ASMID
+type AssessmentTax struct {
+	ASMID               int64     // the assessment to which this tax is bound
+	BID                 int64     // Business
+	TAXID               int64     // which tax
+	FLAGS               uint64    // bit 0 = do not apply this tax, bit 1 = use OverrideAmount
+	OverrideTaxApprover int64     // if tax is overridden, who approved it
+	OverrideAmount      float64   // use this amount rather than computing the tax
+	LastModTime         time.Time // when was this record last written
+	LastModBy           int64     // employee UID (from phonebook) that modified it
+	CreateTS            time.Time // when was this record created
+	CreateBy            int64     // employee UID (from phonebook) that created it
+}
+
+// ASMTAXexempt and the others are bit flags for AssessmentTax and RentalAgreementTax
+const (
+	ASMTAXexempt   = 1 << 0 // AssessmentTax bit 0: do not apply this tax
+	ASMTAXoverride = 1 << 1 // AssessmentTax bit 1: use OverrideAmount
+	RATAXtaxable   = 1 << 0 // RentalAgreementTax bit 0: the agreement is taxable
+)
+
+// ASMLATEFEE is bit 3 of Assessment.FLAGS. It is set when a late fee has been
+// assessed because the assessment was not paid on time.
+const ASMLATEFEE = 1 << 3
+
+// ASMTAXCHARGE is bit 4 of Assessment.FLAGS. It marks the assessment of a tax
+// due on another assessment. It is booked in the Journal entry of the assessment
+// being taxed, so that it can be paid and reversed on its own.
+const ASMTAXCHARGE = 1 << 4
+
+// INVOICEPAID and INVOICEVOID are the bits of Invoice.FLAGS. An invoice is paid
+// when all of its assessments have been paid in full. A void invoice is kept
+// for reference but its assessments can be billed again.
+const (
+	INVOICEPAID = 1 << 0
+	INVOICEVOID = 1 << 1
+)
+
+// LateFeePolicy describes how late fees are charged for a business. A late fee is
+// assessed for each assessment that is still unpaid GraceDays after it is due.
+// If Formula is set, it is evaluated by the RPN calculator with the unpaid amount
+// as "_". Otherwise the late fee is the flat Amount.
+type LateFeePolicy struct {
+	LFPID       int64     // unique id for this policy
+	BID         int64     // Business, there is at most one policy per business
+	ARID        int64     // account rule for the late fee assessment
+	GraceDays   int64     // days after an assessment is due before the late fee is charged
+	Amount      float64   // flat late fee
+	Formula     string    // RPN formula, overrides Amount when present
+	MaxAmount   float64   // the most that is charged for one late fee, 0 = no limit
+	LastModTime time.Time // when was this record last written
+	LastModBy   int64     // employee UID (from phonebook) that modified it
+	CreateTS    time.Time // when was this record created
+	CreateBy    int64     // employee UID (from phonebook) that created it
+}
+
+// CommissionLedger is a commission owed to a leasing agent or referrer for a
+// Rental Agreement. If Percent is non-zero, Amount was computed as that
+// percentage of the rent for the agreement's first term.
+type CommissionLedger struct {
+	CLID           int64     // unique id for this commission
+	BID            int64     // Business
+	RAID           int64     // the Rental Agreement that earned the commission
+	RID            int64     // the Rentable rented
+	Salesperson    string    // who referred
+	UID            int64     // the leasing agent (Accord Directory UserID), 0 if the Salesperson is not an agent
+	TCID           int64     // the referrer, if they are a Transactant
+	Percent        float64   // percent of the first term's rent, 0 = flat Amount
+	Amount         float64   // the commission owed
+	PaymentDueDate time.Time // when the commission should be paid
+	FLAGS          uint64    // see CLPAID
+	DtPaid         time.Time // date the commission was paid
+	JID            int64     // Journal entry for the payment
+	LastModTime    time.Time // when was this record last written
+	LastModBy      int64     // employee UID (from phonebook) that modified it
+	CreateTS       time.Time // when was this record created
+	CreateBy       int64     // employee UID (from phonebook) that created it
+}
+
+// CLPAID is the bit of CommissionLedger.FLAGS set when the commission has been paid
+const CLPAID = 1 << 0
+
 // AR is the table that defines the AcctRules for Assessments and Receipts
 type AR struct {
 	ARID        int64
@@ -693,12 +841,18 @@ type AR struct {
 	RARequired  int64
 	DtStart     time.Time
 	DtStop      time.Time
+	FLAGS       uint64 // bit 0 = this rule assesses rent
 	LastModTime time.Time
 	LastModBy   int64
 	CreateTS    time.Time // when was this record created
 	CreateBy    int64     // employee UID (from phonebook) that created it
 }
 
+// ARRENTASSESSMENT is bit 0 of AR.FLAGS. It marks an account rule that assesses
+// rent. Only rent is taxed by the taxes of a Rentable's type, and only rent is
+// changed by rent increases and renewals.
+const ARRENTASSESSMENT = 1 << 0
+
 // Business is the set of attributes describing a rental or hotel Business
 type Business struct {
 	BID                   int64
@@ -846,6 +1000,7 @@ type Invoice struct {
 	DtDue       time.Time           // Date when the invoice is due
 	Amount      float64             // total amount of all assessments in this invoice
 	DeliveredBy string              // mail, FedEx, UPS, email, fax, hand delivered, carrier pigeon :-) ...
+	FLAGS       uint64              // bit 0 = paid, bit 1 = void
 	LastModTime time.Time           // when was this record last written
 	LastModBy   int64               // employee UID (from phonebook) that modified it
 	A           []InvoiceAssessment // list of assessments in this invoice
@@ -918,9 +1073,9 @@ type RentableMarketRate struct {
 	CreateBy   int64     // employee UID (from phonebook) that created it
 }
 
-// RentableTypeTax - the time based attribute for whether the rental agreement is taxable
+// RentableTypeTax - the time based attribute for which taxes apply to a rentable type
 type RentableTypeTax struct {
-	RAID     int64     //associated rental agreement
+	RTID     int64     // associated rentable type
 	BID      int64     // Business
 	DtStart  time.Time // start date/time for this Payor
 	DtStop   time.Time // stop date/time
@@ -936,6 +1091,7 @@ type Rentable struct {
 	BID            int64             // Business
 	RentableName   string            // name for this rentable
 	AssignmentTime int64             // can we pre-assign or assign only at commencement
+	BLDGID         int64             // Building where this Rentable is located, 0 = none
 	LastModTime    time.Time         // time of last update to the db record
 	LastModBy      int64             // who made the update (Phonebook UID)
 	RT             []RentableTypeRef // the list of RTIDs and timestamps for this Rentable
@@ -1001,6 +1157,193 @@ type RentableStatus struct {
 	CreateBy         int64     // employee UID (from phonebook) that created it
 }
 
+// ServiceRequest is a work order against a Rentable
+type ServiceRequest struct {
+	SRID            int64     // unique id for this service request
+	BID             int64     // the Business
+	RID             int64     // the Rentable needing service
+	TCID            int64     // Transactant who reported it, 0 if reported by staff
+	CategorySLSID   int64     // category, id of a string in a StringList
+	Priority        int64     // SRPRIORITYLOW ... SRPRIORITYEMERGENCY
+	Description     string    // what needs to be done
+	Assignee        int64     // Accord Directory UserID of the person doing the work
+	Status          int64     // SROPEN ... SRCANCELLED
+	DtReported      time.Time // when the request was made
+	DtCompleted     time.Time // when the work was completed
+	CompletionNotes string    // what was done
+	ASMID           int64     // Assessment billing the tenant for the work, 0 if not billed
+	MSID            int64     // MaintenanceSchedule that generated it, 0 if it was reported
+	DtDue           time.Time // when scheduled maintenance is due
+	LastModTime     time.Time // when was this record last written
+	LastModBy       int64     // employee UID (from phonebook) that modified it
+	CreateTS        time.Time // when was this record created
+	CreateBy        int64     // employee UID (from phonebook) that created it
+}
+
+// SRPRIORITYLOW and the others are the priorities of a ServiceRequest
+const (
+	SRPRIORITYLOW       = 0
+	SRPRIORITYNORMAL    = 1
+	SRPRIORITYHIGH      = 2
+	SRPRIORITYEMERGENCY = 3
+)
+
+// SROPEN and the others are the states of a ServiceRequest. A request that
+// is completed or cancelled is closed and can no longer change.
+const (
+	SROPEN       = 0
+	SRASSIGNED   = 1
+	SRINPROGRESS = 2
+	SRONHOLD     = 3
+	SRCOMPLETED  = 4
+	SRCANCELLED  = 5
+)
+
+// ServiceRequestStatus records a change in the status of a ServiceRequest
+type ServiceRequestStatus struct {
+	SRSID    int64     // unique id for this status change
+	SRID     int64     // the service request
+	BID      int64     // the Business
+	Status   int64     // the new status
+	Assignee int64     // the assignee as of this change
+	Dt       time.Time // when the status changed
+	Comment  string    // reason for the change
+	CreateTS time.Time // when was this record created
+	CreateBy int64     // employee UID (from phonebook) that created it
+}
+
+// MaintenanceSchedule is recurring preventative maintenance. Exactly one of
+// RID, RTID and BLDGID is set and selects the Rentables that get a
+// ServiceRequest for each instance.
+type MaintenanceSchedule struct {
+	MSID          int64     // unique id for this schedule
+	BID           int64     // the Business
+	Name          string    // "HVAC filter change"
+	Description   string    // what needs to be done, copied to each ServiceRequest
+	CategorySLSID int64     // category of the ServiceRequests
+	Priority      int64     // priority of the ServiceRequests
+	Assignee      int64     // who is assigned the ServiceRequests, 0 = unassigned
+	RID           int64     // a single Rentable
+	RTID          int64     // every Rentable of this RentableType
+	BLDGID        int64     // every Rentable in this Building
+	Cycle         int64     // recurrence, one of the RECUR* values
+	DtStart       time.Time // first instance
+	DtStop        time.Time // no instances on or after this date
+	LeadDays      int64     // ServiceRequests are generated this many days before an instance is due
+	DtLastDue     time.Time // due date of the last instance generated
+	LastModTime   time.Time // when was this record last written
+	LastModBy     int64     // employee UID (from phonebook) that modified it
+	CreateTS      time.Time // when was this record created
+	CreateBy      int64     // employee UID (from phonebook) that created it
+}
+
+// HousekeepingStatus records a change in the housekeeping state of a Rentable
+type HousekeepingStatus struct {
+	HKSID    int64     // unique id for this state change
+	BID      int64     // the Business
+	RID      int64     // the Rentable
+	Status   int64     // HKDIRTY ... HKOUTOFORDER
+	Dt       time.Time // when the state changed
+	Comment  string    // reason for the change
+	CreateTS time.Time // when was this record created
+	CreateBy int64     // employee UID (from phonebook) that created it
+}
+
+// HKDIRTY and the others are the housekeeping states of a Rentable. A
+// Rentable with no housekeeping history is HKUNKNOWN.
+const (
+	HKUNKNOWN    = 0
+	HKDIRTY      = 1
+	HKCLEAN      = 2
+	HKINSPECTED  = 3
+	HKOUTOFORDER = 4
+)
+
+// Budget is the budgeted activity of a GL account for a month. The Amount
+// uses the sign convention of the ledger: debits are positive, credits are
+// negative.
+type Budget struct {
+	BGID        int64     // unique id for this budget entry
+	BID         int64     // Business
+	LID         int64     // the GLAccount
+	Dt          time.Time // first day of the budgeted month
+	Amount      float64   // budgeted activity for the month
+	LastModTime time.Time // when was this record last written
+	LastModBy   int64     // employee UID (from phonebook) that modified it
+	CreateTS    time.Time // when was this record created
+	CreateBy    int64     // employee UID (from phonebook) that created it
+}
+
+// RentableTypeBudget is the GSR and occupancy targets of a RentableType that
+// is managed to budget for a month
+type RentableTypeBudget struct {
+	RTBGID      int64     // unique id for this target
+	BID         int64     // Business
+	RTID        int64     // the RentableType
+	Dt          time.Time // first day of the budgeted month
+	GSR         float64   // target gross scheduled rent of all Rentables of the type
+	Occupancy   float64   // target occupancy, percent
+	LastModTime time.Time // when was this record last written
+	LastModBy   int64     // employee UID (from phonebook) that modified it
+	CreateTS    time.Time // when was this record created
+	CreateBy    int64     // employee UID (from phonebook) that created it
+}
+
+// GoodsService is an item in a business's catalog of goods and services, such
+// as a parking pass, a laundry card or an amenity fee. A sale is charged as a
+// one-time assessment using ARID and taxed using TAXID.
+type GoodsService struct {
+	GSID        int64     // unique id for this item
+	BID         int64     // Business
+	Name        string    // name of the item
+	Description string    // description of the item
+	Price       float64   // unit price
+	ARID        int64     // account rule used to assess a sale
+	TAXID       int64     // tax charged on a sale, 0 = not taxed
+	FLAGS       uint64    // bit 0: GSINACTIVE
+	LastModTime time.Time // when was this record last written
+	LastModBy   int64     // employee UID (from phonebook) that modified it
+	CreateTS    time.Time // when was this record created
+	CreateBy    int64     // employee UID (from phonebook) that created it
+}
+
+// GSINACTIVE is bit 0 of GoodsService.FLAGS. It is set when the item is no
+// longer sold. It stays in the catalog so past sales can still refer to it.
+const GSINACTIVE = 1 << 0
+
+// Role is a set of web service rights given to phonebook users
+type Role struct {
+	RoleID      int64     // unique id for this Role
+	Name        string    // "Leasing Agent", "Controller"
+	Description string    // what the Role is for
+	LastModTime time.Time // when was this record last written
+	LastModBy   int64     // employee UID (from phonebook) that modified it
+	CreateTS    time.Time // when was this record created
+	CreateBy    int64     // employee UID (from phonebook) that created it
+}
+
+// RolePermission is the right of a Role to run a command of a web service
+// for a Business. BID 0 means every Business, Service and Cmd "*" mean
+// every service and every command.
+type RolePermission struct {
+	RPID     int64     // unique id for this right
+	RoleID   int64     // the Role that has it
+	BID      int64     // the Business it applies to, 0 = every Business
+	Service  string    // web service, "*" = every service
+	Cmd      string    // command, "*" = every command
+	CreateTS time.Time // when was this record created
+	CreateBy int64     // employee UID (from phonebook) that created it
+}
+
+// UserRole gives a Role to a phonebook user
+type UserRole struct {
+	URID     int64     // unique id for this assignment
+	UID      int64     // phonebook UID of the user
+	RoleID   int64     // the Role the user has
+	CreateTS time.Time // when was this record created
+	CreateBy int64     // employee UID (from phonebook) that created it
+}
+
 // XBusiness combines the Business struct and a map of the Business's Rentable types
 type XBusiness struct {
 	P  Business
@@ -1022,8 +1365,8 @@ type Journal struct {
 	BID         int64               // unique id of Business
 	Dt          time.Time           // when this entry was made
 	Amount      float64             // the amount
-	Type        int64               // 0 = unassociated with RA, 1 means this is an assessment, 2 means it is a payment
-	ID          int64               // if Type == 0 then it is the RentableID, if Type == 1 then it is the ASMID that caused this entry, if Type ==2 then it is the RCPTID
+	Type        int64               // 0 = unassociated with RA, 1 means this is an assessment, 2 means it is a payment, 3 means it is an adjustment
+	ID          int64               // if Type == 0 then it is the RentableID, if Type == 1 then it is the ASMID that caused this entry, if Type ==2 then it is the RCPTID, if Type == 3 it is the JID being adjusted
 	Comment     string              // for notes like "prior period adjustment"
 	LastModTime time.Time           // auto updated
 	LastModBy   int64               // user making the mod
@@ -1062,6 +1405,19 @@ type JournalMarker struct {
 	CreateBy    int64     // employee UID (from phonebook) that created it
 }
 
+// AuditRecord describes a change to a Journal, JournalMarker, LedgerEntry or LedgerMarker.
+// It is read from the corresponding audit table: JournalAudit, JournalMarkerAudit, LedgerAudit
+// or LedgerMarkerAudit.
+type AuditRecord struct {
+	Table   string    // the table that was changed: Journal, JournalMarker, LedgerEntry or LedgerMarker
+	ID      int64     // JID, JMID, LEID or LMID of the record that was changed
+	BID     int64     // Business id
+	UID     int64     // UID of person making the change
+	Action  int64     // AUDITCREATE, AUDITUPDATE, AUDITDELETE
+	Comment string    // values of the record at the time of the change
+	ModTime time.Time // when the change was made
+}
+
 // LedgerEntry is the structure for LedgerEntry attributes
 type LedgerEntry struct {
 	LEID        int64
@@ -1460,6 +1816,122 @@ type RRprepSQL struct {
 	GetAssessmentFirstInstance              *sql.Stmt
 	GetDepositoryByName                     *sql.Stmt
 	GetDepositoryByLID                      *sql.Stmt
+	GetTax                                  *sql.Stmt
+	GetTaxByName                            *sql.Stmt
+	GetAllTaxes                             *sql.Stmt
+	InsertTax                               *sql.Stmt
+	UpdateTax                               *sql.Stmt
+	DeleteTax                               *sql.Stmt
+	GetTaxRate                              *sql.Stmt
+	GetTaxRates                             *sql.Stmt
+	GetTaxRateForDate                       *sql.Stmt
+	InsertTaxRate                           *sql.Stmt
+	UpdateTaxRate                           *sql.Stmt
+	DeleteTaxRate                           *sql.Stmt
+	DeleteTaxRates                          *sql.Stmt
+	GetRentableTypeTaxes                    *sql.Stmt
+	InsertRentableTypeTax                   *sql.Stmt
+	DeleteRentableTypeTax                   *sql.Stmt
+	GetAssessmentTax                        *sql.Stmt
+	InsertAssessmentTax                     *sql.Stmt
+	GetJournalMarkerByRange                 *sql.Stmt
+	GetClosedJournalMarkerForDate           *sql.Stmt
+	GetClosedJournalMarkerInRange           *sql.Stmt
+	GetClosedJournalMarkers                 *sql.Stmt
+	UpdateJournalMarker                     *sql.Stmt
+	GetJournalByASMID                       *sql.Stmt
+	GetJournalAdjustments                   *sql.Stmt
+	GetLedgerMarkerDatesAfter               *sql.Stmt
+	GetOpenLedgerMarkersOnDate              *sql.Stmt
+	GetLedgerMarker                         *sql.Stmt
+	InsertJournalAudit                      *sql.Stmt
+	InsertJournalMarkerAudit                *sql.Stmt
+	InsertLedgerAudit                       *sql.Stmt
+	InsertLedgerMarkerAudit                 *sql.Stmt
+	GetJournalAudit                         *sql.Stmt
+	GetJournalMarkerAudit                   *sql.Stmt
+	GetLedgerAudit                          *sql.Stmt
+	GetLedgerMarkerAudit                    *sql.Stmt
+	GetJournalAuditInRange                  *sql.Stmt
+	GetJournalMarkerAuditInRange            *sql.Stmt
+	GetLedgerAuditInRange                   *sql.Stmt
+	GetLedgerMarkerAuditInRange             *sql.Stmt
+	GetUnpaidAssessmentsByBusiness          *sql.Stmt
+	GetLateFeePolicy                        *sql.Stmt
+	InsertLateFeePolicy                     *sql.Stmt
+	UpdateLateFeePolicy                     *sql.Stmt
+	DeleteLateFeePolicy                     *sql.Stmt
+	GetInvoicesByDtRange                    *sql.Stmt
+	GetUndepositedReceipts                  *sql.Stmt
+	GetAllRatePlanRefs                      *sql.Stmt
+	GetProspectsByBusiness                  *sql.Stmt
+	GetCommissionLedger                     *sql.Stmt
+	GetCommissionLedgersByRAID              *sql.Stmt
+	GetUnpaidCommissionLedgers              *sql.Stmt
+	InsertCommissionLedger                  *sql.Stmt
+	UpdateCommissionLedger                  *sql.Stmt
+	DeleteCommissionLedger                  *sql.Stmt
+	GetLeadSource                           *sql.Stmt
+	GetLeadSourceByName                     *sql.Stmt
+	GetAllLeadSources                       *sql.Stmt
+	InsertLeadSource                        *sql.Stmt
+	UpdateLeadSource                        *sql.Stmt
+	DeleteLeadSource                        *sql.Stmt
+	GetServiceRequest                       *sql.Stmt
+	GetServiceRequestsByRentable            *sql.Stmt
+	GetServiceRequestsInRange               *sql.Stmt
+	GetOpenServiceRequests                  *sql.Stmt
+	InsertServiceRequest                    *sql.Stmt
+	UpdateServiceRequest                    *sql.Stmt
+	DeleteServiceRequest                    *sql.Stmt
+	GetServiceRequestStatuses               *sql.Stmt
+	InsertServiceRequestStatus              *sql.Stmt
+	DeleteServiceRequestStatuses            *sql.Stmt
+	GetAllBuildings                         *sql.Stmt
+	GetRentablesByBuilding                  *sql.Stmt
+	GetServiceRequestsBySchedule            *sql.Stmt
+	GetMaintenanceSchedule                  *sql.Stmt
+	GetAllMaintenanceSchedules              *sql.Stmt
+	InsertMaintenanceSchedule               *sql.Stmt
+	UpdateMaintenanceSchedule               *sql.Stmt
+	DeleteMaintenanceSchedule               *sql.Stmt
+	GetHousekeepingStatus                   *sql.Stmt
+	GetHousekeepingHistory                  *sql.Stmt
+	GetCurrentHousekeepingStatuses          *sql.Stmt
+	InsertHousekeepingStatus                *sql.Stmt
+	GetRole                                 *sql.Stmt
+	GetRoleByName                           *sql.Stmt
+	GetAllRoles                             *sql.Stmt
+	InsertRole                              *sql.Stmt
+	UpdateRole                              *sql.Stmt
+	DeleteRole                              *sql.Stmt
+	GetRolePermissions                      *sql.Stmt
+	GetUserPermissions                      *sql.Stmt
+	InsertRolePermission                    *sql.Stmt
+	DeleteRolePermissions                   *sql.Stmt
+	GetUserRoles                            *sql.Stmt
+	CountUserRoles                          *sql.Stmt
+	InsertUserRole                          *sql.Stmt
+	DeleteUserRoles                         *sql.Stmt
+	DeleteUserRolesByRole                   *sql.Stmt
+	GetBudget                               *sql.Stmt
+	GetBudgetForMonth                       *sql.Stmt
+	GetBudgetsByRange                       *sql.Stmt
+	InsertBudget                            *sql.Stmt
+	UpdateBudget                            *sql.Stmt
+	DeleteBudget                            *sql.Stmt
+	GetRentableTypeBudget                   *sql.Stmt
+	GetRentableTypeBudgetForMonth           *sql.Stmt
+	GetRentableTypeBudgetsByRange           *sql.Stmt
+	InsertRentableTypeBudget                *sql.Stmt
+	UpdateRentableTypeBudget                *sql.Stmt
+	DeleteRentableTypeBudget                *sql.Stmt
+	GetGoodsService                         *sql.Stmt
+	GetGoodsServiceByName                   *sql.Stmt
+	GetAllGoodsServices                     *sql.Stmt
+	InsertGoodsService                      *sql.Stmt
+	UpdateGoodsService                      *sql.Stmt
+	DeleteGoodsService                      *sql.Stmt
 }
 
 // AllTables is an array of strings containing the names of every table in the RentRoll database
@@ -1468,6 +1940,7 @@ var AllTables = []string{
 	"AssessmentTax",
 	"Assessments",
 	"AvailabilityTypes",
+	"Budget",
 	"Building",
 	"Business",
 	"BusinessAssessments",
@@ -1481,6 +1954,8 @@ var AllTables = []string{
 	"DepositPart",
 	"Depository",
 	"GLAccount",
+	"GoodsService",
+	"HousekeepingStatus",
 	"Invoice",
 	"InvoiceAssessment",
 	"InvoicePayor",
@@ -1489,11 +1964,13 @@ var AllTables = []string{
 	"JournalAudit",
 	"JournalMarker",
 	"JournalMarkerAudit",
+	"LateFeePolicy",
 	"LeadSource",
 	"LedgerAudit",
 	"LedgerEntry",
 	"LedgerMarker",
 	"LedgerMarkerAudit",
+	"MaintenanceSchedule",
 	"NoteList",
 	"NoteType",
 	"Notes",
@@ -1513,6 +1990,7 @@ var AllTables = []string{
 	"RentableSpecialty",
 	"RentableSpecialtyRef",
 	"RentableStatus",
+	"RentableTypeBudget",
 	"RentableTypeRef",
 	"RentableTypeTax",
 	"RentableTypes",
@@ -1523,12 +2001,17 @@ var AllTables = []string{
 	"RentalAgreementRentables",
 	"RentalAgreementTax",
 	"RentalAgreementTemplate",
+	"Role",
+	"RolePermission",
 	"SLString",
+	"ServiceRequest",
+	"ServiceRequestStatus",
 	"StringList",
 	"Tax",
 	"TaxRate",
 	"Transactant",
 	"User",
+	"UserRole",
 	"Vehicle",
 }
 
@@ -1555,6 +2038,7 @@ type PBprepSQL struct {
 	GetCompanyByDesignation      *sql.Stmt
 	GetCompany                   *sql.Stmt
 	GetBusinessUnitByDesignation *sql.Stmt
+	GetUserLogin                 *sql.Stmt
 }
 
 // BusinessTypeLists is a struct holding a collection of Types associated with a business
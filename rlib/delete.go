@@ -47,6 +47,15 @@ func DeleteDemandSource(id int64) error {
 	return err
 }
 
+// DeleteLeadSource deletes the LeadSource with the specified id from the database
+func DeleteLeadSource(id int64) error {
+	_, err := RRdb.Prepstmt.DeleteLeadSource.Exec(id)
+	if err != nil {
+		Ulog("Error deleting LeadSource for LSID=%d error: %v\n", id, err)
+	}
+	return err
+}
+
 // DeleteDeposit deletes the Deposit associated with the supplied id
 // For convenience, this routine calls DeleteDepositParts. The DepositParts are
 // tightly bound to the Deposit. If a Deposit is deleted, the parts should be deleted as well.
@@ -120,28 +129,40 @@ func DeleteJournalAllocations(jid int64) {
 	}
 }
 
-// DeleteJournal deletes the Journal record with the supplied jid
-func DeleteJournal(jid int64) {
+// DeleteJournal deletes the Journal record with the supplied jid.
+// uid is the user making the change.
+func DeleteJournal(jid, uid int64) {
+	j := GetJournal(jid)
 	_, err := RRdb.Prepstmt.DeleteJournal.Exec(jid)
 	if err != nil {
 		Ulog("Error deleting Journal entry for JID = %d, error: %v\n", jid, err)
+		return
 	}
+	auditJournal(&j, uid, AUDITDELETE)
 }
 
-// DeleteJournalMarker deletes the JournalMarker record for the supplied jmid
-func DeleteJournalMarker(jmid int64) {
+// DeleteJournalMarker deletes the JournalMarker record for the supplied jmid.
+// uid is the user making the change.
+func DeleteJournalMarker(jmid, uid int64) {
+	jm := GetJournalMarker(jmid)
 	_, err := RRdb.Prepstmt.DeleteJournalMarker.Exec(jmid)
 	if err != nil {
 		Ulog("Error deleting Journal marker for JID = %d, error: %v\n", jmid, err)
+		return
 	}
+	auditJournalMarker(&jm, uid, AUDITDELETE)
 }
 
-// DeleteLedgerEntry deletes the LedgerEntry record with the supplied id
-func DeleteLedgerEntry(id int64) error {
+// DeleteLedgerEntry deletes the LedgerEntry record with the supplied id.
+// uid is the user making the change.
+func DeleteLedgerEntry(id, uid int64) error {
+	l := GetLedgerEntry(id)
 	_, err := RRdb.Prepstmt.DeleteLedgerEntry.Exec(id)
 	if err != nil {
 		Ulog("Error deleting LedgerEntry for LEID = %d, error: %v\n", id, err)
+		return err
 	}
+	auditLedgerEntry(&l, uid, AUDITDELETE)
 	return err
 }
 
@@ -154,15 +175,31 @@ func DeleteLedger(lid int64) error {
 	return err
 }
 
-// DeleteLedgerMarker deletes the LedgerMarker record with the supplied lmid
-func DeleteLedgerMarker(lmid int64) error {
+// DeleteLedgerMarker deletes the LedgerMarker record with the supplied lmid.
+// uid is the user making the change.
+func DeleteLedgerMarker(lmid, uid int64) error {
+	l := GetLedgerMarker(lmid)
 	_, err := RRdb.Prepstmt.DeleteLedgerMarker.Exec(lmid)
 	if err != nil {
 		Ulog("Error deleting LedgerMarker for LEID = %d, error: %v\n", lmid, err)
+		return err
 	}
+	auditLedgerMarker(&l, uid, AUDITDELETE)
 	return err
 }
 
+// DeleteOpenLedgerMarkersOnDate deletes all the open LedgerMarkers of business bid dated dt,
+// including the sub-ledger markers for Rental Agreements. uid is the user making the change.
+func DeleteOpenLedgerMarkersOnDate(bid int64, dt *time.Time, uid int64) error {
+	m := GetOpenLedgerMarkersOnDate(bid, dt)
+	for i := 0; i < len(m); i++ {
+		if err := DeleteLedgerMarker(m[i].LMID, uid); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // DeleteNote deletes the Note with the supplied id and all its children
 // PLEASE USE DeleteNoteAndChildNotes IF POSSIBLE
 func DeleteNote(nid int64) error {
@@ -506,3 +543,151 @@ func DeletePayor(id int64) error {
 	}
 	return err
 }
+
+// DeleteRentableTypeTax removes tax taxid from RentableType rtid
+func DeleteRentableTypeTax(rtid, taxid int64) error {
+	_, err := RRdb.Prepstmt.DeleteRentableTypeTax.Exec(rtid, taxid)
+	if err != nil {
+		Ulog("Error deleting RentableTypeTax rtid=%d, taxid=%d error: %v\n", rtid, taxid, err)
+	}
+	return err
+}
+
+// DeleteRentalAgreementTax deletes the RentalAgreementTax records for rental agreement raid
+func DeleteRentalAgreementTax(raid int64) error {
+	_, err := RRdb.Prepstmt.DeleteRentalAgreementTax.Exec(raid)
+	if err != nil {
+		Ulog("Error deleting RentalAgreementTax raid=%d error: %v\n", raid, err)
+	}
+	return err
+}
+
+// DeleteTax deletes the Tax with the specified id and all of its TaxRates
+func DeleteTax(id int64) error {
+	_, err := RRdb.Prepstmt.DeleteTaxRates.Exec(id)
+	if err != nil {
+		Ulog("Error deleting TaxRates for taxid=%d error: %v\n", id, err)
+		return err
+	}
+	_, err = RRdb.Prepstmt.DeleteTax.Exec(id)
+	if err != nil {
+		Ulog("Error deleting Tax taxid=%d error: %v\n", id, err)
+	}
+	return err
+}
+
+// DeleteCommissionLedger deletes the CommissionLedger with the specified id
+func DeleteCommissionLedger(id int64) error {
+	_, err := RRdb.Prepstmt.DeleteCommissionLedger.Exec(id)
+	if err != nil {
+		Ulog("Error deleting CommissionLedger clid=%d error: %v\n", id, err)
+	}
+	return err
+}
+
+// DeleteServiceRequest deletes the ServiceRequest with the specified id along with its status history
+func DeleteServiceRequest(id int64) error {
+	_, err := RRdb.Prepstmt.DeleteServiceRequestStatuses.Exec(id)
+	if err != nil {
+		Ulog("Error deleting ServiceRequestStatus records for srid=%d error: %v\n", id, err)
+		return err
+	}
+	_, err = RRdb.Prepstmt.DeleteServiceRequest.Exec(id)
+	if err != nil {
+		Ulog("Error deleting ServiceRequest srid=%d error: %v\n", id, err)
+	}
+	return err
+}
+
+// DeleteMaintenanceSchedule deletes the MaintenanceSchedule with the supplied id. The service
+// requests it generated are kept.
+func DeleteMaintenanceSchedule(id int64) error {
+	_, err := RRdb.Prepstmt.DeleteMaintenanceSchedule.Exec(id)
+	if err != nil {
+		Ulog("Error deleting MaintenanceSchedule msid=%d error: %v\n", id, err)
+	}
+	return err
+}
+
+// DeleteBudget deletes the Budget with the supplied id
+func DeleteBudget(id int64) error {
+	_, err := RRdb.Prepstmt.DeleteBudget.Exec(id)
+	if err != nil {
+		Ulog("Error deleting Budget bgid=%d error: %v\n", id, err)
+	}
+	return err
+}
+
+// DeleteRentableTypeBudget deletes the RentableTypeBudget with the supplied id
+func DeleteRentableTypeBudget(id int64) error {
+	_, err := RRdb.Prepstmt.DeleteRentableTypeBudget.Exec(id)
+	if err != nil {
+		Ulog("Error deleting RentableTypeBudget rtbgid=%d error: %v\n", id, err)
+	}
+	return err
+}
+
+// DeleteGoodsService deletes the GoodsService with the supplied id
+func DeleteGoodsService(id int64) error {
+	_, err := RRdb.Prepstmt.DeleteGoodsService.Exec(id)
+	if err != nil {
+		Ulog("Error deleting GoodsService gsid=%d error: %v\n", id, err)
+	}
+	return err
+}
+
+// DeleteRole deletes the Role with the supplied id along with its RolePermissions
+// and every UserRole that gives it to a user
+func DeleteRole(id int64) error {
+	_, err := RRdb.Prepstmt.DeleteRolePermissions.Exec(id)
+	if err != nil {
+		Ulog("Error deleting RolePermission records for roleid=%d error: %v\n", id, err)
+		return err
+	}
+	_, err = RRdb.Prepstmt.DeleteUserRolesByRole.Exec(id)
+	if err != nil {
+		Ulog("Error deleting UserRole records for roleid=%d error: %v\n", id, err)
+		return err
+	}
+	_, err = RRdb.Prepstmt.DeleteRole.Exec(id)
+	if err != nil {
+		Ulog("Error deleting Role roleid=%d error: %v\n", id, err)
+	}
+	return err
+}
+
+// DeleteRolePermissions deletes all the RolePermissions of the Role with the supplied id
+func DeleteRolePermissions(id int64) error {
+	_, err := RRdb.Prepstmt.DeleteRolePermissions.Exec(id)
+	if err != nil {
+		Ulog("Error deleting RolePermission records for roleid=%d error: %v\n", id, err)
+	}
+	return err
+}
+
+// DeleteUserRoles deletes all the UserRoles of the phonebook user with the supplied uid
+func DeleteUserRoles(uid int64) error {
+	_, err := RRdb.Prepstmt.DeleteUserRoles.Exec(uid)
+	if err != nil {
+		Ulog("Error deleting UserRole records for uid=%d error: %v\n", uid, err)
+	}
+	return err
+}
+
+// DeleteLateFeePolicy deletes the LateFeePolicy with the specified id
+func DeleteLateFeePolicy(id int64) error {
+	_, err := RRdb.Prepstmt.DeleteLateFeePolicy.Exec(id)
+	if err != nil {
+		Ulog("Error deleting LateFeePolicy lfpid=%d error: %v\n", id, err)
+	}
+	return err
+}
+
+// DeleteTaxRate deletes the TaxRate with the specified id
+func DeleteTaxRate(id int64) error {
+	_, err := RRdb.Prepstmt.DeleteTaxRate.Exec(id)
+	if err != nil {
+		Ulog("Error deleting TaxRate trid=%d error: %v\n", id, err)
+	}
+	return err
+}
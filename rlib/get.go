@@ -19,6 +19,12 @@ func GetAR(id int64) (AR, error) {
 	return a, err
 }
 
+// IsRentAR returns true if account rule arid assesses rent
+func IsRentAR(arid int64) bool {
+	a, err := GetAR(arid)
+	return err == nil && a.FLAGS&ARRENTASSESSMENT != 0
+}
+
 // GetARByName reads a AR the structure for the supplied bid and name
 func GetARByName(id int64, name string) (AR, error) {
 	var a AR
@@ -128,6 +134,24 @@ func GetUnpaidAssessmentsByRAID(RAID int64) []Assessment {
 	return GetAssessmentsByRows(rows)
 }
 
+// GetUnpaidAssessmentsByBusiness returns the unpaid assessment instances on
+// Rental Agreements in business bid that are due before dt, ordered by RAID
+// and then by Start date.
+func GetUnpaidAssessmentsByBusiness(bid int64, dt *time.Time) []Assessment {
+	rows, err := RRdb.Prepstmt.GetUnpaidAssessmentsByBusiness.Query(bid, dt)
+	Errcheck(err)
+	return GetAssessmentsByRows(rows)
+}
+
+// GetAssessmentsByRAIDRange returns the non-recurring assessments and the
+// instances of recurring assessments for Rental Agreement raid in the
+// period d1 - d2
+func GetAssessmentsByRAIDRange(raid int64, d1, d2 *time.Time) []Assessment {
+	rows, err := RRdb.Prepstmt.GetAssessmentsByRAIDRange.Query(raid, d1, d2)
+	Errcheck(err)
+	return GetAssessmentsByRows(rows)
+}
+
 // GetAssessmentInstancesByParent for the supplied RAID
 // INPUTS
 //    id - id of Parent Assessment
@@ -186,6 +210,15 @@ func GetAssessmentDuplicate(start *time.Time, amt float64, pasmid, rid, raid, at
 	return a
 }
 
+// GetAssessmentTax returns the AssessmentTax override for tax taxid on assessment asmid.
+// If no override exists, the returned struct has ASMID == 0.
+func GetAssessmentTax(asmid, taxid int64) (AssessmentTax, error) {
+	var a AssessmentTax
+	row := RRdb.Prepstmt.GetAssessmentTax.QueryRow(asmid, taxid)
+	err := ReadAssessmentTax(row, &a)
+	return a, err
+}
+
 //=======================================================
 //  B U I L D I N G
 //=======================================================
@@ -201,6 +234,21 @@ func GetBuilding(id int64) Building {
 	return t
 }
 
+// GetAllBuildings returns the Buildings of business bid
+func GetAllBuildings(bid int64) []Building {
+	var m []Building
+	rows, err := RRdb.Prepstmt.GetAllBuildings.Query(bid)
+	Errcheck(err)
+	defer rows.Close()
+	for rows.Next() {
+		var t Building
+		Errcheck(rows.Scan(&t.BLDGID, &t.BID, &t.Address, &t.Address2, &t.City, &t.State, &t.PostalCode, &t.Country, &t.CreateTS, &t.CreateBy, &t.LastModTime, &t.LastModBy))
+		m = append(m, t)
+	}
+	Errcheck(rows.Err())
+	return m
+}
+
 //=======================================================
 //  B U S I N E S S
 //=======================================================
@@ -352,6 +400,36 @@ func GetAllDemandSources(id int64) ([]DemandSource, error) {
 	return m, err
 }
 
+//=======================================================
+//  LEAD SOURCE
+//=======================================================
+
+// GetLeadSource reads a LeadSource structure based on the supplied LeadSource id
+func GetLeadSource(id int64, t *LeadSource) {
+	ReadLeadSource(RRdb.Prepstmt.GetLeadSource.QueryRow(id), t)
+}
+
+// GetLeadSourceByName reads the LeadSource of business bid named name
+func GetLeadSourceByName(bid int64, name string, t *LeadSource) {
+	ReadLeadSource(RRdb.Prepstmt.GetLeadSourceByName.QueryRow(bid, name), t)
+}
+
+// GetAllLeadSources returns an array of LeadSource structures containing all lead sources for the supplied BID
+func GetAllLeadSources(id int64) ([]LeadSource, error) {
+	var m []LeadSource
+	rows, err := RRdb.Prepstmt.GetAllLeadSources.Query(id)
+	Errcheck(err)
+	defer rows.Close()
+
+	for rows.Next() {
+		var s LeadSource
+		ReadLeadSources(rows, &s)
+		m = append(m, s)
+	}
+	Errcheck(rows.Err())
+	return m, err
+}
+
 //=======================================================
 //  DEPOSIT
 //  Deposit, Depository, Deposit Method, DepositPart
@@ -512,6 +590,25 @@ func GetAllInvoicesInRange(bid int64, d1, d2 *time.Time) []Invoice {
 	return t
 }
 
+// GetInvoicesByDtRange returns the Invoices of business bid dated in the period d1 - d2
+func GetInvoicesByDtRange(bid int64, d1, d2 *time.Time) []Invoice {
+	var t []Invoice
+	rows, err := RRdb.Prepstmt.GetInvoicesByDtRange.Query(bid, d1, d2)
+	Errcheck(err)
+	defer rows.Close()
+	for rows.Next() {
+		var a Invoice
+		ReadInvoices(rows, &a)
+		a.A, err = GetInvoiceAssessments(a.InvoiceNo)
+		Errcheck(err)
+		a.P, err = GetInvoicePayors(a.InvoiceNo)
+		Errcheck(err)
+		t = append(t, a)
+	}
+	Errcheck(rows.Err())
+	return t
+}
+
 // GetInvoiceAssessments reads a InvoiceAssessment structure based on the supplied InvoiceAssessment DID
 func GetInvoiceAssessments(id int64) ([]InvoiceAssessment, error) {
 	var m []InvoiceAssessment
@@ -585,6 +682,52 @@ func GetJournalByReceiptID(id int64) Journal {
 	return r
 }
 
+// GetJournalByASMID returns the Journal struct for the Journal Entry that was created for
+// assessment asmid
+func GetJournalByASMID(asmid int64) Journal {
+	var r Journal
+	row := RRdb.Prepstmt.GetJournalByASMID.QueryRow(asmid)
+	ReadJournal(row, &r)
+	return r
+}
+
+// GetAssessmentTaxCharges returns the tax assessments that were booked in the
+// Journal entry of assessment asmid.
+func GetAssessmentTaxCharges(asmid int64) []Assessment {
+	var m []Assessment
+	j := GetJournalByASMID(asmid)
+	if j.JID == 0 {
+		return m
+	}
+	GetJournalAllocations(&j)
+	for i := 0; i < len(j.JA); i++ {
+		if j.JA[i].ASMID == 0 || j.JA[i].ASMID == asmid {
+			continue
+		}
+		a, _ := GetAssessment(j.JA[i].ASMID)
+		if a.FLAGS&ASMTAXCHARGE != 0 {
+			m = append(m, a)
+		}
+	}
+	return m
+}
+
+// GetJournalAdjustments returns the adjustment Journal entries that correct the Journal
+// entry jid
+func GetJournalAdjustments(jid int64) []Journal {
+	rows, err := RRdb.Prepstmt.GetJournalAdjustments.Query(jid)
+	Errcheck(err)
+	defer rows.Close()
+	var t []Journal
+	for rows.Next() {
+		var r Journal
+		ReadJournals(rows, &r)
+		t = append(t, r)
+	}
+	Errcheck(rows.Err())
+	return t
+}
+
 // GetJournalsByReceiptID returns a slice of Journal structs where it references the supplied
 // receiptID
 func GetJournalsByReceiptID(id int64) []Journal {
@@ -608,12 +751,64 @@ func GetJournalMarkers(n int64) []JournalMarker {
 	var t = []JournalMarker{}
 	for rows.Next() {
 		var r JournalMarker
-		Errcheck(rows.Scan(&r.JMID, &r.BID, &r.State, &r.DtStart, &r.DtStop, &r.CreateTS, &r.CreateBy))
+		Errcheck(ReadJournalMarkers(rows, &r))
 		t = append(t, r)
 	}
 	return t
 }
 
+// GetJournalMarker returns the JournalMarker with the supplied jmid
+func GetJournalMarker(jmid int64) JournalMarker {
+	var r JournalMarker
+	row := RRdb.Prepstmt.GetJournalMarker.QueryRow(jmid)
+	Errcheck(ReadJournalMarker(row, &r))
+	return r
+}
+
+// GetJournalMarkerByRange returns the most recent JournalMarker for business bid that
+// covers exactly the period d1 - d2
+func GetJournalMarkerByRange(bid int64, d1, d2 *time.Time) (JournalMarker, error) {
+	var r JournalMarker
+	row := RRdb.Prepstmt.GetJournalMarkerByRange.QueryRow(bid, d1, d2)
+	err := ReadJournalMarker(row, &r)
+	return r, err
+}
+
+// GetClosedJournalMarkerForDate returns the closed or locked JournalMarker for business bid
+// whose period contains dt. If dt is not in a closed period, the returned JMID is 0.
+func GetClosedJournalMarkerForDate(bid int64, dt *time.Time) JournalMarker {
+	var r JournalMarker
+	row := RRdb.Prepstmt.GetClosedJournalMarkerForDate.QueryRow(bid, dt, dt)
+	Errcheck(ReadJournalMarker(row, &r))
+	return r
+}
+
+// GetClosedJournalMarkerInRange returns the first closed or locked JournalMarker for
+// business bid whose period overlaps d1 - d2. If no part of d1 - d2 is closed, the
+// returned JMID is 0.
+func GetClosedJournalMarkerInRange(bid int64, d1, d2 *time.Time) JournalMarker {
+	var r JournalMarker
+	row := RRdb.Prepstmt.GetClosedJournalMarkerInRange.QueryRow(bid, d2, d1)
+	Errcheck(ReadJournalMarker(row, &r))
+	return r
+}
+
+// GetClosedJournalMarkers returns all the closed or locked JournalMarkers for business bid,
+// most recent period first
+func GetClosedJournalMarkers(bid int64) []JournalMarker {
+	rows, err := RRdb.Prepstmt.GetClosedJournalMarkers.Query(bid)
+	Errcheck(err)
+	defer rows.Close()
+	var t []JournalMarker
+	for rows.Next() {
+		var r JournalMarker
+		Errcheck(ReadJournalMarkers(rows, &r))
+		t = append(t, r)
+	}
+	Errcheck(rows.Err())
+	return t
+}
+
 // GetLastJournalMarker returns the last Journal marker or nil if no Journal markers exist
 func GetLastJournalMarker() JournalMarker {
 	t := GetJournalMarkers(1)
@@ -673,6 +868,30 @@ func GetLatestLedgerMarkerByLID(bid, lid int64) LedgerMarker {
 	return r
 }
 
+// GetLedgerMarker returns the LedgerMarker with the supplied lmid
+func GetLedgerMarker(lmid int64) LedgerMarker {
+	var r LedgerMarker
+	row := RRdb.Prepstmt.GetLedgerMarker.QueryRow(lmid)
+	ReadLedgerMarker(row, &r)
+	return r
+}
+
+// GetOpenLedgerMarkersOnDate returns all the open LedgerMarkers of business bid dated dt,
+// including the sub-ledger markers for Rental Agreements
+func GetOpenLedgerMarkersOnDate(bid int64, dt *time.Time) []LedgerMarker {
+	rows, err := RRdb.Prepstmt.GetOpenLedgerMarkersOnDate.Query(bid, dt)
+	Errcheck(err)
+	defer rows.Close()
+	var t []LedgerMarker
+	for rows.Next() {
+		var r LedgerMarker
+		ReadLedgerMarkers(rows, &r)
+		t = append(t, r)
+	}
+	Errcheck(rows.Err())
+	return t
+}
+
 // GetLedgerMarkerOnOrBefore returns the LedgerMarker struct for the GLAccount with the supplied LID
 func GetLedgerMarkerOnOrBefore(bid, lid int64, dt *time.Time) LedgerMarker {
 	var r LedgerMarker
@@ -681,6 +900,22 @@ func GetLedgerMarkerOnOrBefore(bid, lid int64, dt *time.Time) LedgerMarker {
 	return r
 }
 
+// GetLedgerMarkerDatesAfter returns the dates of all the open GLAccount LedgerMarkers of
+// business bid that are later than dt, in chronological order
+func GetLedgerMarkerDatesAfter(bid int64, dt *time.Time) []time.Time {
+	rows, err := RRdb.Prepstmt.GetLedgerMarkerDatesAfter.Query(bid, dt)
+	Errcheck(err)
+	defer rows.Close()
+	var t []time.Time
+	for rows.Next() {
+		var d time.Time
+		Errcheck(rows.Scan(&d))
+		t = append(t, d)
+	}
+	Errcheck(rows.Err())
+	return t
+}
+
 // // GetPayorLedgerMarkerOnOrBefore returns the LedgerMarker struct for the TCID
 // func GetPayorLedgerMarkerOnOrBefore(bid, tcid int64, dt *time.Time) LedgerMarker {
 // 	var r LedgerMarker
@@ -858,6 +1093,14 @@ func GetLedger(lid int64) GLAccount {
 	return a
 }
 
+// GetLedgerEntry returns the LedgerEntry with the supplied leid
+func GetLedgerEntry(leid int64) LedgerEntry {
+	var a LedgerEntry
+	row := RRdb.Prepstmt.GetLedgerEntry.QueryRow(leid)
+	ReadLedgerEntry(row, &a)
+	return a
+}
+
 // GetLedgerEntryByJAID returns the GLAccount struct for the supplied LID
 func GetLedgerEntryByJAID(bid, lid, jaid int64) LedgerEntry {
 	var a LedgerEntry
@@ -1174,17 +1417,27 @@ func GetRatePlanRefFull(id int64, a *RatePlanRef) {
 		ReadRatePlanRefRTRates(rows, &p)
 		a.RT = append(a.RT, p)
 	}
-	// now load all Specialty rates
-	rows, err = RRdb.Prepstmt.GetAllRatePlanRefSPRates.Query(a.RPRID, a.RPID)
+	// now load all Specialty rates for each RentableType
+	for i := 0; i < len(a.RT); i++ {
+		a.SP = append(a.SP, GetAllRatePlanRefSPRates(a.RPRID, a.RT[i].RTID)...)
+	}
+}
+
+// GetAllRatePlanRefs returns all the RatePlanRefs for RatePlan rpid in DtStart order
+func GetAllRatePlanRefs(rpid int64) []RatePlanRef {
+	var m []RatePlanRef
+	rows, err := RRdb.Prepstmt.GetAllRatePlanRefs.Query(rpid)
 	if err != nil {
-		Ulog("GetRatePlanRefFull: GetAllRatePlanRefSPRates - error = %s\n", err.Error())
-		return
+		Ulog("GetAllRatePlanRefs: error = %s\n", err.Error())
+		return m
 	}
+	defer rows.Close()
 	for rows.Next() {
-		var p RatePlanRefSPRate
-		ReadRatePlanRefSPRates(rows, &p)
-		a.SP = append(a.SP, p)
+		var a RatePlanRef
+		ReadRatePlanRefs(rows, &a)
+		m = append(m, a)
 	}
+	return m
 }
 
 // GetRatePlanRefsInRange reads a RatePlanRef structure based on the supplied RatePlan id and the date.
@@ -1225,9 +1478,9 @@ func GetRatePlanRefRTRate(rprid, rtid int64, a *RatePlanRefRTRate) {
 	ReadRatePlanRefRTRate(row, a)
 }
 
-// GetRatePlanRefSPRate reads the RatePlanRefSPRate struct for the supplied rprid and rtid
-func GetRatePlanRefSPRate(rprid, rtid int64, a *RatePlanRefSPRate) {
-	row := RRdb.Prepstmt.GetRatePlanRefSPRate.QueryRow(rprid, rtid)
+// GetRatePlanRefSPRate reads the RatePlanRefSPRate struct for the supplied rprid, rtid and rspid
+func GetRatePlanRefSPRate(rprid, rtid, rspid int64, a *RatePlanRefSPRate) {
+	row := RRdb.Prepstmt.GetRatePlanRefSPRate.QueryRow(rprid, rtid, rspid)
 	ReadRatePlanRefSPRate(row, a)
 }
 
@@ -1314,6 +1567,23 @@ func GetReceipts(bid int64, d1, d2 *time.Time) []Receipt {
 	return t
 }
 
+// GetUndepositedReceipts returns the receipts of business bid that have not yet
+// been deposited and that are to be deposited in Depository depid or that have
+// no Depository. Voided receipts are not included.
+func GetUndepositedReceipts(bid, depid int64) []Receipt {
+	rows, err := RRdb.Prepstmt.GetUndepositedReceipts.Query(bid, depid)
+	Errcheck(err)
+	defer rows.Close()
+	var t []Receipt
+	for rows.Next() {
+		var r Receipt
+		ReadReceipts(rows, &r)
+		t = append(t, r)
+	}
+	Errcheck(rows.Err())
+	return t
+}
+
 // GetASMReceiptAllocationsInRAIDDateRange for the supplied RentalAgreement in date range [d1 - d2).
 // To do this we select all the ReceiptAllocations that occurred during d1-d2 that involved
 // raid.
@@ -1396,6 +1666,33 @@ func GetRentableByName(name string, bid int64) (Rentable, error) {
 	return r, err
 }
 
+// GetAllRentablesByBusiness returns all the Rentables of business bid
+func GetAllRentablesByBusiness(bid int64) []Rentable {
+	rows, err := RRdb.Prepstmt.GetAllRentablesByBusiness.Query(bid)
+	Errcheck(err)
+	return getRentables(rows)
+}
+
+// GetRentablesByBuilding returns the Rentables located in Building bldgid
+func GetRentablesByBuilding(bldgid int64) []Rentable {
+	rows, err := RRdb.Prepstmt.GetRentablesByBuilding.Query(bldgid)
+	Errcheck(err)
+	return getRentables(rows)
+}
+
+// getRentables reads the Rentable records from rows
+func getRentables(rows *sql.Rows) []Rentable {
+	var m []Rentable
+	defer rows.Close()
+	for rows.Next() {
+		var r Rentable
+		Errcheck(ReadRentables(rows, &r))
+		m = append(m, r)
+	}
+	Errcheck(rows.Err())
+	return m
+}
+
 // GetRentableTypeDown returns the values needed for typedown controls:
 // input:   bid - business
 //            s - string or substring to search for
@@ -1591,6 +1888,21 @@ func GetRentableTypeRefForDate(RID int64, d1 *time.Time) RentableTypeRef {
 	return r
 }
 
+// GetRentableTypeTaxes returns the RentableTypeTax records for RentableType rtid in effect on dt
+func GetRentableTypeTaxes(rtid int64, dt *time.Time) []RentableTypeTax {
+	var m []RentableTypeTax
+	rows, err := RRdb.Prepstmt.GetRentableTypeTaxes.Query(rtid, dt, dt)
+	Errcheck(err)
+	defer rows.Close()
+	for rows.Next() {
+		var a RentableTypeTax
+		Errcheck(ReadRentableTypeTaxes(rows, &a))
+		m = append(m, a)
+	}
+	Errcheck(rows.Err())
+	return m
+}
+
 // GetRentableStatus gets RentableStatus record for given RSID -- RentableStatus ID (unique ID)
 func GetRentableStatus(rsid int64) (RentableStatus, error) {
 	var rs RentableStatus
@@ -1734,6 +2046,22 @@ func GetRentableUsersInRange(rid int64, d1, d2 *time.Time) []RentableUser {
 //  R E N T A L   A G R E E M E N T
 //=======================================================
 
+// GetAllRentalAgreementsByRange returns the RentalAgreements of business bid that
+// are in effect during part of the period d1 - d2
+func GetAllRentalAgreementsByRange(bid int64, d1, d2 *time.Time) []RentalAgreement {
+	var m []RentalAgreement
+	rows, err := RRdb.Prepstmt.GetAllRentalAgreementsByRange.Query(bid, d1, d2)
+	Errcheck(err)
+	defer rows.Close()
+	for rows.Next() {
+		var a RentalAgreement
+		Errcheck(ReadRentalAgreements(rows, &a))
+		m = append(m, a)
+	}
+	Errcheck(rows.Err())
+	return m
+}
+
 // GetRentalAgreement returns the RentalAgreement struct for the supplied rental agreement id
 func GetRentalAgreement(raid int64) (RentalAgreement, error) {
 	var r RentalAgreement
@@ -1898,6 +2226,21 @@ func GetRentalAgreementPayorsByRows(rows *sql.Rows) []RentalAgreementPayor {
 //  RENTAL AGREEMENT TEMPLATE
 //=======================================================
 
+// GetRentalAgreementTaxes returns the RentalAgreementTax records for rental agreement raid in effect on dt
+func GetRentalAgreementTaxes(raid int64, dt *time.Time) []RentalAgreementTax {
+	var m []RentalAgreementTax
+	rows, err := RRdb.Prepstmt.GetRentalAgreementTax.Query(raid, dt, dt)
+	Errcheck(err)
+	defer rows.Close()
+	for rows.Next() {
+		var a RentalAgreementTax
+		Errcheck(ReadRentalAgreementTaxes(rows, &a))
+		m = append(m, a)
+	}
+	Errcheck(rows.Err())
+	return m
+}
+
 // GetRentalAgreementTemplate returns the RentalAgreementTemplate struct for the supplied rental agreement id
 func GetRentalAgreementTemplate(ratid int64) RentalAgreementTemplate {
 	var r RentalAgreementTemplate
@@ -1964,6 +2307,436 @@ func GetSLStrings(id int64, a *StringList) {
 	Errcheck(rows.Err())
 }
 
+// GetSLString reads the SLString with the supplied SLSID
+func GetSLString(id int64, a *SLString) {
+	ReadSLString(RRdb.Prepstmt.GetSLString.QueryRow(id), a)
+}
+
+//=======================================================
+//  T A X
+//=======================================================
+
+// GetTax reads the Tax with the supplied TAXID
+func GetTax(id int64) (Tax, error) {
+	var a Tax
+	row := RRdb.Prepstmt.GetTax.QueryRow(id)
+	err := ReadTax(row, &a)
+	return a, err
+}
+
+// GetLateFeePolicy reads the LateFeePolicy for business bid. If the business
+// does not have a policy, the returned LFPID is 0.
+func GetLateFeePolicy(bid int64) (LateFeePolicy, error) {
+	var a LateFeePolicy
+	row := RRdb.Prepstmt.GetLateFeePolicy.QueryRow(bid)
+	err := ReadLateFeePolicy(row, &a)
+	return a, err
+}
+
+// GetCommissionLedger reads the CommissionLedger with the supplied CLID
+func GetCommissionLedger(id int64) (CommissionLedger, error) {
+	var a CommissionLedger
+	row := RRdb.Prepstmt.GetCommissionLedger.QueryRow(id)
+	err := ReadCommissionLedger(row, &a)
+	return a, err
+}
+
+// getCommissionLedgers reads the CommissionLedger records from rows
+func getCommissionLedgers(rows *sql.Rows) []CommissionLedger {
+	var m []CommissionLedger
+	defer rows.Close()
+	for rows.Next() {
+		var a CommissionLedger
+		Errcheck(ReadCommissionLedgers(rows, &a))
+		m = append(m, a)
+	}
+	Errcheck(rows.Err())
+	return m
+}
+
+// GetCommissionLedgersByRAID returns the commissions for Rental Agreement raid
+func GetCommissionLedgersByRAID(raid int64) []CommissionLedger {
+	rows, err := RRdb.Prepstmt.GetCommissionLedgersByRAID.Query(raid)
+	Errcheck(err)
+	return getCommissionLedgers(rows)
+}
+
+// GetUnpaidCommissionLedgers returns the unpaid commissions of business bid
+// that are due before dt, earliest due first.
+func GetUnpaidCommissionLedgers(bid int64, dt *time.Time) []CommissionLedger {
+	rows, err := RRdb.Prepstmt.GetUnpaidCommissionLedgers.Query(bid, dt)
+	Errcheck(err)
+	return getCommissionLedgers(rows)
+}
+
+// GetServiceRequest reads the ServiceRequest with the supplied SRID
+func GetServiceRequest(id int64) (ServiceRequest, error) {
+	var a ServiceRequest
+	row := RRdb.Prepstmt.GetServiceRequest.QueryRow(id)
+	err := ReadServiceRequest(row, &a)
+	return a, err
+}
+
+// getServiceRequests reads the ServiceRequest records from rows
+func getServiceRequests(rows *sql.Rows) []ServiceRequest {
+	var m []ServiceRequest
+	defer rows.Close()
+	for rows.Next() {
+		var a ServiceRequest
+		Errcheck(ReadServiceRequests(rows, &a))
+		m = append(m, a)
+	}
+	Errcheck(rows.Err())
+	return m
+}
+
+// GetServiceRequestsByRentable returns the service requests for Rentable rid, most recent first
+func GetServiceRequestsByRentable(rid int64) []ServiceRequest {
+	rows, err := RRdb.Prepstmt.GetServiceRequestsByRentable.Query(rid)
+	Errcheck(err)
+	return getServiceRequests(rows)
+}
+
+// GetServiceRequestsInRange returns the service requests of business bid
+// reported during d1 - d2
+func GetServiceRequestsInRange(bid int64, d1, d2 *time.Time) []ServiceRequest {
+	rows, err := RRdb.Prepstmt.GetServiceRequestsInRange.Query(bid, d1, d2)
+	Errcheck(err)
+	return getServiceRequests(rows)
+}
+
+// GetServiceRequestsBySchedule returns the service requests generated by
+// MaintenanceSchedule msid, earliest due first
+func GetServiceRequestsBySchedule(msid int64) []ServiceRequest {
+	rows, err := RRdb.Prepstmt.GetServiceRequestsBySchedule.Query(msid)
+	Errcheck(err)
+	return getServiceRequests(rows)
+}
+
+// GetOpenServiceRequests returns the service requests of business bid that
+// are neither completed nor cancelled, highest priority first
+func GetOpenServiceRequests(bid int64) []ServiceRequest {
+	rows, err := RRdb.Prepstmt.GetOpenServiceRequests.Query(bid)
+	Errcheck(err)
+	return getServiceRequests(rows)
+}
+
+// GetServiceRequestStatuses returns the status history of service request srid, oldest first
+func GetServiceRequestStatuses(srid int64) []ServiceRequestStatus {
+	var m []ServiceRequestStatus
+	rows, err := RRdb.Prepstmt.GetServiceRequestStatuses.Query(srid)
+	Errcheck(err)
+	defer rows.Close()
+	for rows.Next() {
+		var a ServiceRequestStatus
+		Errcheck(ReadServiceRequestStatuses(rows, &a))
+		m = append(m, a)
+	}
+	Errcheck(rows.Err())
+	return m
+}
+
+// GetMaintenanceSchedule reads the MaintenanceSchedule with the supplied MSID
+func GetMaintenanceSchedule(id int64) (MaintenanceSchedule, error) {
+	var a MaintenanceSchedule
+	row := RRdb.Prepstmt.GetMaintenanceSchedule.QueryRow(id)
+	err := ReadMaintenanceSchedule(row, &a)
+	return a, err
+}
+
+// GetAllMaintenanceSchedules returns the maintenance schedules of business bid
+func GetAllMaintenanceSchedules(bid int64) []MaintenanceSchedule {
+	var m []MaintenanceSchedule
+	rows, err := RRdb.Prepstmt.GetAllMaintenanceSchedules.Query(bid)
+	Errcheck(err)
+	defer rows.Close()
+	for rows.Next() {
+		var a MaintenanceSchedule
+		Errcheck(ReadMaintenanceSchedules(rows, &a))
+		m = append(m, a)
+	}
+	Errcheck(rows.Err())
+	return m
+}
+
+// GetHousekeepingStatus returns the current housekeeping state of Rentable
+// rid. If it has no history the returned Status is HKUNKNOWN.
+func GetHousekeepingStatus(rid int64) HousekeepingStatus {
+	var a HousekeepingStatus
+	row := RRdb.Prepstmt.GetHousekeepingStatus.QueryRow(rid)
+	err := ReadHousekeepingStatus(row, &a)
+	if err != nil && !IsSQLNoResultsError(err) {
+		Ulog("GetHousekeepingStatus: err = %v\n", err)
+	}
+	return a
+}
+
+// getHousekeepingStatuses reads the HousekeepingStatus records from rows
+func getHousekeepingStatuses(rows *sql.Rows) []HousekeepingStatus {
+	var m []HousekeepingStatus
+	defer rows.Close()
+	for rows.Next() {
+		var a HousekeepingStatus
+		Errcheck(ReadHousekeepingStatuses(rows, &a))
+		m = append(m, a)
+	}
+	Errcheck(rows.Err())
+	return m
+}
+
+// GetHousekeepingHistory returns the housekeeping state changes of Rentable
+// rid during d1 - d2, most recent first
+func GetHousekeepingHistory(rid int64, d1, d2 *time.Time) []HousekeepingStatus {
+	rows, err := RRdb.Prepstmt.GetHousekeepingHistory.Query(rid, d1, d2)
+	Errcheck(err)
+	return getHousekeepingStatuses(rows)
+}
+
+// GetCurrentHousekeepingStatuses returns the current housekeeping state of
+// every Rentable of business bid that has a housekeeping history, indexed by RID
+func GetCurrentHousekeepingStatuses(bid int64) map[int64]HousekeepingStatus {
+	t := map[int64]HousekeepingStatus{}
+	rows, err := RRdb.Prepstmt.GetCurrentHousekeepingStatuses.Query(bid)
+	Errcheck(err)
+	m := getHousekeepingStatuses(rows)
+	for i := 0; i < len(m); i++ {
+		t[m[i].RID] = m[i]
+	}
+	return t
+}
+
+// GetBudget reads the Budget with the supplied BGID
+func GetBudget(id int64) (Budget, error) {
+	var a Budget
+	row := RRdb.Prepstmt.GetBudget.QueryRow(id)
+	err := ReadBudget(row, &a)
+	return a, err
+}
+
+// GetBudgetForMonth reads the Budget of GLAccount lid for the month starting on dt
+func GetBudgetForMonth(lid int64, dt *time.Time) (Budget, error) {
+	var a Budget
+	row := RRdb.Prepstmt.GetBudgetForMonth.QueryRow(lid, dt)
+	err := ReadBudget(row, &a)
+	return a, err
+}
+
+// GetBudgetsByRange returns the Budgets of business bid for the months starting
+// in d1 - d2, sorted by GLAccount and month
+func GetBudgetsByRange(bid int64, d1, d2 *time.Time) []Budget {
+	var m []Budget
+	rows, err := RRdb.Prepstmt.GetBudgetsByRange.Query(bid, d1, d2)
+	Errcheck(err)
+	defer rows.Close()
+	for rows.Next() {
+		var a Budget
+		Errcheck(ReadBudgets(rows, &a))
+		m = append(m, a)
+	}
+	Errcheck(rows.Err())
+	return m
+}
+
+// GetRentableTypeBudget reads the RentableTypeBudget with the supplied RTBGID
+func GetRentableTypeBudget(id int64) (RentableTypeBudget, error) {
+	var a RentableTypeBudget
+	row := RRdb.Prepstmt.GetRentableTypeBudget.QueryRow(id)
+	err := ReadRentableTypeBudget(row, &a)
+	return a, err
+}
+
+// GetRentableTypeBudgetForMonth reads the RentableTypeBudget of RentableType rtid for the
+// month starting on dt
+func GetRentableTypeBudgetForMonth(rtid int64, dt *time.Time) (RentableTypeBudget, error) {
+	var a RentableTypeBudget
+	row := RRdb.Prepstmt.GetRentableTypeBudgetForMonth.QueryRow(rtid, dt)
+	err := ReadRentableTypeBudget(row, &a)
+	return a, err
+}
+
+// GetRentableTypeBudgetsByRange returns the RentableTypeBudgets of business bid for the
+// months starting in d1 - d2, sorted by RentableType and month
+func GetRentableTypeBudgetsByRange(bid int64, d1, d2 *time.Time) []RentableTypeBudget {
+	var m []RentableTypeBudget
+	rows, err := RRdb.Prepstmt.GetRentableTypeBudgetsByRange.Query(bid, d1, d2)
+	Errcheck(err)
+	defer rows.Close()
+	for rows.Next() {
+		var a RentableTypeBudget
+		Errcheck(ReadRentableTypeBudgets(rows, &a))
+		m = append(m, a)
+	}
+	Errcheck(rows.Err())
+	return m
+}
+
+// GetGoodsService reads the GoodsService with the supplied GSID
+func GetGoodsService(id int64) (GoodsService, error) {
+	var a GoodsService
+	row := RRdb.Prepstmt.GetGoodsService.QueryRow(id)
+	err := ReadGoodsService(row, &a)
+	return a, err
+}
+
+// GetGoodsServiceByName reads the GoodsService of business bid with the supplied name
+func GetGoodsServiceByName(bid int64, name string) (GoodsService, error) {
+	var a GoodsService
+	row := RRdb.Prepstmt.GetGoodsServiceByName.QueryRow(bid, name)
+	err := ReadGoodsService(row, &a)
+	return a, err
+}
+
+// GetAllGoodsServices returns the catalog of goods and services of business bid, sorted by name
+func GetAllGoodsServices(bid int64) []GoodsService {
+	var m []GoodsService
+	rows, err := RRdb.Prepstmt.GetAllGoodsServices.Query(bid)
+	Errcheck(err)
+	defer rows.Close()
+	for rows.Next() {
+		var a GoodsService
+		Errcheck(ReadGoodsServices(rows, &a))
+		m = append(m, a)
+	}
+	Errcheck(rows.Err())
+	return m
+}
+
+// GetRole reads the Role with the supplied RoleID
+func GetRole(id int64) (Role, error) {
+	var a Role
+	row := RRdb.Prepstmt.GetRole.QueryRow(id)
+	err := ReadRole(row, &a)
+	return a, err
+}
+
+// GetRoleByName reads the Role with the supplied name
+func GetRoleByName(name string) (Role, error) {
+	var a Role
+	row := RRdb.Prepstmt.GetRoleByName.QueryRow(name)
+	err := ReadRole(row, &a)
+	return a, err
+}
+
+// GetAllRoles returns all the Roles sorted by name
+func GetAllRoles() []Role {
+	var m []Role
+	rows, err := RRdb.Prepstmt.GetAllRoles.Query()
+	Errcheck(err)
+	defer rows.Close()
+	for rows.Next() {
+		var a Role
+		Errcheck(ReadRoles(rows, &a))
+		m = append(m, a)
+	}
+	Errcheck(rows.Err())
+	return m
+}
+
+// getRolePermissions reads the RolePermission records from rows
+func getRolePermissions(rows *sql.Rows) []RolePermission {
+	var m []RolePermission
+	defer rows.Close()
+	for rows.Next() {
+		var a RolePermission
+		Errcheck(ReadRolePermissions(rows, &a))
+		m = append(m, a)
+	}
+	Errcheck(rows.Err())
+	return m
+}
+
+// GetRolePermissions returns the RolePermissions of Role roleid
+func GetRolePermissions(roleid int64) []RolePermission {
+	rows, err := RRdb.Prepstmt.GetRolePermissions.Query(roleid)
+	Errcheck(err)
+	return getRolePermissions(rows)
+}
+
+// GetUserPermissions returns the RolePermissions of every Role of phonebook user uid
+func GetUserPermissions(uid int64) []RolePermission {
+	rows, err := RRdb.Prepstmt.GetUserPermissions.Query(uid)
+	Errcheck(err)
+	return getRolePermissions(rows)
+}
+
+// GetUserRoles returns the UserRoles of phonebook user uid
+func GetUserRoles(uid int64) []UserRole {
+	var m []UserRole
+	rows, err := RRdb.Prepstmt.GetUserRoles.Query(uid)
+	Errcheck(err)
+	defer rows.Close()
+	for rows.Next() {
+		var a UserRole
+		Errcheck(ReadUserRoles(rows, &a))
+		m = append(m, a)
+	}
+	Errcheck(rows.Err())
+	return m
+}
+
+// GetCountUserRoles returns the number of UserRoles, that is the number of
+// Roles given to users
+func GetCountUserRoles() int {
+	var count int
+	Errcheck(RRdb.Prepstmt.CountUserRoles.QueryRow().Scan(&count))
+	return count
+}
+
+// GetTaxByName reads the Tax with the supplied name in business bid
+func GetTaxByName(bid int64, name string) (Tax, error) {
+	var a Tax
+	row := RRdb.Prepstmt.GetTaxByName.QueryRow(bid, name)
+	err := ReadTax(row, &a)
+	return a, err
+}
+
+// GetAllTaxes returns all the Taxes defined for business bid
+func GetAllTaxes(bid int64) []Tax {
+	var m []Tax
+	rows, err := RRdb.Prepstmt.GetAllTaxes.Query(bid)
+	Errcheck(err)
+	defer rows.Close()
+	for rows.Next() {
+		var a Tax
+		Errcheck(ReadTaxes(rows, &a))
+		m = append(m, a)
+	}
+	Errcheck(rows.Err())
+	return m
+}
+
+// GetTaxRate reads the TaxRate with the supplied TRID
+func GetTaxRate(id int64) (TaxRate, error) {
+	var a TaxRate
+	row := RRdb.Prepstmt.GetTaxRate.QueryRow(id)
+	err := ReadTaxRate(row, &a)
+	return a, err
+}
+
+// GetTaxRates returns all the TaxRates for tax taxid ordered by DtStart
+func GetTaxRates(taxid int64) []TaxRate {
+	var m []TaxRate
+	rows, err := RRdb.Prepstmt.GetTaxRates.Query(taxid)
+	Errcheck(err)
+	defer rows.Close()
+	for rows.Next() {
+		var a TaxRate
+		Errcheck(ReadTaxRates(rows, &a))
+		m = append(m, a)
+	}
+	Errcheck(rows.Err())
+	return m
+}
+
+// GetTaxRateForDate returns the TaxRate for tax taxid that is in effect on dt.
+// If no rate is in effect, the returned struct has TRID == 0.
+func GetTaxRateForDate(taxid int64, dt *time.Time) (TaxRate, error) {
+	var a TaxRate
+	row := RRdb.Prepstmt.GetTaxRateForDate.QueryRow(taxid, dt, dt)
+	err := ReadTaxRate(row, &a)
+	return a, err
+}
+
 //=======================================================
 //  TRANSACTANT
 //  Transactant, Prospect, User, Payor, XPerson
@@ -2035,6 +2808,21 @@ func GetProspect(id int64, p *Prospect) {
 	ReadProspect(RRdb.Prepstmt.GetProspect.QueryRow(id), p)
 }
 
+// GetProspectsByBusiness returns all the Prospects of business bid
+func GetProspectsByBusiness(bid int64) []Prospect {
+	var m []Prospect
+	rows, err := RRdb.Prepstmt.GetProspectsByBusiness.Query(bid)
+	Errcheck(err)
+	defer rows.Close()
+	for rows.Next() {
+		var p Prospect
+		ReadProspects(rows, &p)
+		m = append(m, p)
+	}
+	Errcheck(rows.Err())
+	return m
+}
+
 // GetUser reads a User structure based on the supplied User id.
 // This call does not load the vehicle list.  You can use GetVehiclesByTransactant()
 // if you need them.  Or you can call GetXPerson, which loads all details about a Transactant.
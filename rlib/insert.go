@@ -4,7 +4,7 @@ package rlib
 // the ARID field is set to its new value.
 func InsertAR(a *AR) (int64, error) {
 	var rid = int64(0)
-	res, err := RRdb.Prepstmt.InsertAR.Exec(a.BID, a.Name, a.ARType, a.DebitLID, a.CreditLID, a.Description, a.RARequired, a.DtStart, a.DtStop, a.CreateBy, a.LastModBy)
+	res, err := RRdb.Prepstmt.InsertAR.Exec(a.BID, a.Name, a.ARType, a.DebitLID, a.CreditLID, a.Description, a.RARequired, a.DtStart, a.DtStop, a.FLAGS, a.CreateBy, a.LastModBy)
 	if nil == err {
 		id, err := res.LastInsertId()
 		if err == nil {
@@ -46,6 +46,16 @@ func InsertAssessment(a *Assessment) (int64, error) {
 	return rid, err
 }
 
+// InsertAssessmentTax writes a new AssessmentTax record to the database
+func InsertAssessmentTax(a *AssessmentTax) error {
+	_, err := RRdb.Prepstmt.InsertAssessmentTax.Exec(a.ASMID, a.BID, a.TAXID, a.FLAGS, a.OverrideTaxApprover, a.OverrideAmount, a.CreateBy, a.LastModBy)
+	if nil != err {
+		Ulog("InsertAssessmentTax: error inserting AssessmentTax:  %v\n", err)
+		Ulog("AssessmentTax = %#v\n", *a)
+	}
+	return err
+}
+
 // InsertBuilding writes a new Building record to the database
 func InsertBuilding(a *Building) (int64, error) {
 	var rid = int64(0)
@@ -133,6 +143,23 @@ func InsertDemandSource(a *DemandSource) (int64, error) {
 	return tid, err
 }
 
+// InsertLeadSource writes a new LeadSource record to the database
+func InsertLeadSource(a *LeadSource) (int64, error) {
+	var tid = int64(0)
+	res, err := RRdb.Prepstmt.InsertLeadSource.Exec(a.BID, a.Name, a.IndustrySLID, a.CreateBy, a.LastModBy)
+	if nil == err {
+		id, err := res.LastInsertId()
+		if err == nil {
+			tid = int64(id)
+			a.LSID = tid
+		}
+	} else {
+		Ulog("InsertLeadSource: error inserting LeadSource:  %v\n", err)
+		Ulog("LeadSource = %#v\n", *a)
+	}
+	return tid, err
+}
+
 // InsertDeposit writes a new Deposit record to the database
 func InsertDeposit(a *Deposit) (int64, error) {
 	var rid = int64(0)
@@ -192,7 +219,7 @@ func InsertDepository(a *Depository) (int64, error) {
 // InsertInvoice writes a new Invoice record to the database
 func InsertInvoice(a *Invoice) (int64, error) {
 	var rid = int64(0)
-	res, err := RRdb.Prepstmt.InsertInvoice.Exec(a.BID, a.Dt, a.DtDue, a.Amount, a.DeliveredBy, a.CreateBy, a.LastModBy)
+	res, err := RRdb.Prepstmt.InsertInvoice.Exec(a.BID, a.Dt, a.DtDue, a.Amount, a.DeliveredBy, a.FLAGS, a.CreateBy, a.LastModBy)
 	if nil == err {
 		id, err := res.LastInsertId()
 		if err == nil {
@@ -234,6 +261,7 @@ func InsertJournal(j *Journal) (int64, error) {
 		if err == nil {
 			id = int64(nid)
 			j.JID = id
+			auditJournal(j, j.CreateBy, AUDITCREATE)
 		}
 	}
 	return id, err
@@ -255,7 +283,14 @@ func InsertJournalAllocationEntry(ja *JournalAllocation) error {
 
 // InsertJournalMarker writes a new JournalMarker record to the database
 func InsertJournalMarker(jm *JournalMarker) error {
-	_, err := RRdb.Prepstmt.InsertJournalMarker.Exec(jm.BID, jm.State, jm.DtStart, jm.DtStop, jm.CreateBy, jm.LastModBy)
+	res, err := RRdb.Prepstmt.InsertJournalMarker.Exec(jm.BID, jm.State, jm.DtStart, jm.DtStop, jm.CreateBy, jm.LastModBy)
+	if nil == err {
+		id, err := res.LastInsertId()
+		if err == nil {
+			jm.JMID = int64(id)
+			auditJournalMarker(jm, jm.CreateBy, AUDITCREATE)
+		}
+	}
 	return err
 }
 
@@ -270,6 +305,7 @@ func InsertLedgerMarker(l *LedgerMarker) error {
 		id, err := res.LastInsertId()
 		if err == nil {
 			l.LMID = int64(id)
+			auditLedgerMarker(l, l.CreateBy, AUDITCREATE)
 		}
 	} else {
 		Ulog("InsertLedgerMarker: err = %#v\n", err)
@@ -286,6 +322,7 @@ func InsertLedgerEntry(l *LedgerEntry) (int64, error) {
 		if err == nil {
 			rid = int64(id)
 			l.LEID = rid
+			auditLedgerEntry(l, l.CreateBy, AUDITCREATE)
 		}
 	} else {
 		Ulog("Error inserting LedgerEntry:  %v\n", err)
@@ -466,7 +503,7 @@ func InsertProspect(a *Prospect) (int64, error) {
 	res, err := RRdb.Prepstmt.InsertProspect.Exec(a.TCID, a.BID, a.EmployerName, a.EmployerStreetAddress, a.EmployerCity,
 		a.EmployerState, a.EmployerPostalCode, a.EmployerEmail, a.EmployerPhone, a.Occupation, a.ApplicationFee,
 		a.DesiredUsageStartDate, a.RentableTypePreference, a.FLAGS, a.Approver, a.DeclineReasonSLSID, a.OtherPreferences,
-		a.FollowUpDate, a.CSAgent, a.OutcomeSLSID, a.FloatingDeposit, a.RAID, a.CreateBy, a.LastModBy)
+		a.FollowUpDate, a.CSAgent, a.OutcomeSLSID, a.FloatingDeposit, a.RAID, a.LSID, a.CreateBy, a.LastModBy)
 	if nil == err {
 		id, err := res.LastInsertId()
 		if err == nil {
@@ -482,7 +519,7 @@ func InsertProspect(a *Prospect) (int64, error) {
 // InsertRentable writes a new Rentable record to the database
 func InsertRentable(a *Rentable) (int64, error) {
 	var rid = int64(0)
-	res, err := RRdb.Prepstmt.InsertRentable.Exec(a.BID, a.RentableName, a.AssignmentTime, a.CreateBy, a.LastModBy)
+	res, err := RRdb.Prepstmt.InsertRentable.Exec(a.BID, a.RentableName, a.AssignmentTime, a.BLDGID, a.CreateBy, a.LastModBy)
 	if nil == err {
 		id, err := res.LastInsertId()
 		if err == nil {
@@ -605,6 +642,16 @@ func InsertRentalAgreementRentable(a *RentalAgreementRentable) (int64, error) {
 //  RENTAL AGREEMENT TEMPLATE
 //=======================================================
 
+// InsertRentalAgreementTax writes a new RentalAgreementTax record to the database
+func InsertRentalAgreementTax(a *RentalAgreementTax) error {
+	_, err := RRdb.Prepstmt.InsertRentalAgreementTax.Exec(a.RAID, a.BID, a.DtStart, a.DtStop, a.FLAGS, a.CreateBy)
+	if nil != err {
+		Ulog("InsertRentalAgreementTax: error inserting RentalAgreementTax:  %v\n", err)
+		Ulog("RentalAgreementTax = %#v\n", *a)
+	}
+	return err
+}
+
 // InsertRentalAgreementTemplate writes a new User record to the database
 func InsertRentalAgreementTemplate(a *RentalAgreementTemplate) (int64, error) {
 	var tid = int64(0)
@@ -685,6 +732,16 @@ func InsertRentableTypeRef(a *RentableTypeRef) error {
 	return err
 }
 
+// InsertRentableTypeTax writes a new RentableTypeTax record to the database
+func InsertRentableTypeTax(a *RentableTypeTax) error {
+	_, err := RRdb.Prepstmt.InsertRentableTypeTax.Exec(a.RTID, a.BID, a.TAXID, a.DtStart, a.DtStop, a.CreateBy)
+	if nil != err {
+		Ulog("InsertRentableTypeTax: error inserting RentableTypeTax:  %v\n", err)
+		Ulog("RentableTypeTax = %#v\n", *a)
+	}
+	return err
+}
+
 // InsertRentableUser writes a new User record to the database
 func InsertRentableUser(a *RentableUser) error {
 	res, err := RRdb.Prepstmt.InsertRentableUser.Exec(a.RID, a.BID, a.TCID, a.DtStart, a.DtStop, a.CreateBy)
@@ -731,6 +788,260 @@ func InsertSLStrings(a *StringList) {
 	}
 }
 
+// InsertTax writes a new Tax record to the database. If the record is successfully written,
+// the TAXID field is set to its new value.
+func InsertTax(a *Tax) (int64, error) {
+	var rid = int64(0)
+	res, err := RRdb.Prepstmt.InsertTax.Exec(a.BID, a.Name, a.ARID, a.TaxingAuthority, a.TaxingAuthorityAddress, a.FilingDate, a.FilingCycle, a.Instructions, a.CreateBy, a.LastModBy)
+	if nil == err {
+		id, err := res.LastInsertId()
+		if err == nil {
+			rid = int64(id)
+			a.TAXID = rid
+		}
+	} else {
+		Ulog("InsertTax: error inserting Tax:  %v\n", err)
+		Ulog("Tax = %#v\n", *a)
+	}
+	return rid, err
+}
+
+// InsertCommissionLedger writes a new CommissionLedger record to the database. If the record is successfully written,
+// the CLID field is set to its new value.
+func InsertCommissionLedger(a *CommissionLedger) (int64, error) {
+	var rid = int64(0)
+	res, err := RRdb.Prepstmt.InsertCommissionLedger.Exec(a.BID, a.RAID, a.RID, a.Salesperson, a.UID, a.TCID, a.Percent, a.Amount, a.PaymentDueDate, a.FLAGS, a.DtPaid, a.JID, a.CreateBy, a.LastModBy)
+	if nil == err {
+		id, err := res.LastInsertId()
+		if err == nil {
+			rid = int64(id)
+			a.CLID = rid
+		}
+	} else {
+		Ulog("InsertCommissionLedger: error inserting CommissionLedger:  %v\n", err)
+		Ulog("CommissionLedger = %#v\n", *a)
+	}
+	return rid, err
+}
+
+// InsertServiceRequest writes a new ServiceRequest record to the database. If the record is successfully written,
+// the SRID field is set to its new value.
+func InsertServiceRequest(a *ServiceRequest) (int64, error) {
+	var rid = int64(0)
+	res, err := RRdb.Prepstmt.InsertServiceRequest.Exec(a.BID, a.RID, a.TCID, a.CategorySLSID, a.Priority, a.Description, a.Assignee, a.Status,
+		a.DtReported, a.DtCompleted, a.CompletionNotes, a.ASMID, a.MSID, a.DtDue, a.CreateBy, a.LastModBy)
+	if nil == err {
+		id, err := res.LastInsertId()
+		if err == nil {
+			rid = int64(id)
+			a.SRID = rid
+		}
+	} else {
+		Ulog("InsertServiceRequest: error inserting ServiceRequest:  %v\n", err)
+		Ulog("ServiceRequest = %#v\n", *a)
+	}
+	return rid, err
+}
+
+// InsertServiceRequestStatus writes a new ServiceRequestStatus record to the database. If the record is successfully
+// written, the SRSID field is set to its new value.
+func InsertServiceRequestStatus(a *ServiceRequestStatus) (int64, error) {
+	var rid = int64(0)
+	res, err := RRdb.Prepstmt.InsertServiceRequestStatus.Exec(a.SRID, a.BID, a.Status, a.Assignee, a.Dt, a.Comment, a.CreateBy)
+	if nil == err {
+		id, err := res.LastInsertId()
+		if err == nil {
+			rid = int64(id)
+			a.SRSID = rid
+		}
+	} else {
+		Ulog("InsertServiceRequestStatus: error inserting ServiceRequestStatus:  %v\n", err)
+		Ulog("ServiceRequestStatus = %#v\n", *a)
+	}
+	return rid, err
+}
+
+// InsertMaintenanceSchedule writes a new MaintenanceSchedule record to the database. If the record is successfully
+// written, the MSID field is set to its new value.
+func InsertMaintenanceSchedule(a *MaintenanceSchedule) (int64, error) {
+	var rid = int64(0)
+	res, err := RRdb.Prepstmt.InsertMaintenanceSchedule.Exec(a.BID, a.Name, a.Description, a.CategorySLSID, a.Priority, a.Assignee, a.RID, a.RTID,
+		a.BLDGID, a.Cycle, a.DtStart, a.DtStop, a.LeadDays, a.DtLastDue, a.CreateBy, a.LastModBy)
+	if nil == err {
+		id, err := res.LastInsertId()
+		if err == nil {
+			rid = int64(id)
+			a.MSID = rid
+		}
+	} else {
+		Ulog("InsertMaintenanceSchedule: error inserting MaintenanceSchedule:  %v\n", err)
+		Ulog("MaintenanceSchedule = %#v\n", *a)
+	}
+	return rid, err
+}
+
+// InsertHousekeepingStatus writes a new HousekeepingStatus record to the database. If the record is successfully
+// written, the HKSID field is set to its new value.
+func InsertHousekeepingStatus(a *HousekeepingStatus) (int64, error) {
+	var rid = int64(0)
+	res, err := RRdb.Prepstmt.InsertHousekeepingStatus.Exec(a.BID, a.RID, a.Status, a.Dt, a.Comment, a.CreateBy)
+	if nil == err {
+		id, err := res.LastInsertId()
+		if err == nil {
+			rid = int64(id)
+			a.HKSID = rid
+		}
+	} else {
+		Ulog("InsertHousekeepingStatus: error inserting HousekeepingStatus:  %v\n", err)
+		Ulog("HousekeepingStatus = %#v\n", *a)
+	}
+	return rid, err
+}
+
+// InsertBudget writes a new Budget record to the database. If the record is successfully written,
+// the BGID field is set to its new value.
+func InsertBudget(a *Budget) (int64, error) {
+	var rid = int64(0)
+	res, err := RRdb.Prepstmt.InsertBudget.Exec(a.BID, a.LID, a.Dt, a.Amount, a.CreateBy, a.LastModBy)
+	if nil == err {
+		id, err := res.LastInsertId()
+		if err == nil {
+			rid = int64(id)
+			a.BGID = rid
+		}
+	} else {
+		Ulog("InsertBudget: error inserting Budget:  %v\n", err)
+		Ulog("Budget = %#v\n", *a)
+	}
+	return rid, err
+}
+
+// InsertRentableTypeBudget writes a new RentableTypeBudget record to the database. If the record is
+// successfully written, the RTBGID field is set to its new value.
+func InsertRentableTypeBudget(a *RentableTypeBudget) (int64, error) {
+	var rid = int64(0)
+	res, err := RRdb.Prepstmt.InsertRentableTypeBudget.Exec(a.BID, a.RTID, a.Dt, a.GSR, a.Occupancy, a.CreateBy, a.LastModBy)
+	if nil == err {
+		id, err := res.LastInsertId()
+		if err == nil {
+			rid = int64(id)
+			a.RTBGID = rid
+		}
+	} else {
+		Ulog("InsertRentableTypeBudget: error inserting RentableTypeBudget:  %v\n", err)
+		Ulog("RentableTypeBudget = %#v\n", *a)
+	}
+	return rid, err
+}
+
+// InsertGoodsService writes a new GoodsService record to the database. If the record is successfully
+// written, the GSID field is set to its new value.
+func InsertGoodsService(a *GoodsService) (int64, error) {
+	var rid = int64(0)
+	res, err := RRdb.Prepstmt.InsertGoodsService.Exec(a.BID, a.Name, a.Description, a.Price, a.ARID, a.TAXID, a.FLAGS, a.CreateBy, a.LastModBy)
+	if nil == err {
+		id, err := res.LastInsertId()
+		if err == nil {
+			rid = int64(id)
+			a.GSID = rid
+		}
+	} else {
+		Ulog("InsertGoodsService: error inserting GoodsService:  %v\n", err)
+		Ulog("GoodsService = %#v\n", *a)
+	}
+	return rid, err
+}
+
+// InsertRole writes a new Role record to the database. If the record is successfully written,
+// the RoleID field is set to its new value.
+func InsertRole(a *Role) (int64, error) {
+	var rid = int64(0)
+	res, err := RRdb.Prepstmt.InsertRole.Exec(a.Name, a.Description, a.CreateBy, a.LastModBy)
+	if nil == err {
+		id, err := res.LastInsertId()
+		if err == nil {
+			rid = int64(id)
+			a.RoleID = rid
+		}
+	} else {
+		Ulog("InsertRole: error inserting Role:  %v\n", err)
+		Ulog("Role = %#v\n", *a)
+	}
+	return rid, err
+}
+
+// InsertRolePermission writes a new RolePermission record to the database. If the record is successfully
+// written, the RPID field is set to its new value.
+func InsertRolePermission(a *RolePermission) (int64, error) {
+	var rid = int64(0)
+	res, err := RRdb.Prepstmt.InsertRolePermission.Exec(a.RoleID, a.BID, a.Service, a.Cmd, a.CreateBy)
+	if nil == err {
+		id, err := res.LastInsertId()
+		if err == nil {
+			rid = int64(id)
+			a.RPID = rid
+		}
+	} else {
+		Ulog("InsertRolePermission: error inserting RolePermission:  %v\n", err)
+		Ulog("RolePermission = %#v\n", *a)
+	}
+	return rid, err
+}
+
+// InsertUserRole writes a new UserRole record to the database. If the record is successfully written,
+// the URID field is set to its new value.
+func InsertUserRole(a *UserRole) (int64, error) {
+	var rid = int64(0)
+	res, err := RRdb.Prepstmt.InsertUserRole.Exec(a.UID, a.RoleID, a.CreateBy)
+	if nil == err {
+		id, err := res.LastInsertId()
+		if err == nil {
+			rid = int64(id)
+			a.URID = rid
+		}
+	} else {
+		Ulog("InsertUserRole: error inserting UserRole:  %v\n", err)
+		Ulog("UserRole = %#v\n", *a)
+	}
+	return rid, err
+}
+
+// InsertLateFeePolicy writes a new LateFeePolicy record to the database. If the record is successfully written,
+// the LFPID field is set to its new value.
+func InsertLateFeePolicy(a *LateFeePolicy) (int64, error) {
+	var rid = int64(0)
+	res, err := RRdb.Prepstmt.InsertLateFeePolicy.Exec(a.BID, a.ARID, a.GraceDays, a.Amount, a.Formula, a.MaxAmount, a.CreateBy, a.LastModBy)
+	if nil == err {
+		id, err := res.LastInsertId()
+		if err == nil {
+			rid = int64(id)
+			a.LFPID = rid
+		}
+	} else {
+		Ulog("InsertLateFeePolicy: error inserting LateFeePolicy:  %v\n", err)
+		Ulog("LateFeePolicy = %#v\n", *a)
+	}
+	return rid, err
+}
+
+// InsertTaxRate writes a new TaxRate record to the database. If the record is successfully written,
+// the TRID field is set to its new value.
+func InsertTaxRate(a *TaxRate) (int64, error) {
+	var rid = int64(0)
+	res, err := RRdb.Prepstmt.InsertTaxRate.Exec(a.TAXID, a.BID, a.DtStart, a.DtStop, a.Rate, a.Fee, a.Formula, a.CreateBy, a.LastModBy)
+	if nil == err {
+		id, err := res.LastInsertId()
+		if err == nil {
+			rid = int64(id)
+			a.TRID = rid
+		}
+	} else {
+		Ulog("InsertTaxRate: error inserting TaxRate:  %v\n", err)
+		Ulog("TaxRate = %#v\n", *a)
+	}
+	return rid, err
+}
+
 // InsertTransactant writes a new Transactant record to the database
 func InsertTransactant(a *Transactant) (int64, error) {
 	var tid = int64(0)
@@ -309,7 +309,19 @@ type GSRdata struct {
 //   error - any error returned by the routines looking for data values
 //========================================================================================================
 func CalculateLoadedGSR(r *Rentable, d1, d2 *time.Time, xbiz *XBusiness) (float64, []GSRdata, time.Duration, error) {
-	funcname := "CalculateLoadedGSR"
+	rta := GetRentableTypeRefsByRange(r.RID, d1, d2) // get the list
+	return CalculateLoadedGSRForRefs(r, rta, d1, d2, xbiz)
+}
+
+// CalculateLoadedGSRForRefs calculates the loaded GSR just like CalculateLoadedGSR but it uses the
+// supplied RentableTypeRefs rather than the ones in the database. This allows the effect of a change
+// to the Rentable's type to be calculated before the change is made.
+// Params:
+//   rta = the RentableTypeRefs of r that overlap d1 - d2, sorted by DtStart
+//   see CalculateLoadedGSR for the others
+//========================================================================================================
+func CalculateLoadedGSRForRefs(r *Rentable, rta []RentableTypeRef, d1, d2 *time.Time, xbiz *XBusiness) (float64, []GSRdata, time.Duration, error) {
+	funcname := "CalculateLoadedGSRForRefs"
 	var period = time.Duration(0)
 	var m []GSRdata
 	var err error
@@ -317,7 +329,6 @@ func CalculateLoadedGSR(r *Rentable, d1, d2 *time.Time, xbiz *XBusiness) (float6
 
 	// fmt.Printf("%s, r.RID = %d, d1 = %s, d2 = %s\n", funcname, r.RID, d1.Format(RRDATEINPFMT), d2.Format(RRDATEINPFMT))
 
-	rta := GetRentableTypeRefsByRange(r.RID, d1, d2) // get the list
 	if len(rta) == 0 {
 		err = fmt.Errorf("%s:  No valid RTID for rentable R%08d during period %s to %s",
 			funcname, r.RID, d1.Format(RRDATEINPFMT), d2.Format(RRDATEINPFMT))
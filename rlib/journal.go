@@ -106,11 +106,17 @@ func ProrateAssessment(xbiz *XBusiness, a *Assessment, d, d1, d2 *time.Time) (fl
 	pf := float64(0)
 	var num, den int64
 	var start, stop time.Time
+	if a.RentCycle == CYCLENORECUR && (a.RID == 0 || a.FLAGS&ASMTAXCHARGE != 0) {
+		// a one-time charge that is not for a Rentable, such as an application
+		// fee or goods and services sold, is never prorated. Nor is a tax, it
+		// was computed on the prorated amount
+		return float64(1), 1, 1, a.Start, a.Stop
+	}
 	r := GetRentable(a.RID)
 	status := GetRentableStateForDate(r.RID, d)
 	// fmt.Printf("GetRentableStateForDate( %d, %s ) = %d\n", r.RID, d.Format(RRDATEINPFMT), status)
 	switch status {
-	case RENTABLESTATUSONLINE:
+	case RENTABLESTATUSONLINE, RENTABLESTATUSOCCUPIED:
 		ra, _ := GetRentalAgreement(a.RAID)
 		switch a.RentCycle {
 		case CYCLEDAILY:
@@ -157,8 +163,9 @@ func ProrateAssessment(xbiz *XBusiness, a *Assessment, d, d1, d2 *time.Time) (fl
 //		d - date of this assessment
 //		a - the assessment
 //		d1-d2 - defines the timerange being covered in this period
+//		uid - the user making the entry, 0 if it is made by a worker
 //=================================================================================================
-func journalAssessment(xbiz *XBusiness, d time.Time, a *Assessment, d1, d2 *time.Time) (Journal, error) {
+func journalAssessment(xbiz *XBusiness, d time.Time, a *Assessment, d1, d2 *time.Time, uid int64) (Journal, error) {
 	// funcname := "journalAssessment"
 	// fmt.Printf("Entered %s\n", funcname)
 	pf, num, den, start, stop := ProrateAssessment(xbiz, a, &d, d1, d2)
@@ -166,7 +173,9 @@ func journalAssessment(xbiz *XBusiness, d time.Time, a *Assessment, d1, d2 *time
 	// fmt.Printf("ProrateAssessment: a.ASMTID = %d, d = %s, d1 = %s, d2 = %s\n", a.ASMID, d.Format(RRDATEFMT4), d1.Format(RRDATEFMT4), d2.Format(RRDATEFMT4))
 	// fmt.Printf("pf = %f, num = %d, den = %d, start = %s, stop = %s\n", pf, num, den, start.Format(RRDATEFMT4), stop.Format(RRDATEFMT4))
 
-	var j = Journal{BID: a.BID, Dt: d, Type: JNLTYPEASMT, ID: a.ASMID}
+	var j = Journal{BID: a.BID, Dt: d, Type: JNLTYPEASMT, ID: a.ASMID, CreateBy: uid, LastModBy: uid}
+	// the amount actually being charged for this instance
+	taxBase := RoundToCent(a.Amount * pf)
 	m := ParseAcctRule(xbiz, a.RID, d1, d2, GetAssessmentAccountRule(a), a.Amount, pf) // a rule such as "d 11001 1000.0, c 40001 1100.0, d 41004 100.00"
 
 	// fmt.Printf("journalAssessment:  m = %#v\n", m)
@@ -190,6 +199,7 @@ func journalAssessment(xbiz *XBusiness, d time.Time, a *Assessment, d1, d2 *time
 		a.Start = start     // adjust to the dates used in the proration
 		a.Stop = stop       // adjust to the dates used in the proration
 		a.Comment = fmt.Sprintf("Prorated: %d %s out of %d", num, ProrationUnits(a.ProrationCycle), den)
+		a.LastModBy = uid
 		if err := UpdateAssessment(a); err != nil {
 			err = fmt.Errorf("Error updating prorated assessment amount: %s", err.Error())
 			return j, err
@@ -227,6 +237,21 @@ func journalAssessment(xbiz *XBusiness, d time.Time, a *Assessment, d1, d2 *time
 
 	}
 
+	//-------------------------------------------------------------------------------------------
+	// If the Rentable's type is taxable, each tax is assessed as its own one-time charge so
+	// that it can be paid. The charge is added to this journal as its own allocation so
+	// that the tax liability is booked along with the assessment.
+	//-------------------------------------------------------------------------------------------
+	asmAmount := j.Amount
+	taxes, err := GetAssessmentTaxes(xbiz, a, taxBase, &d, d1, d2)
+	if err != nil {
+		LogAndPrintError("journalAssessment", err)
+		return j, err
+	}
+	for i := 0; i < len(taxes); i++ {
+		j.Amount = RoundToCent(j.Amount + taxes[i].Amount)
+	}
+
 	// fmt.Printf("INSERTING JOURNAL: Date = %s, Type = %d, amount = %f\n", j.Dt, j.Type, j.Amount)
 
 	jid, err := InsertJournal(&j)
@@ -247,7 +272,7 @@ func journalAssessment(xbiz *XBusiness, d time.Time, a *Assessment, d1, d2 *time
 		ja.JID = jid
 		ja.RID = a.RID
 		ja.ASMID = a.ASMID
-		ja.Amount = RoundToCent(j.Amount)
+		ja.Amount = RoundToCent(asmAmount)
 		ja.AcctRule = s
 		ja.BID = a.BID
 		ja.RAID = a.RAID
@@ -258,6 +283,22 @@ func journalAssessment(xbiz *XBusiness, d time.Time, a *Assessment, d1, d2 *time
 			return j, err
 		}
 		j.JA = append(j.JA, ja)
+
+		for i := 0; i < len(taxes); i++ {
+			t := NewTaxCharge(a, &taxes[i], &d)
+			t.CreateBy = uid
+			t.LastModBy = uid
+			if _, err = InsertAssessment(&t); err != nil {
+				LogAndPrintError("journalAssessment", err)
+				return j, err
+			}
+			jt := NewTaxAllocation(jid, a, &t, &taxes[i])
+			if err = InsertJournalAllocationEntry(&jt); err != nil {
+				LogAndPrintError("journalAssessment", err)
+				return j, err
+			}
+			j.JA = append(j.JA, jt)
+		}
 	}
 
 	return j, err
@@ -276,22 +317,23 @@ func RemoveJournalEntries(xbiz *XBusiness, d1, d2 *time.Time) error {
 		var j Journal
 		ReadJournals(rows, &j)
 		DeleteJournalAllocations(j.JID)
-		DeleteJournal(j.JID)
+		DeleteJournal(j.JID, 0)
 	}
 
 	// only delete the marker if it is in this time range and if it is not the origin marker
 	jm := GetLastJournalMarker()
 	if jm.State == MARKERSTATEOPEN && (jm.DtStart.After(*d1) || jm.DtStart.Equal(*d1)) && (jm.DtStop.Before(*d2) || jm.DtStop.Equal(*d2)) {
-		DeleteJournalMarker(jm.JMID)
+		DeleteJournalMarker(jm.JMID, 0)
 	}
 
 	RemoveLedgerEntries(xbiz, d1, d2)
 	return err
 }
 
-// ProcessNewAssessmentInstance creates a Journal entry for the supplied non-recurring assessment
+// ProcessNewAssessmentInstance creates a Journal entry for the supplied non-recurring assessment.
+// uid is the user making the entry, 0 if it is made by a worker.
 //=================================================================================================
-func ProcessNewAssessmentInstance(xbiz *XBusiness, d1, d2 *time.Time, a *Assessment) (Journal, error) {
+func ProcessNewAssessmentInstance(xbiz *XBusiness, d1, d2 *time.Time, a *Assessment, uid int64) (Journal, error) {
 	funcname := "ProcessNewAssessmentInstance"
 	var j Journal
 	var err error
@@ -310,7 +352,7 @@ func ProcessNewAssessmentInstance(xbiz *XBusiness, d1, d2 *time.Time, a *Assessm
 	}
 
 	// fmt.Printf("%s: Calling journalAssessment for ASMID = %d\n", funcname, a.ASMID)
-	j, err = journalAssessment(xbiz, a.Start, a, d1, d2)
+	j, err = journalAssessment(xbiz, a.Start, a, d1, d2, uid)
 	return j, err
 }
 
@@ -323,6 +365,8 @@ func ProcessNewReceipt(xbiz *XBusiness, d1, d2 *time.Time, r *Receipt) (Journal,
 	j.Dt = r.Dt
 	j.Type = JNLTYPERCPT
 	j.ID = r.RCPTID
+	j.CreateBy = r.LastModBy
+	j.LastModBy = r.LastModBy
 	// j.RAID = r.RAID
 	jid, err := InsertJournal(&j)
 	if err != nil {
@@ -359,16 +403,18 @@ func ProcessNewReceipt(xbiz *XBusiness, d1, d2 *time.Time, r *Receipt) (Journal,
 
 // ProcessJournalEntry processes an assessment. It adds instances of recurring assessments for
 // the time period d1-d2 if they do not already exist. Then creates a journal entry for the assessment.
-func ProcessJournalEntry(a *Assessment, xbiz *XBusiness, d1, d2 *time.Time, updateLedgers bool) {
+// uid is the user making the entries, 0 if they are made by a worker. Any error encountered
+// is logged and returned.
+func ProcessJournalEntry(a *Assessment, xbiz *XBusiness, d1, d2 *time.Time, updateLedgers bool, uid int64) error {
 	funcname := "ProcessJournalEntry"
 	var j Journal
 	var err error
 	// fmt.Printf("ProcessJournalEntry: 1. a.ASMID = %d, d1 - d2 = %s - %s\n", a.ASMID, d1.Format(RRDATEREPORTFMT), d2.Format(RRDATEREPORTFMT))
 	if a.RentCycle == RECURNONE {
-		j, err = ProcessNewAssessmentInstance(xbiz, d1, d2, a)
+		j, err = ProcessNewAssessmentInstance(xbiz, d1, d2, a, uid)
 		if err != nil {
 			LogAndPrintError(funcname, err)
-			return
+			return err
 		}
 		if updateLedgers {
 			GenerateLedgerEntriesFromJournal(xbiz, &j, d1, d2)
@@ -389,13 +435,17 @@ func ProcessJournalEntry(a *Assessment, xbiz *XBusiness, d1, d2 *time.Time, upda
 			a1.Stop = dl[i].Add(CycleDuration(a.ProrationCycle, a.Start)) // add enough time so that the recurrence calculator sees this instance
 			a1.ASMID = 0                                                  // ensure this is a new assessment
 			a1.PASMID = a.ASMID                                           // parent assessment
+			a1.CreateBy = uid                                             // the instance is made by this user...
+			a1.LastModBy = uid                                            // ...not by the one who made the parent
 
 			// The generation of recurring assessment instances needs to be idempotent.
 			// Check to ensure that this instance does not already exist before generating it
 			a2, _ := GetAssessmentInstance(&a1.Start, a1.PASMID) // if this returns an existing instance (ASMID != 0) then it's already been processed...
 			if a2.ASMID == 0 {                                   // ... otherwise, process this instance
-				_, err = InsertAssessment(&a1)
-				Errlog(err)
+				if _, err = InsertAssessment(&a1); err != nil {
+					LogAndPrintError(funcname, err)
+					return err
+				}
 				// fmt.Printf("ProcessJournalEntry: 4, inserted a1.ASMID = %d\n", a1.ASMID)
 
 				// Rent is assessed on the following cycle: a.RentCycle
@@ -411,10 +461,10 @@ func ProcessJournalEntry(a *Assessment, xbiz *XBusiness, d1, d2 *time.Time, upda
 					dtb = dl[i] // add one full cycle diration
 					dte = dtb.Add(CycleDuration(a.RentCycle, dtb))
 				}
-				j, err := ProcessNewAssessmentInstance(xbiz, &dtb, &dte, &a1)
+				j, err := ProcessNewAssessmentInstance(xbiz, &dtb, &dte, &a1, uid)
 				if err != nil {
 					LogAndPrintError(funcname, err)
-					return
+					return err
 				}
 				if updateLedgers {
 					GenerateLedgerEntriesFromJournal(xbiz, &j, d1, d2)
@@ -425,6 +475,7 @@ func ProcessJournalEntry(a *Assessment, xbiz *XBusiness, d1, d2 *time.Time, upda
 			// fmt.Printf("ProcessJournalEntry: 5\n")
 		}
 	}
+	return nil
 }
 
 // GenerateRecurInstances creates Assessment instance records for recurring Assessments and then
@@ -438,7 +489,7 @@ func GenerateRecurInstances(xbiz *XBusiness, d1, d2 *time.Time) {
 	for rows.Next() {
 		var a Assessment
 		ReadAssessments(rows, &a)
-		ProcessJournalEntry(&a, xbiz, d1, d2, false)
+		ProcessJournalEntry(&a, xbiz, d1, d2, false, 0)
 	}
 	Errcheck(rows.Err())
 }
@@ -16,7 +16,7 @@ func RemoveLedgerEntries(xbiz *XBusiness, d1, d2 *time.Time) error {
 	for rows.Next() {
 		var l LedgerEntry
 		ReadLedgerEntries(rows, &l)
-		DeleteLedgerEntry(l.LEID)
+		DeleteLedgerEntry(l.LEID, 0)
 	}
 	return err
 }
@@ -67,6 +67,8 @@ func GenerateLedgerEntriesFromJournal(xbiz *XBusiness, j *Journal, d1, d2 *time.
 			l.RAID = j.JA[i].RAID
 			l.TCID = j.JA[i].TCID
 			l.Dt = j.Dt
+			l.CreateBy = j.CreateBy
+			l.LastModBy = j.LastModBy
 			l.Amount = RoundToCent(m[k].Amount)
 			if m[k].Action == "c" {
 				l.Amount = -l.Amount
@@ -259,7 +261,7 @@ func UpdateSubLedgerMarkers(bid int64, d2 *time.Time) {
 // 	// }
 // }
 
-func closeLedgerPeriod(xbiz *XBusiness, li *GLAccount, lm *LedgerMarker, dt *time.Time, state int64) {
+func closeLedgerPeriod(xbiz *XBusiness, li *GLAccount, lm *LedgerMarker, dt *time.Time, state, uid int64) {
 	bal := GetRAAccountBalance(li.BID, li.LID, 0, dt)
 
 	var nlm LedgerMarker
@@ -267,9 +269,80 @@ func closeLedgerPeriod(xbiz *XBusiness, li *GLAccount, lm *LedgerMarker, dt *tim
 	nlm.Balance = bal
 	nlm.Dt = *dt
 	nlm.State = state
+	nlm.CreateBy = uid
+	nlm.LastModBy = uid
 	InsertLedgerMarker(&nlm)
 }
 
+// IsClosedPeriod returns true if dt falls within a closed or locked accounting period
+// of business bid
+func IsClosedPeriod(bid int64, dt *time.Time) bool {
+	jm := GetClosedJournalMarkerForDate(bid, dt)
+	return jm.JMID > 0
+}
+
+// GetTrialBalance returns the sum of the balances of all the accounts of business bid
+// that allow posts as of dt. The books are in balance when this sum is 0.
+func GetTrialBalance(bid int64, dt *time.Time) float64 {
+	bal := float64(0)
+	t := GetLedgerList(bid)
+	for i := 0; i < len(t); i++ {
+		if t[i].AllowPost == 0 {
+			continue
+		}
+		bal += GetAccountBalance(bid, t[i].LID, dt)
+	}
+	return RoundToCent(bal)
+}
+
+// ClosePeriod marks the period d1 - d2 as closed. The JournalMarker for the period is
+// created or updated with State = MARKERSTATECLOSED, and every GLAccount gets a closed
+// LedgerMarker at d2. Business rules such as a balanced trial balance are the caller's
+// responsibility; see bizlogic.ClosePeriod. uid is the user closing the period.
+//=================================================================================================
+func ClosePeriod(xbiz *XBusiness, d1, d2 *time.Time, uid int64) error {
+	funcname := "ClosePeriod"
+	jm, err := GetJournalMarkerByRange(xbiz.P.BID, d1, d2)
+	if err != nil && !IsSQLNoResultsError(err) {
+		return err
+	}
+	jm.LastModBy = uid
+	if jm.JMID > 0 {
+		jm.State = MARKERSTATECLOSED
+		err = UpdateJournalMarker(&jm)
+	} else {
+		jm.BID = xbiz.P.BID
+		jm.CreateBy = uid
+		jm.State = MARKERSTATECLOSED
+		jm.DtStart = *d1
+		jm.DtStop = *d2
+		err = InsertJournalMarker(&jm)
+	}
+	if err != nil {
+		return fmt.Errorf("%s: could not save JournalMarker: %s", funcname, err.Error())
+	}
+
+	t := GetLedgerList(xbiz.P.BID)
+	for i := 0; i < len(t); i++ {
+		lm := GetLedgerMarkerOnOrBefore(xbiz.P.BID, t[i].LID, d2)
+		if lm.LMID == 0 {
+			fmt.Printf("%s: Could not get GLAccount %d (%s) in business %d\n", funcname, t[i].LID, t[i].GLNumber, xbiz.P.BID)
+			continue
+		}
+		if !lm.Dt.Equal(*d2) {
+			closeLedgerPeriod(xbiz, &t[i], &lm, d2, MARKERSTATECLOSED, uid)
+			continue
+		}
+		lm.Balance = GetRAAccountBalance(t[i].BID, t[i].LID, 0, d2) // a marker already exists at d2, close it
+		lm.State = MARKERSTATECLOSED
+		lm.LastModBy = uid
+		if err = UpdateLedgerMarker(&lm); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // GenerateLedgerMarkers creates all ledgermarkers at d2
 func GenerateLedgerMarkers(xbiz *XBusiness, d2 *time.Time) {
 	funcname := "GenerateLedgerMarkers"
@@ -283,7 +356,10 @@ func GenerateLedgerMarkers(xbiz *XBusiness, d2 *time.Time) {
 			fmt.Printf("%s: Could not get GLAccount %d (%s) in business %d\n", funcname, t[i].LID, t[i].GLNumber, xbiz.P.BID)
 			continue
 		}
-		closeLedgerPeriod(xbiz, &t[i], &lm, d2, MARKERSTATEOPEN)
+		if (lm.State == MARKERSTATECLOSED || lm.State == MARKERSTATELOCKED) && lm.Dt.Equal(*d2) {
+			continue // the period has been closed, leave its marker alone
+		}
+		closeLedgerPeriod(xbiz, &t[i], &lm, d2, MARKERSTATEOPEN, 0)
 	}
 
 	//----------------------------------------------------------------------------------
@@ -293,6 +369,23 @@ func GenerateLedgerMarkers(xbiz *XBusiness, d2 *time.Time) {
 	//UpdatePayorSubLedgers(xbiz.P.BID, d1, d2)
 }
 
+// UpdateLedgerMarkersAfter recomputes the balances of all open LedgerMarkers dated after dt.
+// It is used after an entry has been posted in front of markers that already exist. Each
+// marker date is regenerated in chronological order with GenerateLedgerMarkers, which also
+// rebuilds the Rental Agreement sub-ledger markers via UpdateSubLedgerMarkers. uid is the
+// user whose posting caused the update.
+//=================================================================================================
+func UpdateLedgerMarkersAfter(xbiz *XBusiness, dt *time.Time, uid int64) error {
+	m := GetLedgerMarkerDatesAfter(xbiz.P.BID, dt)
+	for i := 0; i < len(m); i++ {
+		if err := DeleteOpenLedgerMarkersOnDate(xbiz.P.BID, &m[i], uid); err != nil {
+			return err
+		}
+		GenerateLedgerMarkers(xbiz, &m[i])
+	}
+	return nil
+}
+
 // GenerateLedgerEntries creates ledgers records based on the Journal records over the supplied time range.
 func GenerateLedgerEntries(xbiz *XBusiness, d1, d2 *time.Time) int {
 	nr := 0
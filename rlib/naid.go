@@ -42,6 +42,11 @@ func (t *Business) IDtoString() string {
 	return IDtoString("B", t.BID)
 }
 
+// IDtoString is the method to produce a consistent printable id string
+func (a *CommissionLedger) IDtoString() string {
+	return IDtoString("CL", a.CLID)
+}
+
 //-------------------------------------------------
 //  CUSTOM ATTRIBUTE
 //-------------------------------------------------
@@ -107,6 +112,11 @@ func (a *LedgerMarker) IDtoString() string {
 	return IDtoString("LM", a.LMID)
 }
 
+// IDtoString is the method to produce a consistent printable id string
+func (a *MaintenanceSchedule) IDtoString() string {
+	return IDtoString("MS", a.MSID)
+}
+
 // IDtoString is the method to produce a consistent printable id string
 func (a *PaymentType) IDtoString() string {
 	return IDtoString("PMT", a.PMTID)
@@ -252,6 +262,15 @@ func (t *RentalAgreement) GetUserNameList(d1, d2 *time.Time) []string {
 	return m
 }
 
+//-------------------------------------------------
+//  SERVICE REQUEST
+//-------------------------------------------------
+
+// IDtoString is the method to produce a consistent printable id string
+func (a *ServiceRequest) IDtoString() string {
+	return IDtoString("SR", a.SRID)
+}
+
 //-------------------------------------------------
 //  TRANSACTANT
 //-------------------------------------------------
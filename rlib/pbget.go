@@ -30,3 +30,13 @@ func GetBusinessUnitByDesignation(des string) (BusinessUnit, error) {
 	err := RRdb.Dbdir.QueryRow("SELECT ClassCode,CoCode,Name,Designation,Description,LastModTime,LastModBy FROM classes WHERE Designation=?", des).Scan(&c.ClassCode, &c.CoCode, &c.Name, &c.Designation, &c.Description, &c.LastModTime, &c.LastModBy)
 	return c, err
 }
+
+// GetUserLogin returns the UID and password hash of the Phonebook user
+// with the supplied username. If no such user exists, the error is
+// sql.ErrNoRows.
+func GetUserLogin(username string) (int64, string, error) {
+	var uid int64
+	var passhash string
+	err := RRdb.PBsql.GetUserLogin.QueryRow(username).Scan(&uid, &passhash)
+	return uid, passhash, err
+}
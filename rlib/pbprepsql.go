@@ -17,4 +17,10 @@ func buildPBPreparedStatements() {
 	RRdb.PBsql.GetCompanyByDesignation, err = RRdb.Dbdir.Prepare("SELECT CoCode,LegalName,CommonName,Address,Address2,City,State,PostalCode,Country,Phone,Fax,Email,Designation,Active,EmploysPersonnel,LastModTime,LastModBy FROM companies WHERE Designation=?")
 	Errcheck(err)
 
+	//==========================================
+	// PEOPLE
+	//==========================================
+	RRdb.PBsql.GetUserLogin, err = RRdb.Dbdir.Prepare("SELECT UID,passhash FROM people WHERE UserName=?")
+	Errcheck(err)
+
 }
@@ -93,7 +93,7 @@ func buildPreparedStatements() {
 	//  AccountRule
 	//  AR
 	//===============================
-	flds = "ARID,BID,Name,ARType,DebitLID,CreditLID,Description,RARequired,DtStart,DtStop,CreateTS,CreateBy,LastModTime,LastModBy"
+	flds = "ARID,BID,Name,ARType,DebitLID,CreditLID,Description,RARequired,DtStart,DtStop,FLAGS,CreateTS,CreateBy,LastModTime,LastModBy"
 	RRdb.DBFields["AR"] = flds
 	RRdb.Prepstmt.GetAR, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM AR WHERE ARID=?")
 	Errcheck(err)
@@ -142,6 +142,8 @@ func buildPreparedStatements() {
 	// So (FLAGS & 3) < 2 means that the assessment is not yet paid
 	RRdb.Prepstmt.GetUnpaidAssessmentsByRAID, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM Assessments WHERE RAID=? AND (FLAGS & 3)<2 AND (FLAGS & 4)=0 AND (PASMID!=0 OR RentCycle=0) ORDER BY Start ASC")
 	Errcheck(err)
+	RRdb.Prepstmt.GetUnpaidAssessmentsByBusiness, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM Assessments WHERE BID=? AND RAID>0 AND Start<? AND (FLAGS & 3)<2 AND (FLAGS & 4)=0 AND (PASMID!=0 OR RentCycle=0) ORDER BY RAID ASC, Start ASC")
+	Errcheck(err)
 	s1, s2, s3, _, _ = GenSQLInsertAndUpdateStrings(flds)
 	RRdb.Prepstmt.InsertAssessment, err = RRdb.Dbrr.Prepare("INSERT INTO Assessments (" + s1 + ") VALUES(" + s2 + ")")
 	Errcheck(err)
@@ -162,6 +164,8 @@ func buildPreparedStatements() {
 	Errcheck(err)
 	RRdb.Prepstmt.InsertBuildingWithID, err = RRdb.Dbrr.Prepare("INSERT INTO Building (" + s4 + ") VALUES(" + s5 + ")")
 	Errcheck(err)
+	RRdb.Prepstmt.GetAllBuildings, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM Building WHERE BID=? ORDER BY BLDGID ASC")
+	Errcheck(err)
 
 	//==========================================
 	// Business
@@ -303,12 +307,14 @@ func buildPreparedStatements() {
 	//==========================================
 	// INVOICE
 	//==========================================
-	flds = "InvoiceNo,BID,Dt,DtDue,Amount,DeliveredBy,CreateTS,CreateBy,LastModTime,LastModBy"
+	flds = "InvoiceNo,BID,Dt,DtDue,Amount,DeliveredBy,FLAGS,CreateTS,CreateBy,LastModTime,LastModBy"
 	RRdb.DBFields["Invoice"] = flds
 	RRdb.Prepstmt.GetInvoice, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM Invoice WHERE InvoiceNo=?")
 	Errcheck(err)
 	RRdb.Prepstmt.GetAllInvoicesInRange, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM Invoice WHERE BID=? AND ?>=Dt AND DtDue<=?")
 	Errcheck(err)
+	RRdb.Prepstmt.GetInvoicesByDtRange, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM Invoice WHERE BID=? AND Dt>=? AND Dt<? ORDER BY Dt ASC, InvoiceNo ASC")
+	Errcheck(err)
 
 	s1, s2, s3, _, _ = GenSQLInsertAndUpdateStrings(flds)
 	RRdb.Prepstmt.InsertInvoice, err = RRdb.Dbrr.Prepare("INSERT INTO Invoice (" + s1 + ") VALUES(" + s2 + ")")
@@ -357,6 +363,10 @@ func buildPreparedStatements() {
 	Errcheck(err)
 	RRdb.Prepstmt.GetJournalByReceiptID, err = RRdb.Dbrr.Prepare("SELECT " + flds + " from Journal WHERE Type=2 AND ID=?")
 	Errcheck(err)
+	RRdb.Prepstmt.GetJournalByASMID, err = RRdb.Dbrr.Prepare("SELECT " + flds + " from Journal WHERE Type=1 AND ID=? ORDER BY JID ASC LIMIT 1")
+	Errcheck(err)
+	RRdb.Prepstmt.GetJournalAdjustments, err = RRdb.Dbrr.Prepare("SELECT " + flds + " from Journal WHERE Type=3 AND ID=? ORDER BY Dt ASC")
+	Errcheck(err)
 	RRdb.Prepstmt.GetAllJournalsInRange, err = RRdb.Dbrr.Prepare("SELECT " + flds + " from Journal WHERE BID=? AND ?<=Dt AND Dt<?")
 	Errcheck(err)
 
@@ -398,13 +408,62 @@ func buildPreparedStatements() {
 	Errcheck(err)
 	RRdb.Prepstmt.GetJournalMarkers, err = RRdb.Dbrr.Prepare("SELECT " + flds + " from JournalMarker ORDER BY JMID DESC LIMIT ?")
 	Errcheck(err)
+	RRdb.Prepstmt.GetJournalMarkerByRange, err = RRdb.Dbrr.Prepare("SELECT " + flds + " from JournalMarker WHERE BID=? AND DtStart=? AND DtStop=? ORDER BY JMID DESC LIMIT 1")
+	Errcheck(err)
+	RRdb.Prepstmt.GetClosedJournalMarkerForDate, err = RRdb.Dbrr.Prepare("SELECT " + flds + " from JournalMarker WHERE BID=? AND (State=1 OR State=2) AND DtStart<=? AND ?<DtStop LIMIT 1")
+	Errcheck(err)
+	RRdb.Prepstmt.GetClosedJournalMarkerInRange, err = RRdb.Dbrr.Prepare("SELECT " + flds + " from JournalMarker WHERE BID=? AND (State=1 OR State=2) AND DtStart<? AND ?<DtStop ORDER BY DtStart ASC LIMIT 1")
+	Errcheck(err)
+	RRdb.Prepstmt.GetClosedJournalMarkers, err = RRdb.Dbrr.Prepare("SELECT " + flds + " from JournalMarker WHERE BID=? AND (State=1 OR State=2) ORDER BY DtStart DESC")
+	Errcheck(err)
 
-	s1, s2, _, _, _ = GenSQLInsertAndUpdateStrings(flds)
+	s1, s2, s3, _, _ = GenSQLInsertAndUpdateStrings(flds)
 	RRdb.Prepstmt.InsertJournalMarker, err = RRdb.Dbrr.Prepare("INSERT INTO JournalMarker (" + s1 + ") VALUES(" + s2 + ")")
 	Errcheck(err)
+	RRdb.Prepstmt.UpdateJournalMarker, err = RRdb.Dbrr.Prepare("UPDATE JournalMarker SET " + s3 + " WHERE JMID=?")
+	Errcheck(err)
 	RRdb.Prepstmt.DeleteJournalMarker, err = RRdb.Dbrr.Prepare("DELETE FROM JournalMarker WHERE JMID=?")
 	Errcheck(err)
 
+	//==========================================
+	// AUDIT
+	//==========================================
+	flds = "JID,BID,UID,Action,Comment,ModTime"
+	RRdb.DBFields["JournalAudit"] = flds
+	RRdb.Prepstmt.InsertJournalAudit, err = RRdb.Dbrr.Prepare("INSERT INTO JournalAudit (JID,BID,UID,Action,Comment) VALUES(?,?,?,?,?)")
+	Errcheck(err)
+	RRdb.Prepstmt.GetJournalAudit, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM JournalAudit WHERE JID=? ORDER BY ModTime ASC")
+	Errcheck(err)
+	RRdb.Prepstmt.GetJournalAuditInRange, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM JournalAudit WHERE BID=? AND ?<=ModTime AND ModTime<? ORDER BY ModTime ASC")
+	Errcheck(err)
+
+	flds = "JMID,BID,UID,Action,Comment,ModTime"
+	RRdb.DBFields["JournalMarkerAudit"] = flds
+	RRdb.Prepstmt.InsertJournalMarkerAudit, err = RRdb.Dbrr.Prepare("INSERT INTO JournalMarkerAudit (JMID,BID,UID,Action,Comment) VALUES(?,?,?,?,?)")
+	Errcheck(err)
+	RRdb.Prepstmt.GetJournalMarkerAudit, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM JournalMarkerAudit WHERE JMID=? ORDER BY ModTime ASC")
+	Errcheck(err)
+	RRdb.Prepstmt.GetJournalMarkerAuditInRange, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM JournalMarkerAudit WHERE BID=? AND ?<=ModTime AND ModTime<? ORDER BY ModTime ASC")
+	Errcheck(err)
+
+	flds = "LEID,BID,UID,Action,Comment,ModTime"
+	RRdb.DBFields["LedgerAudit"] = flds
+	RRdb.Prepstmt.InsertLedgerAudit, err = RRdb.Dbrr.Prepare("INSERT INTO LedgerAudit (LEID,BID,UID,Action,Comment) VALUES(?,?,?,?,?)")
+	Errcheck(err)
+	RRdb.Prepstmt.GetLedgerAudit, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM LedgerAudit WHERE LEID=? ORDER BY ModTime ASC")
+	Errcheck(err)
+	RRdb.Prepstmt.GetLedgerAuditInRange, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM LedgerAudit WHERE BID=? AND ?<=ModTime AND ModTime<? ORDER BY ModTime ASC")
+	Errcheck(err)
+
+	flds = "LMID,BID,UID,Action,Comment,ModTime"
+	RRdb.DBFields["LedgerMarkerAudit"] = flds
+	RRdb.Prepstmt.InsertLedgerMarkerAudit, err = RRdb.Dbrr.Prepare("INSERT INTO LedgerMarkerAudit (LMID,BID,UID,Action,Comment) VALUES(?,?,?,?,?)")
+	Errcheck(err)
+	RRdb.Prepstmt.GetLedgerMarkerAudit, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM LedgerMarkerAudit WHERE LMID=? ORDER BY ModTime ASC")
+	Errcheck(err)
+	RRdb.Prepstmt.GetLedgerMarkerAuditInRange, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM LedgerMarkerAudit WHERE BID=? AND ?<=ModTime AND ModTime<? ORDER BY ModTime ASC")
+	Errcheck(err)
+
 	//==========================================
 	// LEDGER-->  GLAccount
 	//==========================================
@@ -494,6 +553,12 @@ func buildPreparedStatements() {
 	Errcheck(err)
 	RRdb.Prepstmt.GetRentableLedgerMarkerOnOrBefore, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM LedgerMarker WHERE BID=? and LID=? and RID=? and Dt<=?  ORDER BY Dt DESC LIMIT 1")
 	Errcheck(err)
+	RRdb.Prepstmt.GetLedgerMarker, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM LedgerMarker WHERE LMID=?")
+	Errcheck(err)
+	RRdb.Prepstmt.GetOpenLedgerMarkersOnDate, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM LedgerMarker WHERE BID=? and Dt=? and State=0")
+	Errcheck(err)
+	RRdb.Prepstmt.GetLedgerMarkerDatesAfter, err = RRdb.Dbrr.Prepare("SELECT DISTINCT Dt FROM LedgerMarker WHERE BID=? and RAID=0 and RID=0 and TCID=0 and State=0 and Dt>? ORDER BY Dt ASC")
+	Errcheck(err)
 	RRdb.Prepstmt.DeleteLedgerMarker, err = RRdb.Dbrr.Prepare("DELETE FROM LedgerMarker WHERE LMID=?")
 	Errcheck(err)
 	s1, s2, s3, _, _ = GenSQLInsertAndUpdateStrings(flds)
@@ -590,10 +655,12 @@ func buildPreparedStatements() {
 	//==========================================
 	// PROSPECT
 	//==========================================
-	flds = "TCID,BID,EmployerName,EmployerStreetAddress,EmployerCity,EmployerState,EmployerPostalCode,EmployerEmail,EmployerPhone,Occupation,ApplicationFee,DesiredUsageStartDate,RentableTypePreference,FLAGS,Approver,DeclineReasonSLSID,OtherPreferences,FollowUpDate,CSAgent,OutcomeSLSID,FloatingDeposit,RAID,CreateTS,CreateBy,LastModTime,LastModBy"
+	flds = "TCID,BID,EmployerName,EmployerStreetAddress,EmployerCity,EmployerState,EmployerPostalCode,EmployerEmail,EmployerPhone,Occupation,ApplicationFee,DesiredUsageStartDate,RentableTypePreference,FLAGS,Approver,DeclineReasonSLSID,OtherPreferences,FollowUpDate,CSAgent,OutcomeSLSID,FloatingDeposit,RAID,LSID,CreateTS,CreateBy,LastModTime,LastModBy"
 	RRdb.DBFields["Prospect"] = flds
 	RRdb.Prepstmt.GetProspect, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM Prospect where TCID=?")
 	Errcheck(err)
+	RRdb.Prepstmt.GetProspectsByBusiness, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM Prospect where BID=? ORDER BY TCID ASC")
+	Errcheck(err)
 	_, _, s3, s4, s5 = GenSQLInsertAndUpdateStrings(flds)
 	RRdb.Prepstmt.InsertProspect, err = RRdb.Dbrr.Prepare("INSERT INTO Prospect (" + s4 + ") VALUES(" + s5 + ")")
 	Errcheck(err)
@@ -649,6 +716,8 @@ func buildPreparedStatements() {
 	Errcheck(err)
 	RRdb.Prepstmt.GetAllRatePlanRefsInRange, err = RRdb.Dbrr.Prepare("SELECT " + flds + " from RatePlanRef WHERE ?>=DtStart and ?<DtStop")
 	Errcheck(err)
+	RRdb.Prepstmt.GetAllRatePlanRefs, err = RRdb.Dbrr.Prepare("SELECT " + flds + " from RatePlanRef WHERE RPID=? ORDER BY DtStart ASC")
+	Errcheck(err)
 
 	s1, s2, s3, _, _ = GenSQLInsertAndUpdateStrings(flds)
 	RRdb.Prepstmt.InsertRatePlanRef, err = RRdb.Dbrr.Prepare("INSERT INTO RatePlanRef (" + s1 + ") VALUES(" + s2 + ")")
@@ -711,6 +780,8 @@ func buildPreparedStatements() {
 	Errcheck(err)
 	RRdb.Prepstmt.GetUnallocatedReceiptsByPayor, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM Receipt WHERE BID=? AND TCID=? AND (FLAGS & 3)<2 AND 0=(FLAGS & 4) ORDER BY Dt ASC")
 	Errcheck(err)
+	RRdb.Prepstmt.GetUndepositedReceipts, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM Receipt WHERE BID=? AND DID=0 AND (DEPID=? OR DEPID=0) AND 0=(FLAGS & 4) ORDER BY Dt ASC, RCPTID ASC")
+	Errcheck(err)
 	RRdb.Prepstmt.GetPayorUnallocatedReceiptsCount, err = RRdb.Dbrr.Prepare("SELECT COUNT(*) FROM Receipt WHERE BID=? AND TCID=? AND (FLAGS & 3)<2 AND 0=(FLAGS & 4)")
 	Errcheck(err)
 
@@ -749,7 +820,7 @@ func buildPreparedStatements() {
 	//===============================
 	//  Rentable
 	//===============================
-	flds = "RID,BID,RentableName,AssignmentTime,CreateTS,CreateBy,LastModTime,LastModBy"
+	flds = "RID,BID,RentableName,AssignmentTime,BLDGID,CreateTS,CreateBy,LastModTime,LastModBy"
 	RRdb.DBFields["Rentable"] = flds
 	RRdb.Prepstmt.CountBusinessRentables, err = RRdb.Dbrr.Prepare("SELECT COUNT(RID) FROM Rentable WHERE BID=?")
 	Errcheck(err)
@@ -761,6 +832,8 @@ func buildPreparedStatements() {
 	Errcheck(err)
 	RRdb.Prepstmt.GetAllRentablesByBusiness, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM Rentable WHERE BID=?")
 	Errcheck(err)
+	RRdb.Prepstmt.GetRentablesByBuilding, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM Rentable WHERE BLDGID=? ORDER BY RentableName ASC")
+	Errcheck(err)
 
 	s1, s2, s3, _, _ = GenSQLInsertAndUpdateStrings(flds)
 	RRdb.Prepstmt.InsertRentable, err = RRdb.Dbrr.Prepare("INSERT INTO Rentable (" + s1 + ") VALUES(" + s2 + ")")
@@ -1043,6 +1116,25 @@ func buildPreparedStatements() {
 	RRdb.Prepstmt.DeleteDemandSource, err = RRdb.Dbrr.Prepare("DELETE from DemandSource WHERE SourceSLSID=?")
 	Errcheck(err)
 
+	//==========================================
+	// LEAD SOURCE
+	//==========================================
+	flds = "LSID,BID,Name,IndustrySLID,CreateTS,CreateBy,LastModTime,LastModBy"
+	RRdb.DBFields["LeadSource"] = flds
+	RRdb.Prepstmt.GetLeadSource, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM LeadSource WHERE LSID=?")
+	Errcheck(err)
+	RRdb.Prepstmt.GetLeadSourceByName, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM LeadSource WHERE BID=? and Name=?")
+	Errcheck(err)
+	RRdb.Prepstmt.GetAllLeadSources, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM LeadSource WHERE BID=?")
+	Errcheck(err)
+	s1, s2, s3, _, _ = GenSQLInsertAndUpdateStrings(flds)
+	RRdb.Prepstmt.InsertLeadSource, err = RRdb.Dbrr.Prepare("INSERT INTO LeadSource (" + s1 + ") VALUES(" + s2 + ")")
+	Errcheck(err)
+	RRdb.Prepstmt.UpdateLeadSource, err = RRdb.Dbrr.Prepare("UPDATE LeadSource SET " + s3 + " WHERE LSID=?")
+	Errcheck(err)
+	RRdb.Prepstmt.DeleteLeadSource, err = RRdb.Dbrr.Prepare("DELETE from LeadSource WHERE LSID=?")
+	Errcheck(err)
+
 	//==========================================
 	// STRING LIST
 	//==========================================
@@ -1081,6 +1173,281 @@ func buildPreparedStatements() {
 	RRdb.Prepstmt.DeleteSLStrings, err = RRdb.Dbrr.Prepare("DELETE from SLString WHERE SLID=?")
 	Errcheck(err)
 
+	//==========================================
+	// TAX
+	//==========================================
+	flds = "TAXID,BID,Name,ARID,TaxingAuthority,TaxingAuthorityAddress,FilingDate,FilingCycle,Instructions,CreateTS,CreateBy,LastModTime,LastModBy"
+	RRdb.DBFields["Tax"] = flds
+	RRdb.Prepstmt.GetTax, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM Tax WHERE TAXID=?")
+	Errcheck(err)
+	RRdb.Prepstmt.GetTaxByName, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM Tax WHERE BID=? AND Name=?")
+	Errcheck(err)
+	RRdb.Prepstmt.GetAllTaxes, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM Tax WHERE BID=? ORDER BY TAXID ASC")
+	Errcheck(err)
+	s1, s2, s3, _, _ = GenSQLInsertAndUpdateStrings(flds)
+	RRdb.Prepstmt.InsertTax, err = RRdb.Dbrr.Prepare("INSERT INTO Tax (" + s1 + ") VALUES(" + s2 + ")")
+	Errcheck(err)
+	RRdb.Prepstmt.UpdateTax, err = RRdb.Dbrr.Prepare("UPDATE Tax SET " + s3 + " WHERE TAXID=?")
+	Errcheck(err)
+	RRdb.Prepstmt.DeleteTax, err = RRdb.Dbrr.Prepare("DELETE FROM Tax WHERE TAXID=?")
+	Errcheck(err)
+
+	//==========================================
+	// TAX RATE
+	//==========================================
+	flds = "TRID,TAXID,BID,DtStart,DtStop,Rate,Fee,Formula,CreateTS,CreateBy,LastModTime,LastModBy"
+	RRdb.DBFields["TaxRate"] = flds
+	RRdb.Prepstmt.GetTaxRate, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM TaxRate WHERE TRID=?")
+	Errcheck(err)
+	RRdb.Prepstmt.GetTaxRates, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM TaxRate WHERE TAXID=? ORDER BY DtStart ASC")
+	Errcheck(err)
+	RRdb.Prepstmt.GetTaxRateForDate, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM TaxRate WHERE TAXID=? AND DtStart<=? AND ?<DtStop ORDER BY DtStart DESC LIMIT 1")
+	Errcheck(err)
+	s1, s2, s3, _, _ = GenSQLInsertAndUpdateStrings(flds)
+	RRdb.Prepstmt.InsertTaxRate, err = RRdb.Dbrr.Prepare("INSERT INTO TaxRate (" + s1 + ") VALUES(" + s2 + ")")
+	Errcheck(err)
+	RRdb.Prepstmt.UpdateTaxRate, err = RRdb.Dbrr.Prepare("UPDATE TaxRate SET " + s3 + " WHERE TRID=?")
+	Errcheck(err)
+	RRdb.Prepstmt.DeleteTaxRate, err = RRdb.Dbrr.Prepare("DELETE FROM TaxRate WHERE TRID=?")
+	Errcheck(err)
+	RRdb.Prepstmt.DeleteTaxRates, err = RRdb.Dbrr.Prepare("DELETE FROM TaxRate WHERE TAXID=?")
+	Errcheck(err)
+
+	//==========================================
+	// RENTABLE TYPE TAX
+	//==========================================
+	flds = "RTID,BID,TAXID,DtStart,DtStop,CreateTS,CreateBy"
+	RRdb.DBFields["RentableTypeTax"] = flds
+	RRdb.Prepstmt.GetRentableTypeTaxes, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM RentableTypeTax WHERE RTID=? AND DtStart<=? AND ?<DtStop")
+	Errcheck(err)
+	_, _, _, s4, s5 = GenSQLInsertAndUpdateStrings(flds)
+	RRdb.Prepstmt.InsertRentableTypeTax, err = RRdb.Dbrr.Prepare("INSERT INTO RentableTypeTax (" + s4 + ") VALUES(" + s5 + ")")
+	Errcheck(err)
+	RRdb.Prepstmt.DeleteRentableTypeTax, err = RRdb.Dbrr.Prepare("DELETE FROM RentableTypeTax WHERE RTID=? AND TAXID=?")
+	Errcheck(err)
+
+	//==========================================
+	// RENTAL AGREEMENT TAX
+	//==========================================
+	flds = "RAID,BID,DtStart,DtStop,FLAGS,CreateTS,CreateBy"
+	RRdb.DBFields["RentalAgreementTax"] = flds
+	RRdb.Prepstmt.GetRentalAgreementTax, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM RentalAgreementTax WHERE RAID=? AND DtStart<=? AND ?<DtStop")
+	Errcheck(err)
+	_, _, _, s4, s5 = GenSQLInsertAndUpdateStrings(flds)
+	RRdb.Prepstmt.InsertRentalAgreementTax, err = RRdb.Dbrr.Prepare("INSERT INTO RentalAgreementTax (" + s4 + ") VALUES(" + s5 + ")")
+	Errcheck(err)
+	RRdb.Prepstmt.DeleteRentalAgreementTax, err = RRdb.Dbrr.Prepare("DELETE FROM RentalAgreementTax WHERE RAID=?")
+	Errcheck(err)
+
+	//==========================================
+	// ASSESSMENT TAX
+	//==========================================
+	flds = "ASMID,BID,TAXID,FLAGS,OverrideTaxApprover,OverrideAmount,CreateTS,CreateBy,LastModTime,LastModBy"
+	RRdb.DBFields["AssessmentTax"] = flds
+	RRdb.Prepstmt.GetAssessmentTax, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM AssessmentTax WHERE ASMID=? AND TAXID=?")
+	Errcheck(err)
+	_, _, _, s4, s5 = GenSQLInsertAndUpdateStrings(flds)
+	RRdb.Prepstmt.InsertAssessmentTax, err = RRdb.Dbrr.Prepare("INSERT INTO AssessmentTax (" + s4 + ") VALUES(" + s5 + ")")
+	Errcheck(err)
+
+	//==========================================
+	// COMMISSION LEDGER
+	//==========================================
+	flds = "CLID,BID,RAID,RID,Salesperson,UID,TCID,Percent,Amount,PaymentDueDate,FLAGS,DtPaid,JID,CreateTS,CreateBy,LastModTime,LastModBy"
+	RRdb.DBFields["CommissionLedger"] = flds
+	RRdb.Prepstmt.GetCommissionLedger, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM CommissionLedger WHERE CLID=?")
+	Errcheck(err)
+	RRdb.Prepstmt.GetCommissionLedgersByRAID, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM CommissionLedger WHERE RAID=? ORDER BY CLID ASC")
+	Errcheck(err)
+	RRdb.Prepstmt.GetUnpaidCommissionLedgers, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM CommissionLedger WHERE BID=? AND 0=(FLAGS & 1) AND PaymentDueDate<? ORDER BY PaymentDueDate ASC, CLID ASC")
+	Errcheck(err)
+	s1, s2, s3, _, _ = GenSQLInsertAndUpdateStrings(flds)
+	RRdb.Prepstmt.InsertCommissionLedger, err = RRdb.Dbrr.Prepare("INSERT INTO CommissionLedger (" + s1 + ") VALUES(" + s2 + ")")
+	Errcheck(err)
+	RRdb.Prepstmt.UpdateCommissionLedger, err = RRdb.Dbrr.Prepare("UPDATE CommissionLedger SET " + s3 + " WHERE CLID=?")
+	Errcheck(err)
+	RRdb.Prepstmt.DeleteCommissionLedger, err = RRdb.Dbrr.Prepare("DELETE FROM CommissionLedger WHERE CLID=?")
+	Errcheck(err)
+
+	//==========================================
+	// SERVICE REQUEST
+	//==========================================
+	flds = "SRID,BID,RID,TCID,CategorySLSID,Priority,Description,Assignee,Status,DtReported,DtCompleted,CompletionNotes,ASMID,MSID,DtDue,CreateTS,CreateBy,LastModTime,LastModBy"
+	RRdb.DBFields["ServiceRequest"] = flds
+	RRdb.Prepstmt.GetServiceRequest, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM ServiceRequest WHERE SRID=?")
+	Errcheck(err)
+	RRdb.Prepstmt.GetServiceRequestsByRentable, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM ServiceRequest WHERE RID=? ORDER BY DtReported DESC, SRID DESC")
+	Errcheck(err)
+	RRdb.Prepstmt.GetServiceRequestsInRange, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM ServiceRequest WHERE BID=? AND ?<=DtReported AND DtReported<? ORDER BY DtReported ASC, SRID ASC")
+	Errcheck(err)
+	RRdb.Prepstmt.GetOpenServiceRequests, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM ServiceRequest WHERE BID=? AND Status<4 ORDER BY Priority DESC, DtReported ASC, SRID ASC")
+	Errcheck(err)
+	RRdb.Prepstmt.GetServiceRequestsBySchedule, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM ServiceRequest WHERE MSID=? ORDER BY DtDue ASC, SRID ASC")
+	Errcheck(err)
+	s1, s2, s3, _, _ = GenSQLInsertAndUpdateStrings(flds)
+	RRdb.Prepstmt.InsertServiceRequest, err = RRdb.Dbrr.Prepare("INSERT INTO ServiceRequest (" + s1 + ") VALUES(" + s2 + ")")
+	Errcheck(err)
+	RRdb.Prepstmt.UpdateServiceRequest, err = RRdb.Dbrr.Prepare("UPDATE ServiceRequest SET " + s3 + " WHERE SRID=?")
+	Errcheck(err)
+	RRdb.Prepstmt.DeleteServiceRequest, err = RRdb.Dbrr.Prepare("DELETE FROM ServiceRequest WHERE SRID=?")
+	Errcheck(err)
+
+	flds = "SRSID,SRID,BID,Status,Assignee,Dt,Comment,CreateTS,CreateBy"
+	RRdb.DBFields["ServiceRequestStatus"] = flds
+	RRdb.Prepstmt.GetServiceRequestStatuses, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM ServiceRequestStatus WHERE SRID=? ORDER BY Dt ASC, SRSID ASC")
+	Errcheck(err)
+	s1, s2, _, _, _ = GenSQLInsertAndUpdateStrings(flds)
+	RRdb.Prepstmt.InsertServiceRequestStatus, err = RRdb.Dbrr.Prepare("INSERT INTO ServiceRequestStatus (" + s1 + ") VALUES(" + s2 + ")")
+	Errcheck(err)
+	RRdb.Prepstmt.DeleteServiceRequestStatuses, err = RRdb.Dbrr.Prepare("DELETE FROM ServiceRequestStatus WHERE SRID=?")
+	Errcheck(err)
+
+	//==========================================
+	// MAINTENANCE SCHEDULE
+	//==========================================
+	flds = "MSID,BID,Name,Description,CategorySLSID,Priority,Assignee,RID,RTID,BLDGID,Cycle,DtStart,DtStop,LeadDays,DtLastDue,CreateTS,CreateBy,LastModTime,LastModBy"
+	RRdb.DBFields["MaintenanceSchedule"] = flds
+	RRdb.Prepstmt.GetMaintenanceSchedule, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM MaintenanceSchedule WHERE MSID=?")
+	Errcheck(err)
+	RRdb.Prepstmt.GetAllMaintenanceSchedules, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM MaintenanceSchedule WHERE BID=? ORDER BY Name ASC, MSID ASC")
+	Errcheck(err)
+	s1, s2, s3, _, _ = GenSQLInsertAndUpdateStrings(flds)
+	RRdb.Prepstmt.InsertMaintenanceSchedule, err = RRdb.Dbrr.Prepare("INSERT INTO MaintenanceSchedule (" + s1 + ") VALUES(" + s2 + ")")
+	Errcheck(err)
+	RRdb.Prepstmt.UpdateMaintenanceSchedule, err = RRdb.Dbrr.Prepare("UPDATE MaintenanceSchedule SET " + s3 + " WHERE MSID=?")
+	Errcheck(err)
+	RRdb.Prepstmt.DeleteMaintenanceSchedule, err = RRdb.Dbrr.Prepare("DELETE FROM MaintenanceSchedule WHERE MSID=?")
+	Errcheck(err)
+
+	//==========================================
+	// HOUSEKEEPING STATUS
+	//==========================================
+	flds = "HKSID,BID,RID,Status,Dt,Comment,CreateTS,CreateBy"
+	RRdb.DBFields["HousekeepingStatus"] = flds
+	RRdb.Prepstmt.GetHousekeepingStatus, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM HousekeepingStatus WHERE RID=? ORDER BY HKSID DESC LIMIT 1")
+	Errcheck(err)
+	RRdb.Prepstmt.GetHousekeepingHistory, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM HousekeepingStatus WHERE RID=? AND ?<=Dt AND Dt<? ORDER BY HKSID DESC")
+	Errcheck(err)
+	RRdb.Prepstmt.GetCurrentHousekeepingStatuses, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM HousekeepingStatus WHERE HKSID IN (SELECT MAX(HKSID) FROM HousekeepingStatus WHERE BID=? GROUP BY RID)")
+	Errcheck(err)
+	s1, s2, _, _, _ = GenSQLInsertAndUpdateStrings(flds)
+	RRdb.Prepstmt.InsertHousekeepingStatus, err = RRdb.Dbrr.Prepare("INSERT INTO HousekeepingStatus (" + s1 + ") VALUES(" + s2 + ")")
+	Errcheck(err)
+
+	//==========================================
+	// ROLES AND PERMISSIONS
+	//==========================================
+	flds = "RoleID,Name,Description,CreateTS,CreateBy,LastModTime,LastModBy"
+	RRdb.DBFields["Role"] = flds
+	RRdb.Prepstmt.GetRole, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM Role WHERE RoleID=?")
+	Errcheck(err)
+	RRdb.Prepstmt.GetRoleByName, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM Role WHERE Name=?")
+	Errcheck(err)
+	RRdb.Prepstmt.GetAllRoles, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM Role ORDER BY Name ASC")
+	Errcheck(err)
+	s1, s2, s3, _, _ = GenSQLInsertAndUpdateStrings(flds)
+	RRdb.Prepstmt.InsertRole, err = RRdb.Dbrr.Prepare("INSERT INTO Role (" + s1 + ") VALUES(" + s2 + ")")
+	Errcheck(err)
+	RRdb.Prepstmt.UpdateRole, err = RRdb.Dbrr.Prepare("UPDATE Role SET " + s3 + " WHERE RoleID=?")
+	Errcheck(err)
+	RRdb.Prepstmt.DeleteRole, err = RRdb.Dbrr.Prepare("DELETE FROM Role WHERE RoleID=?")
+	Errcheck(err)
+
+	flds = "RPID,RoleID,BID,Service,Cmd,CreateTS,CreateBy"
+	RRdb.DBFields["RolePermission"] = flds
+	RRdb.Prepstmt.GetRolePermissions, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM RolePermission WHERE RoleID=? ORDER BY BID ASC, Service ASC, Cmd ASC")
+	Errcheck(err)
+	RRdb.Prepstmt.GetUserPermissions, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM RolePermission WHERE RoleID IN (SELECT RoleID FROM UserRole WHERE UID=?)")
+	Errcheck(err)
+	s1, s2, _, _, _ = GenSQLInsertAndUpdateStrings(flds)
+	RRdb.Prepstmt.InsertRolePermission, err = RRdb.Dbrr.Prepare("INSERT INTO RolePermission (" + s1 + ") VALUES(" + s2 + ")")
+	Errcheck(err)
+	RRdb.Prepstmt.DeleteRolePermissions, err = RRdb.Dbrr.Prepare("DELETE FROM RolePermission WHERE RoleID=?")
+	Errcheck(err)
+
+	flds = "URID,UID,RoleID,CreateTS,CreateBy"
+	RRdb.DBFields["UserRole"] = flds
+	RRdb.Prepstmt.GetUserRoles, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM UserRole WHERE UID=?")
+	Errcheck(err)
+	RRdb.Prepstmt.CountUserRoles, err = RRdb.Dbrr.Prepare("SELECT COUNT(URID) FROM UserRole")
+	Errcheck(err)
+	s1, s2, _, _, _ = GenSQLInsertAndUpdateStrings(flds)
+	RRdb.Prepstmt.InsertUserRole, err = RRdb.Dbrr.Prepare("INSERT INTO UserRole (" + s1 + ") VALUES(" + s2 + ")")
+	Errcheck(err)
+	RRdb.Prepstmt.DeleteUserRoles, err = RRdb.Dbrr.Prepare("DELETE FROM UserRole WHERE UID=?")
+	Errcheck(err)
+	RRdb.Prepstmt.DeleteUserRolesByRole, err = RRdb.Dbrr.Prepare("DELETE FROM UserRole WHERE RoleID=?")
+	Errcheck(err)
+
+	//==========================================
+	// BUDGETS
+	//==========================================
+	flds = "BGID,BID,LID,Dt,Amount,CreateTS,CreateBy,LastModTime,LastModBy"
+	RRdb.DBFields["Budget"] = flds
+	RRdb.Prepstmt.GetBudget, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM Budget WHERE BGID=?")
+	Errcheck(err)
+	RRdb.Prepstmt.GetBudgetForMonth, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM Budget WHERE LID=? AND Dt=?")
+	Errcheck(err)
+	RRdb.Prepstmt.GetBudgetsByRange, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM Budget WHERE BID=? AND ?<=Dt AND Dt<? ORDER BY LID ASC, Dt ASC")
+	Errcheck(err)
+	s1, s2, s3, _, _ = GenSQLInsertAndUpdateStrings(flds)
+	RRdb.Prepstmt.InsertBudget, err = RRdb.Dbrr.Prepare("INSERT INTO Budget (" + s1 + ") VALUES(" + s2 + ")")
+	Errcheck(err)
+	RRdb.Prepstmt.UpdateBudget, err = RRdb.Dbrr.Prepare("UPDATE Budget SET " + s3 + " WHERE BGID=?")
+	Errcheck(err)
+	RRdb.Prepstmt.DeleteBudget, err = RRdb.Dbrr.Prepare("DELETE FROM Budget WHERE BGID=?")
+	Errcheck(err)
+
+	flds = "RTBGID,BID,RTID,Dt,GSR,Occupancy,CreateTS,CreateBy,LastModTime,LastModBy"
+	RRdb.DBFields["RentableTypeBudget"] = flds
+	RRdb.Prepstmt.GetRentableTypeBudget, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM RentableTypeBudget WHERE RTBGID=?")
+	Errcheck(err)
+	RRdb.Prepstmt.GetRentableTypeBudgetForMonth, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM RentableTypeBudget WHERE RTID=? AND Dt=?")
+	Errcheck(err)
+	RRdb.Prepstmt.GetRentableTypeBudgetsByRange, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM RentableTypeBudget WHERE BID=? AND ?<=Dt AND Dt<? ORDER BY RTID ASC, Dt ASC")
+	Errcheck(err)
+	s1, s2, s3, _, _ = GenSQLInsertAndUpdateStrings(flds)
+	RRdb.Prepstmt.InsertRentableTypeBudget, err = RRdb.Dbrr.Prepare("INSERT INTO RentableTypeBudget (" + s1 + ") VALUES(" + s2 + ")")
+	Errcheck(err)
+	RRdb.Prepstmt.UpdateRentableTypeBudget, err = RRdb.Dbrr.Prepare("UPDATE RentableTypeBudget SET " + s3 + " WHERE RTBGID=?")
+	Errcheck(err)
+	RRdb.Prepstmt.DeleteRentableTypeBudget, err = RRdb.Dbrr.Prepare("DELETE FROM RentableTypeBudget WHERE RTBGID=?")
+	Errcheck(err)
+
+	//==========================================
+	// GOODS AND SERVICES
+	//==========================================
+	flds = "GSID,BID,Name,Description,Price,ARID,TAXID,FLAGS,CreateTS,CreateBy,LastModTime,LastModBy"
+	RRdb.DBFields["GoodsService"] = flds
+	RRdb.Prepstmt.GetGoodsService, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM GoodsService WHERE GSID=?")
+	Errcheck(err)
+	RRdb.Prepstmt.GetGoodsServiceByName, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM GoodsService WHERE BID=? AND Name=?")
+	Errcheck(err)
+	RRdb.Prepstmt.GetAllGoodsServices, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM GoodsService WHERE BID=? ORDER BY Name ASC")
+	Errcheck(err)
+	s1, s2, s3, _, _ = GenSQLInsertAndUpdateStrings(flds)
+	RRdb.Prepstmt.InsertGoodsService, err = RRdb.Dbrr.Prepare("INSERT INTO GoodsService (" + s1 + ") VALUES(" + s2 + ")")
+	Errcheck(err)
+	RRdb.Prepstmt.UpdateGoodsService, err = RRdb.Dbrr.Prepare("UPDATE GoodsService SET " + s3 + " WHERE GSID=?")
+	Errcheck(err)
+	RRdb.Prepstmt.DeleteGoodsService, err = RRdb.Dbrr.Prepare("DELETE FROM GoodsService WHERE GSID=?")
+	Errcheck(err)
+
+	//==========================================
+	// LATE FEE POLICY
+	//==========================================
+	flds = "LFPID,BID,ARID,GraceDays,Amount,Formula,MaxAmount,CreateTS,CreateBy,LastModTime,LastModBy"
+	RRdb.DBFields["LateFeePolicy"] = flds
+	RRdb.Prepstmt.GetLateFeePolicy, err = RRdb.Dbrr.Prepare("SELECT " + flds + " FROM LateFeePolicy WHERE BID=?")
+	Errcheck(err)
+	s1, s2, s3, _, _ = GenSQLInsertAndUpdateStrings(flds)
+	RRdb.Prepstmt.InsertLateFeePolicy, err = RRdb.Dbrr.Prepare("INSERT INTO LateFeePolicy (" + s1 + ") VALUES(" + s2 + ")")
+	Errcheck(err)
+	RRdb.Prepstmt.UpdateLateFeePolicy, err = RRdb.Dbrr.Prepare("UPDATE LateFeePolicy SET " + s3 + " WHERE LFPID=?")
+	Errcheck(err)
+	RRdb.Prepstmt.DeleteLateFeePolicy, err = RRdb.Dbrr.Prepare("DELETE FROM LateFeePolicy WHERE LFPID=?")
+	Errcheck(err)
+
 	//==========================================
 	// TRANSACTANT
 	//==========================================
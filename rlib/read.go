@@ -7,12 +7,12 @@ import "database/sql"
 
 // ReadAR reads a full AR structure from the database based on the supplied row object
 func ReadAR(row *sql.Row, a *AR) error {
-	return row.Scan(&a.ARID, &a.BID, &a.Name, &a.ARType, &a.DebitLID, &a.CreditLID, &a.Description, &a.RARequired, &a.DtStart, &a.DtStop, &a.CreateTS, &a.CreateBy, &a.LastModTime, &a.LastModBy)
+	return row.Scan(&a.ARID, &a.BID, &a.Name, &a.ARType, &a.DebitLID, &a.CreditLID, &a.Description, &a.RARequired, &a.DtStart, &a.DtStop, &a.FLAGS, &a.CreateTS, &a.CreateBy, &a.LastModTime, &a.LastModBy)
 }
 
 // ReadARs reads a full AR structure from the database based on the supplied rows object
 func ReadARs(rows *sql.Rows, a *AR) error {
-	return rows.Scan(&a.ARID, &a.BID, &a.Name, &a.ARType, &a.DebitLID, &a.CreditLID, &a.Description, &a.RARequired, &a.DtStart, &a.DtStop, &a.CreateTS, &a.CreateBy, &a.LastModTime, &a.LastModBy)
+	return rows.Scan(&a.ARID, &a.BID, &a.Name, &a.ARType, &a.DebitLID, &a.CreditLID, &a.Description, &a.RARequired, &a.DtStart, &a.DtStop, &a.FLAGS, &a.CreateTS, &a.CreateBy, &a.LastModTime, &a.LastModBy)
 }
 
 // ReadAssessment reads a full Assessment structure of data from the database based on the supplied Rows pointer.
@@ -29,6 +29,11 @@ func ReadAssessments(rows *sql.Rows, a *Assessment) {
 		&a.CreateTS, &a.CreateBy, &a.LastModTime, &a.LastModBy))
 }
 
+// ReadAssessmentTax reads a full AssessmentTax structure of data from the database based on the supplied Row pointer.
+func ReadAssessmentTax(row *sql.Row, a *AssessmentTax) error {
+	return row.Scan(&a.ASMID, &a.BID, &a.TAXID, &a.FLAGS, &a.OverrideTaxApprover, &a.OverrideAmount, &a.CreateTS, &a.CreateBy, &a.LastModTime, &a.LastModBy)
+}
+
 // ReadBusiness reads a full Business structure from the database based on the supplied row object
 func ReadBusiness(row *sql.Row, a *Business) {
 	Errcheck(row.Scan(&a.BID, &a.Designation, &a.Name, &a.DefaultRentCycle, &a.DefaultProrationCycle, &a.DefaultGSRPC, &a.CreateTS, &a.CreateBy, &a.LastModTime, &a.LastModBy))
@@ -69,6 +74,16 @@ func ReadDemandSources(rows *sql.Rows, a *DemandSource) {
 	Errcheck(rows.Scan(&a.SourceSLSID, &a.BID, &a.Name, &a.Industry, &a.CreateTS, &a.CreateBy, &a.LastModTime, &a.LastModBy))
 }
 
+// ReadLeadSource reads a full LeadSource structure from the database based on the supplied row object
+func ReadLeadSource(row *sql.Row, a *LeadSource) {
+	Errcheck(row.Scan(&a.LSID, &a.BID, &a.Name, &a.IndustrySLID, &a.CreateTS, &a.CreateBy, &a.LastModTime, &a.LastModBy))
+}
+
+// ReadLeadSources reads a full LeadSource structure from the database based on the supplied rows object
+func ReadLeadSources(rows *sql.Rows, a *LeadSource) {
+	Errcheck(rows.Scan(&a.LSID, &a.BID, &a.Name, &a.IndustrySLID, &a.CreateTS, &a.CreateBy, &a.LastModTime, &a.LastModBy))
+}
+
 // ReadDeposit reads a full Deposit structure from the database based on the supplied row object
 func ReadDeposit(row *sql.Row, a *Deposit) error {
 	return row.Scan(&a.DID, &a.BID, &a.DEPID, &a.DPMID, &a.Dt, &a.Amount, &a.CreateTS, &a.CreateBy, &a.LastModTime, &a.LastModBy)
@@ -115,12 +130,12 @@ func ReadGLAccounts(rows *sql.Rows, a *GLAccount) {
 
 // ReadInvoice reads a full Invoice structure of data from the database based on the supplied Rows pointer.
 func ReadInvoice(row *sql.Row, a *Invoice) {
-	Errcheck(row.Scan(&a.InvoiceNo, &a.BID, &a.Dt, &a.DtDue, &a.Amount, &a.DeliveredBy, &a.CreateTS, &a.CreateBy, &a.LastModTime, &a.LastModBy))
+	Errcheck(row.Scan(&a.InvoiceNo, &a.BID, &a.Dt, &a.DtDue, &a.Amount, &a.DeliveredBy, &a.FLAGS, &a.CreateTS, &a.CreateBy, &a.LastModTime, &a.LastModBy))
 }
 
 // ReadInvoices reads a full Invoice structure of data from the database based on the supplied Rows pointer.
 func ReadInvoices(rows *sql.Rows, a *Invoice) {
-	Errcheck(rows.Scan(&a.InvoiceNo, &a.BID, &a.Dt, &a.DtDue, &a.Amount, &a.DeliveredBy, &a.CreateTS, &a.CreateBy, &a.LastModTime, &a.LastModBy))
+	Errcheck(rows.Scan(&a.InvoiceNo, &a.BID, &a.Dt, &a.DtDue, &a.Amount, &a.DeliveredBy, &a.FLAGS, &a.CreateTS, &a.CreateBy, &a.LastModTime, &a.LastModBy))
 }
 
 // ReadInvoiceAssessments reads a full InvoiceAssessment structure of data from the database based on the supplied Rows pointer.
@@ -173,6 +188,16 @@ func ReadRentableMarketRates(rows *sql.Rows, a *RentableMarketRate) error {
 	return rows.Scan(&a.RMRID, &a.RTID, &a.BID, &a.MarketRate, &a.DtStart, &a.DtStop, &a.CreateTS, &a.CreateBy)
 }
 
+// ReadJournalMarker reads a full JournalMarker structure of data from the database based on the supplied Row pointer.
+func ReadJournalMarker(row *sql.Row, a *JournalMarker) error {
+	return row.Scan(&a.JMID, &a.BID, &a.State, &a.DtStart, &a.DtStop, &a.CreateTS, &a.CreateBy, &a.LastModTime, &a.LastModBy)
+}
+
+// ReadJournalMarkers reads a full JournalMarker structure of data from the database based on the supplied Rows pointer.
+func ReadJournalMarkers(rows *sql.Rows, a *JournalMarker) error {
+	return rows.Scan(&a.JMID, &a.BID, &a.State, &a.DtStart, &a.DtStop, &a.CreateTS, &a.CreateBy, &a.LastModTime, &a.LastModBy)
+}
+
 // ReadLedgerMarker reads a full LedgerMarker structure of data from the database based on the supplied Rows pointer.
 func ReadLedgerMarker(row *sql.Row, a *LedgerMarker) {
 	Errcheck(row.Scan(&a.LMID, &a.LID, &a.BID, &a.RAID, &a.RID, &a.TCID, &a.Dt, &a.Balance, &a.State, &a.CreateTS, &a.CreateBy, &a.LastModTime, &a.LastModBy))
@@ -218,7 +243,7 @@ func ReadProspect(row *sql.Row, a *Prospect) {
 	Errcheck(row.Scan(&a.TCID, &a.BID, &a.EmployerName, &a.EmployerStreetAddress,
 		&a.EmployerCity, &a.EmployerState, &a.EmployerPostalCode, &a.EmployerEmail, &a.EmployerPhone, &a.Occupation,
 		&a.ApplicationFee, &a.DesiredUsageStartDate, &a.RentableTypePreference, &a.FLAGS, &a.Approver, &a.DeclineReasonSLSID,
-		&a.OtherPreferences, &a.FollowUpDate, &a.CSAgent, &a.OutcomeSLSID, &a.FloatingDeposit, &a.RAID,
+		&a.OtherPreferences, &a.FollowUpDate, &a.CSAgent, &a.OutcomeSLSID, &a.FloatingDeposit, &a.RAID, &a.LSID,
 		&a.CreateTS, &a.CreateBy, &a.LastModTime, &a.LastModBy))
 }
 
@@ -227,7 +252,7 @@ func ReadProspects(rows *sql.Rows, a *Prospect) {
 	Errcheck(rows.Scan(&a.TCID, &a.BID, &a.EmployerName, &a.EmployerStreetAddress,
 		&a.EmployerCity, &a.EmployerState, &a.EmployerPostalCode, &a.EmployerEmail, &a.EmployerPhone, &a.Occupation,
 		&a.ApplicationFee, &a.DesiredUsageStartDate, &a.RentableTypePreference, &a.FLAGS, &a.Approver, &a.DeclineReasonSLSID,
-		&a.OtherPreferences, &a.FollowUpDate, &a.CSAgent, &a.OutcomeSLSID, &a.FloatingDeposit, &a.RAID,
+		&a.OtherPreferences, &a.FollowUpDate, &a.CSAgent, &a.OutcomeSLSID, &a.FloatingDeposit, &a.RAID, &a.LSID,
 		&a.CreateTS, &a.CreateBy, &a.LastModTime, &a.LastModBy))
 }
 
@@ -302,12 +327,12 @@ func ReadRentableTypeDown(rows *sql.Rows, a *RentableTypeDown) error {
 
 // ReadRentable reads a full Rentable structure of data from the database based on the supplied Row pointer.
 func ReadRentable(row *sql.Row, a *Rentable) error {
-	return row.Scan(&a.RID, &a.BID, &a.RentableName, &a.AssignmentTime, &a.CreateTS, &a.CreateBy, &a.LastModTime, &a.LastModBy)
+	return row.Scan(&a.RID, &a.BID, &a.RentableName, &a.AssignmentTime, &a.BLDGID, &a.CreateTS, &a.CreateBy, &a.LastModTime, &a.LastModBy)
 }
 
 // ReadRentables reads a full Rentable structure of data from the database based on the supplied Rows pointer.
 func ReadRentables(rows *sql.Rows, a *Rentable) error {
-	return rows.Scan(&a.RID, &a.BID, &a.RentableName, &a.AssignmentTime, &a.CreateTS, &a.CreateBy, &a.LastModTime, &a.LastModBy)
+	return rows.Scan(&a.RID, &a.BID, &a.RentableName, &a.AssignmentTime, &a.BLDGID, &a.CreateTS, &a.CreateBy, &a.LastModTime, &a.LastModBy)
 }
 
 // ReadRentableType reads a full RentableType structure of data from the database based on the supplied Row pointer.
@@ -332,6 +357,11 @@ func ReadRentableTypeRefs(rows *sql.Rows, a *RentableTypeRef) error {
 		&a.CreateTS, &a.CreateBy, &a.LastModTime, &a.LastModBy)
 }
 
+// ReadRentableTypeTaxes reads a full RentableTypeTax structure of data from the database based on the supplied Rows pointer.
+func ReadRentableTypeTaxes(rows *sql.Rows, a *RentableTypeTax) error {
+	return rows.Scan(&a.RTID, &a.BID, &a.TAXID, &a.DtStart, &a.DtStop, &a.CreateTS, &a.CreateBy)
+}
+
 // ReadRentableStatus reads a full RentableStatus structure of data from the database based on the supplied Row pointer.
 func ReadRentableStatus(row *sql.Row, a *RentableStatus) error {
 	return row.Scan(&a.RSID, &a.RID, &a.BID, &a.DtStart, &a.DtStop, &a.DtNoticeToVacate, &a.Status,
@@ -403,6 +433,11 @@ func ReadRentalAgreementRentables(rows *sql.Rows, a *RentalAgreementRentable) er
 	return rows.Scan(&a.RARID, &a.RAID, &a.BID, &a.RID, &a.CLID, &a.ContractRent, &a.RARDtStart, &a.RARDtStop, &a.CreateTS, &a.CreateBy)
 }
 
+// ReadRentalAgreementTaxes reads a full RentalAgreementTax structure of data from the database based on the supplied Rows pointer.
+func ReadRentalAgreementTaxes(rows *sql.Rows, a *RentalAgreementTax) error {
+	return rows.Scan(&a.RAID, &a.BID, &a.DtStart, &a.DtStop, &a.FLAGS, &a.CreateTS, &a.CreateBy)
+}
+
 // ReadRentalAgreementTemplate reads a full RentalAgreementTemplate structure of data from the database based on the supplied Row pointer.
 func ReadRentalAgreementTemplate(row *sql.Row, a *RentalAgreementTemplate) error {
 	return row.Scan(&a.RATID, &a.BID, &a.RATemplateName, &a.CreateTS, &a.CreateBy, &a.LastModTime, &a.LastModBy)
@@ -532,3 +567,131 @@ func ReadCountBusinessRentables(rows *sql.Rows, id *int) {
 func ReadCountBusinessRentalAgreements(rows *sql.Rows, id *int) {
 	Errcheck(rows.Scan(id))
 }
+
+// ReadTax reads a full Tax structure from the database based on the supplied row object
+func ReadTax(row *sql.Row, a *Tax) error {
+	return row.Scan(&a.TAXID, &a.BID, &a.Name, &a.ARID, &a.TaxingAuthority, &a.TaxingAuthorityAddress, &a.FilingDate, &a.FilingCycle, &a.Instructions,
+		&a.CreateTS, &a.CreateBy, &a.LastModTime, &a.LastModBy)
+}
+
+// ReadCommissionLedger reads a full CommissionLedger structure from the database based on the supplied row object
+func ReadCommissionLedger(row *sql.Row, a *CommissionLedger) error {
+	return row.Scan(&a.CLID, &a.BID, &a.RAID, &a.RID, &a.Salesperson, &a.UID, &a.TCID, &a.Percent, &a.Amount, &a.PaymentDueDate,
+		&a.FLAGS, &a.DtPaid, &a.JID, &a.CreateTS, &a.CreateBy, &a.LastModTime, &a.LastModBy)
+}
+
+// ReadCommissionLedgers reads a full CommissionLedger structure from the database based on the supplied rows object
+func ReadCommissionLedgers(rows *sql.Rows, a *CommissionLedger) error {
+	return rows.Scan(&a.CLID, &a.BID, &a.RAID, &a.RID, &a.Salesperson, &a.UID, &a.TCID, &a.Percent, &a.Amount, &a.PaymentDueDate,
+		&a.FLAGS, &a.DtPaid, &a.JID, &a.CreateTS, &a.CreateBy, &a.LastModTime, &a.LastModBy)
+}
+
+// ReadServiceRequest reads a full ServiceRequest structure from the database based on the supplied row object
+func ReadServiceRequest(row *sql.Row, a *ServiceRequest) error {
+	return row.Scan(&a.SRID, &a.BID, &a.RID, &a.TCID, &a.CategorySLSID, &a.Priority, &a.Description, &a.Assignee, &a.Status, &a.DtReported,
+		&a.DtCompleted, &a.CompletionNotes, &a.ASMID, &a.MSID, &a.DtDue, &a.CreateTS, &a.CreateBy, &a.LastModTime, &a.LastModBy)
+}
+
+// ReadServiceRequests reads a full ServiceRequest structure from the database based on the supplied rows object
+func ReadServiceRequests(rows *sql.Rows, a *ServiceRequest) error {
+	return rows.Scan(&a.SRID, &a.BID, &a.RID, &a.TCID, &a.CategorySLSID, &a.Priority, &a.Description, &a.Assignee, &a.Status, &a.DtReported,
+		&a.DtCompleted, &a.CompletionNotes, &a.ASMID, &a.MSID, &a.DtDue, &a.CreateTS, &a.CreateBy, &a.LastModTime, &a.LastModBy)
+}
+
+// ReadServiceRequestStatuses reads a full ServiceRequestStatus structure from the database based on the supplied rows object
+func ReadServiceRequestStatuses(rows *sql.Rows, a *ServiceRequestStatus) error {
+	return rows.Scan(&a.SRSID, &a.SRID, &a.BID, &a.Status, &a.Assignee, &a.Dt, &a.Comment, &a.CreateTS, &a.CreateBy)
+}
+
+// ReadMaintenanceSchedule reads a full MaintenanceSchedule structure from the database based on the supplied row object
+func ReadMaintenanceSchedule(row *sql.Row, a *MaintenanceSchedule) error {
+	return row.Scan(&a.MSID, &a.BID, &a.Name, &a.Description, &a.CategorySLSID, &a.Priority, &a.Assignee, &a.RID, &a.RTID, &a.BLDGID,
+		&a.Cycle, &a.DtStart, &a.DtStop, &a.LeadDays, &a.DtLastDue, &a.CreateTS, &a.CreateBy, &a.LastModTime, &a.LastModBy)
+}
+
+// ReadMaintenanceSchedules reads a full MaintenanceSchedule structure from the database based on the supplied rows object
+func ReadMaintenanceSchedules(rows *sql.Rows, a *MaintenanceSchedule) error {
+	return rows.Scan(&a.MSID, &a.BID, &a.Name, &a.Description, &a.CategorySLSID, &a.Priority, &a.Assignee, &a.RID, &a.RTID, &a.BLDGID,
+		&a.Cycle, &a.DtStart, &a.DtStop, &a.LeadDays, &a.DtLastDue, &a.CreateTS, &a.CreateBy, &a.LastModTime, &a.LastModBy)
+}
+
+// ReadHousekeepingStatus reads a full HousekeepingStatus structure from the database based on the supplied row object
+func ReadHousekeepingStatus(row *sql.Row, a *HousekeepingStatus) error {
+	return row.Scan(&a.HKSID, &a.BID, &a.RID, &a.Status, &a.Dt, &a.Comment, &a.CreateTS, &a.CreateBy)
+}
+
+// ReadHousekeepingStatuses reads a full HousekeepingStatus structure from the database based on the supplied rows object
+func ReadHousekeepingStatuses(rows *sql.Rows, a *HousekeepingStatus) error {
+	return rows.Scan(&a.HKSID, &a.BID, &a.RID, &a.Status, &a.Dt, &a.Comment, &a.CreateTS, &a.CreateBy)
+}
+
+// ReadBudget reads a full Budget structure from the database based on the supplied row object
+func ReadBudget(row *sql.Row, a *Budget) error {
+	return row.Scan(&a.BGID, &a.BID, &a.LID, &a.Dt, &a.Amount, &a.CreateTS, &a.CreateBy, &a.LastModTime, &a.LastModBy)
+}
+
+// ReadBudgets reads a full Budget structure from the database based on the supplied rows object
+func ReadBudgets(rows *sql.Rows, a *Budget) error {
+	return rows.Scan(&a.BGID, &a.BID, &a.LID, &a.Dt, &a.Amount, &a.CreateTS, &a.CreateBy, &a.LastModTime, &a.LastModBy)
+}
+
+// ReadRentableTypeBudget reads a full RentableTypeBudget structure from the database based on the supplied row object
+func ReadRentableTypeBudget(row *sql.Row, a *RentableTypeBudget) error {
+	return row.Scan(&a.RTBGID, &a.BID, &a.RTID, &a.Dt, &a.GSR, &a.Occupancy, &a.CreateTS, &a.CreateBy, &a.LastModTime, &a.LastModBy)
+}
+
+// ReadRentableTypeBudgets reads a full RentableTypeBudget structure from the database based on the supplied rows object
+func ReadRentableTypeBudgets(rows *sql.Rows, a *RentableTypeBudget) error {
+	return rows.Scan(&a.RTBGID, &a.BID, &a.RTID, &a.Dt, &a.GSR, &a.Occupancy, &a.CreateTS, &a.CreateBy, &a.LastModTime, &a.LastModBy)
+}
+
+// ReadGoodsService reads a full GoodsService structure from the database based on the supplied row object
+func ReadGoodsService(row *sql.Row, a *GoodsService) error {
+	return row.Scan(&a.GSID, &a.BID, &a.Name, &a.Description, &a.Price, &a.ARID, &a.TAXID, &a.FLAGS, &a.CreateTS, &a.CreateBy, &a.LastModTime, &a.LastModBy)
+}
+
+// ReadGoodsServices reads a full GoodsService structure from the database based on the supplied rows object
+func ReadGoodsServices(rows *sql.Rows, a *GoodsService) error {
+	return rows.Scan(&a.GSID, &a.BID, &a.Name, &a.Description, &a.Price, &a.ARID, &a.TAXID, &a.FLAGS, &a.CreateTS, &a.CreateBy, &a.LastModTime, &a.LastModBy)
+}
+
+// ReadRole reads a full Role structure from the database based on the supplied row object
+func ReadRole(row *sql.Row, a *Role) error {
+	return row.Scan(&a.RoleID, &a.Name, &a.Description, &a.CreateTS, &a.CreateBy, &a.LastModTime, &a.LastModBy)
+}
+
+// ReadRoles reads a full Role structure from the database based on the supplied rows object
+func ReadRoles(rows *sql.Rows, a *Role) error {
+	return rows.Scan(&a.RoleID, &a.Name, &a.Description, &a.CreateTS, &a.CreateBy, &a.LastModTime, &a.LastModBy)
+}
+
+// ReadRolePermissions reads a full RolePermission structure from the database based on the supplied rows object
+func ReadRolePermissions(rows *sql.Rows, a *RolePermission) error {
+	return rows.Scan(&a.RPID, &a.RoleID, &a.BID, &a.Service, &a.Cmd, &a.CreateTS, &a.CreateBy)
+}
+
+// ReadUserRoles reads a full UserRole structure from the database based on the supplied rows object
+func ReadUserRoles(rows *sql.Rows, a *UserRole) error {
+	return rows.Scan(&a.URID, &a.UID, &a.RoleID, &a.CreateTS, &a.CreateBy)
+}
+
+// ReadLateFeePolicy reads a full LateFeePolicy structure from the database based on the supplied row object
+func ReadLateFeePolicy(row *sql.Row, a *LateFeePolicy) error {
+	return row.Scan(&a.LFPID, &a.BID, &a.ARID, &a.GraceDays, &a.Amount, &a.Formula, &a.MaxAmount, &a.CreateTS, &a.CreateBy, &a.LastModTime, &a.LastModBy)
+}
+
+// ReadTaxes reads a full Tax structure from the database based on the supplied rows object
+func ReadTaxes(rows *sql.Rows, a *Tax) error {
+	return rows.Scan(&a.TAXID, &a.BID, &a.Name, &a.ARID, &a.TaxingAuthority, &a.TaxingAuthorityAddress, &a.FilingDate, &a.FilingCycle, &a.Instructions,
+		&a.CreateTS, &a.CreateBy, &a.LastModTime, &a.LastModBy)
+}
+
+// ReadTaxRate reads a full TaxRate structure from the database based on the supplied row object
+func ReadTaxRate(row *sql.Row, a *TaxRate) error {
+	return row.Scan(&a.TRID, &a.TAXID, &a.BID, &a.DtStart, &a.DtStop, &a.Rate, &a.Fee, &a.Formula, &a.CreateTS, &a.CreateBy, &a.LastModTime, &a.LastModBy)
+}
+
+// ReadTaxRates reads a full TaxRate structure from the database based on the supplied rows object
+func ReadTaxRates(rows *sql.Rows, a *TaxRate) error {
+	return rows.Scan(&a.TRID, &a.TAXID, &a.BID, &a.DtStart, &a.DtStop, &a.Rate, &a.Fee, &a.Formula, &a.CreateTS, &a.CreateBy, &a.LastModTime, &a.LastModBy)
+}
@@ -163,6 +163,7 @@ var RentableStatusString = []string{
 	"employee",
 	"owner occupied",
 	"offline",
+	"occupied",
 }
 
 // RentableStatusToString returns a string representation for the status value
@@ -175,6 +176,11 @@ func RentableStatusToString(n int64) string {
 
 // RentableStatusToNumber returns a number representation for the status value
 func RentableStatusToNumber(rs string) int64 {
+	for i, status := range RentableStatusString {
+		if status == rs { // exact match first, "occupied" is also part of "owner occupied"
+			return int64(i)
+		}
+	}
 	for i, status := range RentableStatusString {
 		if strings.Contains(status, rs) {
 			return int64(i)
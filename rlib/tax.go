@@ -0,0 +1,172 @@
+package rlib
+
+import (
+	"fmt"
+	"time"
+)
+
+// TaxAmount describes the tax computed for one Tax on an assessment
+type TaxAmount struct {
+	TAXID     int64   // which tax
+	Name      string  // name of the tax
+	TRID      int64   // the TaxRate that was used, 0 if the amount was overridden
+	Base      float64 // the taxable amount
+	Amount    float64 // the tax
+	ARID      int64   // the Tax's account rule
+	DebitLID  int64   // receivable debited by the account rule
+	CreditLID int64   // tax liability credited by the account rule
+	AcctRule  string  // how the tax is booked, for example "d 11000 8.25, c 22100 8.25"
+}
+
+// IsRentalAgreementTaxable returns false if a RentalAgreementTax record in effect on dt
+// marks rental agreement raid as non-taxable. Agreements without any RentalAgreementTax
+// records are taxable.
+func IsRentalAgreementTaxable(raid int64, dt *time.Time) bool {
+	if raid == 0 {
+		return true
+	}
+	m := GetRentalAgreementTaxes(raid, dt)
+	for i := 0; i < len(m); i++ {
+		if m[i].FLAGS&RATAXtaxable == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// CalculateTax returns the tax on amount using the supplied TaxRate. If the rate has a
+// Formula, it is evaluated with RpnCalculateEquation where "_" is the taxable amount.
+// Otherwise the tax is Rate percent of amount plus Fee. The Fee is only charged on
+// positive amounts.
+func CalculateTax(xbiz *XBusiness, a *Assessment, tr *TaxRate, amount float64, d1, d2 *time.Time) float64 {
+	if len(tr.Formula) > 0 {
+		var m []AcctRule
+		ctx := RpnCreateCtx(xbiz, a.RID, d1, d2, &m, amount, float64(1))
+		ctx.r = &AcctRule{ASMID: a.ASMID}
+		return RoundToCent(RpnCalculateEquation(&ctx, tr.Formula))
+	}
+	t := amount * tr.Rate / float64(100)
+	if amount > float64(0) {
+		t += tr.Fee
+	}
+	return RoundToCent(t)
+}
+
+// NewTaxCharge returns the assessment for the tax ta due on assessment a. It is
+// a one-time charge dated dt made with the Tax's account rule, so it can be paid
+// like any other assessment. The caller sets who made it.
+func NewTaxCharge(a *Assessment, ta *TaxAmount, dt *time.Time) Assessment {
+	return Assessment{BID: a.BID, RID: a.RID, RAID: a.RAID, ATypeLID: ta.CreditLID, ARID: ta.ARID, Amount: ta.Amount,
+		Start: *dt, Stop: *dt, RentCycle: CYCLENORECUR, ProrationCycle: CYCLENORECUR, FLAGS: ASMTAXCHARGE,
+		Comment: fmt.Sprintf("%s on %s", ta.Name, a.IDtoString())}
+}
+
+// NewTaxAllocation returns the allocation of Journal entry jid that books tax
+// charge t, made for the tax ta on assessment a. It is added to the Journal
+// entry of a, so the tax liability is booked along with the assessment.
+func NewTaxAllocation(jid int64, a, t *Assessment, ta *TaxAmount) JournalAllocation {
+	return JournalAllocation{JID: jid, BID: a.BID, RID: a.RID, RAID: a.RAID, ASMID: t.ASMID, Amount: ta.Amount, AcctRule: ta.AcctRule}
+}
+
+// computeTaxAmount computes the Tax tax on amount for assessment a. An
+// AssessmentTax at marked exempt means no tax, one marked override supplies
+// the tax. Otherwise it is calculated with the TaxRate tr. rule is the Tax's
+// account rule and dgl, cgl are the GL numbers of its debit and credit
+// accounts.
+//
+// Returns:
+//		the tax, its Amount is 0 if there is none
+func computeTaxAmount(xbiz *XBusiness, a *Assessment, tax *Tax, at *AssessmentTax, tr *TaxRate, rule *AR, dgl, cgl string, amount float64, d1, d2 *time.Time) TaxAmount {
+	var ta = TaxAmount{TAXID: tax.TAXID, Name: tax.Name, ARID: tax.ARID, Base: amount}
+	switch {
+	case at.FLAGS&ASMTAXexempt != 0:
+		return ta
+	case at.FLAGS&ASMTAXoverride != 0:
+		ta.Amount = RoundToCent(at.OverrideAmount)
+	default:
+		ta.TRID = tr.TRID
+		ta.Amount = CalculateTax(xbiz, a, tr, amount, d1, d2)
+	}
+	ta.DebitLID = rule.DebitLID
+	ta.CreditLID = rule.CreditLID
+	ta.AcctRule = fmt.Sprintf("d %s %.2f, c %s %.2f", dgl, ta.Amount, cgl, ta.Amount)
+	return ta
+}
+
+// getAssessmentTaxOverride returns the AssessmentTax override for the supplied tax. Recurring
+// assessment instances inherit the override of their parent.
+func getAssessmentTaxOverride(a *Assessment, taxid int64) AssessmentTax {
+	at, _ := GetAssessmentTax(a.ASMID, taxid)
+	if at.ASMID == 0 && a.PASMID > 0 {
+		at, _ = GetAssessmentTax(a.PASMID, taxid)
+	}
+	return at
+}
+
+// GetAssessmentTaxes computes the taxes due on amount for assessment a on date dt. Only
+// rent is taxed: a must be made with an account rule marked ARRENTASSESSMENT, and
+// reversals and tax charges are never taxed. Taxes are taken from the RentableTypeTax
+// records for the Rentable's type on dt, and each is computed using the TaxRate in
+// effect on dt.
+//
+// Parameters:
+//		xbiz   - the business struct
+//		a      - the assessment
+//		amount - the taxable amount (the assessment amount after proration)
+//		dt     - date of the assessment instance
+//		d1-d2  - the time range being covered in this period
+//
+// Returns:
+//		a list of the taxes due, one entry for each tax that applies
+//=================================================================================================
+func GetAssessmentTaxes(xbiz *XBusiness, a *Assessment, amount float64, dt, d1, d2 *time.Time) ([]TaxAmount, error) {
+	funcname := "GetAssessmentTaxes"
+	var t []TaxAmount
+	if a.RID == 0 || amount == float64(0) || a.FLAGS&(ASMREVERSED|ASMTAXCHARGE) != 0 {
+		return t, nil
+	}
+	if !IsRentAR(a.ARID) {
+		return t, nil
+	}
+	if !IsRentalAgreementTaxable(a.RAID, dt) {
+		return t, nil
+	}
+	rtid := GetRTIDForDate(a.RID, dt)
+	if rtid == 0 {
+		return t, nil
+	}
+	rtt := GetRentableTypeTaxes(rtid, dt)
+	for i := 0; i < len(rtt); i++ {
+		tax, err := GetTax(rtt[i].TAXID)
+		if err != nil {
+			return t, fmt.Errorf("%s: could not load Tax %d: %s", funcname, rtt[i].TAXID, err.Error())
+		}
+		at := getAssessmentTaxOverride(a, tax.TAXID)
+		if at.FLAGS&ASMTAXexempt != 0 {
+			continue
+		}
+		var tr TaxRate
+		if at.FLAGS&ASMTAXoverride == 0 {
+			tr, err = GetTaxRateForDate(tax.TAXID, dt)
+			if err != nil && !IsSQLNoResultsError(err) {
+				return t, err
+			}
+			if tr.TRID == 0 {
+				Ulog("%s: Tax %d (%s) has no rate in effect on %s\n", funcname, tax.TAXID, tax.Name, dt.Format(RRDATEINPFMT))
+				continue
+			}
+		}
+		rule, err := GetAR(tax.ARID)
+		if err != nil {
+			return t, fmt.Errorf("%s: could not load account rule %d for Tax %d (%s): %s", funcname, tax.ARID, tax.TAXID, tax.Name, err.Error())
+		}
+		d := GetLedger(rule.DebitLID)
+		c := GetLedger(rule.CreditLID)
+		ta := computeTaxAmount(xbiz, a, &tax, &at, &tr, &rule, d.GLNumber, c.GLNumber, amount, d1, d2)
+		if ta.Amount == float64(0) {
+			continue
+		}
+		t = append(t, ta)
+	}
+	return t, nil
+}
@@ -0,0 +1,124 @@
+package rlib
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestCalculateTax(t *testing.T) {
+	var xbiz XBusiness
+	var a Assessment
+	d1, _ := StringToDate("2017-06-01")
+	d2, _ := StringToDate("2017-07-01")
+	var m = []struct {
+		amount float64
+		rate   float64
+		fee    float64
+		tax    float64
+	}{
+		{1000, 8.25, 0, 82.50},
+		{1000, 8.25, 2, 84.50},
+		{333.33, 7.5, 0, 25.00},
+		{-1000, 8.25, 0, -82.50},
+		{-1000, 8.25, 2, -82.50}, // no fee on a negative amount
+		{0, 5, 2, 0},
+	}
+	for i := 0; i < len(m); i++ {
+		tr := TaxRate{Rate: m[i].rate, Fee: m[i].fee}
+		tax := CalculateTax(&xbiz, &a, &tr, m[i].amount, &d1, &d2)
+		if tax != m[i].tax {
+			t.Errorf("%d: tax on %.2f at %.2f%% + %.2f: expected %.2f, got %.2f", i, m[i].amount, m[i].rate, m[i].fee, m[i].tax, tax)
+		}
+	}
+}
+
+// TestComputeTaxAmount checks the tax GetAssessmentTaxes computes for each Tax:
+// none if the assessment is exempt, the override amount if it is overridden,
+// otherwise the amount at the TaxRate, booked with the Tax's account rule.
+func TestComputeTaxAmount(t *testing.T) {
+	var xbiz XBusiness
+	d1, _ := StringToDate("2017-06-01")
+	d2, _ := StringToDate("2017-07-01")
+	a := Assessment{ASMID: 10, BID: 1, RID: 3, RAID: 4, ARID: 5, Amount: 1000}
+	tax := Tax{TAXID: 1, Name: "Occupancy Tax", ARID: 6}
+	rule := AR{ARID: 6, DebitLID: 11, CreditLID: 22}
+	tr := TaxRate{TRID: 7, TAXID: 1, Rate: 8.25, Fee: 1}
+	var m = []struct {
+		at     AssessmentTax
+		amount float64
+		trid   int64
+	}{
+		{AssessmentTax{}, 83.50, 7},
+		{AssessmentTax{FLAGS: ASMTAXexempt}, 0, 0},
+		{AssessmentTax{FLAGS: ASMTAXoverride, OverrideAmount: 50.004}, 50, 0},
+	}
+	for i := 0; i < len(m); i++ {
+		ta := computeTaxAmount(&xbiz, &a, &tax, &m[i].at, &tr, &rule, "11000", "22100", a.Amount, &d1, &d2)
+		if ta.Amount != m[i].amount || ta.TRID != m[i].trid || ta.Base != a.Amount || ta.TAXID != tax.TAXID {
+			t.Errorf("%d: expected tax %.2f with TRID %d, got %.2f with TRID %d", i, m[i].amount, m[i].trid, ta.Amount, ta.TRID)
+		}
+		if ta.Amount == 0 {
+			continue
+		}
+		rule := fmt.Sprintf("d 11000 %.2f, c 22100 %.2f", m[i].amount, m[i].amount)
+		if ta.AcctRule != rule || ta.ARID != tax.ARID || ta.DebitLID != 11 || ta.CreditLID != 22 {
+			t.Errorf("%d: expected %q on ARID %d, got %q on ARID %d", i, rule, tax.ARID, ta.AcctRule, ta.ARID)
+		}
+	}
+}
+
+// TestTaxChargePaidInFull books a taxed rent assessment with the allocations
+// journalAssessment makes and posts them the way GenerateLedgerEntriesFromJournal
+// does. Paying each assessment in full must bring the receivable back to zero
+// and leave the tax in the tax liability account.
+func TestTaxChargePaidInFull(t *testing.T) {
+	RpnInit()
+	var xbiz XBusiness
+	dt, _ := StringToDate("2017-06-01")
+	d2, _ := StringToDate("2017-07-01")
+	a := Assessment{ASMID: 10, BID: 1, RID: 3, RAID: 4, ARID: 5, ATypeLID: 40, Amount: 1000, Start: dt, Stop: dt,
+		RentCycle: CYCLENORECUR, ProrationCycle: CYCLENORECUR}
+	tax := Tax{TAXID: 1, Name: "Occupancy Tax", ARID: 6}
+	rule := AR{ARID: 6, DebitLID: 11, CreditLID: 22}
+	tr := TaxRate{TRID: 7, TAXID: 1, Rate: 8.25, Fee: 1}
+	ta := computeTaxAmount(&xbiz, &a, &tax, &AssessmentTax{}, &tr, &rule, "11000", "22100", a.Amount, &dt, &d2)
+	tc := NewTaxCharge(&a, &ta, &dt)
+	tc.ASMID = 11
+
+	if tc.Amount != 83.50 || tc.ARID != tax.ARID || tc.ATypeLID != rule.CreditLID || tc.FLAGS&ASMTAXCHARGE == 0 {
+		t.Errorf("tax charge: expected 83.50 on ARID %d, got %.2f on ARID %d, FLAGS = %d", tax.ARID, tc.Amount, tc.ARID, tc.FLAGS)
+	}
+	if tc.RAID != a.RAID || tc.RID != a.RID || tc.RentCycle != CYCLENORECUR {
+		t.Errorf("tax charge: expected a one-time charge to RAID %d RID %d, got RAID %d RID %d RentCycle %d", a.RAID, a.RID, tc.RAID, tc.RID, tc.RentCycle)
+	}
+
+	ja := []JournalAllocation{
+		{JID: 1, BID: a.BID, RID: a.RID, RAID: a.RAID, ASMID: a.ASMID, Amount: a.Amount, AcctRule: "d 11000 1000.00, c 40000 1000.00"},
+		NewTaxAllocation(1, &a, &tc, &ta),
+	}
+	if ja[1].ASMID != tc.ASMID || ja[1].RAID != a.RAID || ja[1].Amount != tc.Amount {
+		t.Errorf("tax allocation: expected ASMID %d RAID %d %.2f, got ASMID %d RAID %d %.2f", tc.ASMID, a.RAID, tc.Amount, ja[1].ASMID, ja[1].RAID, ja[1].Amount)
+	}
+	gl := map[string]float64{}
+	for i := 0; i < len(ja); i++ {
+		m := ParseAcctRule(&xbiz, ja[i].RID, &dt, &d2, ja[i].AcctRule, ja[i].Amount, 1.0)
+		for k := 0; k < len(m); k++ {
+			if m[k].Action == "c" {
+				gl[m[k].Account] -= m[k].Amount
+			} else {
+				gl[m[k].Account] += m[k].Amount
+			}
+		}
+	}
+
+	// pay off every assessment in full
+	for _, p := range []Assessment{a, tc} {
+		gl["11000"] -= p.Amount
+	}
+	if bal := RoundToCent(gl["11000"]); bal != 0 {
+		t.Errorf("receivable after payment: expected 0.00, got %.2f", bal)
+	}
+	if liab := RoundToCent(-gl["22100"]); liab != tc.Amount {
+		t.Errorf("tax liability: expected %.2f, got %.2f", tc.Amount, liab)
+	}
+}
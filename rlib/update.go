@@ -10,7 +10,7 @@ func updateError(err error, n string, a interface{}) error {
 
 // UpdateAR updates an AR record
 func UpdateAR(a *AR) error {
-	_, err := RRdb.Prepstmt.UpdateAR.Exec(a.BID, a.Name, a.ARType, a.DebitLID, a.CreditLID, a.Description, a.RARequired, a.DtStart, a.DtStop, a.LastModBy, a.ARID)
+	_, err := RRdb.Prepstmt.UpdateAR.Exec(a.BID, a.Name, a.ARType, a.DebitLID, a.CreditLID, a.Description, a.RARequired, a.DtStart, a.DtStop, a.FLAGS, a.LastModBy, a.ARID)
 	return updateError(err, "AR", *a)
 }
 
@@ -38,6 +38,12 @@ func UpdateDemandSource(a *DemandSource) error {
 	return updateError(err, "DemandSource", *a)
 }
 
+// UpdateLeadSource updates a LeadSource record in the database
+func UpdateLeadSource(a *LeadSource) error {
+	_, err := RRdb.Prepstmt.UpdateLeadSource.Exec(a.BID, a.Name, a.IndustrySLID, a.LastModBy, a.LSID)
+	return updateError(err, "LeadSource", *a)
+}
+
 // UpdateDeposit updates a Deposit record
 func UpdateDeposit(a *Deposit) error {
 	_, err := RRdb.Prepstmt.UpdateDeposit.Exec(a.BID, a.DEPID, a.DPMID, a.Dt, a.Amount, a.LastModBy, a.DID)
@@ -58,13 +64,25 @@ func UpdateDepository(a *Depository) error {
 
 // UpdateInvoice updates a Invoice record
 func UpdateInvoice(a *Invoice) error {
-	_, err := RRdb.Prepstmt.UpdateInvoice.Exec(a.BID, a.Dt, a.DtDue, a.Amount, a.DeliveredBy, a.LastModBy, a.InvoiceNo)
+	_, err := RRdb.Prepstmt.UpdateInvoice.Exec(a.BID, a.Dt, a.DtDue, a.Amount, a.DeliveredBy, a.FLAGS, a.LastModBy, a.InvoiceNo)
 	return updateError(err, "Deposit", *a)
 }
 
+// UpdateJournalMarker updates a JournalMarker record
+func UpdateJournalMarker(a *JournalMarker) error {
+	_, err := RRdb.Prepstmt.UpdateJournalMarker.Exec(a.BID, a.State, a.DtStart, a.DtStop, a.LastModBy, a.JMID)
+	if err == nil {
+		auditJournalMarker(a, a.LastModBy, AUDITUPDATE)
+	}
+	return updateError(err, "JournalMarker", *a)
+}
+
 // UpdateLedgerMarker updates a LedgerMarker record
 func UpdateLedgerMarker(a *LedgerMarker) error {
 	_, err := RRdb.Prepstmt.UpdateLedgerMarker.Exec(a.LID, a.BID, a.RAID, a.RID, a.TCID, a.Dt, a.Balance, a.State, a.LastModBy, a.LMID)
+	if err == nil {
+		auditLedgerMarker(a, a.LastModBy, AUDITUPDATE)
+	}
 	return updateError(err, "LedgerMarker", *a)
 }
 
@@ -97,13 +115,13 @@ func UpdateProspect(a *Prospect) error {
 	_, err := RRdb.Prepstmt.UpdateProspect.Exec(a.BID, a.EmployerName, a.EmployerStreetAddress, a.EmployerCity,
 		a.EmployerState, a.EmployerPostalCode, a.EmployerEmail, a.EmployerPhone, a.Occupation, a.ApplicationFee,
 		a.DesiredUsageStartDate, a.RentableTypePreference, a.FLAGS, a.Approver, a.DeclineReasonSLSID, a.OtherPreferences,
-		a.FollowUpDate, a.CSAgent, a.OutcomeSLSID, a.FloatingDeposit, a.RAID, a.LastModBy, a.TCID)
+		a.FollowUpDate, a.CSAgent, a.OutcomeSLSID, a.FloatingDeposit, a.RAID, a.LSID, a.LastModBy, a.TCID)
 	return updateError(err, "Prospect", *a)
 }
 
 // UpdateRentable updates a Rentable record in the database
 func UpdateRentable(a *Rentable) error {
-	_, err := RRdb.Prepstmt.UpdateRentable.Exec(a.BID, a.RentableName, a.AssignmentTime, a.LastModBy, a.RID)
+	_, err := RRdb.Prepstmt.UpdateRentable.Exec(a.BID, a.RentableName, a.AssignmentTime, a.BLDGID, a.LastModBy, a.RID)
 	return updateError(err, "Rentable", *a)
 }
 
@@ -233,6 +251,68 @@ func UpdateSLString(a *SLString) error {
 	return updateError(err, "SLString", *a)
 }
 
+// UpdateTax updates a Tax record in the database
+func UpdateTax(a *Tax) error {
+	_, err := RRdb.Prepstmt.UpdateTax.Exec(a.BID, a.Name, a.ARID, a.TaxingAuthority, a.TaxingAuthorityAddress, a.FilingDate, a.FilingCycle, a.Instructions, a.LastModBy, a.TAXID)
+	return updateError(err, "Tax", *a)
+}
+
+// UpdateCommissionLedger updates a CommissionLedger record in the database
+func UpdateCommissionLedger(a *CommissionLedger) error {
+	_, err := RRdb.Prepstmt.UpdateCommissionLedger.Exec(a.BID, a.RAID, a.RID, a.Salesperson, a.UID, a.TCID, a.Percent, a.Amount, a.PaymentDueDate, a.FLAGS, a.DtPaid, a.JID, a.LastModBy, a.CLID)
+	return updateError(err, "CommissionLedger", *a)
+}
+
+// UpdateServiceRequest updates a ServiceRequest record in the database
+func UpdateServiceRequest(a *ServiceRequest) error {
+	_, err := RRdb.Prepstmt.UpdateServiceRequest.Exec(a.BID, a.RID, a.TCID, a.CategorySLSID, a.Priority, a.Description, a.Assignee, a.Status,
+		a.DtReported, a.DtCompleted, a.CompletionNotes, a.ASMID, a.MSID, a.DtDue, a.LastModBy, a.SRID)
+	return updateError(err, "ServiceRequest", *a)
+}
+
+// UpdateMaintenanceSchedule updates a MaintenanceSchedule record in the database
+func UpdateMaintenanceSchedule(a *MaintenanceSchedule) error {
+	_, err := RRdb.Prepstmt.UpdateMaintenanceSchedule.Exec(a.BID, a.Name, a.Description, a.CategorySLSID, a.Priority, a.Assignee, a.RID, a.RTID,
+		a.BLDGID, a.Cycle, a.DtStart, a.DtStop, a.LeadDays, a.DtLastDue, a.LastModBy, a.MSID)
+	return updateError(err, "MaintenanceSchedule", *a)
+}
+
+// UpdateBudget updates a Budget record in the database
+func UpdateBudget(a *Budget) error {
+	_, err := RRdb.Prepstmt.UpdateBudget.Exec(a.BID, a.LID, a.Dt, a.Amount, a.LastModBy, a.BGID)
+	return updateError(err, "Budget", *a)
+}
+
+// UpdateRentableTypeBudget updates a RentableTypeBudget record in the database
+func UpdateRentableTypeBudget(a *RentableTypeBudget) error {
+	_, err := RRdb.Prepstmt.UpdateRentableTypeBudget.Exec(a.BID, a.RTID, a.Dt, a.GSR, a.Occupancy, a.LastModBy, a.RTBGID)
+	return updateError(err, "RentableTypeBudget", *a)
+}
+
+// UpdateGoodsService updates a GoodsService record in the database
+func UpdateGoodsService(a *GoodsService) error {
+	_, err := RRdb.Prepstmt.UpdateGoodsService.Exec(a.BID, a.Name, a.Description, a.Price, a.ARID, a.TAXID, a.FLAGS, a.LastModBy, a.GSID)
+	return updateError(err, "GoodsService", *a)
+}
+
+// UpdateRole updates a Role record in the database
+func UpdateRole(a *Role) error {
+	_, err := RRdb.Prepstmt.UpdateRole.Exec(a.Name, a.Description, a.LastModBy, a.RoleID)
+	return updateError(err, "Role", *a)
+}
+
+// UpdateLateFeePolicy updates a LateFeePolicy record in the database
+func UpdateLateFeePolicy(a *LateFeePolicy) error {
+	_, err := RRdb.Prepstmt.UpdateLateFeePolicy.Exec(a.BID, a.ARID, a.GraceDays, a.Amount, a.Formula, a.MaxAmount, a.LastModBy, a.LFPID)
+	return updateError(err, "LateFeePolicy", *a)
+}
+
+// UpdateTaxRate updates a TaxRate record in the database
+func UpdateTaxRate(a *TaxRate) error {
+	_, err := RRdb.Prepstmt.UpdateTaxRate.Exec(a.TAXID, a.BID, a.DtStart, a.DtStop, a.Rate, a.Fee, a.Formula, a.LastModBy, a.TRID)
+	return updateError(err, "TaxRate", *a)
+}
+
 // UpdateTransactant updates a Transactant record in the database
 func UpdateTransactant(a *Transactant) error {
 	_, err := RRdb.Prepstmt.UpdateTransactant.Exec(a.BID, a.NLID, a.FirstName, a.MiddleName, a.LastName, a.PreferredName, a.CompanyName, a.IsCompany, a.PrimaryEmail, a.SecondaryEmail, a.WorkPhone, a.CellPhone, a.Address, a.Address2, a.City, a.State, a.PostalCode, a.Country, a.Website, a.LastModBy, a.TCID)
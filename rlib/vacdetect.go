@@ -75,7 +75,7 @@ func VacancyDetect(xbiz *XBusiness, d1, d2 *time.Time, r *Rentable) []VacancyMar
 		}
 
 		switch state {
-		case RENTABLESTATUSONLINE:
+		case RENTABLESTATUSONLINE, RENTABLESTATUSOCCUPIED:
 			// fmt.Printf("\tonline... ")
 			for i := 0; i < len(t); i++ {
 				if DateRangeOverlap(&t[i].RARDtStart, &t[i].RARDtStop, &dt, &dtNext) {
@@ -0,0 +1,65 @@
+package rrpt
+
+import (
+	"gotable"
+	"rentroll/bizlogic"
+	"rentroll/rlib"
+)
+
+// MarketingAttributionReportTable generates a table of the prospects, applicants,
+// signed agreements, conversion rate and rent revenue of business ri.Bid for
+// each DemandSource and LeadSource during ri.D1 - ri.D2.
+func MarketingAttributionReportTable(ri *ReporterInfo) gotable.Table {
+	funcname := "MarketingAttributionReportTable"
+
+	// prepare and init some values
+	ri.RptHeaderD1 = true
+	ri.RptHeaderD2 = true
+
+	// table init
+	tbl := getRRTable()
+
+	tbl.AddColumn("Source Type", 12, gotable.CELLSTRING, gotable.COLJUSTIFYLEFT)
+	tbl.AddColumn("Source", 35, gotable.CELLSTRING, gotable.COLJUSTIFYLEFT)
+	tbl.AddColumn("Prospects", 9, gotable.CELLINT, gotable.COLJUSTIFYRIGHT)
+	tbl.AddColumn("Applicants", 10, gotable.CELLINT, gotable.COLJUSTIFYRIGHT)
+	tbl.AddColumn("Agreements", 10, gotable.CELLINT, gotable.COLJUSTIFYRIGHT)
+	tbl.AddColumn("Conversion %", 12, gotable.CELLFLOAT, gotable.COLJUSTIFYRIGHT)
+	tbl.AddColumn("Rent Revenue", 12, gotable.CELLFLOAT, gotable.COLJUSTIFYRIGHT)
+
+	// prepare table's title, sections
+	err := TableReportHeaderBlock(&tbl, "Marketing Attribution", funcname, ri)
+	if err != nil {
+		rlib.LogAndPrintError(funcname, err)
+		return tbl
+	}
+
+	a := bizlogic.GetAttribution(ri.Xbiz.P.BID, &ri.D1, &ri.D2)
+	addRows := func(t string, m []bizlogic.AttributionEntry) {
+		for i := 0; i < len(m); i++ {
+			tbl.AddRow()
+			tbl.Puts(-1, 0, t)
+			tbl.Puts(-1, 1, m[i].Name)
+			tbl.Puti(-1, 2, m[i].Prospects)
+			tbl.Puti(-1, 3, m[i].Applicants)
+			tbl.Puti(-1, 4, m[i].Agreements)
+			tbl.Putf(-1, 5, m[i].Conversion)
+			tbl.Putf(-1, 6, m[i].Revenue)
+		}
+	}
+	addRows("Demand", a.DemandSources)
+	addRows("Lead", a.LeadSources)
+
+	if len(tbl.Row) == 0 {
+		tbl.SetSection3(NoRecordsFoundMsg)
+		return tbl
+	}
+	tbl.TightenColumns()
+	return tbl
+}
+
+// MarketingAttributionReport generates a text version of the marketing attribution report
+func MarketingAttributionReport(ri *ReporterInfo) string {
+	tbl := MarketingAttributionReportTable(ri)
+	return ReportToString(&tbl, ri)
+}
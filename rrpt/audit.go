@@ -0,0 +1,72 @@
+package rrpt
+
+import (
+	"gotable"
+	"rentroll/rlib"
+)
+
+// auditActionName returns a printable name for an audit action
+func auditActionName(action int64) string {
+	switch action {
+	case rlib.AUDITCREATE:
+		return "create"
+	case rlib.AUDITUPDATE:
+		return "update"
+	case rlib.AUDITDELETE:
+		return "delete"
+	}
+	return "?"
+}
+
+// AuditTrailReportTable generates a table of every create, update and delete of the
+// Journal, JournalMarker, LedgerEntry and LedgerMarker records made during ri.D1 - ri.D2,
+// in the order the changes were made.
+func AuditTrailReportTable(ri *ReporterInfo) gotable.Table {
+	funcname := "AuditTrailReportTable"
+
+	// init and prepare some values before table init
+	ri.RptHeaderD1 = true
+	ri.RptHeaderD2 = true
+
+	// table init
+	tbl := getRRTable()
+
+	tbl.AddColumn("Time", 23, gotable.CELLSTRING, gotable.COLJUSTIFYLEFT)
+	tbl.AddColumn("Table", 13, gotable.CELLSTRING, gotable.COLJUSTIFYLEFT)
+	tbl.AddColumn("ID", 9, gotable.CELLSTRING, gotable.COLJUSTIFYLEFT)
+	tbl.AddColumn("Action", 6, gotable.CELLSTRING, gotable.COLJUSTIFYLEFT)
+	tbl.AddColumn("UID", 6, gotable.CELLINT, gotable.COLJUSTIFYRIGHT)
+	tbl.AddColumn("Values", 60, gotable.CELLSTRING, gotable.COLJUSTIFYLEFT)
+
+	// set table title, sections
+	err := TableReportHeaderBlock(&tbl, "Audit Trail", funcname, ri)
+	if err != nil {
+		rlib.LogAndPrintError(funcname, err)
+		return tbl
+	}
+
+	pfx := map[string]string{"Journal": "J", "JournalMarker": "JM", "LedgerEntry": "LE", "LedgerMarker": "LM"}
+	m := rlib.GetAuditTrail(ri.Xbiz.P.BID, &ri.D1, &ri.D2)
+	for i := 0; i < len(m); i++ {
+		tbl.AddRow()
+		tbl.Puts(-1, 0, m[i].ModTime.Format(rlib.RRDATETIMEINPFMT))
+		tbl.Puts(-1, 1, m[i].Table)
+		tbl.Puts(-1, 2, rlib.IDtoString(pfx[m[i].Table], m[i].ID))
+		tbl.Puts(-1, 3, auditActionName(m[i].Action))
+		tbl.Puti(-1, 4, m[i].UID)
+		tbl.Puts(-1, 5, m[i].Comment)
+	}
+
+	if len(m) == 0 {
+		tbl.SetSection3(NoRecordsFoundMsg)
+		return tbl
+	}
+	tbl.TightenColumns()
+	return tbl
+}
+
+// AuditTrailReport generates a text report of the audit trail
+func AuditTrailReport(ri *ReporterInfo) string {
+	tbl := AuditTrailReportTable(ri)
+	return ReportToString(&tbl, ri)
+}
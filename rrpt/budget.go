@@ -0,0 +1,78 @@
+package rrpt
+
+import (
+	"gotable"
+	"rentroll/bizlogic"
+	"rentroll/rlib"
+)
+
+// BudgetVsActualReportTable generates a table comparing the budget of each
+// GL Account of business ri.Bid with its ledger activity, and the GSR and
+// occupancy targets of each RentableType that is managed to budget with
+// their actual figures, for the months in ri.D1 - ri.D2 and for the year
+// to date.
+func BudgetVsActualReportTable(ri *ReporterInfo) gotable.Table {
+	funcname := "BudgetVsActualReportTable"
+
+	// prepare and init some values
+	ri.RptHeaderD1 = true
+	ri.RptHeaderD2 = true
+
+	// table init
+	tbl := getRRTable()
+
+	tbl.AddColumn("Item", 35, gotable.CELLSTRING, gotable.COLJUSTIFYLEFT)
+	tbl.AddColumn("Budget", 12, gotable.CELLFLOAT, gotable.COLJUSTIFYRIGHT)
+	tbl.AddColumn("Actual", 12, gotable.CELLFLOAT, gotable.COLJUSTIFYRIGHT)
+	tbl.AddColumn("Variance", 12, gotable.CELLFLOAT, gotable.COLJUSTIFYRIGHT)
+	tbl.AddColumn("Var %", 8, gotable.CELLFLOAT, gotable.COLJUSTIFYRIGHT)
+	tbl.AddColumn("YTD Budget", 12, gotable.CELLFLOAT, gotable.COLJUSTIFYRIGHT)
+	tbl.AddColumn("YTD Actual", 12, gotable.CELLFLOAT, gotable.COLJUSTIFYRIGHT)
+	tbl.AddColumn("YTD Variance", 12, gotable.CELLFLOAT, gotable.COLJUSTIFYRIGHT)
+
+	// prepare table's title, sections
+	err := TableReportHeaderBlock(&tbl, "Budget vs Actual", funcname, ri)
+	if err != nil {
+		rlib.LogAndPrintError(funcname, err)
+		return tbl
+	}
+
+	a, err := bizlogic.GetAccountBudgetVariance(ri.Xbiz.P.BID, &ri.D1, &ri.D2)
+	if err != nil {
+		rlib.LogAndPrintError(funcname, err)
+		tbl.SetSection3(err.Error())
+		return tbl
+	}
+	rt, err := bizlogic.GetRentableTypeBudgetVariance(ri.Xbiz.P.BID, &ri.D1, &ri.D2)
+	if err != nil {
+		rlib.LogAndPrintError(funcname, err)
+		tbl.SetSection3(err.Error())
+		return tbl
+	}
+
+	m := append(a, rt...)
+	for i := 0; i < len(m); i++ {
+		tbl.AddRow()
+		tbl.Puts(-1, 0, m[i].Item)
+		tbl.Putf(-1, 1, m[i].Budget)
+		tbl.Putf(-1, 2, m[i].Actual)
+		tbl.Putf(-1, 3, m[i].Variance)
+		tbl.Putf(-1, 4, m[i].VariancePct)
+		tbl.Putf(-1, 5, m[i].YTDBudget)
+		tbl.Putf(-1, 6, m[i].YTDActual)
+		tbl.Putf(-1, 7, m[i].YTDVariance)
+	}
+
+	if len(tbl.Row) == 0 {
+		tbl.SetSection3(NoRecordsFoundMsg)
+		return tbl
+	}
+	tbl.TightenColumns()
+	return tbl
+}
+
+// BudgetVsActualReport generates a text version of the budget vs actual report
+func BudgetVsActualReport(ri *ReporterInfo) string {
+	tbl := BudgetVsActualReportTable(ri)
+	return ReportToString(&tbl, ri)
+}
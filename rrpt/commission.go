@@ -0,0 +1,62 @@
+package rrpt
+
+import (
+	"gotable"
+	"rentroll/rlib"
+)
+
+// CommissionsDueReportTable generates a table of the commissions of business
+// ri.Bid that are unpaid and due before ri.D2.
+func CommissionsDueReportTable(ri *ReporterInfo) gotable.Table {
+	funcname := "CommissionsDueReportTable"
+
+	// prepare and init some values
+	ri.RptHeaderD1 = false
+	ri.RptHeaderD2 = true
+
+	// table init
+	tbl := getRRTable()
+
+	tbl.AddColumn("Commission", 10, gotable.CELLSTRING, gotable.COLJUSTIFYLEFT)
+	tbl.AddColumn("Due", 10, gotable.CELLDATE, gotable.COLJUSTIFYLEFT)
+	tbl.AddColumn("Salesperson", 25, gotable.CELLSTRING, gotable.COLJUSTIFYLEFT)
+	tbl.AddColumn("Rental Agreement", 10, gotable.CELLSTRING, gotable.COLJUSTIFYLEFT)
+	tbl.AddColumn("Rentable", 10, gotable.CELLSTRING, gotable.COLJUSTIFYLEFT)
+	tbl.AddColumn("Percent", 8, gotable.CELLFLOAT, gotable.COLJUSTIFYRIGHT)
+	tbl.AddColumn("Amount", 12, gotable.CELLFLOAT, gotable.COLJUSTIFYRIGHT)
+
+	// prepare table's title, sections
+	err := TableReportHeaderBlock(&tbl, "Commissions Due", funcname, ri)
+	if err != nil {
+		rlib.LogAndPrintError(funcname, err)
+		return tbl
+	}
+
+	m := rlib.GetUnpaidCommissionLedgers(ri.Xbiz.P.BID, &ri.D2)
+	for i := 0; i < len(m); i++ {
+		r := rlib.GetRentable(m[i].RID)
+		tbl.AddRow()
+		tbl.Puts(-1, 0, m[i].IDtoString())
+		tbl.Putd(-1, 1, m[i].PaymentDueDate)
+		tbl.Puts(-1, 2, m[i].Salesperson)
+		tbl.Puts(-1, 3, rlib.IDtoString("RA", m[i].RAID))
+		tbl.Puts(-1, 4, r.RentableName)
+		tbl.Putf(-1, 5, m[i].Percent)
+		tbl.Putf(-1, 6, m[i].Amount)
+	}
+
+	if len(m) == 0 {
+		tbl.SetSection3(NoRecordsFoundMsg)
+		return tbl
+	}
+	tbl.AddLineAfter(len(tbl.Row) - 1)
+	tbl.InsertSumRow(len(tbl.Row), 0, len(tbl.Row)-1, []int{6})
+	tbl.TightenColumns()
+	return tbl
+}
+
+// CommissionsDueReport generates a text version of the commissions due report
+func CommissionsDueReport(ri *ReporterInfo) string {
+	tbl := CommissionsDueReportTable(ri)
+	return ReportToString(&tbl, ri)
+}
@@ -3,6 +3,7 @@ package rrpt
 import (
 	"fmt"
 	"gotable"
+	"rentroll/bizlogic"
 	"rentroll/rlib"
 	"strings"
 	"time"
@@ -129,3 +130,60 @@ func DelinquencyReport(ri *ReporterInfo) string {
 	tbl := DelinquencyReportTable(ri)
 	return ReportToString(&tbl, ri)
 }
+
+// DelinquencyAgingReportTable generates a table of the unpaid balance of each Rental Agreement
+// as of ri.D2, aged by how long its unpaid assessments have been past due.
+func DelinquencyAgingReportTable(ri *ReporterInfo) gotable.Table {
+	funcname := "DelinquencyAgingReportTable"
+
+	// prepare and init some values
+	ri.RptHeaderD1 = false
+	ri.RptHeaderD2 = true
+
+	// table init
+	tbl := getRRTable()
+
+	tbl.AddColumn("Rental Agreement", 10, gotable.CELLSTRING, gotable.COLJUSTIFYLEFT)
+	tbl.AddColumn("Payors", 30, gotable.CELLSTRING, gotable.COLJUSTIFYLEFT)
+	tbl.AddColumn("Current", 10, gotable.CELLFLOAT, gotable.COLJUSTIFYRIGHT)
+	tbl.AddColumn("1-30 Days", 10, gotable.CELLFLOAT, gotable.COLJUSTIFYRIGHT)
+	tbl.AddColumn("31-60 Days", 10, gotable.CELLFLOAT, gotable.COLJUSTIFYRIGHT)
+	tbl.AddColumn("61-90 Days", 10, gotable.CELLFLOAT, gotable.COLJUSTIFYRIGHT)
+	tbl.AddColumn("Over 90 Days", 10, gotable.CELLFLOAT, gotable.COLJUSTIFYRIGHT)
+	tbl.AddColumn("Total", 10, gotable.CELLFLOAT, gotable.COLJUSTIFYRIGHT)
+
+	// prepare table's title, sections
+	err := TableReportHeaderBlock(&tbl, "Delinquency Aging", funcname, ri)
+	if err != nil {
+		rlib.LogAndPrintError(funcname, err)
+		return tbl
+	}
+
+	m := bizlogic.GetAging(ri.Xbiz.P.BID, &ri.D2)
+	for i := 0; i < len(m); i++ {
+		tbl.AddRow()
+		tbl.Puts(-1, 0, rlib.IDtoString("RA", m[i].RAID))
+		if ra, err := rlib.GetRentalAgreement(m[i].RAID); err == nil {
+			tbl.Puts(-1, 1, strings.Join(ra.GetPayorNameList(&ri.D2, &ri.D2), ", "))
+		}
+		for j := 0; j < bizlogic.AGINGBUCKETS; j++ {
+			tbl.Putf(-1, 2+j, m[i].Bucket[j])
+		}
+		tbl.Putf(-1, 7, m[i].Total)
+	}
+
+	if len(m) == 0 {
+		tbl.SetSection3(NoRecordsFoundMsg)
+		return tbl
+	}
+	tbl.AddLineAfter(len(tbl.Row) - 1)
+	tbl.InsertSumRow(len(tbl.Row), 0, len(tbl.Row)-1, []int{2, 3, 4, 5, 6, 7})
+	tbl.TightenColumns()
+	return tbl
+}
+
+// DelinquencyAgingReport generates a text report of the delinquency aging
+func DelinquencyAgingReport(ri *ReporterInfo) string {
+	tbl := DelinquencyAgingReportTable(ri)
+	return ReportToString(&tbl, ri)
+}
@@ -0,0 +1,82 @@
+package rrpt
+
+import (
+	"fmt"
+	"gotable"
+	"rentroll/bizlogic"
+	"rentroll/rlib"
+)
+
+// DepositSlipTable generates the deposit slip for deposit d: the receipts that
+// make up the deposit and its total.
+func DepositSlipTable(ri *ReporterInfo, d *rlib.Deposit) gotable.Table {
+	funcname := "DepositSlipTable"
+
+	// init and prepare some values before table init. The header shows the
+	// deposit date rather than the report period.
+	rih := *ri
+	rih.D1 = d.Dt
+	rih.RptHeaderD1 = true
+	rih.RptHeaderD2 = false
+	dep, _ := rlib.GetDepository(d.DEPID)
+	dpm, _ := rlib.GetDepositMethod(d.DPMID)
+
+	// table init
+	tbl := getRRTable()
+
+	tbl.AddColumn("Date", 10, gotable.CELLDATE, gotable.COLJUSTIFYLEFT)
+	tbl.AddColumn("Receipt", 11, gotable.CELLSTRING, gotable.COLJUSTIFYLEFT)
+	tbl.AddColumn("Doc No", 15, gotable.CELLSTRING, gotable.COLJUSTIFYLEFT)
+	tbl.AddColumn("Payor", 30, gotable.CELLSTRING, gotable.COLJUSTIFYLEFT)
+	tbl.AddColumn("Amount", 12, gotable.CELLFLOAT, gotable.COLJUSTIFYRIGHT)
+
+	s := fmt.Sprintf("Deposit Slip %s\nDepository: %s\nAccount No: %s\nMethod: %s\n",
+		d.IDtoString(), dep.Name, dep.AccountNo, dpm.Name)
+	err := TableReportHeaderBlock(&tbl, s, funcname, &rih)
+	if err != nil {
+		rlib.LogAndPrintError(funcname, err)
+		return tbl
+	}
+
+	m := bizlogic.GetDepositReceipts(d)
+	for i := 0; i < len(m); i++ {
+		var t rlib.Transactant
+		if m[i].TCID > 0 {
+			rlib.GetTransactant(m[i].TCID, &t)
+		}
+		tbl.AddRow()
+		tbl.Putd(-1, 0, m[i].Dt)
+		tbl.Puts(-1, 1, m[i].IDtoString())
+		tbl.Puts(-1, 2, m[i].DocNo)
+		tbl.Puts(-1, 3, t.GetFullTransactantName())
+		tbl.Putf(-1, 4, m[i].Amount)
+	}
+	if len(m) == 0 {
+		tbl.SetSection3(NoRecordsFoundMsg)
+		return tbl
+	}
+	tbl.AddLineAfter(len(tbl.Row) - 1)
+	tbl.InsertSumRow(len(tbl.Row), 0, len(tbl.Row)-1, []int{4})
+	tbl.TightenColumns()
+	return tbl
+}
+
+// DepositSlipsReportTable generates a deposit slip for deposit ri.ID or, if
+// ri.ID is 0, for each deposit of business ri.Bid made in the period
+// ri.D1 - ri.D2.
+func DepositSlipsReportTable(ri *ReporterInfo) []gotable.Table {
+	var m []gotable.Table
+	var n []rlib.Deposit
+	if ri.ID > 0 {
+		d, err := rlib.GetDeposit(ri.ID)
+		if err == nil && d.BID == ri.Xbiz.P.BID {
+			n = append(n, d)
+		}
+	} else {
+		n = rlib.GetAllDepositsInRange(ri.Xbiz.P.BID, &ri.D1, &ri.D2)
+	}
+	for i := 0; i < len(n); i++ {
+		m = append(m, DepositSlipTable(ri, &n[i]))
+	}
+	return m
+}
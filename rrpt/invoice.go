@@ -2,10 +2,94 @@ package rrpt
 
 import (
 	"fmt"
+	"gotable"
+	"rentroll/bizlogic"
 	"rentroll/rlib"
 	"strings"
 )
 
+// InvoiceReportTable generates a table for invoice inv that can be printed as
+// text, html, or pdf.
+func InvoiceReportTable(ri *ReporterInfo, inv *rlib.Invoice) gotable.Table {
+	funcname := "InvoiceReportTable"
+
+	// init and prepare some values before table init. The header shows the
+	// invoice date rather than the report period.
+	rih := *ri
+	rih.D1 = inv.Dt
+	rih.RptHeaderD1 = true
+	rih.RptHeaderD2 = false
+	var payors []string
+	for i := 0; i < len(inv.P); i++ {
+		var t rlib.Transactant
+		rlib.GetTransactant(inv.P[i].PID, &t)
+		payors = append(payors, t.GetFullTransactantName())
+	}
+
+	// table init
+	tbl := getRRTable()
+
+	tbl.AddColumn("Date", 10, gotable.CELLDATE, gotable.COLJUSTIFYLEFT)
+	tbl.AddColumn("Assessment", 11, gotable.CELLSTRING, gotable.COLJUSTIFYLEFT)
+	tbl.AddColumn("Rentable", 15, gotable.CELLSTRING, gotable.COLJUSTIFYLEFT)
+	tbl.AddColumn("Description", 40, gotable.CELLSTRING, gotable.COLJUSTIFYLEFT)
+	tbl.AddColumn("Amount", 12, gotable.CELLFLOAT, gotable.COLJUSTIFYRIGHT)
+	tbl.AddColumn("Unpaid", 12, gotable.CELLFLOAT, gotable.COLJUSTIFYRIGHT)
+	tbl.AddColumn("Comment", 20, gotable.CELLSTRING, gotable.COLJUSTIFYLEFT)
+
+	s := fmt.Sprintf("Invoice %s\nDue From: %s\nDate Due: %s\nDelivered By: %s\nStatus: %s\n",
+		inv.IDtoString(), strings.Join(payors, ", "), inv.DtDue.Format(rlib.RRDATEREPORTFMT), inv.DeliveredBy, bizlogic.InvoiceStatus(inv))
+	err := TableReportHeaderBlock(&tbl, s, funcname, &rih)
+	if err != nil {
+		rlib.LogAndPrintError(funcname, err)
+		return tbl
+	}
+
+	for i := 0; i < len(inv.A); i++ {
+		a, err := rlib.GetAssessment(inv.A[i].ASMID)
+		if err != nil {
+			rlib.LogAndPrintError(funcname, err)
+			continue
+		}
+		r := rlib.GetRentable(a.RID)
+		tbl.AddRow()
+		tbl.Putd(-1, 0, a.Start)
+		tbl.Puts(-1, 1, a.IDtoString())
+		tbl.Puts(-1, 2, r.RentableName)
+		tbl.Puts(-1, 3, rlib.RRdb.BizTypes[inv.BID].GLAccounts[a.ATypeLID].Name)
+		tbl.Putf(-1, 4, a.Amount)
+		tbl.Putf(-1, 5, bizlogic.AssessmentUnpaidPortion(&a))
+		tbl.Puts(-1, 6, a.Comment)
+	}
+	if len(inv.A) == 0 {
+		tbl.SetSection3(NoRecordsFoundMsg)
+		return tbl
+	}
+	tbl.AddLineAfter(len(tbl.Row) - 1)
+	tbl.InsertSumRow(len(tbl.Row), 0, len(tbl.Row)-1, []int{4, 5})
+	tbl.TightenColumns()
+	return tbl
+}
+
+// InvoicesReportTable generates a table for invoice ri.ID or, if ri.ID is 0,
+// for each invoice of business ri.Bid dated in the period ri.D1 - ri.D2.
+func InvoicesReportTable(ri *ReporterInfo) []gotable.Table {
+	var m []gotable.Table
+	var n []rlib.Invoice
+	if ri.ID > 0 {
+		inv, err := rlib.GetInvoice(ri.ID)
+		if err == nil && inv.BID == ri.Xbiz.P.BID {
+			n = append(n, inv)
+		}
+	} else {
+		n = rlib.GetInvoicesByDtRange(ri.Xbiz.P.BID, &ri.D1, &ri.D2)
+	}
+	for i := 0; i < len(n); i++ {
+		m = append(m, InvoiceReportTable(ri, &n[i]))
+	}
+	return m
+}
+
 // InvoiceTextReport generates a text invoice for the supplied InvoiceNo
 func InvoiceTextReport(id int64) error {
 	var noerr error
@@ -253,6 +253,49 @@ func textPrintJournalUnassociated(tbl *gotable.Table, xbiz *rlib.XBusiness, jctx
 	tbl.AddRow() // separater line
 }
 
+func textPrintJournalAdjustment(tbl *gotable.Table, xbiz *rlib.XBusiness, jctx *jprintctx, j *rlib.Journal) {
+	jorig := rlib.GetJournal(j.ID) // j.ID is the JID of the entry being adjusted
+	tbl.AddRow()
+	tbl.Puts(-1, 0, j.IDtoString())
+	tbl.Puts(-1, 1, fmt.Sprintf("Adjustment to %s (%s): %s", jorig.IDtoString(), jorig.Dt.Format(rlib.RRDATEFMT4), j.Comment))
+	for i := 0; i < len(j.JA); i++ {
+		r := rlib.GetRentable(j.JA[i].RID)
+		r.BID = j.BID
+		processAcctRuleAmount(tbl, xbiz, j.JA[i].RID, j.Dt, j.JA[i].AcctRule, j.JA[i].RAID, &r, j.JA[i].Amount)
+	}
+	tbl.AddRow() // separater line
+}
+
+func textPrintJournalDeposit(tbl *gotable.Table, xbiz *rlib.XBusiness, jctx *jprintctx, j *rlib.Journal) {
+	d, _ := rlib.GetDeposit(j.ID) // j.ID is the DID of the deposit
+	dep, _ := rlib.GetDepository(d.DEPID)
+	tbl.AddRow()
+	tbl.Puts(-1, 0, j.IDtoString())
+	tbl.Puts(-1, 1, fmt.Sprintf("Deposit %s to %s", d.IDtoString(), dep.Name))
+	for i := 0; i < len(j.JA); i++ {
+		rcpt := rlib.GetReceiptNoAllocations(j.JA[i].RCPTID)
+		var r rlib.Rentable
+		r.BID = j.BID
+		tbl.AddRow()
+		tbl.Puts(-1, 1, fmt.Sprintf("  Receipt %s, #%s", rcpt.IDtoString(), rcpt.DocNo))
+		processAcctRuleAmount(tbl, xbiz, 0, j.Dt, j.JA[i].AcctRule, 0, &r, j.JA[i].Amount)
+	}
+	tbl.AddRow() // separater line
+}
+
+func textPrintJournalCommission(tbl *gotable.Table, xbiz *rlib.XBusiness, jctx *jprintctx, j *rlib.Journal) {
+	cl, _ := rlib.GetCommissionLedger(j.ID) // j.ID is the CLID of the commission
+	tbl.AddRow()
+	tbl.Puts(-1, 0, j.IDtoString())
+	tbl.Puts(-1, 1, fmt.Sprintf("Commission %s to %s", cl.IDtoString(), cl.Salesperson))
+	for i := 0; i < len(j.JA); i++ {
+		r := rlib.GetRentable(j.JA[i].RID)
+		r.BID = j.BID
+		processAcctRuleAmount(tbl, xbiz, j.JA[i].RID, j.Dt, j.JA[i].AcctRule, j.JA[i].RAID, &r, j.JA[i].Amount)
+	}
+	tbl.AddRow() // separater line
+}
+
 func textPrintJournalEntry(tbl *gotable.Table, ri *ReporterInfo, jctx *jprintctx, j *rlib.Journal, rentDuration, assessmentDuration int64) {
 	switch j.Type {
 	case rlib.JNLTYPEUNAS:
@@ -268,6 +311,12 @@ func textPrintJournalEntry(tbl *gotable.Table, ri *ReporterInfo, jctx *jprintctx
 		a, _ := rlib.GetAssessment(j.ID)
 		r := rlib.GetRentable(a.RID)
 		textPrintJournalAssessment(tbl, jctx, ri.Xbiz, j, &a, &r, rentDuration, assessmentDuration)
+	case rlib.JNLTYPEADJ:
+		textPrintJournalAdjustment(tbl, ri.Xbiz, jctx, j)
+	case rlib.JNLTYPEDEP:
+		textPrintJournalDeposit(tbl, ri.Xbiz, jctx, j)
+	case rlib.JNLTYPECOMM:
+		textPrintJournalCommission(tbl, ri.Xbiz, jctx, j)
 	default:
 		rlib.LogAndPrint("printJournalEntry: unrecognized type: %d\n", j.Type)
 	}
@@ -57,6 +57,20 @@ func getLedgerEntryDescription(l *rlib.LedgerEntry) (string, string, string) {
 			reason = rlib.RRdb.BizTypes[l.BID].GLAccounts[a.ATypeLID].Name
 		}
 		return "Assessment - " + reason, r.RentableName, sra
+	case rlib.JNLTYPEADJ:
+		jorig := rlib.GetJournal(j.ID) // ID is the JID of the entry being adjusted
+		r := rlib.GetRentable(l.RID)
+		return fmt.Sprintf("Adjustment to %s (%s) - %s", jorig.IDtoString(), jorig.Dt.Format(rlib.RRDATEFMT4), j.Comment), r.RentableName, sra
+	case rlib.JNLTYPEDEP:
+		d, _ := rlib.GetDeposit(j.ID) // ID is the DID of the deposit
+		dep, _ := rlib.GetDepository(d.DEPID)
+		ja := rlib.GetJournalAllocation(l.JAID)
+		rcpt := rlib.GetReceiptNoAllocations(ja.RCPTID)
+		return fmt.Sprintf("Deposit %s to %s - Payment #%s", d.IDtoString(), dep.Name, rcpt.DocNo), "", sra
+	case rlib.JNLTYPECOMM:
+		cl, _ := rlib.GetCommissionLedger(j.ID) // ID is the CLID of the commission
+		r := rlib.GetRentable(l.RID)
+		return fmt.Sprintf("Commission %s to %s", cl.IDtoString(), cl.Salesperson), r.RentableName, sra
 
 	default:
 		fmt.Printf("getLedgerEntryDescription: unrecognized type: %d\n", j.Type)
@@ -0,0 +1,76 @@
+package rrpt
+
+import (
+	"gotable"
+	"rentroll/bizlogic"
+	"rentroll/rlib"
+)
+
+// MaintenanceDueReportTable generates a table of the preventative maintenance
+// of business ri.Bid that is still outstanding, by Building. Maintenance due
+// before ri.D1 is overdue, maintenance due during ri.D1 - ri.D2 is upcoming.
+func MaintenanceDueReportTable(ri *ReporterInfo) gotable.Table {
+	funcname := "MaintenanceDueReportTable"
+
+	// prepare and init some values
+	ri.RptHeaderD1 = true
+	ri.RptHeaderD2 = true
+
+	// table init
+	tbl := getRRTable()
+
+	tbl.AddColumn("Building", 25, gotable.CELLSTRING, gotable.COLJUSTIFYLEFT)
+	tbl.AddColumn("Rentable", 15, gotable.CELLSTRING, gotable.COLJUSTIFYLEFT)
+	tbl.AddColumn("Maintenance", 30, gotable.CELLSTRING, gotable.COLJUSTIFYLEFT)
+	tbl.AddColumn("Due", 10, gotable.CELLDATE, gotable.COLJUSTIFYLEFT)
+	tbl.AddColumn("When", 8, gotable.CELLSTRING, gotable.COLJUSTIFYLEFT)
+	tbl.AddColumn("Service Request", 15, gotable.CELLSTRING, gotable.COLJUSTIFYLEFT)
+	tbl.AddColumn("Status", 12, gotable.CELLSTRING, gotable.COLJUSTIFYLEFT)
+
+	// prepare table's title, sections
+	err := TableReportHeaderBlock(&tbl, "Maintenance Due", funcname, ri)
+	if err != nil {
+		rlib.LogAndPrintError(funcname, err)
+		return tbl
+	}
+
+	bldg := map[int64]string{0: "(no building)"}
+	b := rlib.GetAllBuildings(ri.Xbiz.P.BID)
+	for i := 0; i < len(b); i++ {
+		bldg[b[i].BLDGID] = b[i].Address
+	}
+
+	m := bizlogic.GetMaintenanceDue(ri.Xbiz.P.BID, &ri.D1, &ri.D2)
+	for i := 0; i < len(m); i++ {
+		when := "upcoming"
+		if m[i].Overdue {
+			when = "overdue"
+		}
+		sr, status := "not generated", ""
+		if m[i].SRID > 0 {
+			sr = rlib.IDtoString("SR", m[i].SRID)
+			status = bizlogic.SRStatusNames[m[i].Status]
+		}
+		tbl.AddRow()
+		tbl.Puts(-1, 0, bldg[m[i].BLDGID])
+		tbl.Puts(-1, 1, m[i].RentableName)
+		tbl.Puts(-1, 2, m[i].Name)
+		tbl.Putd(-1, 3, m[i].DtDue)
+		tbl.Puts(-1, 4, when)
+		tbl.Puts(-1, 5, sr)
+		tbl.Puts(-1, 6, status)
+	}
+
+	if len(tbl.Row) == 0 {
+		tbl.SetSection3(NoRecordsFoundMsg)
+		return tbl
+	}
+	tbl.TightenColumns()
+	return tbl
+}
+
+// MaintenanceDueReport generates a text version of the maintenance due report
+func MaintenanceDueReport(ri *ReporterInfo) string {
+	tbl := MaintenanceDueReportTable(ri)
+	return ReportToString(&tbl, ri)
+}
@@ -0,0 +1,73 @@
+package rrpt
+
+import (
+	"gotable"
+	"rentroll/bizlogic"
+	"rentroll/rlib"
+)
+
+// MoveOutStatementTable generates the itemized move-out statement for the
+// disposition d: the charges assessed at move-out, the unpaid assessments paid
+// from the security deposit, and the refund or balance due.
+func MoveOutStatementTable(ri *ReporterInfo, d *bizlogic.MoveOutDisposition) gotable.Table {
+	funcname := "MoveOutStatementTable"
+
+	// init and prepare some values before table init
+	ri.D1 = d.Dt
+	ri.RptHeaderD1 = true
+	ri.RptHeaderD2 = false
+
+	// table init
+	tbl := getRRTable()
+
+	tbl.AddColumn("Date", 10, gotable.CELLDATE, gotable.COLJUSTIFYLEFT)
+	tbl.AddColumn("Item", 9, gotable.CELLSTRING, gotable.COLJUSTIFYLEFT)
+	tbl.AddColumn("Description", 40, gotable.CELLSTRING, gotable.COLJUSTIFYLEFT)
+	tbl.AddColumn("Amount", 12, gotable.CELLFLOAT, gotable.COLJUSTIFYRIGHT)
+	tbl.AddColumn("Deposit Applied", 12, gotable.CELLFLOAT, gotable.COLJUSTIFYRIGHT)
+
+	// set table title, sections
+	err := TableReportHeaderBlock(&tbl, "Move-Out Statement "+rlib.IDtoString("RA", d.RAID), funcname, ri)
+	if err != nil {
+		rlib.LogAndPrintError(funcname, err)
+		return tbl
+	}
+
+	total := func(s string, x float64) {
+		tbl.AddRow()
+		tbl.Puts(-1, 2, s)
+		tbl.Putf(-1, 3, x)
+	}
+
+	total("Security deposit held", d.DepositHeld)
+	for i := 0; i < len(d.Charges); i++ {
+		tbl.AddRow()
+		tbl.Putd(-1, 0, d.Charges[i].Dt)
+		tbl.Puts(-1, 1, rlib.IDtoString("ASM", d.Charges[i].ASMID))
+		tbl.Puts(-1, 2, "Move-out charge: "+d.Charges[i].Descr)
+		tbl.Putf(-1, 3, d.Charges[i].Amount)
+	}
+	if len(d.Applied) > 0 {
+		tbl.AddLineAfter(len(tbl.Row) - 1)
+	}
+	for i := 0; i < len(d.Applied); i++ {
+		tbl.AddRow()
+		tbl.Putd(-1, 0, d.Applied[i].Dt)
+		tbl.Puts(-1, 1, rlib.IDtoString("ASM", d.Applied[i].ASMID))
+		tbl.Puts(-1, 2, d.Applied[i].Descr)
+		tbl.Putf(-1, 3, d.Applied[i].Amount)
+		tbl.Putf(-1, 4, d.Applied[i].Applied)
+	}
+	tbl.AddLineAfter(len(tbl.Row) - 1)
+	total("Total deposit applied", d.TotApplied)
+	total("Balance due from resident", d.BalanceDue)
+	total("Security deposit refund due", d.Refund)
+	tbl.TightenColumns()
+	return tbl
+}
+
+// MoveOutStatement generates a text version of the move-out statement
+func MoveOutStatement(ri *ReporterInfo, d *bizlogic.MoveOutDisposition) string {
+	tbl := MoveOutStatementTable(ri, d)
+	return ReportToString(&tbl, ri)
+}
@@ -0,0 +1,176 @@
+package rrpt
+
+import (
+	"bytes"
+	"fmt"
+	"gotable"
+	"os"
+	"rentroll/bizlogic"
+	"rentroll/rlib"
+	"strings"
+	"text/template"
+
+	"github.com/kardianos/osext"
+)
+
+// noticeTitles are the report titles for each notice type
+var noticeTitles = map[int64]string{
+	bizlogic.NOTICERENTINCREASE:    "Notice of Rent Increase",
+	bizlogic.NOTICELATEPAYMENT:     "Notice of Late Payment",
+	bizlogic.NOTICELEASEEXPIRATION: "Notice of Lease Expiration",
+	bizlogic.NOTICEEXTENSIONOPTION: "Notice of Extension Option",
+	bizlogic.NOTICEEXPANSIONOPTION: "Notice of Expansion Option",
+}
+
+// NoticeData is the data available to a notice template
+type NoticeData struct {
+	Business   string   // business name
+	BUD        string   // business designation
+	RAID       string   // Rental Agreement id, ex: RA00000012
+	Payors     []string // names of the payors
+	Date       string   // date the notice is issued
+	NoticeDate string   // the date the notice is about: rate change, agreement end, option deadline
+	Rent       string   // current contract rent, rent increase notices only
+	NewRent    string   // rent after the increase, rent increase notices only
+	RateChange string   // rent increase percentage
+	Amount     string   // past due balance, late payment notices only
+	Aging      []string // past due balance by aging bucket, late payment notices only
+	Option     string   // extension or expansion option
+}
+
+// getNoticeTemplate loads the template for notice type t. A business can
+// override the default template by supplying notices/<BUD>/<name>.tmpl in
+// the folder containing the executable. Otherwise notices/default/<name>.tmpl
+// is used.
+func getNoticeTemplate(bud string, t int64) (*template.Template, error) {
+	folderPath, err := osext.ExecutableFolder()
+	if err != nil {
+		return nil, err
+	}
+	name := bizlogic.NoticeNames[t] + ".tmpl"
+	fname := folderPath + "/notices/" + bud + "/" + name
+	if _, err = os.Stat(fname); err != nil {
+		fname = folderPath + "/notices/default/" + name
+	}
+	return template.ParseFiles(fname)
+}
+
+// getNoticeData fills in the template data for notice n issued on ri.D1
+func getNoticeData(ri *ReporterInfo, n *bizlogic.Notice) NoticeData {
+	d := NoticeData{
+		Business:   ri.Xbiz.P.Name,
+		BUD:        ri.Xbiz.P.Designation,
+		RAID:       n.RA.IDtoString(),
+		Payors:     n.RA.GetPayorNameList(&ri.D1, &ri.D2),
+		Date:       ri.D1.Format(rlib.RRDATEFMT4),
+		NoticeDate: n.Dt.Format(rlib.RRDATEFMT4),
+		Option:     n.Option,
+	}
+	switch n.Type {
+	case bizlogic.NOTICERENTINCREASE:
+		var rent float64
+		dt := n.Dt.AddDate(0, 0, 1)
+		m := rlib.GetRentalAgreementRentables(n.RA.RAID, &n.Dt, &dt)
+		for i := 0; i < len(m); i++ {
+			rent += m[i].ContractRent
+		}
+		d.Rent = rlib.RRCommaf(rent)
+		d.NewRent = rlib.RRCommaf(rlib.RoundToCent(rent * (1 + n.Amount/100)))
+		d.RateChange = fmt.Sprintf("%.2f%%", n.Amount)
+	case bizlogic.NOTICELATEPAYMENT:
+		d.Amount = rlib.RRCommaf(n.Amount)
+		labels := []string{"1 - 30 days", "31 - 60 days", "61 - 90 days", "over 90 days"}
+		for i := bizlogic.AGING30; i < bizlogic.AGINGBUCKETS; i++ {
+			if n.Aging.Bucket[i] > 0 {
+				d.Aging = append(d.Aging, fmt.Sprintf("%s past due: %s", labels[i-bizlogic.AGING30], rlib.RRCommaf(n.Aging.Bucket[i])))
+			}
+		}
+	}
+	return d
+}
+
+// RenderNotice returns the text of notice n produced by its template
+func RenderNotice(ri *ReporterInfo, n *bizlogic.Notice) (string, error) {
+	t, err := getNoticeTemplate(ri.Xbiz.P.Designation, n.Type)
+	if err != nil {
+		return "", err
+	}
+	var b bytes.Buffer
+	if err = t.Execute(&b, getNoticeData(ri, n)); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+// NoticeTable renders notice n into a table with one line of the notice
+// per row, so that it can be printed as text, html, or pdf.
+func NoticeTable(ri *ReporterInfo, n *bizlogic.Notice, s string) gotable.Table {
+	funcname := "NoticeTable"
+
+	// table init
+	tbl := getRRTable()
+	tbl.AddColumn(n.RA.IDtoString(), 80, gotable.CELLSTRING, gotable.COLJUSTIFYLEFT)
+
+	// set table title, sections
+	err := TableReportHeaderBlock(&tbl, noticeTitles[n.Type], funcname, ri)
+	if err != nil {
+		rlib.LogAndPrintError(funcname, err)
+		return tbl
+	}
+
+	for _, line := range strings.Split(strings.TrimRight(s, "\n"), "\n") {
+		tbl.AddRow()
+		tbl.Puts(-1, 0, line)
+	}
+	return tbl
+}
+
+// NoticesReportTable generates the notices that are due for business ri.Bid
+// in the period ri.D1 - ri.D2. Nothing is written; use RecordNotices to keep
+// a record of the notices that were sent.
+func NoticesReportTable(ri *ReporterInfo) []gotable.Table {
+	funcname := "NoticesReportTable"
+	var m []gotable.Table
+
+	// init some values
+	ri.RptHeaderD1 = true
+	ri.RptHeaderD2 = false
+
+	n := bizlogic.GetNoticesDue(ri.Xbiz.P.BID, &ri.D1, &ri.D2)
+	for i := 0; i < len(n); i++ {
+		s, err := RenderNotice(ri, &n[i])
+		if err != nil {
+			rlib.LogAndPrintError(funcname, err)
+			continue
+		}
+		m = append(m, NoticeTable(ri, &n[i], s))
+	}
+	return m
+}
+
+// RecordNotices records each notice that is due for business ri.Bid in the
+// period ri.D1 - ri.D2 as a Note on its Rental Agreement. It returns the
+// number of notices recorded.
+func RecordNotices(ri *ReporterInfo, uid int64) (int, error) {
+	n := bizlogic.GetNoticesDue(ri.Xbiz.P.BID, &ri.D1, &ri.D2)
+	for i := 0; i < len(n); i++ {
+		s, err := RenderNotice(ri, &n[i])
+		if err != nil {
+			return i, err
+		}
+		if err = bizlogic.RecordNotice(&n[i], s, uid); err != nil {
+			return i, err
+		}
+	}
+	return len(n), nil
+}
+
+// NoticesTextReport is a text version of the notices due
+func NoticesTextReport(ri *ReporterInfo) string {
+	m := NoticesReportTable(ri)
+	var s string
+	for _, tbl := range m {
+		s += ReportToString(&tbl, ri) + "\n"
+	}
+	return s
+}
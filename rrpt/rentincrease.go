@@ -0,0 +1,67 @@
+package rrpt
+
+import (
+	"gotable"
+	"rentroll/bizlogic"
+	"rentroll/rlib"
+	"strings"
+)
+
+// RentIncreaseReportTable is a dry run of the scheduled rent increases. It
+// lists the increases that will take effect in the period ri.D1 - ri.D2
+// without changing anything.
+func RentIncreaseReportTable(ri *ReporterInfo) gotable.Table {
+	funcname := "RentIncreaseReportTable"
+
+	// prepare and init some values
+	ri.RptHeaderD1 = true
+	ri.RptHeaderD2 = true
+
+	// table init
+	tbl := getRRTable()
+
+	tbl.AddColumn("Rental Agreement", 10, gotable.CELLSTRING, gotable.COLJUSTIFYLEFT)
+	tbl.AddColumn("Payors", 30, gotable.CELLSTRING, gotable.COLJUSTIFYLEFT)
+	tbl.AddColumn("Rentable", 10, gotable.CELLSTRING, gotable.COLJUSTIFYLEFT)
+	tbl.AddColumn("Assessment", 10, gotable.CELLSTRING, gotable.COLJUSTIFYLEFT)
+	tbl.AddColumn("Effective", 10, gotable.CELLDATE, gotable.COLJUSTIFYLEFT)
+	tbl.AddColumn("Current Amount", 12, gotable.CELLFLOAT, gotable.COLJUSTIFYRIGHT)
+	tbl.AddColumn("Rate Change %", 8, gotable.CELLFLOAT, gotable.COLJUSTIFYRIGHT)
+	tbl.AddColumn("New Amount", 12, gotable.CELLFLOAT, gotable.COLJUSTIFYRIGHT)
+
+	// prepare table's title, sections
+	err := TableReportHeaderBlock(&tbl, "Scheduled Rent Increases", funcname, ri)
+	if err != nil {
+		rlib.LogAndPrintError(funcname, err)
+		return tbl
+	}
+
+	m := bizlogic.GetRentIncreasesDue(ri.Xbiz.P.BID, &ri.D1, &ri.D2)
+	for i := 0; i < len(m); i++ {
+		tbl.AddRow()
+		tbl.Puts(-1, 0, m[i].RA.IDtoString())
+		tbl.Puts(-1, 1, strings.Join(m[i].RA.GetPayorNameList(&m[i].Dt, &m[i].Dt), ", "))
+		r := rlib.GetRentable(m[i].ASM.RID)
+		tbl.Puts(-1, 2, r.RentableName)
+		tbl.Puts(-1, 3, m[i].ASM.IDtoString())
+		tbl.Putd(-1, 4, m[i].Dt)
+		tbl.Putf(-1, 5, m[i].OldAmount)
+		tbl.Putf(-1, 6, m[i].Rate)
+		tbl.Putf(-1, 7, m[i].NewAmount)
+	}
+
+	if len(m) == 0 {
+		tbl.SetSection3(NoRecordsFoundMsg)
+		return tbl
+	}
+	tbl.AddLineAfter(len(tbl.Row) - 1)
+	tbl.InsertSumRow(len(tbl.Row), 0, len(tbl.Row)-1, []int{5, 7})
+	tbl.TightenColumns()
+	return tbl
+}
+
+// RentIncreaseReport generates a text version of the rent increase dry run
+func RentIncreaseReport(ri *ReporterInfo) string {
+	tbl := RentIncreaseReportTable(ri)
+	return ReportToString(&tbl, ri)
+}
@@ -91,6 +91,9 @@ func GetStatementData(bid int64, raid int64, d1, d2 *time.Time) []StmtEntry {
 		se.Amt = n[i].Amount
 		se.Dt = n[i].Dt
 		j := rlib.GetJournal(n[i].JID)
+		if j.Type == rlib.JNLTYPEADJ { // show an adjustment against the entry it corrects
+			j = rlib.GetJournal(j.ID)
+		}
 		se.T = int(j.Type)
 		se.ID = j.ID
 		if se.T == rlib.JOURNALTYPEASMID {
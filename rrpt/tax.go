@@ -0,0 +1,83 @@
+package rrpt
+
+import (
+	"gotable"
+	"rentroll/rlib"
+)
+
+// TaxLiabilityReportTable generates a table showing, for each Tax defined for the
+// business, the tax collected during ri.D1 - ri.D2 and the liability outstanding on ri.D2.
+// Both are read from the ledger account credited by the Tax's account rule, so each Tax
+// should be booked to its own liability account.
+func TaxLiabilityReportTable(ri *ReporterInfo) gotable.Table {
+	funcname := "TaxLiabilityReportTable"
+
+	// init and prepare some values before table init
+	bid := ri.Xbiz.P.BID
+	ri.RptHeaderD1 = true
+	ri.RptHeaderD2 = true
+
+	// table init
+	tbl := getRRTable()
+
+	tbl.AddColumn("TAXID", 9, gotable.CELLSTRING, gotable.COLJUSTIFYLEFT)
+	tbl.AddColumn("Name", 25, gotable.CELLSTRING, gotable.COLJUSTIFYLEFT)
+	tbl.AddColumn("Taxing Authority", 30, gotable.CELLSTRING, gotable.COLJUSTIFYLEFT)
+	tbl.AddColumn("Liability Account", 12, gotable.CELLSTRING, gotable.COLJUSTIFYLEFT)
+	tbl.AddColumn("Rate", 8, gotable.CELLFLOAT, gotable.COLJUSTIFYRIGHT)
+	tbl.AddColumn("Fee", 10, gotable.CELLFLOAT, gotable.COLJUSTIFYRIGHT)
+	tbl.AddColumn("Collected", 12, gotable.CELLFLOAT, gotable.COLJUSTIFYRIGHT)
+	tbl.AddColumn("Liability", 12, gotable.CELLFLOAT, gotable.COLJUSTIFYRIGHT)
+	tbl.AddColumn("Filing Date", 10, gotable.CELLDATE, gotable.COLJUSTIFYLEFT)
+
+	// set table title, sections
+	err := TableReportHeaderBlock(&tbl, "Tax Liability", funcname, ri)
+	if err != nil {
+		rlib.LogAndPrintError(funcname, err)
+		return tbl
+	}
+
+	m := rlib.GetAllTaxes(bid)
+	for i := 0; i < len(m); i++ {
+		tbl.AddRow()
+		tbl.Puts(-1, 0, rlib.IDtoString("TAX", m[i].TAXID))
+		tbl.Puts(-1, 1, m[i].Name)
+		tbl.Puts(-1, 2, m[i].TaxingAuthority)
+		tbl.Putd(-1, 8, m[i].FilingDate)
+
+		tr, _ := rlib.GetTaxRateForDate(m[i].TAXID, &ri.D2)
+		tbl.Putf(-1, 4, tr.Rate)
+		tbl.Putf(-1, 5, tr.Fee)
+
+		ar, err := rlib.GetAR(m[i].ARID)
+		if err != nil || ar.CreditLID == 0 {
+			tbl.Puts(-1, 3, "n/a")
+			continue
+		}
+		l := rlib.GetLedger(ar.CreditLID)
+		tbl.Puts(-1, 3, l.GLNumber)
+
+		// the liability account is credited, so its ledger amounts are negative
+		act, err := rlib.GetAccountActivity(bid, l.LID, &ri.D1, &ri.D2)
+		if err != nil {
+			rlib.LogAndPrintError(funcname, err)
+		}
+		tbl.Putf(-1, 6, -act)
+		tbl.Putf(-1, 7, -rlib.GetAccountBalance(bid, l.LID, &ri.D2))
+	}
+
+	if len(m) == 0 {
+		tbl.SetSection3(NoRecordsFoundMsg)
+		return tbl
+	}
+	tbl.AddLineAfter(len(tbl.Row) - 1)
+	tbl.InsertSumRow(len(tbl.Row), 0, len(tbl.Row)-1, []int{6, 7})
+	tbl.TightenColumns()
+	return tbl
+}
+
+// TaxLiabilityReport generates a text report of tax liabilities
+func TaxLiabilityReport(ri *ReporterInfo) string {
+	tbl := TaxLiabilityReportTable(ri)
+	return ReportToString(&tbl, ri)
+}
@@ -109,6 +109,7 @@ type ReporterInfo struct {
 	OutputFormat          int       // text, html, maybe more in the future
 	Bid                   int64     // associated business
 	Raid                  int64     // associated Rental Agreement if needed
+	ID                    int64     // associated record id if needed, ex: InvoiceNo
 	D1                    time.Time // associated date if needed
 	D2                    time.Time // associated date if needed
 	NeedsBID              bool      // true if BID is needed for this report
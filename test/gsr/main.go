@@ -244,7 +244,7 @@ func doTest() {
 	// We assume the user chose to work on Payor with TCID = 2
 	tcid := int64(2)
 	dt := time.Now()
-	bizlogic.AutoAllocatePayorReceipts(tcid, &dt)
+	bizlogic.AutoAllocatePayorReceipts(tcid, &dt, 0)
 
 	// print remaining unpaid assessments, and remaining receipts with unallocated funds
 	m := bizlogic.GetAllUnpaidAssessmentsForPayor(bid, tcid, &dt)
@@ -37,6 +37,7 @@ type RRuiSupport struct {
 	PDFPageWidth       float64 // page width
 	PDFPageHeight      float64 // page height
 	PDFPageSizeUnit    string  // page size unit, default is inch ("in")
+	ID                 int64   // id of the record to report on, for reports on a single record
 }
 
 //========================================================================================================
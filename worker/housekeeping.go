@@ -0,0 +1,38 @@
+package worker
+
+import (
+	"fmt"
+	"rentroll/bizlogic"
+	"rentroll/rlib"
+	"time"
+	"tws"
+)
+
+// ResetHousekeeping is a worker that is called by TWS each morning to start
+// the housekeeping day. Nightly rooms whose guests stay over or check out
+// today are marked dirty. When it finishes it reschedules itself to be called
+// again at 6:00 AM tomorrow in the business time zone.
+func ResetHousekeeping(item *tws.Item) {
+	tws.ItemWorking(item)
+
+	m, err := rlib.GetAllBusinesses()
+	if err != nil {
+		rlib.Ulog("Error with rlib.GetAllBusinesses: %s\n", err.Error())
+	} else {
+		now := time.Now()
+		for i := 0; i < len(m); i++ {
+			n, errlist := bizlogic.ResetHousekeeping(m[i].BID, &now, 0)
+			for j := 0; j < len(errlist); j++ {
+				rlib.Ulog("ResetHousekeeping: %s - %s\n", m[i].Designation, errlist[j].Message)
+			}
+			if n > 0 {
+				fmt.Printf("MARKED %d ROOMS DIRTY FOR BIZ: %s - %s\n", n, m[i].Designation, m[i].Name)
+			}
+		}
+	}
+
+	// reschedule for 6:00 AM tomorrow...
+	now := time.Now().In(rlib.RRdb.Zone)
+	resched := time.Date(now.Year(), now.Month(), now.Day()+1, 6, 0, 0, 0, rlib.RRdb.Zone)
+	tws.RescheduleItem(item, resched)
+}
@@ -12,6 +12,11 @@ var workers = []struct {
 	Worker func(*tws.Item)
 }{
 	{"CreateAssessmentInstances", CreateAssessmentInstances},
+	{"RenewMonthToMonth", RenewMonthToMonth},
+	{"ApplyRentIncreases", ApplyRentIncreases},
+	{"AssessLateFees", AssessLateFees},
+	{"GenerateMaintenanceRequests", GenerateMaintenanceRequests},
+	{"ResetHousekeeping", ResetHousekeeping},
 }
 
 // Init registers the TWS functions needed by RentRoll
@@ -0,0 +1,38 @@
+package worker
+
+import (
+	"fmt"
+	"rentroll/bizlogic"
+	"rentroll/rlib"
+	"time"
+	"tws"
+)
+
+// AssessLateFees is a worker that is called by TWS once a day to apply the
+// late fee policy of each business. Assessments that are still unpaid after
+// the grace period are charged a late fee. When it finishes it reschedules
+// itself to be called again the next day.
+func AssessLateFees(item *tws.Item) {
+	tws.ItemWorking(item)
+
+	m, err := rlib.GetAllBusinesses()
+	if err != nil {
+		rlib.Ulog("Error with rlib.GetAllBusinesses: %s\n", err.Error())
+	} else {
+		now := time.Now()
+		for i := 0; i < len(m); i++ {
+			n, errlist := bizlogic.AssessLateFees(m[i].BID, &now, 0)
+			for j := 0; j < len(errlist); j++ {
+				rlib.Ulog("AssessLateFees: %s - %s\n", m[i].Designation, errlist[j].Message)
+			}
+			if n > 0 {
+				fmt.Printf("ASSESSED %d LATE FEES FOR BIZ: %s - %s\n", n, m[i].Designation, m[i].Name)
+			}
+		}
+	}
+
+	// reschedule for midnight tomorrow...
+	now := time.Now().In(rlib.RRdb.Zone)
+	resched := time.Date(now.Year(), now.Month(), now.Day()+1, 0, 0, 0, 0, time.UTC).In(rlib.RRdb.Zone)
+	tws.RescheduleItem(item, resched)
+}
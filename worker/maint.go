@@ -0,0 +1,39 @@
+package worker
+
+import (
+	"fmt"
+	"rentroll/bizlogic"
+	"rentroll/rlib"
+	"time"
+	"tws"
+)
+
+// GenerateMaintenanceRequests is a worker that is called by TWS once a day to
+// create the service requests for preventative maintenance. Each instance of a
+// maintenance schedule that is within the schedule's lead time gets a service
+// request for every Rentable it covers. When it finishes it reschedules itself
+// to be called again the next day.
+func GenerateMaintenanceRequests(item *tws.Item) {
+	tws.ItemWorking(item)
+
+	m, err := rlib.GetAllBusinesses()
+	if err != nil {
+		rlib.Ulog("Error with rlib.GetAllBusinesses: %s\n", err.Error())
+	} else {
+		now := time.Now()
+		for i := 0; i < len(m); i++ {
+			n, errlist := bizlogic.GenerateMaintenanceRequests(m[i].BID, &now, 0)
+			for j := 0; j < len(errlist); j++ {
+				rlib.Ulog("GenerateMaintenanceRequests: %s - %s\n", m[i].Designation, errlist[j].Message)
+			}
+			if n > 0 {
+				fmt.Printf("GENERATED %d MAINTENANCE SERVICE REQUESTS FOR BIZ: %s - %s\n", n, m[i].Designation, m[i].Name)
+			}
+		}
+	}
+
+	// reschedule for midnight tomorrow...
+	now := time.Now().In(rlib.RRdb.Zone)
+	resched := time.Date(now.Year(), now.Month(), now.Day()+1, 0, 0, 0, 0, time.UTC).In(rlib.RRdb.Zone)
+	tws.RescheduleItem(item, resched)
+}
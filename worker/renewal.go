@@ -0,0 +1,39 @@
+package worker
+
+import (
+	"fmt"
+	"rentroll/bizlogic"
+	"rentroll/rlib"
+	"time"
+	"tws"
+)
+
+// RenewMonthToMonth is a worker that is called by TWS once a day to renew the
+// month to month Rental Agreements of each business. Agreements set for month
+// to month automatic renewal that have reached their stop date are extended by
+// another month. When it finishes it reschedules itself to be called again the
+// next day.
+func RenewMonthToMonth(item *tws.Item) {
+	tws.ItemWorking(item)
+
+	m, err := rlib.GetAllBusinesses()
+	if err != nil {
+		rlib.Ulog("Error with rlib.GetAllBusinesses: %s\n", err.Error())
+	} else {
+		now := time.Now()
+		for i := 0; i < len(m); i++ {
+			n, errlist := bizlogic.RenewMonthToMonth(m[i].BID, &now, 0)
+			for j := 0; j < len(errlist); j++ {
+				rlib.Ulog("RenewMonthToMonth: %s - %s\n", m[i].Designation, errlist[j].Message)
+			}
+			if n > 0 {
+				fmt.Printf("RENEWED %d MONTH TO MONTH AGREEMENTS FOR BIZ: %s - %s\n", n, m[i].Designation, m[i].Name)
+			}
+		}
+	}
+
+	// reschedule for midnight tomorrow...
+	now := time.Now().In(rlib.RRdb.Zone)
+	resched := time.Date(now.Year(), now.Month(), now.Day()+1, 0, 0, 0, 0, time.UTC).In(rlib.RRdb.Zone)
+	tws.RescheduleItem(item, resched)
+}
@@ -0,0 +1,39 @@
+package worker
+
+import (
+	"fmt"
+	"rentroll/bizlogic"
+	"rentroll/rlib"
+	"time"
+	"tws"
+)
+
+// ApplyRentIncreases is a worker that is called by TWS once a day to apply the
+// scheduled rent increases of each business. Rental Agreements whose
+// NextRateChange has arrived have their recurring rent increased by their
+// RateChange. When it finishes it reschedules itself to be called again the
+// next day.
+func ApplyRentIncreases(item *tws.Item) {
+	tws.ItemWorking(item)
+
+	m, err := rlib.GetAllBusinesses()
+	if err != nil {
+		rlib.Ulog("Error with rlib.GetAllBusinesses: %s\n", err.Error())
+	} else {
+		now := time.Now()
+		for i := 0; i < len(m); i++ {
+			n, errlist := bizlogic.ApplyRentIncreases(m[i].BID, &now, 0)
+			for j := 0; j < len(errlist); j++ {
+				rlib.Ulog("ApplyRentIncreases: %s - %s\n", m[i].Designation, errlist[j].Message)
+			}
+			if len(n) > 0 {
+				fmt.Printf("APPLIED %d RENT INCREASES FOR BIZ: %s - %s\n", len(n), m[i].Designation, m[i].Name)
+			}
+		}
+	}
+
+	// reschedule for midnight tomorrow...
+	now := time.Now().In(rlib.RRdb.Zone)
+	resched := time.Date(now.Year(), now.Month(), now.Day()+1, 0, 0, 0, 0, time.UTC).In(rlib.RRdb.Zone)
+	tws.RescheduleItem(item, resched)
+}
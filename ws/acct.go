@@ -597,6 +597,9 @@ func saveGLAccount(w http.ResponseWriter, r *http.Request, d *ServiceData) {
 	// migrate foo.Record data to a struct's fields
 	rlib.MigrateStructVals(&foo.Record, &a) // the variables that don't need special handling
 	fmt.Printf("saveAcct - first migrate: a = %#v\n", a)
+	if !svcCheckBID(w, d, a.BID, funcname) {
+		return
+	}
 
 	// data validation
 	if a.Name == "" {
@@ -663,6 +666,9 @@ func saveGLAccount(w http.ResponseWriter, r *http.Request, d *ServiceData) {
 		}
 	} else {
 		// update existing record
+		if old := rlib.GetLedger(a.LID); !svcCheckBID(w, d, old.BID, funcname) {
+			return
+		}
 		errlist := bizlogic.SaveGLAccount(&a)
 		if len(errlist) > 0 {
 			SvcErrListReturn(w, errlist, funcname)
@@ -796,6 +802,9 @@ func deleteGLAccount(w http.ResponseWriter, r *http.Request, d *ServiceData) {
 		SvcGridErrorReturn(w, err, funcname)
 		return
 	}
+	if !svcCheckBID(w, d, gl.BID, funcname) {
+		return
+	}
 
 	// First, remove LedgerMarkers for this LID
 	lm := rlib.GetLatestLedgerMarkerByLID(d.BID, del.LID)
@@ -804,7 +813,7 @@ func deleteGLAccount(w http.ResponseWriter, r *http.Request, d *ServiceData) {
 		SvcGridErrorReturn(w, e, funcname)
 		return
 	}
-	if err := rlib.DeleteLedgerMarker(lm.LMID); err != nil {
+	if err := rlib.DeleteLedgerMarker(lm.LMID, d.UID); err != nil {
 		SvcGridErrorReturn(w, err, funcname)
 		return
 	}
@@ -0,0 +1,169 @@
+package ws
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"rentroll/bizlogic"
+	"rentroll/rlib"
+	"time"
+)
+
+// AdjustmentGrid describes an adjustment Journal entry
+type AdjustmentGrid struct {
+	Recid       int64 `json:"recid"`
+	JID         int64
+	BID         int64
+	BUD         rlib.XJSONBud
+	Dt          rlib.JSONDate
+	Amount      float64
+	ID          int64 // JID of the entry that was adjusted
+	Comment     string
+	LastModTime rlib.JSONDateTime
+	LastModBy   int64
+	CreateTS    rlib.JSONDateTime
+	CreateBy    int64
+}
+
+// AdjustmentSearchResponse is the response to a request for the adjustments of a Journal entry
+type AdjustmentSearchResponse struct {
+	Status  string           `json:"status"`
+	Total   int64            `json:"total"`
+	Records []AdjustmentGrid `json:"records"`
+}
+
+// AdjustmentForm contains the data from the Adjust Closed Period FORM
+type AdjustmentForm struct {
+	BUD     rlib.XJSONBud
+	JID     int64 // closed-period Journal entry to adjust, or...
+	ASMID   int64 // ...closed-period Assessment to adjust
+	ARID    int64 // account rule for the correction, 0 reverses the original entry
+	Amount  float64
+	Dt      rlib.JSONDate
+	Comment string
+}
+
+// AdjustmentInput is the input data format for a Save command
+type AdjustmentInput struct {
+	Status   string         `json:"status"`
+	Recid    int64          `json:"recid"`
+	FormName string         `json:"name"`
+	Record   AdjustmentForm `json:"record"`
+}
+
+// SvcHandlerAdjustment posts and lists adjustments to closed-period entries.
+// For this call, we expect the URI to contain the BID and, for "get", the JID
+// of the adjusted entry:  /v1/adjust/:BUI/:JID
+//
+// The server command can be:
+//      get
+//      save
+//-----------------------------------------------------------------------------------
+func SvcHandlerAdjustment(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	var (
+		funcname = "SvcHandlerAdjustment"
+		err      error
+	)
+
+	fmt.Printf("Entered %s\n", funcname)
+	fmt.Printf("Request: %s:  BID = %d,  JID = %d\n", d.wsSearchReq.Cmd, d.BID, d.ID)
+
+	switch d.wsSearchReq.Cmd {
+	case "get":
+		getAdjustments(w, r, d)
+		break
+	case "save":
+		saveAdjustment(w, r, d)
+		break
+	default:
+		err = fmt.Errorf("Unhandled command: %s", d.wsSearchReq.Cmd)
+		SvcGridErrorReturn(w, err, funcname)
+		return
+	}
+}
+
+// getAdjustments returns the adjustments made to a Journal entry
+// wsdoc {
+//  @Title  Get Adjustments
+//	@URL /v1/adjust/:BUI/:JID
+//  @Method  POST
+//	@Synopsis Get the adjustments to a closed-period Journal entry
+//  @Description  Returns every adjustment Journal entry that corrects Journal entry :JID.
+//	@Input WebGridSearchRequest
+//  @Response AdjustmentSearchResponse
+// wsdoc }
+func getAdjustments(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	var (
+		funcname = "getAdjustments"
+		g        AdjustmentSearchResponse
+	)
+
+	fmt.Printf("Entered %s\n", funcname)
+	if d.ID <= 0 {
+		SvcGridErrorReturn(w, fmt.Errorf("JID is required but was not specified"), funcname)
+		return
+	}
+	if j := rlib.GetJournal(d.ID); !svcCheckBID(w, d, j.BID, funcname) {
+		return
+	}
+	m := rlib.GetJournalAdjustments(d.ID)
+	for i := 0; i < len(m); i++ {
+		var q AdjustmentGrid
+		rlib.MigrateStructVals(&m[i], &q)
+		q.Recid = q.JID
+		q.BUD = getBUDFromBIDList(q.BID)
+		g.Records = append(g.Records, q)
+	}
+	g.Total = int64(len(g.Records))
+	g.Status = "success"
+	w.Header().Set("Content-Type", "application/json")
+	SvcWriteResponse(&g, w)
+}
+
+// saveAdjustment posts an adjustment to a closed-period entry
+// wsdoc {
+//  @Title  Adjust Closed Period
+//	@URL /v1/adjust/:BUI
+//  @Method  POST
+//	@Synopsis Post a correcting entry for a closed period
+//  @Description  Posts a correcting Journal entry on Dt, which must be in an open period, for the
+//  @Description  closed-period Journal entry JID or Assessment ASMID. If ARID is 0 the original entry
+//  @Description  is reversed, otherwise Amount is booked using account rule ARID. Later ledger
+//  @Description  markers are recomputed. The response contains the JID of the new entry.
+//	@Input AdjustmentInput
+//  @Response SvcStatusResponse
+// wsdoc }
+func saveAdjustment(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	var (
+		funcname = "saveAdjustment"
+		foo      AdjustmentInput
+	)
+
+	fmt.Printf("Entered %s\n", funcname)
+	fmt.Printf("record data = %s\n", d.data)
+
+	if err := json.Unmarshal([]byte(d.data), &foo); err != nil {
+		e := fmt.Errorf("%s: Error with json.Unmarshal:  %s", funcname, err.Error())
+		SvcGridErrorReturn(w, e, funcname)
+		return
+	}
+
+	var adj bizlogic.Adjustment
+	rlib.MigrateStructVals(&foo.Record, &adj) // the variables that don't need special handling
+
+	var ok bool
+	adj.BID, ok = getBIDFromBUD(w, d, foo.Record.BUD, funcname)
+	if !ok {
+		return
+	}
+	adj.Dt = time.Time(foo.Record.Dt)
+	adj.UID = d.UID
+
+	jnl, errlist := bizlogic.PostAdjustment(&adj)
+	if len(errlist) > 0 {
+		SvcErrListReturn(w, errlist, funcname)
+		return
+	}
+
+	SvcWriteSuccessResponseWithID(w, jnl.JID)
+}
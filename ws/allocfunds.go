@@ -233,6 +233,9 @@ func allocatePayorFund(w http.ResponseWriter, r *http.Request, d *ServiceData) {
 	}
 
 	fmt.Printf("Began to allocate funds for TCID=%d, BID=%d\n", foo.TCID, foo.BID)
+	if !svcCheckBID(w, d, foo.BID, funcname) {
+		return
+	}
 
 	// Need to init some internals for Business
 	var xbiz rlib.XBusiness
@@ -259,6 +262,9 @@ func allocatePayorFund(w http.ResponseWriter, r *http.Request, d *ServiceData) {
 			SvcGridErrorReturn(w, err, funcname)
 			return
 		}
+		if !svcCheckBID(w, d, asm.BID, funcname) {
+			return
+		}
 
 		needed := bizlogic.AssessmentUnpaidPortion(&asm)
 		fmt.Printf("ASMID = %d, Requested Amount = %.2f, AR = %d\n", asm.ASMID, amt, asm.ARID)
@@ -269,7 +275,7 @@ func allocatePayorFund(w http.ResponseWriter, r *http.Request, d *ServiceData) {
 				continue // move on to the next receipt
 			}
 
-			err := bizlogic.PayAssessment(&asm, &n[j], &needed, &amt, &n[j].Dt)
+			err := bizlogic.PayAssessment(&asm, &n[j], &needed, &amt, &n[j].Dt, d.UID)
 			fmt.Printf("amt = %.2f .  Amount still owed: %.2f\n", amt, needed)
 			if err != nil {
 				SvcGridErrorReturn(w, err, funcname)
@@ -29,6 +29,8 @@ type ARSendForm struct {
 	raRequired       int
 	PriorToRAStart   bool // is it ok to charge prior to RA start
 	PriorToRAStop    bool // is it ok to charge after RA stop
+	flags            uint64
+	IsRent           bool // does this rule assess rent
 	LastModTime      rlib.JSONDateTime
 	LastModBy        int64
 	CreateTS         rlib.JSONDateTime
@@ -56,6 +58,7 @@ type ARSaveForm struct {
 	DtStop         rlib.JSONDate
 	PriorToRAStart bool // is it ok to charge prior to RA start
 	PriorToRAStop  bool // is it ok to charge after RA stop
+	IsRent         bool // does this rule assess rent
 }
 
 // PrARGrid is a structure specifically for the UI Grid.
@@ -349,10 +352,8 @@ func saveARForm(w http.ResponseWriter, r *http.Request, d *ServiceData) {
 	fmt.Printf("saveAR - first migrate: a = %#v\n", a)
 
 	var ok bool
-	a.BID, ok = rlib.RRdb.BUDlist[string(foo.Record.BUD)]
+	a.BID, ok = getBIDFromBUD(w, d, foo.Record.BUD, funcname)
 	if !ok {
-		e := fmt.Errorf("%s: Could not map BID value: %s", funcname, foo.Record.BUD)
-		SvcGridErrorReturn(w, e, funcname)
 		return
 	}
 
@@ -364,6 +365,14 @@ func saveARForm(w http.ResponseWriter, r *http.Request, d *ServiceData) {
 			break
 		}
 	}
+	if foo.Record.IsRent {
+		if a.ARType != rlib.ARASSESSMENT {
+			e := fmt.Errorf("%s: only an Assessment rule can assess rent", funcname)
+			SvcGridErrorReturn(w, e, funcname)
+			return
+		}
+		a.FLAGS |= rlib.ARRENTASSESSMENT
+	}
 
 	// save or update
 	if a.ARID == 0 && d.ARID == 0 {
@@ -373,6 +382,9 @@ func saveARForm(w http.ResponseWriter, r *http.Request, d *ServiceData) {
 	} else {
 		// update existing record
 		fmt.Printf("Updating existing AR: %d\n", a.ARID)
+		if old, _ := rlib.GetAR(a.ARID); !svcCheckBID(w, d, old.BID, funcname) {
+			return
+		}
 		err = rlib.UpdateAR(&a)
 	}
 	if err != nil {
@@ -397,6 +409,7 @@ var getARQuerySelectFields = selectQueryFields{
 	"AR.DtStart",
 	"AR.DtStop",
 	"AR.RARequired",
+	"AR.FLAGS",
 	"AR.LastModTime",
 	"AR.LastModBy",
 	"AR.CreateTS",
@@ -461,7 +474,7 @@ func getARForm(w http.ResponseWriter, r *http.Request, d *ServiceData) {
 		gg.BID = d.BID
 		gg.BUD = getBUDFromBIDList(d.BID)
 
-		err = rows.Scan(&gg.ARID, &gg.Name, &gg.ARType, &gg.DebitLID, &gg.DebitLedgerName, &gg.CreditLID, &gg.CreditLedgerName, &gg.Description, &gg.DtStart, &gg.DtStop, &gg.raRequired, &gg.LastModTime, &gg.LastModBy, &gg.CreateTS, &gg.CreateBy)
+		err = rows.Scan(&gg.ARID, &gg.Name, &gg.ARType, &gg.DebitLID, &gg.DebitLedgerName, &gg.CreditLID, &gg.CreditLedgerName, &gg.Description, &gg.DtStart, &gg.DtStop, &gg.raRequired, &gg.flags, &gg.LastModTime, &gg.LastModBy, &gg.CreateTS, &gg.CreateBy)
 		if err != nil {
 			SvcGridErrorReturn(w, err, funcname)
 			return
@@ -471,6 +484,7 @@ func getARForm(w http.ResponseWriter, r *http.Request, d *ServiceData) {
 		raReqMappedVal := raRequiredMap[gg.raRequired]
 		gg.PriorToRAStart = raReqMappedVal[0]
 		gg.PriorToRAStop = raReqMappedVal[1]
+		gg.IsRent = gg.flags&rlib.ARRENTASSESSMENT != 0
 		g.Record = gg
 	}
 
@@ -509,6 +523,9 @@ func deleteARForm(w http.ResponseWriter, r *http.Request, d *ServiceData) {
 		SvcGridErrorReturn(w, err, funcname)
 		return
 	}
+	if old, _ := rlib.GetAR(del.ARID); !svcCheckBID(w, d, old.BID, funcname) {
+		return
+	}
 
 	if err := rlib.DeleteAR(del.ARID); err != nil {
 		SvcGridErrorReturn(w, err, funcname)
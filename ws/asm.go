@@ -352,21 +352,29 @@ func saveAssessment(w http.ResponseWriter, r *http.Request, d *ServiceData) {
 	//----------------------------------------------------------
 	var a rlib.Assessment
 	rlib.MigrateStructVals(&foo.Record, &a) // the variables that don't need special handling
+	a.LastModBy = d.UID
+	if !svcCheckBID(w, d, a.BID, funcname) {
+		return
+	}
 
 	fmt.Printf("\nAfter MigrateStructVals: a = %#v\n", a)
 	fmt.Printf("Start = %s, Stop = %s\n\n", a.Start.Format(rlib.RRDATEINPFMT), a.Stop.Format(rlib.RRDATEINPFMT))
 
 	// Now just update the database
 	if a.ASMID == 0 && d.ASMID == 0 {
-		errlist := bizlogic.InsertAssessment(&a, foo.Record.ExpandPastInst)
+		a.CreateBy = d.UID
+		errlist := bizlogic.InsertAssessment(&a, foo.Record.ExpandPastInst, d.UID)
 		if len(errlist) > 0 {
 			SvcErrListReturn(w, errlist, funcname)
 			return
 		}
 	} else if a.ASMID > 0 || d.ASMID > 0 {
 		fmt.Printf(">>>> UPDATE EXISTING ASSESSMENT  ASMID = %d\n", a.ASMID)
+		if old, _ := rlib.GetAssessment(a.ASMID); !svcCheckBID(w, d, old.BID, funcname) {
+			return
+		}
 		now := time.Now() // mark Assessment reversed at this time
-		errlist = bizlogic.UpdateAssessment(&a, foo.Record.Mode, &now, foo.Record.ExpandPastInst)
+		errlist = bizlogic.UpdateAssessment(&a, foo.Record.Mode, &now, foo.Record.ExpandPastInst, d.UID)
 		if len(errlist) > 0 {
 			SvcErrListReturn(w, errlist, funcname)
 			return
@@ -515,11 +523,14 @@ func deleteAssessment(w http.ResponseWriter, r *http.Request, d *ServiceData) {
 		SvcGridErrorReturn(w, err, funcname)
 		return
 	}
+	if !svcCheckBID(w, d, a.BID, funcname) {
+		return
+	}
 
 	fmt.Printf("Reversal Mode = %d\n", del.ReverseMode)
 
 	now := time.Now() // mark Assessment reversed at this time
-	errlist := bizlogic.ReverseAssessment(&a, del.ReverseMode, &now)
+	errlist := bizlogic.ReverseAssessment(&a, del.ReverseMode, &now, d.UID)
 	if len(errlist) > 0 {
 		s := ""
 		for i := 0; i < len(errlist); i++ {
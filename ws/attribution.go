@@ -0,0 +1,90 @@
+package ws
+
+import (
+	"fmt"
+	"net/http"
+	"rentroll/bizlogic"
+)
+
+// AttributionGrid is the marketing performance of one DemandSource or LeadSource
+type AttributionGrid struct {
+	Recid      int64   `json:"recid"`
+	SourceType string  // Demand or Lead
+	ID         int64   // SourceSLSID or LSID, 0 = no source recorded
+	Name       string  // name of the source
+	Prospects  int64   // prospects created during the period
+	Applicants int64   // the prospects that went on to apply
+	Agreements int64   // rental agreements signed during the period
+	Conversion float64 // Agreements as a percentage of Prospects
+	Revenue    float64 // rent revenue of those agreements during the period
+}
+
+// AttributionResponse is the response to a request for the marketing attribution
+type AttributionResponse struct {
+	Status  string            `json:"status"`
+	Total   int64             `json:"total"`
+	Records []AttributionGrid `json:"records"`
+}
+
+// SvcHandlerAttribution returns the marketing attribution of a business.
+// For this call, we expect the URI to contain the BID:
+//       /v1/attribution/:BUI
+//
+// The server command can be:
+//      get
+//-----------------------------------------------------------------------------------
+func SvcHandlerAttribution(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	var (
+		funcname = "SvcHandlerAttribution"
+		err      error
+	)
+
+	fmt.Printf("Entered %s\n", funcname)
+	fmt.Printf("Request: %s:  BID = %d\n", d.wsSearchReq.Cmd, d.BID)
+
+	switch d.wsSearchReq.Cmd {
+	case "get":
+		getAttribution(w, r, d)
+		break
+	default:
+		err = fmt.Errorf("Unhandled command: %s", d.wsSearchReq.Cmd)
+		SvcGridErrorReturn(w, err, funcname)
+		return
+	}
+}
+
+// getAttribution returns the marketing attribution
+// wsdoc {
+//  @Title  Get Marketing Attribution
+//	@URL /v1/attribution/:BUI
+//  @Method  POST
+//	@Synopsis Get the marketing attribution by DemandSource and LeadSource
+//  @Description  Returns, for each DemandSource and LeadSource of business :BUI, the number
+//  @Description  of prospects, applicants and signed rental agreements between searchDtStart
+//  @Description  and searchDtStop, the conversion rate, and the rent revenue of the agreements.
+//	@Input WebGridSearchRequest
+//  @Response AttributionResponse
+// wsdoc }
+func getAttribution(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	var (
+		funcname = "getAttribution"
+		g        AttributionResponse
+	)
+
+	fmt.Printf("Entered %s\n", funcname)
+	a := bizlogic.GetAttribution(d.BID, &d.wsSearchReq.SearchDtStart, &d.wsSearchReq.SearchDtStop)
+	add := func(t string, m []bizlogic.AttributionEntry) {
+		for i := 0; i < len(m); i++ {
+			q := AttributionGrid{SourceType: t, ID: m[i].ID, Name: m[i].Name, Prospects: m[i].Prospects,
+				Applicants: m[i].Applicants, Agreements: m[i].Agreements, Conversion: m[i].Conversion, Revenue: m[i].Revenue}
+			q.Recid = int64(len(g.Records) + 1)
+			g.Records = append(g.Records, q)
+		}
+	}
+	add("Demand", a.DemandSources)
+	add("Lead", a.LeadSources)
+	g.Total = int64(len(g.Records))
+	g.Status = "success"
+	w.Header().Set("Content-Type", "application/json")
+	SvcWriteResponse(&g, w)
+}
@@ -0,0 +1,120 @@
+package ws
+
+import (
+	"fmt"
+	"net/http"
+	"rentroll/rlib"
+	"strings"
+)
+
+// AuditGrid describes one change to a Journal, JournalMarker, LedgerEntry or LedgerMarker
+type AuditGrid struct {
+	Recid   int64  `json:"recid"`
+	Table   string // Journal, JournalMarker, LedgerEntry or LedgerMarker
+	ID      int64  // JID, JMID, LEID or LMID
+	BID     int64
+	BUD     rlib.XJSONBud
+	UID     int64 // user who made the change
+	Action  int64 // 1 = create, 2 = update, 3 = delete
+	Comment string
+	ModTime rlib.JSONDateTime
+}
+
+// AuditSearchResponse is the response to a request for the history of a record
+type AuditSearchResponse struct {
+	Status  string      `json:"status"`
+	Total   int64       `json:"total"`
+	Records []AuditGrid `json:"records"`
+}
+
+// SvcHandlerAudit returns the change history of a Journal entry or LedgerEntry.
+// For this call, we expect the URI to contain the BID and the id of the record,
+// prefixed to identify its table:  /v1/audit/:BUI/:XID
+//
+//      J<jid>    Journal entry
+//      JM<jmid>  JournalMarker
+//      LE<leid>  LedgerEntry
+//      LM<lmid>  LedgerMarker
+//
+// A number with no prefix is taken to be a JID.
+//
+// The server command can be:
+//      get
+//-----------------------------------------------------------------------------------
+func SvcHandlerAudit(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	var (
+		funcname = "SvcHandlerAudit"
+		err      error
+	)
+
+	fmt.Printf("Entered %s\n", funcname)
+	fmt.Printf("Request: %s:  BID = %d,  XID = %s\n", d.wsSearchReq.Cmd, d.BID, d.DetVal)
+
+	switch d.wsSearchReq.Cmd {
+	case "get":
+		getAuditHistory(w, r, d)
+		break
+	default:
+		err = fmt.Errorf("Unhandled command: %s", d.wsSearchReq.Cmd)
+		SvcGridErrorReturn(w, err, funcname)
+		return
+	}
+}
+
+// getAuditHistory returns the change history of a record
+// wsdoc {
+//  @Title  Get Audit History
+//	@URL /v1/audit/:BUI/:XID
+//  @Method  POST
+//	@Synopsis Get the change history of a Journal entry or LedgerEntry
+//  @Description  Returns every create, update and delete of the record :XID, oldest first.
+//  @Description  :XID is a JID prefixed with J, or an LEID prefixed with LE. JournalMarkers
+//  @Description  (JM) and LedgerMarkers (LM) are also supported.
+//	@Input WebGridSearchRequest
+//  @Response AuditSearchResponse
+// wsdoc }
+func getAuditHistory(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	var (
+		funcname = "getAuditHistory"
+		g        AuditSearchResponse
+		m        []rlib.AuditRecord
+	)
+
+	fmt.Printf("Entered %s\n", funcname)
+	xid := strings.ToUpper(d.DetVal)
+	pfx := strings.TrimRight(xid, "0123456789")
+	id, err := rlib.IntFromString(xid[len(pfx):], "bad request integer value")
+	if err != nil || id <= 0 {
+		SvcGridErrorReturn(w, fmt.Errorf("%s: invalid record id: %s", funcname, d.DetVal), funcname)
+		return
+	}
+
+	switch pfx {
+	case "", "J":
+		m = rlib.GetJournalAudit(id)
+	case "JM":
+		m = rlib.GetJournalMarkerAudit(id)
+	case "LE":
+		m = rlib.GetLedgerAudit(id)
+	case "LM":
+		m = rlib.GetLedgerMarkerAudit(id)
+	default:
+		SvcGridErrorReturn(w, fmt.Errorf("%s: unknown record type: %s", funcname, pfx), funcname)
+		return
+	}
+
+	for i := 0; i < len(m); i++ {
+		if m[i].BID != d.BID {
+			continue
+		}
+		var q AuditGrid
+		rlib.MigrateStructVals(&m[i], &q)
+		q.Recid = int64(i + 1)
+		q.BUD = getBUDFromBIDList(q.BID)
+		g.Records = append(g.Records, q)
+	}
+	g.Total = int64(len(g.Records))
+	g.Status = "success"
+	w.Header().Set("Content-Type", "application/json")
+	SvcWriteResponse(&g, w)
+}
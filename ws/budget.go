@@ -0,0 +1,529 @@
+package ws
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"rentroll/bizlogic"
+	"rentroll/rlib"
+	"time"
+)
+
+// BudgetGrid is the budget of a GL Account for a month
+type BudgetGrid struct {
+	Recid       int64 `json:"recid"`
+	BGID        int64
+	BID         int64
+	BUD         rlib.XJSONBud
+	LID         int64
+	GLNumber    string
+	Name        string // name of the GL Account
+	Dt          rlib.JSONDate
+	Amount      float64
+	LastModTime rlib.JSONDateTime
+	LastModBy   int64
+	CreateTS    rlib.JSONDateTime
+	CreateBy    int64
+}
+
+// BudgetSearchResponse is the response to a request for the budgets of a period
+type BudgetSearchResponse struct {
+	Status  string       `json:"status"`
+	Total   int64        `json:"total"`
+	Records []BudgetGrid `json:"records"`
+}
+
+// BudgetGetResponse is the response to a request for a single budget
+type BudgetGetResponse struct {
+	Status string     `json:"status"`
+	Record BudgetGrid `json:"record"`
+}
+
+// BudgetForm contains the data from the Budget FORM
+type BudgetForm struct {
+	BGID   int64 // 0 = new budget
+	BUD    rlib.XJSONBud
+	LID    int64
+	Dt     rlib.JSONDate // any date in the budgeted month
+	Amount float64       // debits are positive, credits are negative
+}
+
+// BudgetInput is the input data format for a Save command
+type BudgetInput struct {
+	Status   string     `json:"status"`
+	Recid    int64      `json:"recid"`
+	FormName string     `json:"name"`
+	Record   BudgetForm `json:"record"`
+}
+
+// RentableTypeBudgetGrid is the GSR and occupancy targets of a RentableType for a month
+type RentableTypeBudgetGrid struct {
+	Recid       int64 `json:"recid"`
+	RTBGID      int64
+	BID         int64
+	BUD         rlib.XJSONBud
+	RTID        int64
+	Style       string // style of the RentableType
+	Dt          rlib.JSONDate
+	GSR         float64
+	Occupancy   float64 // percent
+	LastModTime rlib.JSONDateTime
+	LastModBy   int64
+	CreateTS    rlib.JSONDateTime
+	CreateBy    int64
+}
+
+// RentableTypeBudgetSearchResponse is the response to a request for the targets of a period
+type RentableTypeBudgetSearchResponse struct {
+	Status  string                   `json:"status"`
+	Total   int64                    `json:"total"`
+	Records []RentableTypeBudgetGrid `json:"records"`
+}
+
+// RentableTypeBudgetGetResponse is the response to a request for a single set of targets
+type RentableTypeBudgetGetResponse struct {
+	Status string                 `json:"status"`
+	Record RentableTypeBudgetGrid `json:"record"`
+}
+
+// RentableTypeBudgetForm contains the data from the RentableType Budget FORM
+type RentableTypeBudgetForm struct {
+	RTBGID    int64 // 0 = new targets
+	BUD       rlib.XJSONBud
+	RTID      int64
+	Dt        rlib.JSONDate // any date in the budgeted month
+	GSR       float64
+	Occupancy float64 // percent, 0 - 100
+}
+
+// RentableTypeBudgetInput is the input data format for a Save command
+type RentableTypeBudgetInput struct {
+	Status   string                 `json:"status"`
+	Recid    int64                  `json:"recid"`
+	FormName string                 `json:"name"`
+	Record   RentableTypeBudgetForm `json:"record"`
+}
+
+// budgetGridRecord fills out a BudgetGrid from budget a
+func budgetGridRecord(a *rlib.Budget) BudgetGrid {
+	var q BudgetGrid
+	rlib.MigrateStructVals(a, &q)
+	q.Recid = a.BGID
+	q.BUD = getBUDFromBIDList(a.BID)
+	l := rlib.GetLedger(a.LID)
+	q.GLNumber = l.GLNumber
+	q.Name = l.Name
+	return q
+}
+
+// rtBudgetGridRecord fills out a RentableTypeBudgetGrid from targets a
+func rtBudgetGridRecord(a *rlib.RentableTypeBudget) RentableTypeBudgetGrid {
+	var q RentableTypeBudgetGrid
+	rlib.MigrateStructVals(a, &q)
+	q.Recid = a.RTBGID
+	q.BUD = getBUDFromBIDList(a.BID)
+	var rt rlib.RentableType
+	if err := rlib.GetRentableType(a.RTID, &rt); err == nil {
+		q.Style = rt.Style
+	}
+	return q
+}
+
+// SvcHandlerBudgets lists the GL Account budgets of a business.
+// For this call, we expect the URI to contain the BID:  /v1/budgets/:BUI
+//
+// The server command can be:
+//      get
+//-----------------------------------------------------------------------------------
+func SvcHandlerBudgets(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	var (
+		funcname = "SvcHandlerBudgets"
+		err      error
+	)
+
+	fmt.Printf("Entered %s\n", funcname)
+	fmt.Printf("Request: %s:  BID = %d\n", d.wsSearchReq.Cmd, d.BID)
+
+	switch d.wsSearchReq.Cmd {
+	case "get":
+		getBudgets(w, r, d)
+		break
+	default:
+		err = fmt.Errorf("Unhandled command: %s", d.wsSearchReq.Cmd)
+		SvcGridErrorReturn(w, err, funcname)
+		return
+	}
+}
+
+// getBudgets returns the GL Account budgets of a period
+// wsdoc {
+//  @Title  Get Budgets
+//	@URL /v1/budgets/:BUI
+//  @Method  POST
+//	@Synopsis Get the GL Account budgets of a period
+//  @Description  Returns the monthly GL Account budgets of business :BUI for the months
+//  @Description  starting from searchDtStart up to searchDtStop, sorted by account and month.
+//	@Input WebGridSearchRequest
+//  @Response BudgetSearchResponse
+// wsdoc }
+func getBudgets(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	var (
+		funcname = "getBudgets"
+		g        BudgetSearchResponse
+	)
+
+	fmt.Printf("Entered %s\n", funcname)
+	m := rlib.GetBudgetsByRange(d.BID, &d.wsSearchReq.SearchDtStart, &d.wsSearchReq.SearchDtStop)
+	for i := 0; i < len(m); i++ {
+		g.Records = append(g.Records, budgetGridRecord(&m[i]))
+	}
+	g.Total = int64(len(g.Records))
+	g.Status = "success"
+	w.Header().Set("Content-Type", "application/json")
+	SvcWriteResponse(&g, w)
+}
+
+// SvcHandlerBudget returns, creates, updates and deletes the budget of a GL Account for a month.
+// For this call, we expect the URI to contain the BID and the BGID:
+//    /v1/budget/:BUI/:BGID
+//
+// The server command can be:
+//      get
+//      save
+//      delete
+//-----------------------------------------------------------------------------------
+func SvcHandlerBudget(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	var (
+		funcname = "SvcHandlerBudget"
+		err      error
+	)
+
+	fmt.Printf("Entered %s\n", funcname)
+	fmt.Printf("Request: %s:  BID = %d,  BGID = %d\n", d.wsSearchReq.Cmd, d.BID, d.ID)
+
+	switch d.wsSearchReq.Cmd {
+	case "get":
+		getBudget(w, r, d)
+		break
+	case "save":
+		saveBudget(w, r, d)
+		break
+	case "delete":
+		deleteBudget(w, r, d)
+		break
+	default:
+		err = fmt.Errorf("Unhandled command: %s", d.wsSearchReq.Cmd)
+		SvcGridErrorReturn(w, err, funcname)
+		return
+	}
+}
+
+// getBudget returns the requested budget
+// wsdoc {
+//  @Title  Get Budget
+//	@URL /v1/budget/:BUI/:BGID
+//  @Method  GET
+//	@Synopsis Get the budget of a GL Account for a month
+//  @Desc  This service returns the budget with id :BGID.
+//	@Input WebGridSearchRequest
+//  @Response BudgetGetResponse
+// wsdoc }
+func getBudget(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	var (
+		funcname = "getBudget"
+		g        BudgetGetResponse
+	)
+
+	fmt.Printf("Entered %s\n", funcname)
+	a, err := rlib.GetBudget(d.ID)
+	if err != nil || a.BID != d.BID {
+		e := fmt.Errorf("%s: Budget %d not found", funcname, d.ID)
+		SvcGridErrorReturn(w, e, funcname)
+		return
+	}
+	g.Record = budgetGridRecord(&a)
+	g.Status = "success"
+	SvcWriteResponse(&g, w)
+}
+
+// saveBudget creates or updates the budget of a GL Account for a month
+// wsdoc {
+//  @Title  Save Budget
+//	@URL /v1/budget/:BUI/:BGID
+//  @Method  POST
+//	@Synopsis Create or update the budget of a GL Account for a month
+//  @Description  If BGID is 0 a new budget is created, otherwise budget BGID is updated.
+//  @Description  Dt may be any date in the budgeted month. Amount uses the sign convention
+//  @Description  of the ledger: debits are positive, credits are negative. An account can
+//  @Description  only have one budget per month. The response contains the BGID.
+//	@Input BudgetInput
+//  @Response SvcStatusResponse
+// wsdoc }
+func saveBudget(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	var (
+		funcname = "saveBudget"
+		foo      BudgetInput
+	)
+
+	fmt.Printf("Entered %s\n", funcname)
+	fmt.Printf("record data = %s\n", d.data)
+
+	if err := json.Unmarshal([]byte(d.data), &foo); err != nil {
+		e := fmt.Errorf("%s: Error with json.Unmarshal:  %s", funcname, err.Error())
+		SvcGridErrorReturn(w, e, funcname)
+		return
+	}
+
+	a := rlib.Budget{BGID: foo.Record.BGID, BID: d.BID, LID: foo.Record.LID, Dt: time.Time(foo.Record.Dt), Amount: foo.Record.Amount}
+	if len(foo.Record.BUD) > 0 {
+		var ok bool
+		if a.BID, ok = getBIDFromBUD(w, d, foo.Record.BUD, funcname); !ok {
+			return
+		}
+	}
+	if a.BGID == 0 {
+		a.BGID = d.ID
+	}
+	if a.BGID > 0 {
+		old, err := rlib.GetBudget(a.BGID)
+		if err != nil || old.BID != a.BID {
+			e := fmt.Errorf("%s: Budget %d not found", funcname, a.BGID)
+			SvcGridErrorReturn(w, e, funcname)
+			return
+		}
+	}
+
+	if errlist := bizlogic.SaveBudget(&a, d.UID); len(errlist) > 0 {
+		SvcErrListReturn(w, errlist, funcname)
+		return
+	}
+	SvcWriteSuccessResponseWithID(w, a.BGID)
+}
+
+// deleteBudget deletes the budget of a GL Account for a month
+// wsdoc {
+//  @Title  Delete Budget
+//	@URL /v1/budget/:BUI/:BGID
+//  @Method  POST
+//	@Synopsis Delete the budget of a GL Account for a month
+//  @Description  Deletes budget :BGID.
+//	@Input WebGridSearchRequest
+//  @Response SvcStatusResponse
+// wsdoc }
+func deleteBudget(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	funcname := "deleteBudget"
+	fmt.Printf("Entered %s\n", funcname)
+
+	a, err := rlib.GetBudget(d.ID)
+	if err != nil || a.BID != d.BID {
+		e := fmt.Errorf("%s: Budget %d not found", funcname, d.ID)
+		SvcGridErrorReturn(w, e, funcname)
+		return
+	}
+	if err = rlib.DeleteBudget(a.BGID); err != nil {
+		SvcGridErrorReturn(w, err, funcname)
+		return
+	}
+	SvcWriteSuccessResponse(w)
+}
+
+// SvcHandlerRentableTypeBudgets lists the RentableType GSR and occupancy targets of a business.
+// For this call, we expect the URI to contain the BID:  /v1/rtbudgets/:BUI
+//
+// The server command can be:
+//      get
+//-----------------------------------------------------------------------------------
+func SvcHandlerRentableTypeBudgets(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	var (
+		funcname = "SvcHandlerRentableTypeBudgets"
+		err      error
+	)
+
+	fmt.Printf("Entered %s\n", funcname)
+	fmt.Printf("Request: %s:  BID = %d\n", d.wsSearchReq.Cmd, d.BID)
+
+	switch d.wsSearchReq.Cmd {
+	case "get":
+		getRentableTypeBudgets(w, r, d)
+		break
+	default:
+		err = fmt.Errorf("Unhandled command: %s", d.wsSearchReq.Cmd)
+		SvcGridErrorReturn(w, err, funcname)
+		return
+	}
+}
+
+// getRentableTypeBudgets returns the RentableType targets of a period
+// wsdoc {
+//  @Title  Get RentableType Budgets
+//	@URL /v1/rtbudgets/:BUI
+//  @Method  POST
+//	@Synopsis Get the RentableType GSR and occupancy targets of a period
+//  @Description  Returns the monthly GSR and occupancy targets of the RentableTypes of
+//  @Description  business :BUI for the months starting from searchDtStart up to searchDtStop,
+//  @Description  sorted by RentableType and month.
+//	@Input WebGridSearchRequest
+//  @Response RentableTypeBudgetSearchResponse
+// wsdoc }
+func getRentableTypeBudgets(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	var (
+		funcname = "getRentableTypeBudgets"
+		g        RentableTypeBudgetSearchResponse
+	)
+
+	fmt.Printf("Entered %s\n", funcname)
+	m := rlib.GetRentableTypeBudgetsByRange(d.BID, &d.wsSearchReq.SearchDtStart, &d.wsSearchReq.SearchDtStop)
+	for i := 0; i < len(m); i++ {
+		g.Records = append(g.Records, rtBudgetGridRecord(&m[i]))
+	}
+	g.Total = int64(len(g.Records))
+	g.Status = "success"
+	w.Header().Set("Content-Type", "application/json")
+	SvcWriteResponse(&g, w)
+}
+
+// SvcHandlerRentableTypeBudget returns, creates, updates and deletes the GSR and occupancy
+// targets of a RentableType for a month.
+// For this call, we expect the URI to contain the BID and the RTBGID:
+//    /v1/rtbudget/:BUI/:RTBGID
+//
+// The server command can be:
+//      get
+//      save
+//      delete
+//-----------------------------------------------------------------------------------
+func SvcHandlerRentableTypeBudget(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	var (
+		funcname = "SvcHandlerRentableTypeBudget"
+		err      error
+	)
+
+	fmt.Printf("Entered %s\n", funcname)
+	fmt.Printf("Request: %s:  BID = %d,  RTBGID = %d\n", d.wsSearchReq.Cmd, d.BID, d.ID)
+
+	switch d.wsSearchReq.Cmd {
+	case "get":
+		getRentableTypeBudget(w, r, d)
+		break
+	case "save":
+		saveRentableTypeBudget(w, r, d)
+		break
+	case "delete":
+		deleteRentableTypeBudget(w, r, d)
+		break
+	default:
+		err = fmt.Errorf("Unhandled command: %s", d.wsSearchReq.Cmd)
+		SvcGridErrorReturn(w, err, funcname)
+		return
+	}
+}
+
+// getRentableTypeBudget returns the requested RentableType targets
+// wsdoc {
+//  @Title  Get RentableType Budget
+//	@URL /v1/rtbudget/:BUI/:RTBGID
+//  @Method  GET
+//	@Synopsis Get the GSR and occupancy targets of a RentableType for a month
+//  @Desc  This service returns the targets with id :RTBGID.
+//	@Input WebGridSearchRequest
+//  @Response RentableTypeBudgetGetResponse
+// wsdoc }
+func getRentableTypeBudget(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	var (
+		funcname = "getRentableTypeBudget"
+		g        RentableTypeBudgetGetResponse
+	)
+
+	fmt.Printf("Entered %s\n", funcname)
+	a, err := rlib.GetRentableTypeBudget(d.ID)
+	if err != nil || a.BID != d.BID {
+		e := fmt.Errorf("%s: RentableTypeBudget %d not found", funcname, d.ID)
+		SvcGridErrorReturn(w, e, funcname)
+		return
+	}
+	g.Record = rtBudgetGridRecord(&a)
+	g.Status = "success"
+	SvcWriteResponse(&g, w)
+}
+
+// saveRentableTypeBudget creates or updates the targets of a RentableType for a month
+// wsdoc {
+//  @Title  Save RentableType Budget
+//	@URL /v1/rtbudget/:BUI/:RTBGID
+//  @Method  POST
+//	@Synopsis Create or update the GSR and occupancy targets of a RentableType for a month
+//  @Description  If RTBGID is 0 new targets are created, otherwise targets RTBGID are updated.
+//  @Description  Dt may be any date in the budgeted month. Occupancy is a percentage from
+//  @Description  0 to 100. Only RentableTypes that are managed to budget can have targets,
+//  @Description  and only one set per month. The response contains the RTBGID.
+//	@Input RentableTypeBudgetInput
+//  @Response SvcStatusResponse
+// wsdoc }
+func saveRentableTypeBudget(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	var (
+		funcname = "saveRentableTypeBudget"
+		foo      RentableTypeBudgetInput
+	)
+
+	fmt.Printf("Entered %s\n", funcname)
+	fmt.Printf("record data = %s\n", d.data)
+
+	if err := json.Unmarshal([]byte(d.data), &foo); err != nil {
+		e := fmt.Errorf("%s: Error with json.Unmarshal:  %s", funcname, err.Error())
+		SvcGridErrorReturn(w, e, funcname)
+		return
+	}
+
+	a := rlib.RentableTypeBudget{RTBGID: foo.Record.RTBGID, BID: d.BID, RTID: foo.Record.RTID, Dt: time.Time(foo.Record.Dt),
+		GSR: foo.Record.GSR, Occupancy: foo.Record.Occupancy}
+	if len(foo.Record.BUD) > 0 {
+		var ok bool
+		if a.BID, ok = getBIDFromBUD(w, d, foo.Record.BUD, funcname); !ok {
+			return
+		}
+	}
+	if a.RTBGID == 0 {
+		a.RTBGID = d.ID
+	}
+	if a.RTBGID > 0 {
+		old, err := rlib.GetRentableTypeBudget(a.RTBGID)
+		if err != nil || old.BID != a.BID {
+			e := fmt.Errorf("%s: RentableTypeBudget %d not found", funcname, a.RTBGID)
+			SvcGridErrorReturn(w, e, funcname)
+			return
+		}
+	}
+
+	if errlist := bizlogic.SaveRentableTypeBudget(&a, d.UID); len(errlist) > 0 {
+		SvcErrListReturn(w, errlist, funcname)
+		return
+	}
+	SvcWriteSuccessResponseWithID(w, a.RTBGID)
+}
+
+// deleteRentableTypeBudget deletes the targets of a RentableType for a month
+// wsdoc {
+//  @Title  Delete RentableType Budget
+//	@URL /v1/rtbudget/:BUI/:RTBGID
+//  @Method  POST
+//	@Synopsis Delete the GSR and occupancy targets of a RentableType for a month
+//  @Description  Deletes targets :RTBGID.
+//	@Input WebGridSearchRequest
+//  @Response SvcStatusResponse
+// wsdoc }
+func deleteRentableTypeBudget(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	funcname := "deleteRentableTypeBudget"
+	fmt.Printf("Entered %s\n", funcname)
+
+	a, err := rlib.GetRentableTypeBudget(d.ID)
+	if err != nil || a.BID != d.BID {
+		e := fmt.Errorf("%s: RentableTypeBudget %d not found", funcname, d.ID)
+		SvcGridErrorReturn(w, e, funcname)
+		return
+	}
+	if err = rlib.DeleteRentableTypeBudget(a.RTBGID); err != nil {
+		SvcGridErrorReturn(w, err, funcname)
+		return
+	}
+	SvcWriteSuccessResponse(w)
+}
@@ -0,0 +1,159 @@
+package ws
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"rentroll/bizlogic"
+	"rentroll/rlib"
+	"time"
+)
+
+// RentableTypeChangeGrid describes the change of the type of a Rentable
+type RentableTypeChangeGrid struct {
+	Recid    int64 `json:"recid"`
+	BID      int64
+	BUD      rlib.XJSONBud
+	RID      int64
+	OldRTID  int64
+	NewRTID  int64
+	Dt       rlib.JSONDate
+	OldGSR   float64 // loaded GSR for the month starting Dt, before the change
+	NewGSR   float64 // loaded GSR for the same month, after the change
+	OldMR    float64 // market rate of the old type on Dt
+	NewMR    float64 // market rate of the new type on Dt
+	Reissued bool    // true if the recurring rent assessments were re-issued at NewMR
+}
+
+// RentableTypeChangeAsmGrid is a recurring rent assessment affected by the change
+type RentableTypeChangeAsmGrid struct {
+	Recid     int64 `json:"recid"`
+	ASMID     int64
+	RAID      int64
+	RentCycle int64
+	OldAmount float64
+	NewAmount float64
+}
+
+// RentableTypeChangeResponse is the response to a Rentable type change request
+type RentableTypeChangeResponse struct {
+	Status      string                      `json:"status"`
+	Record      RentableTypeChangeGrid      `json:"record"`
+	Assessments []RentableTypeChangeAsmGrid `json:"assessments"`
+}
+
+// RentableTypeChangeForm contains the data from the Change Rentable Type FORM
+type RentableTypeChangeForm struct {
+	BUD     rlib.XJSONBud
+	RID     int64
+	RTID    int64         // the new type
+	Dt      rlib.JSONDate // date the change takes effect
+	Reissue bool          // re-issue the recurring rent assessments at the new market rate
+}
+
+// RentableTypeChangeInput is the input data format for the get and save commands
+type RentableTypeChangeInput struct {
+	Cmd      string                 `json:"cmd"`
+	Recid    int64                  `json:"recid"`
+	FormName string                 `json:"name"`
+	Record   RentableTypeChangeForm `json:"record"`
+}
+
+// SvcHandlerChangeRentableType previews and makes a change of the type of a Rentable.
+// For this call, we expect the URI to contain the BID and the RID:
+//    /v1/chgrt/:BUI/:RID
+//
+// The server command can be:
+//      get
+//      save
+//-----------------------------------------------------------------------------------
+func SvcHandlerChangeRentableType(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	var (
+		funcname = "SvcHandlerChangeRentableType"
+		err      error
+	)
+
+	fmt.Printf("Entered %s\n", funcname)
+	fmt.Printf("Request: %s:  BID = %d,  RID = %d\n", d.wsSearchReq.Cmd, d.BID, d.ID)
+
+	switch d.wsSearchReq.Cmd {
+	case "get":
+		changeRentableType(w, r, d, false)
+		break
+	case "save":
+		changeRentableType(w, r, d, true)
+		break
+	default:
+		err = fmt.Errorf("Unhandled command: %s", d.wsSearchReq.Cmd)
+		SvcGridErrorReturn(w, err, funcname)
+		return
+	}
+}
+
+// changeRentableType previews or makes a change of the type of a Rentable
+// wsdoc {
+//  @Title  Change Rentable Type
+//	@URL /v1/chgrt/:BUI/:RID
+//  @Method  POST
+//	@Synopsis Change the type of a Rentable as of a date
+//  @Description  Changes the type of Rentable RID to RTID as of Dt. The type in effect on Dt
+//  @Description  is closed on Dt and the new one is opened. The response shows the loaded
+//  @Description  GSR for the month starting Dt before and after the change, and the recurring
+//  @Description  rent assessments in place on Dt with the market rate of the new type. If
+//  @Description  Reissue is true, those assessments are stopped on Dt and re-issued at the
+//  @Description  new market rate. The get command makes no changes, it is a dry run.
+//	@Input RentableTypeChangeInput
+//  @Response RentableTypeChangeResponse
+// wsdoc }
+func changeRentableType(w http.ResponseWriter, r *http.Request, d *ServiceData, save bool) {
+	var (
+		funcname = "changeRentableType"
+		foo      RentableTypeChangeInput
+		g        RentableTypeChangeResponse
+		c        bizlogic.RentableTypeChange
+		errlist  []bizlogic.BizError
+	)
+
+	fmt.Printf("Entered %s\n", funcname)
+	fmt.Printf("record data = %s\n", d.data)
+
+	if err := json.Unmarshal([]byte(d.data), &foo); err != nil {
+		e := fmt.Errorf("%s: Error with json.Unmarshal:  %s", funcname, err.Error())
+		SvcGridErrorReturn(w, e, funcname)
+		return
+	}
+
+	bid := d.BID
+	if len(foo.Record.BUD) > 0 {
+		var ok bool
+		if bid, ok = getBIDFromBUD(w, d, foo.Record.BUD, funcname); !ok {
+			return
+		}
+	}
+	rid := foo.Record.RID
+	if rid == 0 {
+		rid = d.ID
+	}
+	dt := time.Time(foo.Record.Dt)
+	if save {
+		c, errlist = bizlogic.ChangeRentableType(bid, rid, foo.Record.RTID, &dt, foo.Record.Reissue, d.UID)
+	} else {
+		c, errlist = bizlogic.GetRentableTypeChange(bid, rid, foo.Record.RTID, &dt)
+	}
+	if len(errlist) > 0 {
+		SvcErrListReturn(w, errlist, funcname)
+		return
+	}
+
+	rlib.MigrateStructVals(&c, &g.Record)
+	g.Record.Recid = c.RID
+	g.Record.BID = bid
+	g.Record.BUD = getBUDFromBIDList(bid)
+	for i := 0; i < len(c.Assessments); i++ {
+		a := &c.Assessments[i]
+		g.Assessments = append(g.Assessments, RentableTypeChangeAsmGrid{Recid: a.ASM.ASMID, ASMID: a.ASM.ASMID,
+			RAID: a.ASM.RAID, RentCycle: a.ASM.RentCycle, OldAmount: a.OldAmount, NewAmount: a.NewAmount})
+	}
+	g.Status = "success"
+	SvcWriteResponse(&g, w)
+}
@@ -0,0 +1,151 @@
+package ws
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"rentroll/bizlogic"
+	"rentroll/rlib"
+	"time"
+)
+
+// ClosePeriodGrid describes a closed accounting period
+type ClosePeriodGrid struct {
+	Recid       int64 `json:"recid"`
+	JMID        int64
+	BID         int64
+	BUD         rlib.XJSONBud
+	State       int64
+	DtStart     rlib.JSONDate
+	DtStop      rlib.JSONDate
+	LastModTime rlib.JSONDateTime
+	LastModBy   int64
+	CreateTS    rlib.JSONDateTime
+	CreateBy    int64
+}
+
+// ClosePeriodSearchResponse is the response to a request for the closed periods
+type ClosePeriodSearchResponse struct {
+	Status  string            `json:"status"`
+	Total   int64             `json:"total"`
+	Records []ClosePeriodGrid `json:"records"`
+}
+
+// ClosePeriodForm holds the period to close
+type ClosePeriodForm struct {
+	BUD     rlib.XJSONBud
+	DtStart rlib.JSONDate
+	DtStop  rlib.JSONDate
+}
+
+// ClosePeriodInput is the input data format for a Save command
+type ClosePeriodInput struct {
+	Status   string          `json:"status"`
+	Recid    int64           `json:"recid"`
+	FormName string          `json:"name"`
+	Record   ClosePeriodForm `json:"record"`
+}
+
+// SvcHandlerClosePeriod lists and closes accounting periods.
+// For this call, we expect the URI to contain the BID:  /v1/closeperiod/:BUI
+//
+// The server command can be:
+//      get
+//      save
+//-----------------------------------------------------------------------------------
+func SvcHandlerClosePeriod(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	var (
+		funcname = "SvcHandlerClosePeriod"
+		err      error
+	)
+
+	fmt.Printf("Entered %s\n", funcname)
+	fmt.Printf("Request: %s:  BID = %d\n", d.wsSearchReq.Cmd, d.BID)
+
+	switch d.wsSearchReq.Cmd {
+	case "get":
+		getClosedPeriods(w, r, d)
+		break
+	case "save":
+		saveClosePeriod(w, r, d)
+		break
+	default:
+		err = fmt.Errorf("Unhandled command: %s", d.wsSearchReq.Cmd)
+		SvcGridErrorReturn(w, err, funcname)
+		return
+	}
+}
+
+// getClosedPeriods returns the closed accounting periods of the business
+// wsdoc {
+//  @Title  Get Closed Periods
+//	@URL /v1/closeperiod/:BUI
+//  @Method  POST
+//	@Synopsis Get the closed accounting periods
+//  @Description  Returns all closed or locked periods for business :BUI, most recent first.
+//	@Input WebGridSearchRequest
+//  @Response ClosePeriodSearchResponse
+// wsdoc }
+func getClosedPeriods(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	var (
+		funcname = "getClosedPeriods"
+		g        ClosePeriodSearchResponse
+	)
+
+	fmt.Printf("Entered %s\n", funcname)
+	m := rlib.GetClosedJournalMarkers(d.BID)
+	for i := 0; i < len(m); i++ {
+		var q ClosePeriodGrid
+		rlib.MigrateStructVals(&m[i], &q)
+		q.Recid = q.JMID
+		q.BUD = getBUDFromBIDList(q.BID)
+		g.Records = append(g.Records, q)
+	}
+	g.Total = int64(len(g.Records))
+	g.Status = "success"
+	w.Header().Set("Content-Type", "application/json")
+	SvcWriteResponse(&g, w)
+}
+
+// saveClosePeriod closes an accounting period
+// wsdoc {
+//  @Title  Close Period
+//	@URL /v1/closeperiod/:BUI
+//  @Method  POST
+//	@Synopsis Close an accounting period
+//  @Description  Closes the period DtStart - DtStop. The trial balance must be zero on DtStop
+//  @Description  and no part of the period may already be closed. After the period is closed,
+//  @Description  Assessments and Receipts dated within the period are rejected.
+//	@Input ClosePeriodInput
+//  @Response SvcStatusResponse
+// wsdoc }
+func saveClosePeriod(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	var (
+		funcname = "saveClosePeriod"
+		foo      ClosePeriodInput
+	)
+
+	fmt.Printf("Entered %s\n", funcname)
+	fmt.Printf("record data = %s\n", d.data)
+
+	if err := json.Unmarshal([]byte(d.data), &foo); err != nil {
+		e := fmt.Errorf("%s: Error with json.Unmarshal:  %s", funcname, err.Error())
+		SvcGridErrorReturn(w, e, funcname)
+		return
+	}
+
+	bid, ok := getBIDFromBUD(w, d, foo.Record.BUD, funcname)
+	if !ok {
+		return
+	}
+
+	d1 := time.Time(foo.Record.DtStart)
+	d2 := time.Time(foo.Record.DtStop)
+	errlist := bizlogic.ClosePeriod(bid, &d1, &d2, d.UID)
+	if len(errlist) > 0 {
+		SvcErrListReturn(w, errlist, funcname)
+		return
+	}
+
+	SvcWriteSuccessResponse(w)
+}
@@ -0,0 +1,194 @@
+package ws
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"rentroll/bizlogic"
+	"rentroll/rlib"
+	"time"
+)
+
+// CommissionGrid describes a commission in the CommissionLedger
+type CommissionGrid struct {
+	Recid          int64 `json:"recid"`
+	CLID           int64
+	BID            int64
+	BUD            rlib.XJSONBud
+	RAID           int64
+	RID            int64
+	RentableName   string
+	Salesperson    string
+	UID            int64
+	TCID           int64
+	Percent        float64
+	Amount         float64
+	PaymentDueDate rlib.JSONDate
+	Paid           bool
+	DtPaid         rlib.JSONDate
+	JID            int64
+	LastModTime    rlib.JSONDateTime
+	LastModBy      int64
+	CreateTS       rlib.JSONDateTime
+	CreateBy       int64
+}
+
+// CommissionSearchResponse is the response to a request for a list of commissions
+type CommissionSearchResponse struct {
+	Status  string           `json:"status"`
+	Total   int64            `json:"total"`
+	Records []CommissionGrid `json:"records"`
+}
+
+// CommissionForm describes a commission earned when a Rental Agreement is created
+type CommissionForm struct {
+	Salesperson    string
+	UID            int64   // the leasing agent, 0 if not an agent
+	TCID           int64   // the referrer, if they are a Transactant
+	Percent        float64 // percent of the first term's rent, 0 = flat Amount
+	Amount         float64 // flat fee
+	PaymentDueDate rlib.JSONDate
+}
+
+// commissionsFromForm converts the commissions supplied with a form
+func commissionsFromForm(f []CommissionForm) []bizlogic.Commission {
+	var m []bizlogic.Commission
+	for i := 0; i < len(f); i++ {
+		var c bizlogic.Commission
+		rlib.MigrateStructVals(&f[i], &c)
+		c.PaymentDueDate = time.Time(f[i].PaymentDueDate)
+		m = append(m, c)
+	}
+	return m
+}
+
+// CommissionPayForm contains the data from the Pay Commissions FORM
+type CommissionPayForm struct {
+	BUD        rlib.XJSONBud
+	CLIDs      []int64 // the commissions to pay
+	Dt         rlib.JSONDate
+	ExpenseLID int64 // commission expense GL account
+	CashLID    int64 // GL account the commissions are paid from
+}
+
+// CommissionPayInput is the input data format for a Save command
+type CommissionPayInput struct {
+	Status   string            `json:"status"`
+	Recid    int64             `json:"recid"`
+	FormName string            `json:"name"`
+	Record   CommissionPayForm `json:"record"`
+}
+
+// SvcHandlerCommission lists commissions and marks them paid.
+// For this call, we expect the URI to contain the BID and possibly the RAID:
+//       /v1/commissions/:BUI/[RAID]
+//
+// The server command can be:
+//      get
+//      save
+//-----------------------------------------------------------------------------------
+func SvcHandlerCommission(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	var (
+		funcname = "SvcHandlerCommission"
+		err      error
+	)
+
+	fmt.Printf("Entered %s\n", funcname)
+	fmt.Printf("Request: %s:  BID = %d,  RAID = %d\n", d.wsSearchReq.Cmd, d.BID, d.ID)
+
+	switch d.wsSearchReq.Cmd {
+	case "get":
+		getCommissions(w, r, d)
+		break
+	case "save":
+		saveCommissionPayment(w, r, d)
+		break
+	default:
+		err = fmt.Errorf("Unhandled command: %s", d.wsSearchReq.Cmd)
+		SvcGridErrorReturn(w, err, funcname)
+		return
+	}
+}
+
+// getCommissions returns commissions
+// wsdoc {
+//  @Title  Get Commissions
+//	@URL /v1/commissions/:BUI/[RAID]
+//  @Method  POST
+//	@Synopsis Get the commissions due or the commissions for a Rental Agreement
+//  @Description  If :RAID is supplied, returns all the commissions for that Rental Agreement.
+//  @Description  Otherwise returns the unpaid commissions of business :BUI that are due
+//  @Description  before searchDtStop.
+//	@Input WebGridSearchRequest
+//  @Response CommissionSearchResponse
+// wsdoc }
+func getCommissions(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	var (
+		funcname = "getCommissions"
+		g        CommissionSearchResponse
+		m        []rlib.CommissionLedger
+	)
+
+	fmt.Printf("Entered %s\n", funcname)
+	if d.ID > 0 {
+		m = rlib.GetCommissionLedgersByRAID(d.ID)
+	} else {
+		m = rlib.GetUnpaidCommissionLedgers(d.BID, &d.wsSearchReq.SearchDtStop)
+	}
+	for i := 0; i < len(m); i++ {
+		if m[i].BID != d.BID {
+			continue
+		}
+		var q CommissionGrid
+		rlib.MigrateStructVals(&m[i], &q)
+		q.Recid = m[i].CLID
+		q.BUD = getBUDFromBIDList(m[i].BID)
+		q.RentableName = rlib.GetRentable(m[i].RID).RentableName
+		q.Paid = m[i].FLAGS&rlib.CLPAID != 0
+		g.Records = append(g.Records, q)
+	}
+	g.Total = int64(len(g.Records))
+	g.Status = "success"
+	w.Header().Set("Content-Type", "application/json")
+	SvcWriteResponse(&g, w)
+}
+
+// saveCommissionPayment marks commissions paid
+// wsdoc {
+//  @Title  Pay Commissions
+//	@URL /v1/commissions/:BUI
+//  @Method  POST
+//	@Synopsis Mark commissions as paid
+//  @Description  Marks the commissions in CLIDs as paid on Dt. For each one a journal entry
+//  @Description  is made that debits the commission expense account ExpenseLID and credits
+//  @Description  CashLID, the account the commission is paid from.
+//	@Input CommissionPayInput
+//  @Response SvcStatusResponse
+// wsdoc }
+func saveCommissionPayment(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	var (
+		funcname = "saveCommissionPayment"
+		foo      CommissionPayInput
+	)
+
+	fmt.Printf("Entered %s\n", funcname)
+	fmt.Printf("record data = %s\n", d.data)
+
+	if err := json.Unmarshal([]byte(d.data), &foo); err != nil {
+		e := fmt.Errorf("%s: Error with json.Unmarshal:  %s", funcname, err.Error())
+		SvcGridErrorReturn(w, e, funcname)
+		return
+	}
+
+	bid, ok := getBIDFromBUD(w, d, foo.Record.BUD, funcname)
+	if !ok {
+		return
+	}
+	dt := time.Time(foo.Record.Dt)
+
+	if errlist := bizlogic.PayCommissions(bid, foo.Record.CLIDs, &dt, foo.Record.ExpenseLID, foo.Record.CashLID, d.UID); len(errlist) > 0 {
+		SvcErrListReturn(w, errlist, funcname)
+		return
+	}
+	SvcWriteSuccessResponse(w)
+}
@@ -280,6 +280,9 @@ func deleteDepository(w http.ResponseWriter, r *http.Request, d *ServiceData) {
 		return
 	}
 
+	if old, _ := rlib.GetDepository(del.ID); !svcCheckBID(w, d, old.BID, funcname) {
+		return
+	}
 	if err := rlib.DeleteDepository(del.ID); err != nil {
 		SvcGridErrorReturn(w, err, funcname)
 		return
@@ -322,11 +325,8 @@ func saveDepository(w http.ResponseWriter, r *http.Request, d *ServiceData) {
 	rlib.MigrateStructVals(&foo.Record, &a) // the variables that don't need special handling
 
 	var ok bool
-	a.BID, ok = rlib.RRdb.BUDlist[string(foo.Record.BUD)]
+	a.BID, ok = getBIDFromBUD(w, d, foo.Record.BUD, funcname)
 	if !ok {
-		e := fmt.Errorf("%s: Could not map BID value: %s", funcname, foo.Record.BUD)
-		rlib.Ulog("%s", e.Error())
-		SvcGridErrorReturn(w, e, funcname)
 		return
 	}
 
@@ -358,6 +358,9 @@ func saveDepository(w http.ResponseWriter, r *http.Request, d *ServiceData) {
 	} else {
 		// update existing record
 		fmt.Printf("Updating existing Depository: %d\n", a.DEPID)
+		if old, _ := rlib.GetDepository(a.DEPID); !svcCheckBID(w, d, old.BID, funcname) {
+			return
+		}
 		err = rlib.UpdateDepository(&a)
 	}
 
@@ -385,7 +388,7 @@ func getDepository(w http.ResponseWriter, r *http.Request, d *ServiceData) {
 	var (
 		funcname = "getDepository"
 		g        DepositoryGetResponse
-		whr      = fmt.Sprintf("Depository.DEPID=%d", d.ID)
+		whr      = fmt.Sprintf("Depository.BID=%d AND Depository.DEPID=%d", d.BID, d.ID)
 	)
 
 	fmt.Printf("entered %s\n", funcname)
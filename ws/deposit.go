@@ -0,0 +1,228 @@
+package ws
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"rentroll/bizlogic"
+	"rentroll/rlib"
+	"time"
+)
+
+// DepositGrid describes a deposit to a Depository
+type DepositGrid struct {
+	Recid       int64 `json:"recid"`
+	DID         int64
+	BID         int64
+	BUD         rlib.XJSONBud
+	DEPID       int64
+	Depository  string // name of the Depository
+	DPMID       int64
+	Method      string // name of the Deposit Method
+	Dt          rlib.JSONDate
+	Amount      float64
+	RCPTIDs     []int64 // the receipts deposited
+	LastModTime rlib.JSONDateTime
+	LastModBy   int64
+	CreateTS    rlib.JSONDateTime
+	CreateBy    int64
+}
+
+// DepositSearchResponse is the response to a request for a list of deposits
+type DepositSearchResponse struct {
+	Status  string        `json:"status"`
+	Total   int64         `json:"total"`
+	Records []DepositGrid `json:"records"`
+}
+
+// DepositReceiptGrid describes a receipt that has not yet been deposited
+type DepositReceiptGrid struct {
+	Recid  int64 `json:"recid"`
+	RCPTID int64
+	DEPID  int64
+	Dt     rlib.JSONDate
+	DocNo  string
+	Payor  string
+	Amount float64
+}
+
+// DepositReceiptsResponse is the response to a request for the receipts to deposit
+type DepositReceiptsResponse struct {
+	Status  string               `json:"status"`
+	Total   int64                `json:"total"`
+	Records []DepositReceiptGrid `json:"records"`
+}
+
+// DepositForm contains the data from the Make A Deposit FORM
+type DepositForm struct {
+	BUD      rlib.XJSONBud
+	DEPID    int64 // the Depository
+	DPMID    int64 // the Deposit Method
+	Dt       rlib.JSONDate
+	Receipts []int64 // RCPTIDs of the receipts to deposit
+}
+
+// DepositInput is the input data format for a Save command
+type DepositInput struct {
+	Status   string      `json:"status"`
+	Recid    int64       `json:"recid"`
+	FormName string      `json:"name"`
+	Record   DepositForm `json:"record"`
+}
+
+// SvcHandlerDeposit lists deposits and the receipts available to deposit, and
+// makes deposits.
+// For this call, we expect the URI to contain the BID and possibly the DEPID:
+//       /v1/deposit/:BUI/[DEPID]
+//
+// The server command can be:
+//      get
+//      save
+//-----------------------------------------------------------------------------------
+func SvcHandlerDeposit(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	var (
+		funcname = "SvcHandlerDeposit"
+		err      error
+	)
+
+	fmt.Printf("Entered %s\n", funcname)
+	fmt.Printf("Request: %s:  BID = %d,  DEPID = %d\n", d.wsSearchReq.Cmd, d.BID, d.ID)
+
+	switch d.wsSearchReq.Cmd {
+	case "get":
+		if d.ID > 0 {
+			getUndepositedReceipts(w, r, d)
+		} else {
+			getDeposits(w, r, d)
+		}
+		break
+	case "save":
+		saveDeposit(w, r, d)
+		break
+	default:
+		err = fmt.Errorf("Unhandled command: %s", d.wsSearchReq.Cmd)
+		SvcGridErrorReturn(w, err, funcname)
+		return
+	}
+}
+
+// getDeposits returns the deposits of the business
+// wsdoc {
+//  @Title  Get Deposits
+//	@URL /v1/deposit/:BUI
+//  @Method  POST
+//	@Synopsis Get the deposits made in a period
+//  @Description  Returns the deposits for business :BUI made from searchDtStart up to
+//  @Description  searchDtStop. The deposit slip is available as report RPTdepslip with id=:DID.
+//	@Input WebGridSearchRequest
+//  @Response DepositSearchResponse
+// wsdoc }
+func getDeposits(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	var (
+		funcname = "getDeposits"
+		g        DepositSearchResponse
+	)
+
+	fmt.Printf("Entered %s\n", funcname)
+	m := rlib.GetAllDepositsInRange(d.BID, &d.wsSearchReq.SearchDtStart, &d.wsSearchReq.SearchDtStop)
+	for i := 0; i < len(m); i++ {
+		var q DepositGrid
+		rlib.MigrateStructVals(&m[i], &q)
+		q.Recid = m[i].DID
+		q.BUD = getBUDFromBIDList(m[i].BID)
+		dep, _ := rlib.GetDepository(m[i].DEPID)
+		q.Depository = dep.Name
+		dpm, _ := rlib.GetDepositMethod(m[i].DPMID)
+		q.Method = dpm.Name
+		for j := 0; j < len(m[i].DP); j++ {
+			q.RCPTIDs = append(q.RCPTIDs, m[i].DP[j].RCPTID)
+		}
+		g.Records = append(g.Records, q)
+	}
+	g.Total = int64(len(g.Records))
+	g.Status = "success"
+	w.Header().Set("Content-Type", "application/json")
+	SvcWriteResponse(&g, w)
+}
+
+// getUndepositedReceipts returns the receipts that can be deposited
+// wsdoc {
+//  @Title  Get Receipts To Deposit
+//	@URL /v1/deposit/:BUI/:DEPID
+//  @Method  GET
+//	@Synopsis Get the receipts not yet deposited
+//  @Description  Returns the receipts of business :BUI that are not part of a deposit and
+//  @Description  that are designated for Depository :DEPID or for no Depository. Voided
+//  @Description  receipts are not included.
+//	@Input WebGridSearchRequest
+//  @Response DepositReceiptsResponse
+// wsdoc }
+func getUndepositedReceipts(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	var (
+		funcname = "getUndepositedReceipts"
+		g        DepositReceiptsResponse
+	)
+
+	fmt.Printf("Entered %s\n", funcname)
+	m := rlib.GetUndepositedReceipts(d.BID, d.ID)
+	for i := 0; i < len(m); i++ {
+		var q DepositReceiptGrid
+		rlib.MigrateStructVals(&m[i], &q)
+		q.Recid = m[i].RCPTID
+		if m[i].TCID > 0 {
+			var t rlib.Transactant
+			rlib.GetTransactant(m[i].TCID, &t)
+			q.Payor = t.GetFullTransactantName()
+		}
+		g.Records = append(g.Records, q)
+	}
+	g.Total = int64(len(g.Records))
+	g.Status = "success"
+	w.Header().Set("Content-Type", "application/json")
+	SvcWriteResponse(&g, w)
+}
+
+// saveDeposit makes a deposit
+// wsdoc {
+//  @Title  Make A Deposit
+//	@URL /v1/deposit/:BUI
+//  @Method  POST
+//	@Synopsis Deposit receipts into a Depository
+//  @Description  Creates a Deposit to Depository DEPID made up of the supplied receipts, marks
+//  @Description  each receipt as deposited, and posts a journal entry moving the funds from the
+//  @Description  account each receipt was received into to the Depository's GL account.
+//  @Description  The response contains the DID.
+//	@Input DepositInput
+//  @Response SvcStatusResponse
+// wsdoc }
+func saveDeposit(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	var (
+		funcname = "saveDeposit"
+		foo      DepositInput
+	)
+
+	fmt.Printf("Entered %s\n", funcname)
+	fmt.Printf("record data = %s\n", d.data)
+
+	if err := json.Unmarshal([]byte(d.data), &foo); err != nil {
+		e := fmt.Errorf("%s: Error with json.Unmarshal:  %s", funcname, err.Error())
+		SvcGridErrorReturn(w, e, funcname)
+		return
+	}
+
+	var a rlib.Deposit
+	var ok bool
+	a.BID, ok = getBIDFromBUD(w, d, foo.Record.BUD, funcname)
+	if !ok {
+		return
+	}
+	a.DEPID = foo.Record.DEPID
+	a.DPMID = foo.Record.DPMID
+	a.Dt = time.Time(foo.Record.Dt)
+
+	if errlist := bizlogic.MakeDeposit(&a, foo.Record.Receipts, d.UID); len(errlist) > 0 {
+		SvcErrListReturn(w, errlist, funcname)
+		return
+	}
+	SvcWriteSuccessResponseWithID(w, a.DID)
+}
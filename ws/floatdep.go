@@ -0,0 +1,195 @@
+package ws
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"rentroll/bizlogic"
+	"rentroll/rlib"
+	"time"
+)
+
+// FloatingDepositForm contains the data from the Floating Deposit FORM
+type FloatingDepositForm struct {
+	BUD     rlib.XJSONBud
+	TCID    int64 // the prospect
+	PMTID   int64 // payment type
+	DEPID   int64 // the depository where this receipt will be deposited
+	Dt      rlib.JSONDate
+	DocNo   string // check number, money order number, etc.
+	Amount  float64
+	ARID    int64 // account rule for the receipt
+	Comment string
+}
+
+// FloatingDepositInput is the input data format for a Save command
+type FloatingDepositInput struct {
+	Status   string              `json:"status"`
+	Recid    int64               `json:"recid"`
+	FormName string              `json:"name"`
+	Record   FloatingDepositForm `json:"record"`
+}
+
+// FloatingDepositRefundForm contains the data from the Refund Floating Deposit FORM
+type FloatingDepositRefundForm struct {
+	BUD  rlib.XJSONBud
+	TCID int64 // the prospect
+	Dt   rlib.JSONDate
+}
+
+// FloatingDepositRefundInput is the input data format for a Save command
+type FloatingDepositRefundInput struct {
+	Status   string                    `json:"status"`
+	Recid    int64                     `json:"recid"`
+	FormName string                    `json:"name"`
+	Record   FloatingDepositRefundForm `json:"record"`
+}
+
+// FloatingDepositRefundResponse is the response to a refund request
+type FloatingDepositRefundResponse struct {
+	Status string                         `json:"status"`
+	Record bizlogic.FloatingDepositRefund `json:"record"`
+}
+
+// SvcHandlerProspectDeposit accepts a floating deposit from a prospect.
+// For this call, we expect the URI to contain the BID:  /v1/prospectdeposit/:BUI
+//
+// The server command can be:
+//      save
+//-----------------------------------------------------------------------------------
+func SvcHandlerProspectDeposit(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	var (
+		funcname = "SvcHandlerProspectDeposit"
+		err      error
+	)
+
+	fmt.Printf("Entered %s\n", funcname)
+	fmt.Printf("Request: %s:  BID = %d\n", d.wsSearchReq.Cmd, d.BID)
+
+	switch d.wsSearchReq.Cmd {
+	case "save":
+		saveProspectDeposit(w, r, d)
+		break
+	default:
+		err = fmt.Errorf("Unhandled command: %s", d.wsSearchReq.Cmd)
+		SvcGridErrorReturn(w, err, funcname)
+		return
+	}
+}
+
+// saveProspectDeposit accepts a floating deposit from a prospect
+// wsdoc {
+//  @Title  Accept Floating Deposit
+//	@URL /v1/prospectdeposit/:BUI
+//  @Method  POST
+//	@Synopsis Receive a deposit from a prospect before a lease exists
+//  @Description  Records a receipt from prospect TCID as a floating deposit. The receipt is
+//  @Description  assigned to a Rental Agreement that holds the prospect's charges, which is
+//  @Description  created if needed. When the prospect is converted the deposit is applied to
+//  @Description  the new agreement's charges. If the prospect is declined it can be refunded
+//  @Description  with prospectrefund. The response contains the RCPTID.
+//	@Input FloatingDepositInput
+//  @Response SvcStatusResponse
+// wsdoc }
+func saveProspectDeposit(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	var (
+		funcname = "saveProspectDeposit"
+		foo      FloatingDepositInput
+	)
+
+	fmt.Printf("Entered %s\n", funcname)
+	fmt.Printf("record data = %s\n", d.data)
+
+	if err := json.Unmarshal([]byte(d.data), &foo); err != nil {
+		e := fmt.Errorf("%s: Error with json.Unmarshal:  %s", funcname, err.Error())
+		SvcGridErrorReturn(w, e, funcname)
+		return
+	}
+
+	var a rlib.Receipt
+	rlib.MigrateStructVals(&foo.Record, &a) // the variables that don't need special handling
+
+	var ok bool
+	a.BID, ok = getBIDFromBUD(w, d, foo.Record.BUD, funcname)
+	if !ok {
+		return
+	}
+	a.Dt = time.Time(foo.Record.Dt)
+
+	if errlist := bizlogic.AcceptFloatingDeposit(&a, d.UID); len(errlist) > 0 {
+		SvcErrListReturn(w, errlist, funcname)
+		return
+	}
+	SvcWriteSuccessResponseWithID(w, a.RCPTID)
+}
+
+// SvcHandlerProspectRefund refunds the floating deposit of a declined prospect.
+// For this call, we expect the URI to contain the BID:  /v1/prospectrefund/:BUI
+//
+// The server command can be:
+//      save
+//-----------------------------------------------------------------------------------
+func SvcHandlerProspectRefund(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	var (
+		funcname = "SvcHandlerProspectRefund"
+		err      error
+	)
+
+	fmt.Printf("Entered %s\n", funcname)
+	fmt.Printf("Request: %s:  BID = %d\n", d.wsSearchReq.Cmd, d.BID)
+
+	switch d.wsSearchReq.Cmd {
+	case "save":
+		saveProspectRefund(w, r, d)
+		break
+	default:
+		err = fmt.Errorf("Unhandled command: %s", d.wsSearchReq.Cmd)
+		SvcGridErrorReturn(w, err, funcname)
+		return
+	}
+}
+
+// saveProspectRefund refunds the floating deposit of a declined prospect
+// wsdoc {
+//  @Title  Refund Floating Deposit
+//	@URL /v1/prospectrefund/:BUI
+//  @Method  POST
+//	@Synopsis Refund the floating deposit of a declined prospect
+//  @Description  Pays any unpaid charges of declined prospect TCID, such as the application
+//  @Description  fee, from its floating deposit and refunds the rest on Dt. Each refund is a
+//  @Description  receipt for a negative amount that reverses the journal entries of the deposit.
+//  @Description  The response lists the amount applied, the amount refunded and the refund receipts.
+//	@Input FloatingDepositRefundInput
+//  @Response FloatingDepositRefundResponse
+// wsdoc }
+func saveProspectRefund(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	var (
+		funcname = "saveProspectRefund"
+		foo      FloatingDepositRefundInput
+		g        FloatingDepositRefundResponse
+	)
+
+	fmt.Printf("Entered %s\n", funcname)
+	fmt.Printf("record data = %s\n", d.data)
+
+	if err := json.Unmarshal([]byte(d.data), &foo); err != nil {
+		e := fmt.Errorf("%s: Error with json.Unmarshal:  %s", funcname, err.Error())
+		SvcGridErrorReturn(w, e, funcname)
+		return
+	}
+
+	bid, ok := getBIDFromBUD(w, d, foo.Record.BUD, funcname)
+	if !ok {
+		return
+	}
+	dt := time.Time(foo.Record.Dt)
+
+	fr, errlist := bizlogic.RefundFloatingDeposit(bid, foo.Record.TCID, &dt, d.UID)
+	if len(errlist) > 0 {
+		SvcErrListReturn(w, errlist, funcname)
+		return
+	}
+	g.Record = fr
+	g.Status = "success"
+	SvcWriteResponse(&g, w)
+}
@@ -0,0 +1,411 @@
+package ws
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"rentroll/bizlogic"
+	"rentroll/rlib"
+	"time"
+)
+
+// GoodsServiceGrid is an item of the catalog of goods and services
+type GoodsServiceGrid struct {
+	Recid       int64 `json:"recid"`
+	GSID        int64
+	BID         int64
+	BUD         rlib.XJSONBud
+	Name        string
+	Description string
+	Price       float64
+	ARID        int64
+	AcctRule    string // name of the account rule
+	TAXID       int64
+	Tax         string // name of the tax, empty if not taxed
+	Inactive    bool   // true if the item is no longer sold
+	LastModTime rlib.JSONDateTime
+	LastModBy   int64
+	CreateTS    rlib.JSONDateTime
+	CreateBy    int64
+}
+
+// GoodsServiceSearchResponse is the response to a request for the catalog
+type GoodsServiceSearchResponse struct {
+	Status  string             `json:"status"`
+	Total   int64              `json:"total"`
+	Records []GoodsServiceGrid `json:"records"`
+}
+
+// GoodsServiceGetResponse is the response to a request for a single item
+type GoodsServiceGetResponse struct {
+	Status string           `json:"status"`
+	Record GoodsServiceGrid `json:"record"`
+}
+
+// GoodsServiceForm contains the data from the Goods & Services FORM
+type GoodsServiceForm struct {
+	GSID        int64 // 0 = new item
+	BUD         rlib.XJSONBud
+	Name        string
+	Description string
+	Price       float64
+	ARID        int64
+	TAXID       int64 // 0 = not taxed
+	Inactive    bool
+}
+
+// GoodsServiceInput is the input data format for a Save command
+type GoodsServiceInput struct {
+	Status   string           `json:"status"`
+	Recid    int64            `json:"recid"`
+	FormName string           `json:"name"`
+	Record   GoodsServiceForm `json:"record"`
+}
+
+// SaleForm contains the data from the Goods & Services sale FORM
+type SaleForm struct {
+	BUD      rlib.XJSONBud
+	GSID     int64   // the item sold
+	Quantity int64   // number of items sold
+	Price    float64 // unit price, 0 = the catalog price
+	RAID     int64   // Rental Agreement charged, 0 for a walk-in sale
+	TCID     int64   // payor, required for a walk-in sale
+	Dt       rlib.JSONDate
+	Paid     bool   // true to make a receipt for the sale
+	PMTID    int64  // payment type of the receipt
+	RcptARID int64  // account rule of the receipt
+	DocNo    string // check number or other document of the payment
+}
+
+// SaleInput is the input data format for a Save command
+type SaleInput struct {
+	Status   string   `json:"status"`
+	Recid    int64    `json:"recid"`
+	FormName string   `json:"name"`
+	Record   SaleForm `json:"record"`
+}
+
+// SaleAsmGrid is an assessment made by a sale
+type SaleAsmGrid struct {
+	Recid   int64 `json:"recid"`
+	ASMID   int64
+	ARID    int64
+	Amount  float64
+	Comment string
+}
+
+// SaleResponse is the response to a Save command
+type SaleResponse struct {
+	Status      string        `json:"status"`
+	Recid       int64         `json:"recid"` // RCPTID of the receipt, 0 if the sale was not paid
+	Total       float64       `json:"total"` // total charged, tax included
+	Assessments []SaleAsmGrid `json:"assessments"`
+}
+
+// goodsServiceGridRecord fills out a GoodsServiceGrid from item a
+func goodsServiceGridRecord(a *rlib.GoodsService) GoodsServiceGrid {
+	var q GoodsServiceGrid
+	rlib.MigrateStructVals(a, &q)
+	q.Recid = a.GSID
+	q.BUD = getBUDFromBIDList(a.BID)
+	q.AcctRule = rlib.RRdb.BizTypes[a.BID].AR[a.ARID].Name
+	if a.TAXID > 0 {
+		if t, err := rlib.GetTax(a.TAXID); err == nil {
+			q.Tax = t.Name
+		}
+	}
+	q.Inactive = a.FLAGS&rlib.GSINACTIVE != 0
+	return q
+}
+
+// SvcHandlerGoodsServices lists the catalog of goods and services of a business.
+// For this call, we expect the URI to contain the BID:  /v1/gaslist/:BUI
+//
+// The server command can be:
+//      get
+//-----------------------------------------------------------------------------------
+func SvcHandlerGoodsServices(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	var (
+		funcname = "SvcHandlerGoodsServices"
+		err      error
+	)
+
+	fmt.Printf("Entered %s\n", funcname)
+	fmt.Printf("Request: %s:  BID = %d\n", d.wsSearchReq.Cmd, d.BID)
+
+	switch d.wsSearchReq.Cmd {
+	case "get":
+		getGoodsServices(w, r, d)
+		break
+	default:
+		err = fmt.Errorf("Unhandled command: %s", d.wsSearchReq.Cmd)
+		SvcGridErrorReturn(w, err, funcname)
+		return
+	}
+}
+
+// getGoodsServices returns the catalog of goods and services
+// wsdoc {
+//  @Title  Get Goods & Services
+//	@URL /v1/gaslist/:BUI
+//  @Method  POST
+//	@Synopsis Get the catalog of goods and services
+//  @Description  Returns every item in the catalog of goods and services of business :BUI,
+//  @Description  sorted by name, including those that are no longer sold.
+//	@Input WebGridSearchRequest
+//  @Response GoodsServiceSearchResponse
+// wsdoc }
+func getGoodsServices(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	var (
+		funcname = "getGoodsServices"
+		g        GoodsServiceSearchResponse
+	)
+
+	fmt.Printf("Entered %s\n", funcname)
+	m := rlib.GetAllGoodsServices(d.BID)
+	for i := 0; i < len(m); i++ {
+		g.Records = append(g.Records, goodsServiceGridRecord(&m[i]))
+	}
+	g.Total = int64(len(g.Records))
+	g.Status = "success"
+	w.Header().Set("Content-Type", "application/json")
+	SvcWriteResponse(&g, w)
+}
+
+// SvcHandlerGoodsService returns, creates, updates and deletes an item of the catalog of
+// goods and services.
+// For this call, we expect the URI to contain the BID and the GSID:
+//    /v1/gas/:BUI/:GSID
+//
+// The server command can be:
+//      get
+//      save
+//      delete
+//-----------------------------------------------------------------------------------
+func SvcHandlerGoodsService(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	var (
+		funcname = "SvcHandlerGoodsService"
+		err      error
+	)
+
+	fmt.Printf("Entered %s\n", funcname)
+	fmt.Printf("Request: %s:  BID = %d,  GSID = %d\n", d.wsSearchReq.Cmd, d.BID, d.ID)
+
+	switch d.wsSearchReq.Cmd {
+	case "get":
+		getGoodsService(w, r, d)
+		break
+	case "save":
+		saveGoodsService(w, r, d)
+		break
+	case "delete":
+		deleteGoodsService(w, r, d)
+		break
+	default:
+		err = fmt.Errorf("Unhandled command: %s", d.wsSearchReq.Cmd)
+		SvcGridErrorReturn(w, err, funcname)
+		return
+	}
+}
+
+// getGoodsService returns the requested item
+// wsdoc {
+//  @Title  Get Goods or Service
+//	@URL /v1/gas/:BUI/:GSID
+//  @Method  GET
+//	@Synopsis Get an item of the catalog of goods and services
+//  @Desc  This service returns the item with id :GSID.
+//	@Input WebGridSearchRequest
+//  @Response GoodsServiceGetResponse
+// wsdoc }
+func getGoodsService(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	var (
+		funcname = "getGoodsService"
+		g        GoodsServiceGetResponse
+	)
+
+	fmt.Printf("Entered %s\n", funcname)
+	a, err := rlib.GetGoodsService(d.ID)
+	if err != nil || a.BID != d.BID {
+		e := fmt.Errorf("%s: GoodsService %d not found", funcname, d.ID)
+		SvcGridErrorReturn(w, e, funcname)
+		return
+	}
+	g.Record = goodsServiceGridRecord(&a)
+	g.Status = "success"
+	SvcWriteResponse(&g, w)
+}
+
+// saveGoodsService creates or updates an item of the catalog of goods and services
+// wsdoc {
+//  @Title  Save Goods or Service
+//	@URL /v1/gas/:BUI/:GSID
+//  @Method  POST
+//	@Synopsis Create or update an item of the catalog of goods and services
+//  @Description  If GSID is 0 a new item is created, otherwise item GSID is updated. ARID
+//  @Description  is the account rule used to assess a sale and TAXID, if not 0, the tax
+//  @Description  charged on it. Set Inactive to stop selling an item while keeping it in
+//  @Description  the catalog. The response contains the GSID.
+//	@Input GoodsServiceInput
+//  @Response SvcStatusResponse
+// wsdoc }
+func saveGoodsService(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	var (
+		funcname = "saveGoodsService"
+		foo      GoodsServiceInput
+	)
+
+	fmt.Printf("Entered %s\n", funcname)
+	fmt.Printf("record data = %s\n", d.data)
+
+	if err := json.Unmarshal([]byte(d.data), &foo); err != nil {
+		e := fmt.Errorf("%s: Error with json.Unmarshal:  %s", funcname, err.Error())
+		SvcGridErrorReturn(w, e, funcname)
+		return
+	}
+
+	var a rlib.GoodsService
+	rlib.MigrateStructVals(&foo.Record, &a) // the variables that don't need special handling
+	a.BID = d.BID
+	if len(foo.Record.BUD) > 0 {
+		var ok bool
+		if a.BID, ok = getBIDFromBUD(w, d, foo.Record.BUD, funcname); !ok {
+			return
+		}
+	}
+	if a.GSID == 0 {
+		a.GSID = d.ID
+	}
+	if a.GSID > 0 {
+		old, err := rlib.GetGoodsService(a.GSID)
+		if err != nil || old.BID != a.BID {
+			e := fmt.Errorf("%s: GoodsService %d not found", funcname, a.GSID)
+			SvcGridErrorReturn(w, e, funcname)
+			return
+		}
+		a.FLAGS = old.FLAGS &^ rlib.GSINACTIVE
+	}
+	if foo.Record.Inactive {
+		a.FLAGS |= rlib.GSINACTIVE
+	}
+
+	if errlist := bizlogic.SaveGoodsService(&a, d.UID); len(errlist) > 0 {
+		SvcErrListReturn(w, errlist, funcname)
+		return
+	}
+	SvcWriteSuccessResponseWithID(w, a.GSID)
+}
+
+// deleteGoodsService deletes an item of the catalog of goods and services
+// wsdoc {
+//  @Title  Delete Goods or Service
+//	@URL /v1/gas/:BUI/:GSID
+//  @Method  POST
+//	@Synopsis Delete an item of the catalog of goods and services
+//  @Description  Deletes item :GSID. Past sales are not affected, their assessments
+//  @Description  remain. To stop selling an item but keep it listed, save it as Inactive.
+//	@Input WebGridSearchRequest
+//  @Response SvcStatusResponse
+// wsdoc }
+func deleteGoodsService(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	funcname := "deleteGoodsService"
+	fmt.Printf("Entered %s\n", funcname)
+
+	a, err := rlib.GetGoodsService(d.ID)
+	if err != nil || a.BID != d.BID {
+		e := fmt.Errorf("%s: GoodsService %d not found", funcname, d.ID)
+		SvcGridErrorReturn(w, e, funcname)
+		return
+	}
+	if err = rlib.DeleteGoodsService(a.GSID); err != nil {
+		SvcGridErrorReturn(w, err, funcname)
+		return
+	}
+	SvcWriteSuccessResponse(w)
+}
+
+// SvcHandlerSale rings up a sale of goods or services.
+// For this call, we expect the URI to contain the BID:  /v1/gassale/:BUI
+//
+// The server command can be:
+//      save
+//-----------------------------------------------------------------------------------
+func SvcHandlerSale(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	var (
+		funcname = "SvcHandlerSale"
+		err      error
+	)
+
+	fmt.Printf("Entered %s\n", funcname)
+	fmt.Printf("Request: %s:  BID = %d\n", d.wsSearchReq.Cmd, d.BID)
+
+	switch d.wsSearchReq.Cmd {
+	case "save":
+		saveSale(w, r, d)
+		break
+	default:
+		err = fmt.Errorf("Unhandled command: %s", d.wsSearchReq.Cmd)
+		SvcGridErrorReturn(w, err, funcname)
+		return
+	}
+}
+
+// saveSale rings up a sale of goods or services
+// wsdoc {
+//  @Title  Goods & Services Sale
+//	@URL /v1/gassale/:BUI
+//  @Method  POST
+//	@Synopsis Charge a sale of goods or services to a Rental Agreement or a walk-in
+//  @Description  Charges Quantity of item GSID to Rental Agreement RAID, or to walk-in
+//  @Description  Transactant TCID if RAID is 0, with a one-time assessment on Dt. Price
+//  @Description  overrides the catalog price when it is not 0. If the item is taxed, the
+//  @Description  tax is assessed separately using the rate in effect on Dt. If Paid is true,
+//  @Description  a receipt for the total is made with payment type PMTID and account rule
+//  @Description  RcptARID and applied to the assessments. A walk-in sale must be paid.
+//  @Description  The response contains the RCPTID, the total and the assessments made.
+//	@Input SaleInput
+//  @Response SaleResponse
+// wsdoc }
+func saveSale(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	var (
+		funcname = "saveSale"
+		foo      SaleInput
+		g        SaleResponse
+	)
+
+	fmt.Printf("Entered %s\n", funcname)
+	fmt.Printf("record data = %s\n", d.data)
+
+	if err := json.Unmarshal([]byte(d.data), &foo); err != nil {
+		e := fmt.Errorf("%s: Error with json.Unmarshal:  %s", funcname, err.Error())
+		SvcGridErrorReturn(w, e, funcname)
+		return
+	}
+
+	var s bizlogic.Sale
+	rlib.MigrateStructVals(&foo.Record, &s) // the variables that don't need special handling
+	s.BID = d.BID
+	if len(foo.Record.BUD) > 0 {
+		var ok bool
+		if s.BID, ok = getBIDFromBUD(w, d, foo.Record.BUD, funcname); !ok {
+			return
+		}
+	}
+	s.Dt = time.Time(foo.Record.Dt)
+	s.UID = d.UID
+
+	res, errlist := bizlogic.ProcessSale(&s)
+	if len(errlist) > 0 {
+		SvcErrListReturn(w, errlist, funcname)
+		return
+	}
+
+	for i := 0; i < len(res.Assessments); i++ {
+		a := &res.Assessments[i]
+		g.Assessments = append(g.Assessments, SaleAsmGrid{Recid: a.ASMID, ASMID: a.ASMID, ARID: a.ARID, Amount: a.Amount, Comment: a.Comment})
+	}
+	g.Recid = res.Receipt.RCPTID
+	g.Total = res.Total
+	g.Status = "success"
+	SvcWriteResponse(&g, w)
+}
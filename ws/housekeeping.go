@@ -0,0 +1,247 @@
+package ws
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"rentroll/bizlogic"
+	"rentroll/rlib"
+	"time"
+)
+
+// HousekeepingBoardGrid is one room on the housekeeping board
+type HousekeepingBoardGrid struct {
+	Recid        int64 `json:"recid"`
+	BID          int64
+	BUD          rlib.XJSONBud
+	BLDGID       int64
+	Building     string
+	RID          int64
+	RentableName string
+	Status       int64
+	StatusName   string // unknown, dirty, clean, inspected, out of order
+	Dt           rlib.JSONDateTime
+	Comment      string
+	Occupancy    string // vacant, arrival, stay-over, departure, turnover
+}
+
+// HousekeepingBoardResponse is the response to a request for the housekeeping board
+type HousekeepingBoardResponse struct {
+	Status  string                  `json:"status"`
+	Total   int64                   `json:"total"`
+	Records []HousekeepingBoardGrid `json:"records"`
+}
+
+// HousekeepingStatusGrid is a change in the housekeeping state of a room
+type HousekeepingStatusGrid struct {
+	Recid      int64 `json:"recid"`
+	HKSID      int64
+	RID        int64
+	Status     int64
+	StatusName string
+	Dt         rlib.JSONDateTime
+	Comment    string
+	CreateBy   int64
+}
+
+// HousekeepingHistoryResponse is the response to a request for the housekeeping history of a room
+type HousekeepingHistoryResponse struct {
+	Status  string                   `json:"status"`
+	Total   int64                    `json:"total"`
+	Records []HousekeepingStatusGrid `json:"records"`
+}
+
+// HousekeepingForm contains the data from the Housekeeping FORM
+type HousekeepingForm struct {
+	BUD     rlib.XJSONBud
+	RID     int64
+	Status  string // dirty, clean, inspected, out of order
+	Comment string
+}
+
+// HousekeepingInput is the input data format for a Save command
+type HousekeepingInput struct {
+	Status   string           `json:"status"`
+	Recid    int64            `json:"recid"`
+	FormName string           `json:"name"`
+	Record   HousekeepingForm `json:"record"`
+}
+
+// SvcHandlerHousekeepingBoard returns the housekeeping board.
+// For this call, we expect the URI to contain the BID:
+//       /v1/hkboard/:BUI
+//
+// The server command can be:
+//      get
+//-----------------------------------------------------------------------------------
+func SvcHandlerHousekeepingBoard(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	var (
+		funcname = "SvcHandlerHousekeepingBoard"
+		err      error
+	)
+
+	fmt.Printf("Entered %s\n", funcname)
+	fmt.Printf("Request: %s:  BID = %d\n", d.wsSearchReq.Cmd, d.BID)
+
+	switch d.wsSearchReq.Cmd {
+	case "get":
+		getHousekeepingBoard(w, r, d)
+		break
+	default:
+		err = fmt.Errorf("Unhandled command: %s", d.wsSearchReq.Cmd)
+		SvcGridErrorReturn(w, err, funcname)
+		return
+	}
+}
+
+// getHousekeepingBoard returns the housekeeping board for a day
+// wsdoc {
+//  @Title  Get Housekeeping Board
+//	@URL /v1/hkboard/:BUI
+//  @Method  POST
+//	@Synopsis Get the housekeeping state and occupancy of every nightly room
+//  @Description  Returns every Rentable of business :BUI that rents by the night, sorted
+//  @Description  by Building and name, with its housekeeping state and its occupancy on
+//  @Description  the day searchDtStart, which defaults to today.
+//	@Input WebGridSearchRequest
+//  @Response HousekeepingBoardResponse
+// wsdoc }
+func getHousekeepingBoard(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	var (
+		funcname = "getHousekeepingBoard"
+		g        HousekeepingBoardResponse
+	)
+
+	fmt.Printf("Entered %s\n", funcname)
+	dt := d.wsSearchReq.SearchDtStart
+	if dt.Year() <= 1970 {
+		dt = time.Now()
+	}
+	bud := getBUDFromBIDList(d.BID)
+	m := bizlogic.GetHousekeepingBoard(d.BID, &dt)
+	for i := 0; i < len(m); i++ {
+		var q HousekeepingBoardGrid
+		rlib.MigrateStructVals(&m[i], &q)
+		q.Recid = m[i].RID
+		q.BID = d.BID
+		q.BUD = bud
+		q.StatusName = bizlogic.HKStatusNames[m[i].Status]
+		g.Records = append(g.Records, q)
+	}
+	g.Total = int64(len(g.Records))
+	g.Status = "success"
+	w.Header().Set("Content-Type", "application/json")
+	SvcWriteResponse(&g, w)
+}
+
+// SvcHandlerHousekeeping returns the housekeeping history of a room and changes its state.
+// For this call, we expect the URI to contain the BID and the RID:
+//    /v1/housekeeping/:BUI/:RID
+//
+// The server command can be:
+//      get
+//      save
+//-----------------------------------------------------------------------------------
+func SvcHandlerHousekeeping(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	var (
+		funcname = "SvcHandlerHousekeeping"
+		err      error
+	)
+
+	fmt.Printf("Entered %s\n", funcname)
+	fmt.Printf("Request: %s:  BID = %d,  RID = %d\n", d.wsSearchReq.Cmd, d.BID, d.ID)
+
+	switch d.wsSearchReq.Cmd {
+	case "get":
+		getHousekeepingHistory(w, r, d)
+		break
+	case "save":
+		saveHousekeeping(w, r, d)
+		break
+	default:
+		err = fmt.Errorf("Unhandled command: %s", d.wsSearchReq.Cmd)
+		SvcGridErrorReturn(w, err, funcname)
+		return
+	}
+}
+
+// getHousekeepingHistory returns the housekeeping history of a room
+// wsdoc {
+//  @Title  Get Housekeeping History
+//	@URL /v1/housekeeping/:BUI/:RID
+//  @Method  POST
+//	@Synopsis Get the housekeeping state changes of a room
+//  @Description  Returns the housekeeping state changes of Rentable :RID made between
+//  @Description  searchDtStart and searchDtStop, most recent first.
+//	@Input WebGridSearchRequest
+//  @Response HousekeepingHistoryResponse
+// wsdoc }
+func getHousekeepingHistory(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	var (
+		funcname = "getHousekeepingHistory"
+		g        HousekeepingHistoryResponse
+	)
+
+	fmt.Printf("Entered %s\n", funcname)
+	if rt := rlib.GetRentable(d.ID); rt.RID == 0 || rt.BID != d.BID {
+		e := fmt.Errorf("%s: Rentable %d not found", funcname, d.ID)
+		SvcGridErrorReturn(w, e, funcname)
+		return
+	}
+	m := rlib.GetHousekeepingHistory(d.ID, &d.wsSearchReq.SearchDtStart, &d.wsSearchReq.SearchDtStop)
+	for i := 0; i < len(m); i++ {
+		var q HousekeepingStatusGrid
+		rlib.MigrateStructVals(&m[i], &q)
+		q.Recid = m[i].HKSID
+		q.StatusName = bizlogic.HKStatusNames[m[i].Status]
+		g.Records = append(g.Records, q)
+	}
+	g.Total = int64(len(g.Records))
+	g.Status = "success"
+	w.Header().Set("Content-Type", "application/json")
+	SvcWriteResponse(&g, w)
+}
+
+// saveHousekeeping changes the housekeeping state of a room
+// wsdoc {
+//  @Title  Save Housekeeping State
+//	@URL /v1/housekeeping/:BUI/:RID
+//  @Method  POST
+//	@Synopsis Change the housekeeping state of a room
+//  @Description  Sets the housekeeping state of Rentable RID to Status, which is one of dirty,
+//  @Description  clean, inspected or out of order, and records the change in its history.
+//  @Description  A room must be clean before it can be inspected.
+//	@Input HousekeepingInput
+//  @Response SvcStatusResponse
+// wsdoc }
+func saveHousekeeping(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	var (
+		funcname = "saveHousekeeping"
+		foo      HousekeepingInput
+	)
+
+	fmt.Printf("Entered %s\n", funcname)
+	fmt.Printf("record data = %s\n", d.data)
+
+	if err := json.Unmarshal([]byte(d.data), &foo); err != nil {
+		e := fmt.Errorf("%s: Error with json.Unmarshal:  %s", funcname, err.Error())
+		SvcGridErrorReturn(w, e, funcname)
+		return
+	}
+
+	bid, ok := getBIDFromBUD(w, d, foo.Record.BUD, funcname)
+	if !ok {
+		return
+	}
+	rid := foo.Record.RID
+	if rid == 0 {
+		rid = d.ID
+	}
+	now := time.Now()
+	status := bizlogic.HKStatusFromName(foo.Record.Status)
+	if errlist := bizlogic.SetHousekeepingStatus(bid, rid, status, &now, foo.Record.Comment, d.UID); len(errlist) > 0 {
+		SvcErrListReturn(w, errlist, funcname)
+		return
+	}
+	SvcWriteSuccessResponseWithID(w, rid)
+}
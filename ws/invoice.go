@@ -0,0 +1,260 @@
+package ws
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"rentroll/bizlogic"
+	"rentroll/rlib"
+	"strings"
+	"time"
+)
+
+// InvoiceGrid describes an invoice and its payment status
+type InvoiceGrid struct {
+	Recid       int64 `json:"recid"`
+	InvoiceNo   int64
+	BID         int64
+	BUD         rlib.XJSONBud
+	Dt          rlib.JSONDate
+	DtDue       rlib.JSONDate
+	Amount      float64
+	Balance     float64 // amount still unpaid
+	DeliveredBy string
+	FLAGS       uint64
+	Status      string  // Paid, Unpaid, Void
+	Payors      string  // names of the payors
+	ASMIDs      []int64 // the assessments billed
+	LastModTime rlib.JSONDateTime
+	LastModBy   int64
+	CreateTS    rlib.JSONDateTime
+	CreateBy    int64
+}
+
+// InvoiceSearchResponse is the response to a request for a list of invoices
+type InvoiceSearchResponse struct {
+	Status  string        `json:"status"`
+	Total   int64         `json:"total"`
+	Records []InvoiceGrid `json:"records"`
+}
+
+// InvoiceGetResponse is the response to a request for a single invoice
+type InvoiceGetResponse struct {
+	Status string      `json:"status"`
+	Record InvoiceGrid `json:"record"`
+}
+
+// InvoiceForm contains the data from the Generate Invoice FORM
+type InvoiceForm struct {
+	BUD         rlib.XJSONBud
+	RAID        int64 // bill this Rental Agreement, or
+	TCID        int64 // bill this payor
+	DtStart     rlib.JSONDate
+	DtStop      rlib.JSONDate
+	Dt          rlib.JSONDate
+	DtDue       rlib.JSONDate
+	DeliveredBy string
+}
+
+// InvoiceInput is the input data format for a Save command
+type InvoiceInput struct {
+	Status   string      `json:"status"`
+	Recid    int64       `json:"recid"`
+	FormName string      `json:"name"`
+	Record   InvoiceForm `json:"record"`
+}
+
+// SvcHandlerInvoice generates, lists, and voids invoices.
+// For this call, we expect the URI to contain the BID and possibly the InvoiceNo:
+//       /v1/invoice/:BUI/[InvoiceNo]
+//
+// The server command can be:
+//      get
+//      save
+//      delete
+//-----------------------------------------------------------------------------------
+func SvcHandlerInvoice(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	var (
+		funcname = "SvcHandlerInvoice"
+		err      error
+	)
+
+	fmt.Printf("Entered %s\n", funcname)
+	fmt.Printf("Request: %s:  BID = %d,  InvoiceNo = %d\n", d.wsSearchReq.Cmd, d.BID, d.ID)
+
+	switch d.wsSearchReq.Cmd {
+	case "get":
+		if d.ID > 0 {
+			getInvoice(w, r, d)
+		} else {
+			getInvoices(w, r, d)
+		}
+		break
+	case "save":
+		saveInvoice(w, r, d)
+		break
+	case "delete":
+		voidInvoice(w, r, d)
+		break
+	default:
+		err = fmt.Errorf("Unhandled command: %s", d.wsSearchReq.Cmd)
+		SvcGridErrorReturn(w, err, funcname)
+		return
+	}
+}
+
+// invoiceToGrid fills in the grid record for invoice inv
+func invoiceToGrid(inv *rlib.Invoice) InvoiceGrid {
+	var q InvoiceGrid
+	rlib.MigrateStructVals(inv, &q)
+	q.Recid = inv.InvoiceNo
+	q.BUD = getBUDFromBIDList(inv.BID)
+	q.Status = bizlogic.InvoiceStatus(inv)
+	if inv.FLAGS&rlib.INVOICEVOID == 0 {
+		q.Balance = bizlogic.InvoiceBalance(inv)
+	}
+	var names []string
+	for i := 0; i < len(inv.P); i++ {
+		var t rlib.Transactant
+		rlib.GetTransactant(inv.P[i].PID, &t)
+		names = append(names, t.GetFullTransactantName())
+	}
+	q.Payors = strings.Join(names, ", ")
+	for i := 0; i < len(inv.A); i++ {
+		q.ASMIDs = append(q.ASMIDs, inv.A[i].ASMID)
+	}
+	return q
+}
+
+// getInvoices returns the invoices of the business
+// wsdoc {
+//  @Title  Get Invoices
+//	@URL /v1/invoice/:BUI
+//  @Method  POST
+//	@Synopsis Get the invoices dated in a period
+//  @Description  Returns the invoices for business :BUI dated from searchDtStart up to
+//  @Description  searchDtStop, with the unpaid balance and payment status of each.
+//	@Input WebGridSearchRequest
+//  @Response InvoiceSearchResponse
+// wsdoc }
+func getInvoices(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	var (
+		funcname = "getInvoices"
+		g        InvoiceSearchResponse
+	)
+
+	fmt.Printf("Entered %s\n", funcname)
+	m := rlib.GetInvoicesByDtRange(d.BID, &d.wsSearchReq.SearchDtStart, &d.wsSearchReq.SearchDtStop)
+	for i := 0; i < len(m); i++ {
+		g.Records = append(g.Records, invoiceToGrid(&m[i]))
+	}
+	g.Total = int64(len(g.Records))
+	g.Status = "success"
+	w.Header().Set("Content-Type", "application/json")
+	SvcWriteResponse(&g, w)
+}
+
+// getInvoice returns the requested invoice
+// wsdoc {
+//  @Title  Get Invoice
+//	@URL /v1/invoice/:BUI/:InvoiceNo
+//  @Method  GET
+//	@Synopsis Get information on an Invoice
+//  @Description  Return the invoice, its assessments, unpaid balance and payment status.
+//  @Description  The printable invoice is available as report RPTinvoice with id=:InvoiceNo.
+//	@Input WebGridSearchRequest
+//  @Response InvoiceGetResponse
+// wsdoc }
+func getInvoice(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	var (
+		funcname = "getInvoice"
+		g        InvoiceGetResponse
+	)
+
+	fmt.Printf("Entered %s\n", funcname)
+	inv, err := rlib.GetInvoice(d.ID)
+	if err != nil {
+		SvcGridErrorReturn(w, err, funcname)
+		return
+	}
+	if inv.InvoiceNo == 0 || inv.BID != d.BID {
+		e := fmt.Errorf("%s: Invoice %d not found", funcname, d.ID)
+		SvcGridErrorReturn(w, e, funcname)
+		return
+	}
+	g.Record = invoiceToGrid(&inv)
+	g.Status = "success"
+	SvcWriteResponse(&g, w)
+}
+
+// saveInvoice generates an invoice
+// wsdoc {
+//  @Title  Generate Invoice
+//	@URL /v1/invoice/:BUI
+//  @Method  POST
+//	@Synopsis Bill the unbilled assessments of a Rental Agreement or payor
+//  @Description  Creates an invoice for the assessments of Rental Agreement RAID, or of every
+//  @Description  Rental Agreement on which TCID is a payor, that start in DtStart - DtStop and are
+//  @Description  not already on an invoice. Reversed and fully paid assessments are not billed.
+//  @Description  Each assessment billed is stamped with the InvoiceNo, which is returned in the response.
+//	@Input InvoiceInput
+//  @Response SvcStatusResponse
+// wsdoc }
+func saveInvoice(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	var (
+		funcname = "saveInvoice"
+		foo      InvoiceInput
+	)
+
+	fmt.Printf("Entered %s\n", funcname)
+	fmt.Printf("record data = %s\n", d.data)
+
+	if err := json.Unmarshal([]byte(d.data), &foo); err != nil {
+		e := fmt.Errorf("%s: Error with json.Unmarshal:  %s", funcname, err.Error())
+		SvcGridErrorReturn(w, e, funcname)
+		return
+	}
+
+	var req bizlogic.InvoiceRequest
+	rlib.MigrateStructVals(&foo.Record, &req) // the variables that don't need special handling
+
+	var ok bool
+	req.BID, ok = getBIDFromBUD(w, d, foo.Record.BUD, funcname)
+	if !ok {
+		return
+	}
+	req.DtStart = time.Time(foo.Record.DtStart)
+	req.DtStop = time.Time(foo.Record.DtStop)
+	req.Dt = time.Time(foo.Record.Dt)
+	req.DtDue = time.Time(foo.Record.DtDue)
+	req.UID = d.UID
+
+	inv, errlist := bizlogic.GenerateInvoice(&req)
+	if len(errlist) > 0 {
+		SvcErrListReturn(w, errlist, funcname)
+		return
+	}
+	SvcWriteSuccessResponseWithID(w, inv.InvoiceNo)
+}
+
+// voidInvoice voids an invoice
+// wsdoc {
+//  @Title  Void Invoice
+//	@URL /v1/invoice/:BUI/:InvoiceNo
+//  @Method  POST
+//	@Synopsis Void an invoice
+//  @Description  Marks invoice :InvoiceNo void. The invoice is kept, but its assessments
+//  @Description  are released so that they can be billed on another invoice.
+//	@Input WebGridSearchRequest
+//  @Response SvcStatusResponse
+// wsdoc }
+func voidInvoice(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	funcname := "voidInvoice"
+
+	fmt.Printf("Entered %s\n", funcname)
+	if errlist := bizlogic.VoidInvoice(d.BID, d.ID, d.UID); len(errlist) > 0 {
+		SvcErrListReturn(w, errlist, funcname)
+		return
+	}
+	SvcWriteSuccessResponse(w)
+}
@@ -0,0 +1,219 @@
+package ws
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"rentroll/rlib"
+)
+
+// LateFeePolicyGrid contains the data from LateFeePolicy that is targeted to the UI Form
+type LateFeePolicyGrid struct {
+	Recid       int64 `json:"recid"`
+	LFPID       int64
+	BID         int64
+	BUD         rlib.XJSONBud
+	ARID        int64
+	GraceDays   int64
+	Amount      float64
+	Formula     string
+	MaxAmount   float64
+	LastModTime rlib.JSONDateTime
+	LastModBy   int64
+	CreateTS    rlib.JSONDateTime
+	CreateBy    int64
+}
+
+// LateFeePolicySaveForm contains the data from the Late Fee Policy FORM
+type LateFeePolicySaveForm struct {
+	Recid     int64 `json:"recid"`
+	LFPID     int64
+	BID       int64
+	BUD       rlib.XJSONBud
+	ARID      int64
+	GraceDays int64
+	Amount    float64
+	Formula   string
+	MaxAmount float64
+}
+
+// LateFeePolicySave is the input data format for a Save command
+type LateFeePolicySave struct {
+	Status   string                `json:"status"`
+	Recid    int64                 `json:"recid"`
+	FormName string                `json:"name"`
+	Record   LateFeePolicySaveForm `json:"record"`
+}
+
+// LateFeePolicyGetResponse is the response to a GetLateFeePolicy request
+type LateFeePolicyGetResponse struct {
+	Status string            `json:"status"`
+	Record LateFeePolicyGrid `json:"record"`
+}
+
+// SvcHandlerLateFeePolicy handles the late fee policy of a business.
+// For this call, we expect the URI to contain the BID:  /v1/latefeepolicy/:BUI
+//
+// The server command can be:
+//      get
+//      save
+//      delete
+//-----------------------------------------------------------------------------------
+func SvcHandlerLateFeePolicy(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	var (
+		funcname = "SvcHandlerLateFeePolicy"
+		err      error
+	)
+
+	fmt.Printf("Entered %s\n", funcname)
+	fmt.Printf("Request: %s:  BID = %d\n", d.wsSearchReq.Cmd, d.BID)
+
+	switch d.wsSearchReq.Cmd {
+	case "get":
+		getLateFeePolicy(w, r, d)
+		break
+	case "save":
+		saveLateFeePolicy(w, r, d)
+		break
+	case "delete":
+		deleteLateFeePolicy(w, r, d)
+		break
+	default:
+		err = fmt.Errorf("Unhandled command: %s", d.wsSearchReq.Cmd)
+		SvcGridErrorReturn(w, err, funcname)
+		return
+	}
+}
+
+// deleteLateFeePolicy deletes the late fee policy of a business
+// wsdoc {
+//  @Title  Delete Late Fee Policy
+//	@URL /v1/latefeepolicy/:BUI
+//  @Method  POST
+//	@Synopsis Delete the late fee policy
+//  @Desc  This service deletes the late fee policy of the business. No more late fees
+//  @Desc  are charged until a new policy is saved.
+//	@Input WebGridSearchRequest
+//  @Response SvcStatusResponse
+// wsdoc }
+func deleteLateFeePolicy(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	funcname := "deleteLateFeePolicy"
+
+	fmt.Printf("Entered %s\n", funcname)
+	a, err := rlib.GetLateFeePolicy(d.BID)
+	if err != nil && !rlib.IsSQLNoResultsError(err) {
+		SvcGridErrorReturn(w, err, funcname)
+		return
+	}
+	if a.LFPID > 0 {
+		if err = rlib.DeleteLateFeePolicy(a.LFPID); err != nil {
+			SvcGridErrorReturn(w, err, funcname)
+			return
+		}
+	}
+	SvcWriteSuccessResponse(w)
+}
+
+// saveLateFeePolicy creates or updates the late fee policy of a business
+// wsdoc {
+//  @Title  Save Late Fee Policy
+//	@URL /v1/latefeepolicy/:BUI
+//  @Method  POST
+//	@Synopsis Set the late fee policy
+//  @Description  This service sets the late fee policy of the business. Assessments that are
+//  @Description  still unpaid GraceDays after they are due are charged a late fee using account
+//  @Description  rule ARID. The fee is computed from Formula, an RPN expression in which _ is the
+//  @Description  unpaid amount, or is the flat Amount if Formula is blank. All fields must be supplied.
+//	@Input LateFeePolicySave
+//  @Response SvcStatusResponse
+// wsdoc }
+func saveLateFeePolicy(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	var (
+		funcname = "saveLateFeePolicy"
+		foo      LateFeePolicySave
+		err      error
+	)
+
+	fmt.Printf("Entered %s\n", funcname)
+	fmt.Printf("record data = %s\n", d.data)
+
+	if err := json.Unmarshal([]byte(d.data), &foo); err != nil {
+		e := fmt.Errorf("%s: Error with json.Unmarshal:  %s", funcname, err.Error())
+		SvcGridErrorReturn(w, e, funcname)
+		return
+	}
+
+	var a rlib.LateFeePolicy
+	rlib.MigrateStructVals(&foo.Record, &a) // the variables that don't need special handling
+
+	var ok bool
+	a.BID, ok = getBIDFromBUD(w, d, foo.Record.BUD, funcname)
+	if !ok {
+		return
+	}
+
+	ar, err := rlib.GetAR(a.ARID)
+	if err != nil || ar.BID != a.BID || ar.ARType != rlib.ARASSESSMENT {
+		e := fmt.Errorf("%s: Account rule %d is not an assessment rule for this business", funcname, a.ARID)
+		SvcGridErrorReturn(w, e, funcname)
+		return
+	}
+	if a.GraceDays < 0 || a.Amount < 0 || a.MaxAmount < 0 {
+		e := fmt.Errorf("%s: GraceDays, Amount and MaxAmount cannot be negative", funcname)
+		SvcGridErrorReturn(w, e, funcname)
+		return
+	}
+
+	// there is only one policy per business
+	old, _ := rlib.GetLateFeePolicy(a.BID)
+	a.LFPID = old.LFPID
+	a.LastModBy = d.UID
+	if a.LFPID == 0 {
+		a.CreateBy = d.UID
+		_, err = rlib.InsertLateFeePolicy(&a)
+	} else {
+		err = rlib.UpdateLateFeePolicy(&a)
+	}
+
+	if err != nil {
+		e := fmt.Errorf("%s: Error saving LateFeePolicy (LFPID=%d): %s", funcname, a.LFPID, err.Error())
+		SvcGridErrorReturn(w, e, funcname)
+		return
+	}
+
+	SvcWriteSuccessResponseWithID(w, a.LFPID)
+}
+
+// getLateFeePolicy returns the late fee policy of a business
+// wsdoc {
+//  @Title  Get Late Fee Policy
+//	@URL /v1/latefeepolicy/:BUI
+//  @Method  GET
+//	@Synopsis Get the late fee policy
+//  @Description  Return all fields of the late fee policy of the business. LFPID is 0 if the
+//  @Description  business does not charge late fees.
+//	@Input WebGridSearchRequest
+//  @Response LateFeePolicyGetResponse
+// wsdoc }
+func getLateFeePolicy(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	var (
+		funcname = "getLateFeePolicy"
+		g        LateFeePolicyGetResponse
+	)
+
+	fmt.Printf("entered %s\n", funcname)
+	a, err := rlib.GetLateFeePolicy(d.BID)
+	if err != nil && !rlib.IsSQLNoResultsError(err) {
+		SvcGridErrorReturn(w, err, funcname)
+		return
+	}
+	if a.LFPID > 0 {
+		var gg LateFeePolicyGrid
+		rlib.MigrateStructVals(&a, &gg)
+		gg.Recid = gg.LFPID
+		gg.BUD = getBUDFromBIDList(gg.BID)
+		g.Record = gg
+	}
+	g.Status = "success"
+	SvcWriteResponse(&g, w)
+}
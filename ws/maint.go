@@ -0,0 +1,288 @@
+package ws
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"rentroll/bizlogic"
+	"rentroll/rlib"
+	"time"
+)
+
+// MaintenanceScheduleGrid describes a preventative maintenance schedule
+type MaintenanceScheduleGrid struct {
+	Recid         int64 `json:"recid"`
+	MSID          int64
+	BID           int64
+	BUD           rlib.XJSONBud
+	Name          string
+	Description   string
+	CategorySLSID int64
+	Priority      int64
+	Assignee      int64
+	RID           int64
+	RTID          int64
+	BLDGID        int64
+	AppliesTo     string // what the schedule covers, such as "Rentable 101"
+	Cycle         rlib.XJSONCycleFreq
+	DtStart       rlib.JSONDate
+	DtStop        rlib.JSONDate
+	LeadDays      int64
+	DtLastDue     rlib.JSONDate // due date of the last instance generated
+	LastModTime   rlib.JSONDateTime
+	LastModBy     int64
+	CreateTS      rlib.JSONDateTime
+	CreateBy      int64
+}
+
+// MaintenanceScheduleSearchResponse is the response to a request for a list of maintenance schedules
+type MaintenanceScheduleSearchResponse struct {
+	Status  string                    `json:"status"`
+	Total   int64                     `json:"total"`
+	Records []MaintenanceScheduleGrid `json:"records"`
+}
+
+// MaintenanceScheduleGetResponse is the response to a request for a single maintenance schedule
+type MaintenanceScheduleGetResponse struct {
+	Status string                  `json:"status"`
+	Record MaintenanceScheduleGrid `json:"record"`
+}
+
+// MaintenanceScheduleForm contains the data from the Preventative Maintenance FORM
+type MaintenanceScheduleForm struct {
+	BUD           rlib.XJSONBud
+	MSID          int64 // 0 = new schedule
+	Name          string
+	Description   string
+	CategorySLSID int64
+	Priority      int64 // 0 = low, 1 = normal, 2 = high, 3 = emergency
+	Assignee      int64
+	RID           int64 // supply exactly one of RID, RTID, BLDGID
+	RTID          int64
+	BLDGID        int64
+	Cycle         rlib.XJSONCycleFreq
+	DtStart       rlib.JSONDate
+	DtStop        rlib.JSONDate
+	LeadDays      int64
+}
+
+// MaintenanceScheduleInput is the input data format for a Save command
+type MaintenanceScheduleInput struct {
+	Status   string                  `json:"status"`
+	Recid    int64                   `json:"recid"`
+	FormName string                  `json:"name"`
+	Record   MaintenanceScheduleForm `json:"record"`
+}
+
+// maintenanceScheduleGridRecord fills out a MaintenanceScheduleGrid from schedule ms
+func maintenanceScheduleGridRecord(ms *rlib.MaintenanceSchedule) MaintenanceScheduleGrid {
+	var q MaintenanceScheduleGrid
+	rlib.MigrateStructVals(ms, &q)
+	q.Recid = ms.MSID
+	q.BUD = getBUDFromBIDList(ms.BID)
+	switch {
+	case ms.RID > 0:
+		q.AppliesTo = "Rentable " + rlib.GetRentable(ms.RID).RentableName
+	case ms.RTID > 0:
+		var rt rlib.RentableType
+		rlib.GetRentableType(ms.RTID, &rt)
+		q.AppliesTo = "Rentable Type " + rt.Style
+	case ms.BLDGID > 0:
+		q.AppliesTo = "Building " + rlib.GetBuilding(ms.BLDGID).Address
+	}
+	return q
+}
+
+// SvcHandlerMaintenanceSchedules lists the maintenance schedules of a business.
+// For this call, we expect the URI to contain the BID:
+//       /v1/maintscheds/:BUI
+//
+// The server command can be:
+//      get
+//-----------------------------------------------------------------------------------
+func SvcHandlerMaintenanceSchedules(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	var (
+		funcname = "SvcHandlerMaintenanceSchedules"
+		err      error
+	)
+
+	fmt.Printf("Entered %s\n", funcname)
+	fmt.Printf("Request: %s:  BID = %d\n", d.wsSearchReq.Cmd, d.BID)
+
+	switch d.wsSearchReq.Cmd {
+	case "get":
+		getMaintenanceSchedules(w, r, d)
+		break
+	default:
+		err = fmt.Errorf("Unhandled command: %s", d.wsSearchReq.Cmd)
+		SvcGridErrorReturn(w, err, funcname)
+		return
+	}
+}
+
+// getMaintenanceSchedules returns the maintenance schedules of a business
+// wsdoc {
+//  @Title  Get Maintenance Schedules
+//	@URL /v1/maintscheds/:BUI
+//  @Method  POST
+//	@Synopsis Get the preventative maintenance schedules of a business
+//  @Description  Returns every maintenance schedule of business :BUI, sorted by name.
+//	@Input WebGridSearchRequest
+//  @Response MaintenanceScheduleSearchResponse
+// wsdoc }
+func getMaintenanceSchedules(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	var (
+		funcname = "getMaintenanceSchedules"
+		g        MaintenanceScheduleSearchResponse
+	)
+
+	fmt.Printf("Entered %s\n", funcname)
+	m := rlib.GetAllMaintenanceSchedules(d.BID)
+	for i := 0; i < len(m); i++ {
+		g.Records = append(g.Records, maintenanceScheduleGridRecord(&m[i]))
+	}
+	g.Total = int64(len(g.Records))
+	g.Status = "success"
+	w.Header().Set("Content-Type", "application/json")
+	SvcWriteResponse(&g, w)
+}
+
+// SvcHandlerMaintenanceSchedule returns, creates, updates and deletes a maintenance schedule.
+// For this call, we expect the URI to contain the BID and the MSID:
+//    /v1/maintsched/:BUI/:MSID
+//
+// The server command can be:
+//      get
+//      save
+//      delete
+//-----------------------------------------------------------------------------------
+func SvcHandlerMaintenanceSchedule(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	var (
+		funcname = "SvcHandlerMaintenanceSchedule"
+		err      error
+	)
+
+	fmt.Printf("Entered %s\n", funcname)
+	fmt.Printf("Request: %s:  BID = %d,  MSID = %d\n", d.wsSearchReq.Cmd, d.BID, d.ID)
+
+	switch d.wsSearchReq.Cmd {
+	case "get":
+		getMaintenanceSchedule(w, r, d)
+		break
+	case "save":
+		saveMaintenanceSchedule(w, r, d)
+		break
+	case "delete":
+		deleteMaintenanceSchedule(w, r, d)
+		break
+	default:
+		err = fmt.Errorf("Unhandled command: %s", d.wsSearchReq.Cmd)
+		SvcGridErrorReturn(w, err, funcname)
+		return
+	}
+}
+
+// getMaintenanceSchedule returns the requested maintenance schedule
+// wsdoc {
+//  @Title  Get Maintenance Schedule
+//	@URL /v1/maintsched/:BUI/:MSID
+//  @Method  GET
+//	@Synopsis Get a preventative maintenance schedule
+//  @Desc  This service returns the maintenance schedule with id :MSID.
+//	@Input WebGridSearchRequest
+//  @Response MaintenanceScheduleGetResponse
+// wsdoc }
+func getMaintenanceSchedule(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	var (
+		funcname = "getMaintenanceSchedule"
+		g        MaintenanceScheduleGetResponse
+	)
+
+	fmt.Printf("Entered %s\n", funcname)
+	ms, err := rlib.GetMaintenanceSchedule(d.ID)
+	if err != nil || ms.BID != d.BID {
+		e := fmt.Errorf("%s: maintenance schedule %d not found", funcname, d.ID)
+		SvcGridErrorReturn(w, e, funcname)
+		return
+	}
+	g.Record = maintenanceScheduleGridRecord(&ms)
+	g.Status = "success"
+	SvcWriteResponse(&g, w)
+}
+
+// saveMaintenanceSchedule creates or updates a maintenance schedule
+// wsdoc {
+//  @Title  Save Maintenance Schedule
+//	@URL /v1/maintsched/:BUI/:MSID
+//  @Method  POST
+//	@Synopsis Create or update a preventative maintenance schedule
+//  @Description  If MSID is 0 a new schedule is created, otherwise schedule MSID is updated.
+//  @Description  Exactly one of RID, RTID and BLDGID must be supplied. A service request is
+//  @Description  generated for each Rentable covered by an instance LeadDays before it is due.
+//  @Description  Cycle is a recurrence such as Monthly or Quarterly. The response contains
+//  @Description  the MSID.
+//	@Input MaintenanceScheduleInput
+//  @Response SvcStatusResponse
+// wsdoc }
+func saveMaintenanceSchedule(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	var (
+		funcname = "saveMaintenanceSchedule"
+		foo      MaintenanceScheduleInput
+	)
+
+	fmt.Printf("Entered %s\n", funcname)
+	fmt.Printf("record data = %s\n", d.data)
+
+	if err := json.Unmarshal([]byte(d.data), &foo); err != nil {
+		e := fmt.Errorf("%s: Error with json.Unmarshal:  %s", funcname, err.Error())
+		SvcGridErrorReturn(w, e, funcname)
+		return
+	}
+
+	var ms rlib.MaintenanceSchedule
+	rlib.MigrateStructVals(&foo.Record, &ms) // the variables that don't need special handling
+
+	var ok bool
+	if ms.BID, ok = getBIDFromBUD(w, d, foo.Record.BUD, funcname); !ok {
+		return
+	}
+	if ms.MSID == 0 {
+		ms.MSID = d.ID
+	}
+	ms.DtStart = time.Time(foo.Record.DtStart)
+	ms.DtStop = time.Time(foo.Record.DtStop)
+
+	if errlist := bizlogic.SaveMaintenanceSchedule(&ms, d.UID); len(errlist) > 0 {
+		SvcErrListReturn(w, errlist, funcname)
+		return
+	}
+	SvcWriteSuccessResponseWithID(w, ms.MSID)
+}
+
+// deleteMaintenanceSchedule deletes a maintenance schedule
+// wsdoc {
+//  @Title  Delete Maintenance Schedule
+//	@URL /v1/maintsched/:BUI/:MSID
+//  @Method  POST
+//	@Synopsis Delete a preventative maintenance schedule
+//  @Description  Deletes schedule :MSID. No more service requests are generated for it, the
+//  @Description  ones already generated are kept.
+//	@Input WebGridSearchRequest
+//  @Response SvcStatusResponse
+// wsdoc }
+func deleteMaintenanceSchedule(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	funcname := "deleteMaintenanceSchedule"
+	fmt.Printf("Entered %s\n", funcname)
+
+	ms, err := rlib.GetMaintenanceSchedule(d.ID)
+	if err != nil || ms.BID != d.BID {
+		e := fmt.Errorf("%s: maintenance schedule %d not found", funcname, d.ID)
+		SvcGridErrorReturn(w, e, funcname)
+		return
+	}
+	if err = rlib.DeleteMaintenanceSchedule(ms.MSID); err != nil {
+		SvcGridErrorReturn(w, err, funcname)
+		return
+	}
+	SvcWriteSuccessResponse(w)
+}
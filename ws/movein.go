@@ -0,0 +1,114 @@
+package ws
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"rentroll/bizlogic"
+	"rentroll/rlib"
+	"time"
+)
+
+// MoveInForm contains the data from the Resident Arrival FORM
+type MoveInForm struct {
+	BUD          rlib.XJSONBud
+	RAID         int64   // existing Rental Agreement, 0 = create a new one
+	RATID        int64   // Rental Agreement template for a new Rental Agreement
+	RID          int64   // the Rentable being occupied
+	Payors       []int64 // TCIDs of the payors
+	Users        []int64 // TCIDs of the users
+	DtStart      rlib.JSONDate
+	DtStop       rlib.JSONDate
+	ContractRent float64 // monthly rent, 0 = use the market rate
+	RentARID     int64
+	Deposit      float64
+	DepositARID  int64
+	Commissions  []CommissionForm // commissions earned by leasing agents and referrers
+}
+
+// MoveInInput is the input data format for a Save command
+type MoveInInput struct {
+	Status   string     `json:"status"`
+	Recid    int64      `json:"recid"`
+	FormName string     `json:"name"`
+	Record   MoveInForm `json:"record"`
+}
+
+// SvcHandlerMoveIn processes a resident arrival.
+// For this call, we expect the URI to contain the BID:  /v1/movein/:BUI
+//
+// The server command can be:
+//      save
+//-----------------------------------------------------------------------------------
+func SvcHandlerMoveIn(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	var (
+		funcname = "SvcHandlerMoveIn"
+		err      error
+	)
+
+	fmt.Printf("Entered %s\n", funcname)
+	fmt.Printf("Request: %s:  BID = %d\n", d.wsSearchReq.Cmd, d.BID)
+
+	switch d.wsSearchReq.Cmd {
+	case "save":
+		saveMoveIn(w, r, d)
+		break
+	default:
+		err = fmt.Errorf("Unhandled command: %s", d.wsSearchReq.Cmd)
+		SvcGridErrorReturn(w, err, funcname)
+		return
+	}
+}
+
+// saveMoveIn moves a resident into a Rentable
+// wsdoc {
+//  @Title  Resident Move-In
+//	@URL /v1/movein/:BUI
+//  @Method  POST
+//	@Synopsis Move a resident into a Rentable
+//  @Description  Creates a Rental Agreement, or adds to Rental Agreement RAID if it is supplied, with
+//  @Description  Rentable RID, its Payors and Users for DtStart - DtStop, and sets the Rentable's
+//  @Description  status to occupied. The first month's rent is assessed prorated from DtStart and
+//  @Description  the security deposit, if any, is assessed on DtStart. Nothing is saved if any
+//  @Description  step fails. The response contains the RAID.
+//	@Input MoveInInput
+//  @Response SvcStatusResponse
+// wsdoc }
+func saveMoveIn(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	var (
+		funcname = "saveMoveIn"
+		foo      MoveInInput
+	)
+
+	fmt.Printf("Entered %s\n", funcname)
+	fmt.Printf("record data = %s\n", d.data)
+
+	if err := json.Unmarshal([]byte(d.data), &foo); err != nil {
+		e := fmt.Errorf("%s: Error with json.Unmarshal:  %s", funcname, err.Error())
+		SvcGridErrorReturn(w, e, funcname)
+		return
+	}
+
+	var mi bizlogic.MoveIn
+	rlib.MigrateStructVals(&foo.Record, &mi) // the variables that don't need special handling
+
+	var ok bool
+	mi.BID, ok = getBIDFromBUD(w, d, foo.Record.BUD, funcname)
+	if !ok {
+		return
+	}
+	mi.Payors = foo.Record.Payors
+	mi.Users = foo.Record.Users
+	mi.DtStart = time.Time(foo.Record.DtStart)
+	mi.DtStop = time.Time(foo.Record.DtStop)
+	mi.Commissions = commissionsFromForm(foo.Record.Commissions)
+	mi.UID = d.UID
+
+	ra, errlist := bizlogic.ProcessMoveIn(&mi)
+	if len(errlist) > 0 {
+		SvcErrListReturn(w, errlist, funcname)
+		return
+	}
+
+	SvcWriteSuccessResponseWithID(w, ra.RAID)
+}
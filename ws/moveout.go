@@ -0,0 +1,123 @@
+package ws
+
+import (
+	"encoding/json"
+	"fmt"
+	"gotable"
+	"net/http"
+	"rentroll/bizlogic"
+	"rentroll/rlib"
+	"rentroll/rrpt"
+	"time"
+)
+
+// MoveOutForm contains the data from the Resident Departure FORM
+type MoveOutForm struct {
+	BUD         rlib.XJSONBud
+	RAID        int64 // the Rental Agreement
+	RID         int64 // the Rentable being vacated
+	Dt          rlib.JSONDate
+	Charges     []bizlogic.MoveOutCharge // damages and other charges
+	DepositARID int64                    // receipt account rule that applies the security deposit
+}
+
+// MoveOutInput is the input data format for a Save command
+type MoveOutInput struct {
+	Status   string      `json:"status"`
+	Recid    int64       `json:"recid"`
+	FormName string      `json:"name"`
+	Record   MoveOutForm `json:"record"`
+}
+
+// MoveOutResponse is the response to a Save command. It contains the
+// disposition of the security deposit and the printable statement.
+type MoveOutResponse struct {
+	Status    string                      `json:"status"`
+	Recid     int64                       `json:"recid"`
+	Record    bizlogic.MoveOutDisposition `json:"record"`
+	Statement string                      `json:"statement"`
+}
+
+// SvcHandlerMoveOut processes a resident departure.
+// For this call, we expect the URI to contain the BID:  /v1/moveout/:BUI
+//
+// The server command can be:
+//      save
+//-----------------------------------------------------------------------------------
+func SvcHandlerMoveOut(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	var (
+		funcname = "SvcHandlerMoveOut"
+		err      error
+	)
+
+	fmt.Printf("Entered %s\n", funcname)
+	fmt.Printf("Request: %s:  BID = %d\n", d.wsSearchReq.Cmd, d.BID)
+
+	switch d.wsSearchReq.Cmd {
+	case "save":
+		saveMoveOut(w, r, d)
+		break
+	default:
+		err = fmt.Errorf("Unhandled command: %s", d.wsSearchReq.Cmd)
+		SvcGridErrorReturn(w, err, funcname)
+		return
+	}
+}
+
+// saveMoveOut moves a resident out of a Rentable
+// wsdoc {
+//  @Title  Resident Move-Out
+//	@URL /v1/moveout/:BUI
+//  @Method  POST
+//	@Synopsis Move a resident out of a Rentable and settle the security deposit
+//  @Description  Ends Rentable RID on Rental Agreement RAID on Dt, reverses the recurring rent
+//  @Description  from the start of that month forward and assesses the rent prorated to Dt, and
+//  @Description  puts the Rentable back online. Charges, such as damages, are assessed on Dt.
+//  @Description  The security deposit is then applied to the unpaid assessments on the agreement
+//  @Description  with a receipt under account rule DepositARID.
+//  @Description  The response contains the disposition of the deposit and an itemized statement.
+//	@Input MoveOutInput
+//  @Response MoveOutResponse
+// wsdoc }
+func saveMoveOut(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	var (
+		funcname = "saveMoveOut"
+		foo      MoveOutInput
+		g        MoveOutResponse
+	)
+
+	fmt.Printf("Entered %s\n", funcname)
+	fmt.Printf("record data = %s\n", d.data)
+
+	if err := json.Unmarshal([]byte(d.data), &foo); err != nil {
+		e := fmt.Errorf("%s: Error with json.Unmarshal:  %s", funcname, err.Error())
+		SvcGridErrorReturn(w, e, funcname)
+		return
+	}
+
+	var mo bizlogic.MoveOut
+	rlib.MigrateStructVals(&foo.Record, &mo) // the variables that don't need special handling
+
+	var ok bool
+	mo.BID, ok = getBIDFromBUD(w, d, foo.Record.BUD, funcname)
+	if !ok {
+		return
+	}
+	mo.Charges = foo.Record.Charges
+	mo.Dt = time.Time(foo.Record.Dt)
+	mo.UID = d.UID
+
+	disp, errlist := bizlogic.ProcessMoveOut(&mo)
+	if len(errlist) > 0 {
+		SvcErrListReturn(w, errlist, funcname)
+		return
+	}
+
+	ri := rrpt.ReporterInfo{OutputFormat: gotable.TABLEOUTTEXT, Bid: mo.BID}
+	g.Record = disp
+	g.Recid = disp.RAID
+	g.Statement = rrpt.MoveOutStatement(&ri, &disp)
+	g.Status = "success"
+	w.Header().Set("Content-Type", "application/json")
+	SvcWriteResponse(&g, w)
+}
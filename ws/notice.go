@@ -0,0 +1,107 @@
+package ws
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"rentroll/rlib"
+	"rentroll/rrpt"
+	"time"
+)
+
+// NoticesForm holds the period for which the notices are due
+type NoticesForm struct {
+	BUD     rlib.XJSONBud
+	DtStart rlib.JSONDate
+	DtStop  rlib.JSONDate
+}
+
+// NoticesInput is the input data format for a Save command
+type NoticesInput struct {
+	Status   string      `json:"status"`
+	Recid    int64       `json:"recid"`
+	FormName string      `json:"name"`
+	Record   NoticesForm `json:"record"`
+}
+
+// NoticesResponse is the response to a Save command
+type NoticesResponse struct {
+	Status string `json:"status"`
+	Total  int64  `json:"total"` // number of notices recorded
+}
+
+// SvcHandlerNotices records the notices sent to residents.
+// For this call, we expect the URI to contain the BID:  /v1/notices/:BUI
+//
+// The server command can be:
+//      save
+//-----------------------------------------------------------------------------------
+func SvcHandlerNotices(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	var (
+		funcname = "SvcHandlerNotices"
+		err      error
+	)
+
+	fmt.Printf("Entered %s\n", funcname)
+	fmt.Printf("Request: %s:  BID = %d\n", d.wsSearchReq.Cmd, d.BID)
+
+	switch d.wsSearchReq.Cmd {
+	case "save":
+		saveNotices(w, r, d)
+		break
+	default:
+		err = fmt.Errorf("Unhandled command: %s", d.wsSearchReq.Cmd)
+		SvcGridErrorReturn(w, err, funcname)
+		return
+	}
+}
+
+// saveNotices records the notices due in a period
+// wsdoc {
+//  @Title  Record Notices
+//	@URL /v1/notices/:BUI
+//  @Method  POST
+//	@Synopsis Record the notices that were sent
+//  @Description  Generates the notices due for business :BUI in the period DtStart - DtStop,
+//  @Description  the same notices shown by the Notices report, and records each one as a
+//  @Description  Note on its Rental Agreement. A notice that was already recorded is not
+//  @Description  recorded again.
+//	@Input NoticesInput
+//  @Response NoticesResponse
+// wsdoc }
+func saveNotices(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	var (
+		funcname = "saveNotices"
+		foo      NoticesInput
+		g        NoticesResponse
+	)
+
+	fmt.Printf("Entered %s\n", funcname)
+	fmt.Printf("record data = %s\n", d.data)
+
+	if err := json.Unmarshal([]byte(d.data), &foo); err != nil {
+		e := fmt.Errorf("%s: Error with json.Unmarshal:  %s", funcname, err.Error())
+		SvcGridErrorReturn(w, e, funcname)
+		return
+	}
+
+	bid, ok := getBIDFromBUD(w, d, foo.Record.BUD, funcname)
+	if !ok {
+		return
+	}
+
+	var xbiz rlib.XBusiness
+	rlib.InitBizInternals(bid, &xbiz)
+	ri := rrpt.ReporterInfo{Bid: bid, D1: time.Time(foo.Record.DtStart), D2: time.Time(foo.Record.DtStop), Xbiz: &xbiz}
+	n, err := rrpt.RecordNotices(&ri, d.UID)
+	if err != nil {
+		e := fmt.Errorf("%s: Error recording notices: %s", funcname, err.Error())
+		SvcGridErrorReturn(w, e, funcname)
+		return
+	}
+
+	g.Total = int64(n)
+	g.Status = "success"
+	w.Header().Set("Content-Type", "application/json")
+	SvcWriteResponse(&g, w)
+}
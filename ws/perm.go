@@ -0,0 +1,471 @@
+package ws
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"rentroll/bizlogic"
+	"rentroll/rlib"
+)
+
+// svcPublic lists the services anyone may use. They are not checked against
+// the requester's Roles.
+var svcPublic = map[string]bool{
+	"authn":   true,
+	"ping":    true,
+	"uilists": true,
+	"uival":   true,
+}
+
+// svcCheckPermission decides whether the requester may run the command of the
+// service in d. Apart from the public services, the requester must be logged
+// in, unless no Roles have been assigned yet: until then every request is let
+// through so that the first Roles can be set up. If the request is denied, the
+// denial is logged and an error is returned to the client.
+//
+// RETURNS
+//    true if the request may proceed
+func svcCheckPermission(w http.ResponseWriter, d *ServiceData) bool {
+	funcname := "svcCheckPermission"
+	if svcPublic[d.Service] {
+		return true
+	}
+	if bizlogic.HasPermission(d.UID, d.BID, d.Service, d.wsSearchReq.Cmd) {
+		return true
+	}
+	if d.UID == 0 {
+		rlib.Ulog("%s: no session, BID = %d, service = %s, cmd = %s\n", funcname, d.BID, d.Service, d.wsSearchReq.Cmd)
+		SvcGridErrorReturn(w, fmt.Errorf("Not logged in"), funcname)
+		return false
+	}
+	rlib.Ulog("%s: permission denied, UID = %d, BID = %d, service = %s, cmd = %s\n", funcname, d.UID, d.BID, d.Service, d.wsSearchReq.Cmd)
+	e := fmt.Errorf("Permission denied: %s %s", d.Service, d.wsSearchReq.Cmd)
+	SvcGridErrorReturn(w, e, funcname)
+	return false
+}
+
+// CheckReportPermission decides whether the requester of r may run report
+// reportname for business bid. Reports are the "reports" service and the
+// report name is the command. The requester is taken from the login session,
+// with the same bootstrap rule as svcCheckPermission.
+//
+// RETURNS
+//    nil if the report may be run, otherwise the reason it may not
+func CheckReportPermission(r *http.Request, bid int64, reportname string) error {
+	funcname := "CheckReportPermission"
+	var uid int64
+	if s := SessionGet(r); s != nil {
+		uid = s.UID
+	}
+	if bizlogic.HasPermission(uid, bid, "reports", reportname) {
+		return nil
+	}
+	if uid == 0 {
+		rlib.Ulog("%s: no session, BID = %d, report = %s\n", funcname, bid, reportname)
+		return fmt.Errorf("Not logged in")
+	}
+	rlib.Ulog("%s: permission denied, UID = %d, BID = %d, report = %s\n", funcname, uid, bid, reportname)
+	return fmt.Errorf("Permission denied: reports %s", reportname)
+}
+
+// RoleGrid describes a Role
+type RoleGrid struct {
+	Recid       int64 `json:"recid"`
+	RoleID      int64
+	Name        string
+	Description string
+	LastModTime rlib.JSONDateTime
+	LastModBy   int64
+	CreateTS    rlib.JSONDateTime
+	CreateBy    int64
+}
+
+// RolePermissionGrid is a right of a Role
+type RolePermissionGrid struct {
+	Recid   int64 `json:"recid"`
+	RPID    int64
+	BID     int64 // 0 = every business
+	BUD     rlib.XJSONBud
+	Service string // "*" = every service
+	Cmd     string // "*" = every command
+}
+
+// RoleSearchResponse is the response to a request for the list of Roles
+type RoleSearchResponse struct {
+	Status  string     `json:"status"`
+	Total   int64      `json:"total"`
+	Records []RoleGrid `json:"records"`
+}
+
+// RoleGetResponse is the response to a request for a single Role
+type RoleGetResponse struct {
+	Status      string               `json:"status"`
+	Record      RoleGrid             `json:"record"`
+	Permissions []RolePermissionGrid `json:"permissions"`
+}
+
+// RolePermissionForm is a right of a Role as entered on the Role FORM
+type RolePermissionForm struct {
+	BUD     rlib.XJSONBud // empty = every business
+	Service string
+	Cmd     string
+}
+
+// RoleForm contains the data from the Role FORM
+type RoleForm struct {
+	RoleID      int64 // 0 = new Role
+	Name        string
+	Description string
+	Permissions []RolePermissionForm
+}
+
+// RoleInput is the input data format for a Save command
+type RoleInput struct {
+	Status   string   `json:"status"`
+	Recid    int64    `json:"recid"`
+	FormName string   `json:"name"`
+	Record   RoleForm `json:"record"`
+}
+
+// UserRolesResponse is the response to a request for the Roles of a user
+type UserRolesResponse struct {
+	Status  string     `json:"status"`
+	Total   int64      `json:"total"`
+	Records []RoleGrid `json:"records"`
+}
+
+// UserRolesForm contains the data from the User Roles FORM
+type UserRolesForm struct {
+	UID     int64 // phonebook UID of the user
+	RoleIDs []int64
+}
+
+// UserRolesInput is the input data format for a Save command
+type UserRolesInput struct {
+	Status   string        `json:"status"`
+	Recid    int64         `json:"recid"`
+	FormName string        `json:"name"`
+	Record   UserRolesForm `json:"record"`
+}
+
+// roleGridRecord fills out a RoleGrid from role a
+func roleGridRecord(a *rlib.Role) RoleGrid {
+	var q RoleGrid
+	rlib.MigrateStructVals(a, &q)
+	q.Recid = a.RoleID
+	return q
+}
+
+// SvcHandlerRoles lists the Roles.
+// For this call, we expect the URI to contain the BID, which is ignored:
+//       /v1/roles/:BUI
+//
+// The server command can be:
+//      get
+//-----------------------------------------------------------------------------------
+func SvcHandlerRoles(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	var (
+		funcname = "SvcHandlerRoles"
+		err      error
+	)
+
+	fmt.Printf("Entered %s\n", funcname)
+	fmt.Printf("Request: %s\n", d.wsSearchReq.Cmd)
+
+	switch d.wsSearchReq.Cmd {
+	case "get":
+		getRoles(w, r, d)
+		break
+	default:
+		err = fmt.Errorf("Unhandled command: %s", d.wsSearchReq.Cmd)
+		SvcGridErrorReturn(w, err, funcname)
+		return
+	}
+}
+
+// getRoles returns every Role
+// wsdoc {
+//  @Title  Get Roles
+//	@URL /v1/roles/:BUI
+//  @Method  POST
+//	@Synopsis Get the list of Roles
+//  @Description  Returns every Role, sorted by name. Roles are not specific to a business.
+//	@Input WebGridSearchRequest
+//  @Response RoleSearchResponse
+// wsdoc }
+func getRoles(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	var (
+		funcname = "getRoles"
+		g        RoleSearchResponse
+	)
+
+	fmt.Printf("Entered %s\n", funcname)
+	m := rlib.GetAllRoles()
+	for i := 0; i < len(m); i++ {
+		g.Records = append(g.Records, roleGridRecord(&m[i]))
+	}
+	g.Total = int64(len(g.Records))
+	g.Status = "success"
+	w.Header().Set("Content-Type", "application/json")
+	SvcWriteResponse(&g, w)
+}
+
+// SvcHandlerRole returns, creates, updates and deletes a Role.
+// For this call, we expect the URI to contain the BID, which is ignored, and the RoleID:
+//    /v1/role/:BUI/:RoleID
+//
+// The server command can be:
+//      get
+//      save
+//      delete
+//-----------------------------------------------------------------------------------
+func SvcHandlerRole(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	var (
+		funcname = "SvcHandlerRole"
+		err      error
+	)
+
+	fmt.Printf("Entered %s\n", funcname)
+	fmt.Printf("Request: %s:  RoleID = %d\n", d.wsSearchReq.Cmd, d.ID)
+
+	switch d.wsSearchReq.Cmd {
+	case "get":
+		getRole(w, r, d)
+		break
+	case "save":
+		saveRole(w, r, d)
+		break
+	case "delete":
+		deleteRole(w, r, d)
+		break
+	default:
+		err = fmt.Errorf("Unhandled command: %s", d.wsSearchReq.Cmd)
+		SvcGridErrorReturn(w, err, funcname)
+		return
+	}
+}
+
+// getRole returns the requested Role and its permissions
+// wsdoc {
+//  @Title  Get Role
+//	@URL /v1/role/:BUI/:RoleID
+//  @Method  GET
+//	@Synopsis Get a Role
+//  @Desc  This service returns the Role with id :RoleID and the services and
+//  @Desc  commands it may use.
+//	@Input WebGridSearchRequest
+//  @Response RoleGetResponse
+// wsdoc }
+func getRole(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	var (
+		funcname = "getRole"
+		g        RoleGetResponse
+	)
+
+	fmt.Printf("Entered %s\n", funcname)
+	a, err := rlib.GetRole(d.ID)
+	if err != nil {
+		e := fmt.Errorf("%s: Role %d not found", funcname, d.ID)
+		SvcGridErrorReturn(w, e, funcname)
+		return
+	}
+	g.Record = roleGridRecord(&a)
+	m := rlib.GetRolePermissions(a.RoleID)
+	for i := 0; i < len(m); i++ {
+		q := RolePermissionGrid{Recid: m[i].RPID, RPID: m[i].RPID, BID: m[i].BID, Service: m[i].Service, Cmd: m[i].Cmd}
+		if m[i].BID > 0 {
+			q.BUD = getBUDFromBIDList(m[i].BID)
+		}
+		g.Permissions = append(g.Permissions, q)
+	}
+	g.Status = "success"
+	SvcWriteResponse(&g, w)
+}
+
+// saveRole creates or updates a Role
+// wsdoc {
+//  @Title  Save Role
+//	@URL /v1/role/:BUI/:RoleID
+//  @Method  POST
+//	@Synopsis Create or update a Role
+//  @Description  If RoleID is 0 a new Role is created, otherwise Role RoleID is updated.
+//  @Description  The Role's permissions are replaced by Permissions. Each one names a
+//  @Description  business (empty for every business), a service and a command. Service
+//  @Description  and Cmd may be "*" to match any. The response contains the RoleID.
+//	@Input RoleInput
+//  @Response SvcStatusResponse
+// wsdoc }
+func saveRole(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	var (
+		funcname = "saveRole"
+		foo      RoleInput
+	)
+
+	fmt.Printf("Entered %s\n", funcname)
+	fmt.Printf("record data = %s\n", d.data)
+
+	if err := json.Unmarshal([]byte(d.data), &foo); err != nil {
+		e := fmt.Errorf("%s: Error with json.Unmarshal:  %s", funcname, err.Error())
+		SvcGridErrorReturn(w, e, funcname)
+		return
+	}
+
+	a := rlib.Role{RoleID: foo.Record.RoleID, Name: foo.Record.Name, Description: foo.Record.Description}
+	if a.RoleID == 0 {
+		a.RoleID = d.ID
+	}
+	var perms []rlib.RolePermission
+	for i := 0; i < len(foo.Record.Permissions); i++ {
+		p := rlib.RolePermission{Service: foo.Record.Permissions[i].Service, Cmd: foo.Record.Permissions[i].Cmd}
+		if len(foo.Record.Permissions[i].BUD) > 0 {
+			var ok bool
+			if p.BID, ok = rlib.RRdb.BUDlist[string(foo.Record.Permissions[i].BUD)]; !ok {
+				e := fmt.Errorf("%s: Could not map BID value: %s", funcname, foo.Record.Permissions[i].BUD)
+				SvcGridErrorReturn(w, e, funcname)
+				return
+			}
+		}
+		// permissions may only be granted in businesses where the requester may change roles
+		if p.BID != d.BID && !bizlogic.HasPermission(d.UID, p.BID, d.Service, d.wsSearchReq.Cmd) {
+			e := fmt.Errorf("%s: Permission denied for business %s", funcname, foo.Record.Permissions[i].BUD)
+			rlib.Ulog("%s: UID = %d\n", e.Error(), d.UID)
+			SvcGridErrorReturn(w, e, funcname)
+			return
+		}
+		perms = append(perms, p)
+	}
+
+	if errlist := bizlogic.SaveRole(&a, perms, d.UID); len(errlist) > 0 {
+		SvcErrListReturn(w, errlist, funcname)
+		return
+	}
+	SvcWriteSuccessResponseWithID(w, a.RoleID)
+}
+
+// deleteRole deletes a Role
+// wsdoc {
+//  @Title  Delete Role
+//	@URL /v1/role/:BUI/:RoleID
+//  @Method  POST
+//	@Synopsis Delete a Role
+//  @Description  Deletes Role :RoleID and its permissions, and takes it away from every
+//  @Description  user who has it.
+//	@Input WebGridSearchRequest
+//  @Response SvcStatusResponse
+// wsdoc }
+func deleteRole(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	funcname := "deleteRole"
+	fmt.Printf("Entered %s\n", funcname)
+
+	a, err := rlib.GetRole(d.ID)
+	if err != nil {
+		e := fmt.Errorf("%s: Role %d not found", funcname, d.ID)
+		SvcGridErrorReturn(w, e, funcname)
+		return
+	}
+	if err = rlib.DeleteRole(a.RoleID); err != nil {
+		SvcGridErrorReturn(w, err, funcname)
+		return
+	}
+	SvcWriteSuccessResponse(w)
+}
+
+// SvcHandlerUserRoles returns and sets the Roles of a phonebook user.
+// For this call, we expect the URI to contain the BID, which is ignored, and the UID:
+//    /v1/userroles/:BUI/:UID
+//
+// The server command can be:
+//      get
+//      save
+//-----------------------------------------------------------------------------------
+func SvcHandlerUserRoles(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	var (
+		funcname = "SvcHandlerUserRoles"
+		err      error
+	)
+
+	fmt.Printf("Entered %s\n", funcname)
+	fmt.Printf("Request: %s:  UID = %d\n", d.wsSearchReq.Cmd, d.ID)
+
+	switch d.wsSearchReq.Cmd {
+	case "get":
+		getUserRoles(w, r, d)
+		break
+	case "save":
+		saveUserRoles(w, r, d)
+		break
+	default:
+		err = fmt.Errorf("Unhandled command: %s", d.wsSearchReq.Cmd)
+		SvcGridErrorReturn(w, err, funcname)
+		return
+	}
+}
+
+// getUserRoles returns the Roles of a user
+// wsdoc {
+//  @Title  Get User Roles
+//	@URL /v1/userroles/:BUI/:UID
+//  @Method  POST
+//	@Synopsis Get the Roles of a user
+//  @Description  Returns the Roles of the phonebook user :UID.
+//	@Input WebGridSearchRequest
+//  @Response UserRolesResponse
+// wsdoc }
+func getUserRoles(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	var (
+		funcname = "getUserRoles"
+		g        UserRolesResponse
+	)
+
+	fmt.Printf("Entered %s\n", funcname)
+	m := rlib.GetUserRoles(d.ID)
+	for i := 0; i < len(m); i++ {
+		a, err := rlib.GetRole(m[i].RoleID)
+		if err != nil {
+			continue
+		}
+		g.Records = append(g.Records, roleGridRecord(&a))
+	}
+	g.Total = int64(len(g.Records))
+	g.Status = "success"
+	w.Header().Set("Content-Type", "application/json")
+	SvcWriteResponse(&g, w)
+}
+
+// saveUserRoles sets the Roles of a user
+// wsdoc {
+//  @Title  Save User Roles
+//	@URL /v1/userroles/:BUI/:UID
+//  @Method  POST
+//	@Synopsis Set the Roles of a user
+//  @Description  Replaces the Roles of phonebook user UID with RoleIDs. An empty list takes
+//  @Description  every Role away from the user. Permissions are enforced once any user has
+//  @Description  a Role, so give an administrator a Role that may use this service first.
+//	@Input UserRolesInput
+//  @Response SvcStatusResponse
+// wsdoc }
+func saveUserRoles(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	var (
+		funcname = "saveUserRoles"
+		foo      UserRolesInput
+	)
+
+	fmt.Printf("Entered %s\n", funcname)
+	fmt.Printf("record data = %s\n", d.data)
+
+	if err := json.Unmarshal([]byte(d.data), &foo); err != nil {
+		e := fmt.Errorf("%s: Error with json.Unmarshal:  %s", funcname, err.Error())
+		SvcGridErrorReturn(w, e, funcname)
+		return
+	}
+
+	uid := foo.Record.UID
+	if uid == 0 {
+		uid = d.ID
+	}
+	if errlist := bizlogic.SetUserRoles(uid, foo.Record.RoleIDs, d.UID); len(errlist) > 0 {
+		SvcErrListReturn(w, errlist, funcname)
+		return
+	}
+	SvcWriteSuccessResponseWithID(w, uid)
+}
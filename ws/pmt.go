@@ -268,6 +268,10 @@ func deletePaymentType(w http.ResponseWriter, r *http.Request, d *ServiceData) {
 		return
 	}
 
+	var old rlib.PaymentType
+	if rlib.GetPaymentType(del.ID, &old); !svcCheckBID(w, d, old.BID, funcname) {
+		return
+	}
 	if err = rlib.DeletePaymentType(del.ID); err != nil {
 		SvcGridErrorReturn(w, err, funcname)
 		return
@@ -309,11 +313,8 @@ func savePaymentType(w http.ResponseWriter, r *http.Request, d *ServiceData) {
 	rlib.MigrateStructVals(&foo.Record, &a) // the variables that don't need special handling
 
 	var ok bool
-	a.BID, ok = rlib.RRdb.BUDlist[string(foo.Record.BUD)]
+	a.BID, ok = getBIDFromBUD(w, d, foo.Record.BUD, funcname)
 	if !ok {
-		e := fmt.Errorf("%s: Could not map BID value: %s", funcname, foo.Record.BUD)
-		rlib.Ulog("%s", e.Error())
-		SvcGridErrorReturn(w, e, funcname)
 		return
 	}
 	if len(a.Name) == 0 {
@@ -336,6 +337,10 @@ func savePaymentType(w http.ResponseWriter, r *http.Request, d *ServiceData) {
 	} else {
 		// update existing record
 		fmt.Printf("Updating existing Payment Type: %d\n", a.PMTID)
+		var old rlib.PaymentType
+		if rlib.GetPaymentType(a.PMTID, &old); !svcCheckBID(w, d, old.BID, funcname) {
+			return
+		}
 		err = rlib.UpdatePaymentType(&a)
 	}
 
@@ -367,6 +372,9 @@ func getPaymentType(w http.ResponseWriter, r *http.Request, d *ServiceData) {
 	fmt.Printf("entered %s\n", funcname)
 	var a rlib.PaymentType
 	rlib.GetPaymentType(d.ID, &a)
+	if a.PMTID > 0 && !svcCheckBID(w, d, a.BID, funcname) {
+		return
+	}
 	if a.PMTID > 0 {
 		var gg PaymentTypeGrid
 		rlib.MigrateStructVals(&a, &gg)
@@ -0,0 +1,495 @@
+package ws
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"rentroll/bizlogic"
+	"rentroll/rlib"
+	"time"
+)
+
+// ProspectGrid describes a prospect in the pipeline
+type ProspectGrid struct {
+	Recid                  int64 `json:"recid"`
+	TCID                   int64
+	BID                    int64
+	BUD                    rlib.XJSONBud
+	Name                   string // the prospect's full name
+	Stage                  string // lead, applicant, approved, declined, converted
+	ApplicationFee         float64
+	DesiredUsageStartDate  rlib.JSONDate
+	RentableTypePreference int64
+	Approver               int64
+	DeclineReasonSLSID     int64
+	OutcomeSLSID           int64
+	FollowUpDate           rlib.JSONDate
+	FloatingDeposit        float64 // floating deposit held, not yet applied or refunded
+	RAID                   int64
+	LSID                   int64 // the LeadSource that produced this prospect
+	LastModTime            rlib.JSONDateTime
+	LastModBy              int64
+}
+
+// ProspectSearchResponse is the response to a request for the prospect pipeline
+type ProspectSearchResponse struct {
+	Status  string         `json:"status"`
+	Total   int64          `json:"total"`
+	Records []ProspectGrid `json:"records"`
+}
+
+// ProspectGetResponse is the response to a request for a single prospect
+type ProspectGetResponse struct {
+	Status string       `json:"status"`
+	Record ProspectGrid `json:"record"`
+}
+
+// ProspectDecisionForm contains the data from the Approve / Decline Applicant FORM
+type ProspectDecisionForm struct {
+	BUD          rlib.XJSONBud
+	TCID         int64 // the prospect
+	Approve      bool  // true = approve, false = decline
+	SLSID        int64 // the outcome or decline reason, from a StringList
+	FollowUpDate rlib.JSONDate
+}
+
+// ProspectDecisionInput is the input data format for a Save command
+type ProspectDecisionInput struct {
+	Status   string               `json:"status"`
+	Recid    int64                `json:"recid"`
+	FormName string               `json:"name"`
+	Record   ProspectDecisionForm `json:"record"`
+}
+
+// ProspectFeeForm contains the data from the Assess Application Fee FORM
+type ProspectFeeForm struct {
+	BUD  rlib.XJSONBud
+	TCID int64 // the prospect
+	ARID int64 // account rule for the assessment
+	Dt   rlib.JSONDate
+}
+
+// ProspectFeeInput is the input data format for a Save command
+type ProspectFeeInput struct {
+	Status   string          `json:"status"`
+	Recid    int64           `json:"recid"`
+	FormName string          `json:"name"`
+	Record   ProspectFeeForm `json:"record"`
+}
+
+// ProspectConvertForm contains the data from the Convert Applicant FORM
+type ProspectConvertForm struct {
+	BUD          rlib.XJSONBud
+	TCID         int64   // the prospect
+	RATID        int64   // Rental Agreement template
+	RID          int64   // the Rentable being rented
+	Users        []int64 // TCIDs of the users
+	DtStart      rlib.JSONDate
+	DtStop       rlib.JSONDate
+	ContractRent float64 // monthly rent, 0 = use the market rate
+	RentARID     int64
+	Deposit      float64
+	DepositARID  int64
+	Commissions  []CommissionForm // commissions earned, one with no salesperson goes to the prospect's CSAgent
+}
+
+// ProspectConvertInput is the input data format for a Save command
+type ProspectConvertInput struct {
+	Status   string              `json:"status"`
+	Recid    int64               `json:"recid"`
+	FormName string              `json:"name"`
+	Record   ProspectConvertForm `json:"record"`
+}
+
+// prospectGridRecord fills out a ProspectGrid from pipeline entry e
+func prospectGridRecord(e *bizlogic.ProspectEntry) ProspectGrid {
+	var q ProspectGrid
+	rlib.MigrateStructVals(&e.P, &q)
+	q.Recid = e.P.TCID
+	q.BUD = getBUDFromBIDList(e.P.BID)
+	q.Name = e.Name
+	q.Stage = bizlogic.ProspectStageNames[e.Stage]
+	return q
+}
+
+// SvcHandlerProspects lists the prospect pipeline.
+// For this call, we expect the URI to contain the BID:  /v1/prospects/:BUI
+//
+// The server command can be:
+//      get
+//-----------------------------------------------------------------------------------
+func SvcHandlerProspects(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	var (
+		funcname = "SvcHandlerProspects"
+		err      error
+	)
+
+	fmt.Printf("Entered %s\n", funcname)
+	fmt.Printf("Request: %s:  BID = %d\n", d.wsSearchReq.Cmd, d.BID)
+
+	switch d.wsSearchReq.Cmd {
+	case "get":
+		getProspects(w, r, d)
+		break
+	default:
+		err = fmt.Errorf("Unhandled command: %s", d.wsSearchReq.Cmd)
+		SvcGridErrorReturn(w, err, funcname)
+		return
+	}
+}
+
+// getProspects returns the prospect pipeline
+// wsdoc {
+//  @Title  Get Prospect Pipeline
+//	@URL /v1/prospects/:BUI
+//  @Method  POST
+//	@Synopsis Get the prospects by stage
+//  @Description  Returns the prospects of business :BUI. To list only the prospects in one
+//  @Description  stage, supply a search term with field "Stage" and one of the values lead,
+//  @Description  applicant, approved, declined or converted.
+//	@Input WebGridSearchRequest
+//  @Response ProspectSearchResponse
+// wsdoc }
+func getProspects(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	var (
+		funcname = "getProspects"
+		g        ProspectSearchResponse
+		stage    = -1
+	)
+
+	fmt.Printf("Entered %s\n", funcname)
+	for i := 0; i < len(d.wsSearchReq.Search); i++ {
+		if d.wsSearchReq.Search[i].Field == "Stage" {
+			if stage = bizlogic.ProspectStageFromName(d.wsSearchReq.Search[i].Value); stage < 0 {
+				e := fmt.Errorf("%s: unknown stage: %s", funcname, d.wsSearchReq.Search[i].Value)
+				SvcGridErrorReturn(w, e, funcname)
+				return
+			}
+		}
+	}
+	m := bizlogic.GetProspectPipeline(d.BID, stage)
+	for i := 0; i < len(m); i++ {
+		g.Records = append(g.Records, prospectGridRecord(&m[i]))
+	}
+	g.Total = int64(len(g.Records))
+	g.Status = "success"
+	w.Header().Set("Content-Type", "application/json")
+	SvcWriteResponse(&g, w)
+}
+
+// SvcHandlerProspectFollowUp lists the prospects due for a follow-up.
+// For this call, we expect the URI to contain the BID:  /v1/prospectfollowup/:BUI
+//
+// The server command can be:
+//      get
+//-----------------------------------------------------------------------------------
+func SvcHandlerProspectFollowUp(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	var (
+		funcname = "SvcHandlerProspectFollowUp"
+		err      error
+	)
+
+	fmt.Printf("Entered %s\n", funcname)
+	fmt.Printf("Request: %s:  BID = %d\n", d.wsSearchReq.Cmd, d.BID)
+
+	switch d.wsSearchReq.Cmd {
+	case "get":
+		getProspectFollowUps(w, r, d)
+		break
+	default:
+		err = fmt.Errorf("Unhandled command: %s", d.wsSearchReq.Cmd)
+		SvcGridErrorReturn(w, err, funcname)
+		return
+	}
+}
+
+// getProspectFollowUps returns the follow-up queue
+// wsdoc {
+//  @Title  Get Prospect Follow-Up Queue
+//	@URL /v1/prospectfollowup/:BUI
+//  @Method  POST
+//	@Synopsis Get the prospects due for a follow-up
+//  @Description  Returns the open prospects of business :BUI whose FollowUpDate is on or
+//  @Description  before searchDtStop, or before now if searchDtStop is not supplied. The
+//  @Description  oldest follow-up is first. Declined and converted prospects are not included.
+//	@Input WebGridSearchRequest
+//  @Response ProspectSearchResponse
+// wsdoc }
+func getProspectFollowUps(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	var (
+		funcname = "getProspectFollowUps"
+		g        ProspectSearchResponse
+	)
+
+	fmt.Printf("Entered %s\n", funcname)
+	dt := d.wsSearchReq.SearchDtStop
+	if dt.Year() <= 1970 {
+		dt = time.Now()
+	}
+	m := bizlogic.GetProspectFollowUps(d.BID, &dt)
+	for i := 0; i < len(m); i++ {
+		g.Records = append(g.Records, prospectGridRecord(&m[i]))
+	}
+	g.Total = int64(len(g.Records))
+	g.Status = "success"
+	w.Header().Set("Content-Type", "application/json")
+	SvcWriteResponse(&g, w)
+}
+
+// SvcHandlerProspect returns a prospect and records approve / decline decisions.
+// For this call, we expect the URI to contain the BID and the TCID:
+//    /v1/prospect/:BUI/:TCID
+//
+// The server command can be:
+//      get
+//      save
+//-----------------------------------------------------------------------------------
+func SvcHandlerProspect(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	var (
+		funcname = "SvcHandlerProspect"
+		err      error
+	)
+
+	fmt.Printf("Entered %s\n", funcname)
+	fmt.Printf("Request: %s:  BID = %d,  TCID = %d\n", d.wsSearchReq.Cmd, d.BID, d.ID)
+
+	switch d.wsSearchReq.Cmd {
+	case "get":
+		getProspect(w, r, d)
+		break
+	case "save":
+		saveProspectDecision(w, r, d)
+		break
+	default:
+		err = fmt.Errorf("Unhandled command: %s", d.wsSearchReq.Cmd)
+		SvcGridErrorReturn(w, err, funcname)
+		return
+	}
+}
+
+// getProspect returns the requested prospect
+// wsdoc {
+//  @Title  Get Prospect
+//	@URL /v1/prospect/:BUI/:TCID
+//  @Method  GET
+//	@Synopsis Get a prospect and its pipeline stage
+//  @Desc  This service returns the prospect with transactant id :TCID.
+//	@Input WebGridSearchRequest
+//  @Response ProspectGetResponse
+// wsdoc }
+func getProspect(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	var (
+		funcname = "getProspect"
+		g        ProspectGetResponse
+	)
+
+	fmt.Printf("Entered %s\n", funcname)
+	var e bizlogic.ProspectEntry
+	rlib.GetProspect(d.ID, &e.P)
+	if e.P.TCID == 0 || e.P.BID != d.BID {
+		err := fmt.Errorf("%s: prospect %d not found", funcname, d.ID)
+		SvcGridErrorReturn(w, err, funcname)
+		return
+	}
+	var t rlib.Transactant
+	rlib.GetTransactant(e.P.TCID, &t)
+	e.Name = t.GetFullTransactantName()
+	e.Stage = bizlogic.ProspectStage(&e.P)
+	g.Record = prospectGridRecord(&e)
+	g.Status = "success"
+	SvcWriteResponse(&g, w)
+}
+
+// saveProspectDecision approves or declines an applicant
+// wsdoc {
+//  @Title  Approve Or Decline Applicant
+//	@URL /v1/prospect/:BUI/:TCID
+//  @Method  POST
+//	@Synopsis Record the screening decision for an applicant
+//  @Description  Approves the prospect if Approve is true, otherwise declines it. SLSID is the
+//  @Description  id of a string from one of the business's StringLists. It is the outcome for an
+//  @Description  approval and the decline reason, which is required, for a decline. The
+//  @Description  requesting user is recorded as the Approver.
+//	@Input ProspectDecisionInput
+//  @Response SvcStatusResponse
+// wsdoc }
+func saveProspectDecision(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	var (
+		funcname = "saveProspectDecision"
+		foo      ProspectDecisionInput
+	)
+
+	fmt.Printf("Entered %s\n", funcname)
+	fmt.Printf("record data = %s\n", d.data)
+
+	if err := json.Unmarshal([]byte(d.data), &foo); err != nil {
+		e := fmt.Errorf("%s: Error with json.Unmarshal:  %s", funcname, err.Error())
+		SvcGridErrorReturn(w, e, funcname)
+		return
+	}
+
+	var x bizlogic.ProspectDecision
+	rlib.MigrateStructVals(&foo.Record, &x) // the variables that don't need special handling
+
+	var ok bool
+	x.BID, ok = getBIDFromBUD(w, d, foo.Record.BUD, funcname)
+	if !ok {
+		return
+	}
+	if x.TCID == 0 {
+		x.TCID = d.ID
+	}
+	x.FollowUpDate = time.Time(foo.Record.FollowUpDate)
+	x.UID = d.UID
+
+	if errlist := bizlogic.DecideProspect(&x); len(errlist) > 0 {
+		SvcErrListReturn(w, errlist, funcname)
+		return
+	}
+	SvcWriteSuccessResponseWithID(w, x.TCID)
+}
+
+// SvcHandlerProspectFee assesses the application fee of a prospect.
+// For this call, we expect the URI to contain the BID:  /v1/prospectfee/:BUI
+//
+// The server command can be:
+//      save
+//-----------------------------------------------------------------------------------
+func SvcHandlerProspectFee(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	var (
+		funcname = "SvcHandlerProspectFee"
+		err      error
+	)
+
+	fmt.Printf("Entered %s\n", funcname)
+	fmt.Printf("Request: %s:  BID = %d\n", d.wsSearchReq.Cmd, d.BID)
+
+	switch d.wsSearchReq.Cmd {
+	case "save":
+		saveProspectFee(w, r, d)
+		break
+	default:
+		err = fmt.Errorf("Unhandled command: %s", d.wsSearchReq.Cmd)
+		SvcGridErrorReturn(w, err, funcname)
+		return
+	}
+}
+
+// saveProspectFee assesses the application fee of a prospect
+// wsdoc {
+//  @Title  Assess Application Fee
+//	@URL /v1/prospectfee/:BUI
+//  @Method  POST
+//	@Synopsis Assess the ApplicationFee of a prospect
+//  @Description  Assesses the ApplicationFee of prospect TCID on Dt using account rule ARID.
+//  @Description  The fee is assessed on a Rental Agreement that holds the prospect's charges
+//  @Description  until the prospect is converted. The fee can only be assessed once. The
+//  @Description  response contains the ASMID.
+//	@Input ProspectFeeInput
+//  @Response SvcStatusResponse
+// wsdoc }
+func saveProspectFee(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	var (
+		funcname = "saveProspectFee"
+		foo      ProspectFeeInput
+	)
+
+	fmt.Printf("Entered %s\n", funcname)
+	fmt.Printf("record data = %s\n", d.data)
+
+	if err := json.Unmarshal([]byte(d.data), &foo); err != nil {
+		e := fmt.Errorf("%s: Error with json.Unmarshal:  %s", funcname, err.Error())
+		SvcGridErrorReturn(w, e, funcname)
+		return
+	}
+
+	bid, ok := getBIDFromBUD(w, d, foo.Record.BUD, funcname)
+	if !ok {
+		return
+	}
+	dt := time.Time(foo.Record.Dt)
+
+	a, errlist := bizlogic.AssessApplicationFee(bid, foo.Record.TCID, foo.Record.ARID, &dt, d.UID)
+	if len(errlist) > 0 {
+		SvcErrListReturn(w, errlist, funcname)
+		return
+	}
+	SvcWriteSuccessResponseWithID(w, a.ASMID)
+}
+
+// SvcHandlerProspectConvert converts an approved applicant into a Rental Agreement.
+// For this call, we expect the URI to contain the BID:  /v1/prospectconvert/:BUI
+//
+// The server command can be:
+//      save
+//-----------------------------------------------------------------------------------
+func SvcHandlerProspectConvert(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	var (
+		funcname = "SvcHandlerProspectConvert"
+		err      error
+	)
+
+	fmt.Printf("Entered %s\n", funcname)
+	fmt.Printf("Request: %s:  BID = %d\n", d.wsSearchReq.Cmd, d.BID)
+
+	switch d.wsSearchReq.Cmd {
+	case "save":
+		saveProspectConvert(w, r, d)
+		break
+	default:
+		err = fmt.Errorf("Unhandled command: %s", d.wsSearchReq.Cmd)
+		SvcGridErrorReturn(w, err, funcname)
+		return
+	}
+}
+
+// saveProspectConvert converts an approved applicant into a Rental Agreement
+// wsdoc {
+//  @Title  Convert Applicant
+//	@URL /v1/prospectconvert/:BUI
+//  @Method  POST
+//	@Synopsis Convert an approved applicant into a Rental Agreement
+//  @Description  Moves approved prospect TCID into Rentable RID as the payor of a Rental
+//  @Description  Agreement from DtStart to DtStop, assessing the first month's rent and the
+//  @Description  security deposit as a move-in does. Charges already made to the prospect,
+//  @Description  such as the application fee, stay with the new agreement. The response
+//  @Description  contains the RAID.
+//	@Input ProspectConvertInput
+//  @Response SvcStatusResponse
+// wsdoc }
+func saveProspectConvert(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	var (
+		funcname = "saveProspectConvert"
+		foo      ProspectConvertInput
+	)
+
+	fmt.Printf("Entered %s\n", funcname)
+	fmt.Printf("record data = %s\n", d.data)
+
+	if err := json.Unmarshal([]byte(d.data), &foo); err != nil {
+		e := fmt.Errorf("%s: Error with json.Unmarshal:  %s", funcname, err.Error())
+		SvcGridErrorReturn(w, e, funcname)
+		return
+	}
+
+	var x bizlogic.ProspectConversion
+	rlib.MigrateStructVals(&foo.Record, &x) // the variables that don't need special handling
+
+	var ok bool
+	x.BID, ok = getBIDFromBUD(w, d, foo.Record.BUD, funcname)
+	if !ok {
+		return
+	}
+	x.Users = foo.Record.Users
+	x.DtStart = time.Time(foo.Record.DtStart)
+	x.DtStop = time.Time(foo.Record.DtStop)
+	x.Commissions = commissionsFromForm(foo.Record.Commissions)
+	x.UID = d.UID
+
+	ra, errlist := bizlogic.ConvertProspect(&x)
+	if len(errlist) > 0 {
+		SvcErrListReturn(w, errlist, funcname)
+		return
+	}
+	SvcWriteSuccessResponseWithID(w, ra.RAID)
+}
@@ -502,9 +502,15 @@ func saveRentalAgreement(w http.ResponseWriter, r *http.Request, d *ServiceData)
 	//===============================================================
 
 	fmt.Printf("Update complete:  RA = %#v\n", a)
+	if !svcCheckBID(w, d, a.BID, funcname) {
+		return
+	}
 
 	// Now just update the database
 	if a.RAID > 0 {
+		if !svcCheckRAID(w, d, a.RAID, funcname) {
+			return
+		}
 		err = rlib.UpdateRentalAgreement(&a)
 	} else {
 		_, err = rlib.InsertRentalAgreement(&a)
@@ -544,6 +550,9 @@ func getRentalAgreement(w http.ResponseWriter, r *http.Request, d *ServiceData)
 		SvcGridErrorReturn(w, e, funcname)
 		return
 	}
+	if a.RAID > 0 && !svcCheckBID(w, d, a.BID, funcname) {
+		return
+	}
 	if a.RAID > 0 {
 		var gg RentalAgr
 		rlib.MigrateStructVals(&a, &gg)
@@ -587,6 +596,9 @@ func deleteRentalAgreement(w http.ResponseWriter, r *http.Request, d *ServiceDat
 		SvcGridErrorReturn(w, err, funcname)
 		return
 	}
+	if !svcCheckBID(w, d, ra.BID, funcname) {
+		return
+	}
 
 	// remove all pets associated with this rental Agreement
 	if err = rlib.DeleteAllRentalAgreementPets(delRAID); err != nil {
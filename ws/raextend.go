@@ -0,0 +1,101 @@
+package ws
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"rentroll/bizlogic"
+	"rentroll/rlib"
+	"time"
+)
+
+// RAExtendForm contains the data from the Extend Rental Agreement FORM
+type RAExtendForm struct {
+	BUD        rlib.XJSONBud
+	RAID       int64 // the Rental Agreement
+	NewTerm    bool  // true = renew as a new agreement, false = extend the existing one
+	DtStop     rlib.JSONDate
+	RateChange float64 // change to the rent, as a percentage
+}
+
+// RAExtendInput is the input data format for a Save command
+type RAExtendInput struct {
+	Status   string       `json:"status"`
+	Recid    int64        `json:"recid"`
+	FormName string       `json:"name"`
+	Record   RAExtendForm `json:"record"`
+}
+
+// SvcHandlerRAExtend extends or renews a Rental Agreement.
+// For this call, we expect the URI to contain the BID:  /v1/raextend/:BUI
+//
+// The server command can be:
+//      save
+//-----------------------------------------------------------------------------------
+func SvcHandlerRAExtend(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	var (
+		funcname = "SvcHandlerRAExtend"
+		err      error
+	)
+
+	fmt.Printf("Entered %s\n", funcname)
+	fmt.Printf("Request: %s:  BID = %d\n", d.wsSearchReq.Cmd, d.BID)
+
+	switch d.wsSearchReq.Cmd {
+	case "save":
+		saveRAExtend(w, r, d)
+		break
+	default:
+		err = fmt.Errorf("Unhandled command: %s", d.wsSearchReq.Cmd)
+		SvcGridErrorReturn(w, err, funcname)
+		return
+	}
+}
+
+// saveRAExtend extends or renews a Rental Agreement
+// wsdoc {
+//  @Title  Extend Rental Agreement
+//	@URL /v1/raextend/:BUI
+//  @Method  POST
+//	@Synopsis Extend or renew a Rental Agreement
+//  @Description  Extends Rental Agreement RAID to DtStop or, if NewTerm is true, renews it as a
+//  @Description  new agreement that starts when RAID ends and runs to DtStop. The Rentables, payors,
+//  @Description  users and open-ended recurring assessments are carried forward. If RateChange is
+//  @Description  not 0 the rent for the new term is changed by that percentage. The response
+//  @Description  contains the RAID of the new term.
+//	@Input RAExtendInput
+//  @Response SvcStatusResponse
+// wsdoc }
+func saveRAExtend(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	var (
+		funcname = "saveRAExtend"
+		foo      RAExtendInput
+	)
+
+	fmt.Printf("Entered %s\n", funcname)
+	fmt.Printf("record data = %s\n", d.data)
+
+	if err := json.Unmarshal([]byte(d.data), &foo); err != nil {
+		e := fmt.Errorf("%s: Error with json.Unmarshal:  %s", funcname, err.Error())
+		SvcGridErrorReturn(w, e, funcname)
+		return
+	}
+
+	var x bizlogic.RAExtension
+	rlib.MigrateStructVals(&foo.Record, &x) // the variables that don't need special handling
+
+	var ok bool
+	x.BID, ok = getBIDFromBUD(w, d, foo.Record.BUD, funcname)
+	if !ok {
+		return
+	}
+	x.DtStop = time.Time(foo.Record.DtStop)
+	x.UID = d.UID
+
+	ra, errlist := bizlogic.ExtendRentalAgreement(&x)
+	if len(errlist) > 0 {
+		SvcErrListReturn(w, errlist, funcname)
+		return
+	}
+	SvcWriteSuccessResponseWithID(w, ra.RAID)
+}
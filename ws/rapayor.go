@@ -159,6 +159,9 @@ func deleteRAPayor(w http.ResponseWriter, r *http.Request, d *ServiceData) {
 		return
 	}
 
+	if !svcCheckRAID(w, d, d.RAID, funcname) {
+		return
+	}
 	dtStart := time.Time(del.DtStart)
 	dtStop := time.Time(del.DtStop)
 
@@ -219,6 +222,9 @@ func saveRAPayor(w http.ResponseWriter, r *http.Request, d *ServiceData) {
 
 	fmt.Printf("saveRAPayor - first migrate: a = RAID = %d, BID = %d, TCID = %d, DtStart = %s, DtStop = %s\n",
 		a.RAID, a.BID, a.TCID, a.DtStart.Format(rlib.RRDATEFMT3), a.DtStop.Format(rlib.RRDATEFMT3))
+	if !svcCheckBID(w, d, a.BID, funcname) || !svcCheckRAID(w, d, a.RAID, funcname) {
+		return
+	}
 
 	// Try to read an existing record...
 	m := rlib.GetRentalAgreementPayorsInRange(a.RAID, &a.DtStart, &a.DtStop)
@@ -277,6 +283,9 @@ func SvcUpdateRAPayor(w http.ResponseWriter, r *http.Request, d *ServiceData) {
 			return
 		}
 		fmt.Printf("Found rapayor: %#v\n", rapayor)
+		if !svcCheckBID(w, d, rapayor.BID, funcname) {
+			return
+		}
 		dt := time.Time(foo.Changes[i].DtStart)
 		if dt.Year() > 1969 {
 			rapayor.DtStart = dt
@@ -327,6 +336,9 @@ func SvcGetRAPayor(w http.ResponseWriter, r *http.Request, d *ServiceData) {
 		gxp      RAPayorResponse
 	)
 	fmt.Printf("Entered %s\n", funcname)
+	if !svcCheckRAID(w, d, d.RAID, funcname) {
+		return
+	}
 
 	m := rlib.GetRentalAgreementPayorsInRange(d.RAID, &d.Dt, &d.Dt)
 	for i := 0; i < len(m); i++ {
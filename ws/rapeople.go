@@ -95,6 +95,9 @@ func SvcGetRAPeople(ptype string, w http.ResponseWriter, r *http.Request, d *Ser
 	// Get the transactants... either payors or users...
 	//------------------------------------------------------
 	var gxp RAPeopleResponse
+	if !svcCheckRAID(w, d, d.RAID, "SvcGetRAPeople") {
+		return
+	}
 	if ptype == "rapayor" {
 		m := rlib.GetRentalAgreementPayorsInRange(d.RAID, &d.Dt, &d.Dt)
 		for i := 0; i < len(m); i++ {
@@ -88,6 +88,9 @@ func SvcRAPets(w http.ResponseWriter, r *http.Request, d *ServiceData) {
 	var gxp RAPets
 	var m []rlib.RentalAgreementPet
 	if raid > 0 {
+		if !svcCheckRAID(w, d, raid, funcname) {
+			return
+		}
 		m = rlib.GetAllRentalAgreementPets(raid)
 	}
 
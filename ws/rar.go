@@ -173,6 +173,9 @@ func saveRARentable(w http.ResponseWriter, r *http.Request, d *ServiceData) {
 
 	fmt.Printf("saveRARentable: a = RARID = %d, RAID = %d, BID = %d, RID = %d, ContractRent = %8.2f, DtStart = %s, DtStop = %s\n",
 		a.RARID, a.RAID, a.BID, a.RID, a.ContractRent, a.RARDtStart.Format(rlib.RRDATEFMT3), a.RARDtStop.Format(rlib.RRDATEFMT3))
+	if !svcCheckBID(w, d, a.BID, funcname) || !svcCheckRAID(w, d, a.RAID, funcname) || !svcCheckRID(w, d, a.RID, funcname) {
+		return
+	}
 
 	m := rlib.GetRentalAgreementRentables(d.RAID, &a.RARDtStart, &a.RARDtStop)
 	for i := 0; i < len(m); i++ {
@@ -220,6 +223,9 @@ func SvcUpdateRARentable(w http.ResponseWriter, r *http.Request, d *ServiceData)
 			SvcGridErrorReturn(w, e, funcname)
 			return
 		}
+		if !svcCheckBID(w, d, rec.BID, funcname) {
+			return
+		}
 		// The only updates allowed are to the dates and the amount.  We check those directly...
 		dt := time.Time(foo.Changes[i].RARDtStart)
 		if dt.Year() > 1969 {
@@ -272,6 +278,11 @@ func deleteRARentable(w http.ResponseWriter, r *http.Request, d *ServiceData) {
 		return
 	}
 
+	for i := 0; i < len(del.Selected); i++ {
+		if rar, _ := rlib.GetRentalAgreementRentable(del.Selected[i]); !svcCheckBID(w, d, rar.BID, funcname) {
+			return
+		}
+	}
 	for i := 0; i < len(del.Selected); i++ {
 		if err := rlib.DeleteRentalAgreementRentable(del.Selected[i]); err != nil {
 			SvcGridErrorReturn(w, err, funcname)
@@ -303,6 +314,9 @@ func GetRARentables(w http.ResponseWriter, r *http.Request, d *ServiceData) {
 	var m []rlib.RentalAgreementRentable
 	var rar RARList
 	if d.ID > 0 {
+		if !svcCheckRAID(w, d, d.ID, funcname) {
+			return
+		}
 		m = rlib.GetRentalAgreementRentables(d.ID, &d.Dt, &d.Dt)
 		fmt.Printf("d.ID = %d, d.DT = %s, len(m) = %d\n", d.ID, d.Dt.Format(rlib.RRDATEFMT3), len(m))
 		for i := 0; i < len(m); i++ {
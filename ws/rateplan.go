@@ -0,0 +1,455 @@
+package ws
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"rentroll/bizlogic"
+	"rentroll/rlib"
+	"time"
+)
+
+// RatePlanGrid describes a RatePlan
+type RatePlanGrid struct {
+	Recid       int64 `json:"recid"`
+	RPID        int64
+	BID         int64
+	BUD         rlib.XJSONBud
+	Name        string
+	LastModTime rlib.JSONDateTime
+	LastModBy   int64
+	CreateTS    rlib.JSONDateTime
+	CreateBy    int64
+}
+
+// RatePlanSearchResponse is the response to a request for the list of RatePlans
+type RatePlanSearchResponse struct {
+	Status  string         `json:"status"`
+	Total   int64          `json:"total"`
+	Records []RatePlanGrid `json:"records"`
+}
+
+// RatePlanDetail is a RatePlan and all of its RatePlanRefs
+type RatePlanDetail struct {
+	RatePlanGrid
+	Refs []RatePlanRefGrid
+}
+
+// RatePlanGetResponse is the response to a request for a single RatePlan
+type RatePlanGetResponse struct {
+	Status string         `json:"status"`
+	Record RatePlanDetail `json:"record"`
+}
+
+// RatePlanForm contains the data from the RatePlan FORM
+type RatePlanForm struct {
+	BUD  rlib.XJSONBud
+	RPID int64
+	Name string
+}
+
+// RatePlanInput is the input data format for a Save command
+type RatePlanInput struct {
+	Status   string       `json:"status"`
+	Recid    int64        `json:"recid"`
+	FormName string       `json:"name"`
+	Record   RatePlanForm `json:"record"`
+}
+
+// RatePlanRefGrid describes a RatePlanRef and its rates
+type RatePlanRefGrid struct {
+	Recid             int64 `json:"recid"`
+	RPRID             int64
+	BID               int64
+	BUD               rlib.XJSONBud
+	RPID              int64
+	DtStart           rlib.JSONDate
+	DtStop            rlib.JSONDate
+	FeeAppliesAge     int64
+	MaxNoFeeUsers     int64
+	AdditionalUserFee float64
+	PromoCode         string
+	CancellationFee   float64
+	FLAGS             uint64
+	RT                []rlib.RatePlanRefRTRate // RentableType rates
+	SP                []rlib.RatePlanRefSPRate // Specialty rates
+	LastModTime       rlib.JSONDateTime
+	LastModBy         int64
+	CreateTS          rlib.JSONDateTime
+	CreateBy          int64
+}
+
+// RatePlanRefGetResponse is the response to a request for a single RatePlanRef
+type RatePlanRefGetResponse struct {
+	Status string          `json:"status"`
+	Record RatePlanRefGrid `json:"record"`
+}
+
+// RatePlanRefInput is the input data format for a Save command
+type RatePlanRefInput struct {
+	Status   string          `json:"status"`
+	Recid    int64           `json:"recid"`
+	FormName string          `json:"name"`
+	Record   RatePlanRefGrid `json:"record"`
+}
+
+// SvcHandlerRatePlan lists, returns, saves, and deletes RatePlans.
+// For this call, we expect the URI to contain the BID and possibly the RPID:
+//       /v1/rateplan/:BUI/[RPID]
+//
+// The server command can be:
+//      get
+//      save
+//      delete
+//-----------------------------------------------------------------------------------
+func SvcHandlerRatePlan(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	var (
+		funcname = "SvcHandlerRatePlan"
+		err      error
+	)
+
+	fmt.Printf("Entered %s\n", funcname)
+	fmt.Printf("Request: %s:  BID = %d,  RPID = %d\n", d.wsSearchReq.Cmd, d.BID, d.ID)
+
+	switch d.wsSearchReq.Cmd {
+	case "get":
+		if d.ID > 0 {
+			getRatePlan(w, r, d)
+		} else {
+			getRatePlans(w, r, d)
+		}
+		break
+	case "save":
+		saveRatePlan(w, r, d)
+		break
+	case "delete":
+		deleteRatePlan(w, r, d)
+		break
+	default:
+		err = fmt.Errorf("Unhandled command: %s", d.wsSearchReq.Cmd)
+		SvcGridErrorReturn(w, err, funcname)
+		return
+	}
+}
+
+// ratePlanToGrid fills in the grid record for RatePlan a
+func ratePlanToGrid(a *rlib.RatePlan) RatePlanGrid {
+	var q RatePlanGrid
+	rlib.MigrateStructVals(a, &q)
+	q.Recid = a.RPID
+	q.BUD = getBUDFromBIDList(a.BID)
+	return q
+}
+
+// ratePlanRefToGrid fills in the grid record for RatePlanRef a
+func ratePlanRefToGrid(a *rlib.RatePlanRef) RatePlanRefGrid {
+	var q RatePlanRefGrid
+	rlib.MigrateStructVals(a, &q)
+	q.Recid = a.RPRID
+	q.BUD = getBUDFromBIDList(a.BID)
+	q.RT = a.RT
+	q.SP = a.SP
+	return q
+}
+
+// getRatePlans returns the RatePlans of the business
+// wsdoc {
+//  @Title  Get Rate Plans
+//	@URL /v1/rateplan/:BUI
+//  @Method  POST
+//	@Synopsis Get the Rate Plans of a business
+//  @Description  Returns all the Rate Plans for business :BUI.
+//	@Input WebGridSearchRequest
+//  @Response RatePlanSearchResponse
+// wsdoc }
+func getRatePlans(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	var (
+		funcname = "getRatePlans"
+		g        RatePlanSearchResponse
+	)
+
+	fmt.Printf("Entered %s\n", funcname)
+	m := rlib.GetAllRatePlans(d.BID)
+	for i := 0; i < len(m); i++ {
+		g.Records = append(g.Records, ratePlanToGrid(&m[i]))
+	}
+	g.Total = int64(len(g.Records))
+	g.Status = "success"
+	w.Header().Set("Content-Type", "application/json")
+	SvcWriteResponse(&g, w)
+}
+
+// getRatePlan returns the requested RatePlan
+// wsdoc {
+//  @Title  Get Rate Plan
+//	@URL /v1/rateplan/:BUI/:RPID
+//  @Method  GET
+//	@Synopsis Get a Rate Plan and its rates
+//  @Description  Return Rate Plan :RPID and all of its Rate Plan Refs, each with its
+//  @Description  Rentable Type and Specialty rates.
+//	@Input WebGridSearchRequest
+//  @Response RatePlanGetResponse
+// wsdoc }
+func getRatePlan(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	var (
+		funcname = "getRatePlan"
+		g        RatePlanGetResponse
+		a        rlib.RatePlan
+	)
+
+	fmt.Printf("Entered %s\n", funcname)
+	rlib.GetRatePlan(d.ID, &a)
+	if a.RPID == 0 || a.BID != d.BID {
+		e := fmt.Errorf("%s: Rate Plan %d not found", funcname, d.ID)
+		SvcGridErrorReturn(w, e, funcname)
+		return
+	}
+	g.Record.RatePlanGrid = ratePlanToGrid(&a)
+	m := rlib.GetAllRatePlanRefs(a.RPID)
+	for i := 0; i < len(m); i++ {
+		rlib.GetRatePlanRefFull(m[i].RPRID, &m[i])
+		g.Record.Refs = append(g.Record.Refs, ratePlanRefToGrid(&m[i]))
+	}
+	g.Status = "success"
+	SvcWriteResponse(&g, w)
+}
+
+// saveRatePlan creates or updates a RatePlan
+// wsdoc {
+//  @Title  Save Rate Plan
+//	@URL /v1/rateplan/:BUI/[RPID]
+//  @Method  POST
+//	@Synopsis Create or update a Rate Plan
+//  @Description  Creates a new Rate Plan if RPID is 0, otherwise updates Rate Plan RPID.
+//  @Description  The name must be unique within the business. The response contains the RPID.
+//	@Input RatePlanInput
+//  @Response SvcStatusResponse
+// wsdoc }
+func saveRatePlan(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	var (
+		funcname = "saveRatePlan"
+		foo      RatePlanInput
+	)
+
+	fmt.Printf("Entered %s\n", funcname)
+	fmt.Printf("record data = %s\n", d.data)
+
+	if err := json.Unmarshal([]byte(d.data), &foo); err != nil {
+		e := fmt.Errorf("%s: Error with json.Unmarshal:  %s", funcname, err.Error())
+		SvcGridErrorReturn(w, e, funcname)
+		return
+	}
+
+	var a rlib.RatePlan
+	rlib.MigrateStructVals(&foo.Record, &a) // the variables that don't need special handling
+
+	var ok bool
+	a.BID, ok = getBIDFromBUD(w, d, foo.Record.BUD, funcname)
+	if !ok {
+		return
+	}
+	if a.RPID > 0 {
+		var b rlib.RatePlan
+		rlib.GetRatePlan(a.RPID, &b)
+		if b.BID != a.BID {
+			e := fmt.Errorf("%s: Rate Plan %d not found", funcname, a.RPID)
+			SvcGridErrorReturn(w, e, funcname)
+			return
+		}
+		a.CreateBy = b.CreateBy
+	} else {
+		a.CreateBy = d.UID
+	}
+	a.LastModBy = d.UID
+
+	if errlist := bizlogic.SaveRatePlan(&a); len(errlist) > 0 {
+		SvcErrListReturn(w, errlist, funcname)
+		return
+	}
+	SvcWriteSuccessResponseWithID(w, a.RPID)
+}
+
+// deleteRatePlan deletes a RatePlan
+// wsdoc {
+//  @Title  Delete Rate Plan
+//	@URL /v1/rateplan/:BUI/:RPID
+//  @Method  POST
+//	@Synopsis Delete a Rate Plan
+//  @Description  Deletes Rate Plan :RPID along with all of its Rate Plan Refs and their rates.
+//	@Input WebGridSearchRequest
+//  @Response SvcStatusResponse
+// wsdoc }
+func deleteRatePlan(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	var (
+		funcname = "deleteRatePlan"
+		a        rlib.RatePlan
+	)
+
+	fmt.Printf("Entered %s\n", funcname)
+	rlib.GetRatePlan(d.ID, &a)
+	if a.RPID == 0 || a.BID != d.BID {
+		e := fmt.Errorf("%s: Rate Plan %d not found", funcname, d.ID)
+		SvcGridErrorReturn(w, e, funcname)
+		return
+	}
+	if errlist := bizlogic.DeleteRatePlan(a.RPID); len(errlist) > 0 {
+		SvcErrListReturn(w, errlist, funcname)
+		return
+	}
+	SvcWriteSuccessResponse(w)
+}
+
+// SvcHandlerRatePlanRef returns, saves, and deletes RatePlanRefs.
+// For this call, we expect the URI to contain the BID and possibly the RPRID:
+//       /v1/rateplanref/:BUI/[RPRID]
+//
+// The server command can be:
+//      get
+//      save
+//      delete
+//-----------------------------------------------------------------------------------
+func SvcHandlerRatePlanRef(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	var (
+		funcname = "SvcHandlerRatePlanRef"
+		err      error
+	)
+
+	fmt.Printf("Entered %s\n", funcname)
+	fmt.Printf("Request: %s:  BID = %d,  RPRID = %d\n", d.wsSearchReq.Cmd, d.BID, d.ID)
+
+	switch d.wsSearchReq.Cmd {
+	case "get":
+		getRatePlanRef(w, r, d)
+		break
+	case "save":
+		saveRatePlanRef(w, r, d)
+		break
+	case "delete":
+		deleteRatePlanRef(w, r, d)
+		break
+	default:
+		err = fmt.Errorf("Unhandled command: %s", d.wsSearchReq.Cmd)
+		SvcGridErrorReturn(w, err, funcname)
+		return
+	}
+}
+
+// getRatePlanRef returns the requested RatePlanRef
+// wsdoc {
+//  @Title  Get Rate Plan Ref
+//	@URL /v1/rateplanref/:BUI/:RPRID
+//  @Method  GET
+//	@Synopsis Get a Rate Plan Ref and its rates
+//  @Description  Return Rate Plan Ref :RPRID with its Rentable Type and Specialty rates.
+//	@Input WebGridSearchRequest
+//  @Response RatePlanRefGetResponse
+// wsdoc }
+func getRatePlanRef(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	var (
+		funcname = "getRatePlanRef"
+		g        RatePlanRefGetResponse
+		a        rlib.RatePlanRef
+	)
+
+	fmt.Printf("Entered %s\n", funcname)
+	rlib.GetRatePlanRefFull(d.ID, &a)
+	if a.RPRID == 0 || a.BID != d.BID {
+		e := fmt.Errorf("%s: Rate Plan Ref %d not found", funcname, d.ID)
+		SvcGridErrorReturn(w, e, funcname)
+		return
+	}
+	g.Record = ratePlanRefToGrid(&a)
+	g.Status = "success"
+	SvcWriteResponse(&g, w)
+}
+
+// saveRatePlanRef creates or updates a RatePlanRef
+// wsdoc {
+//  @Title  Save Rate Plan Ref
+//	@URL /v1/rateplanref/:BUI/[RPRID]
+//  @Method  POST
+//	@Synopsis Create or update a Rate Plan Ref and its rates
+//  @Description  Creates a new Rate Plan Ref for Rate Plan RPID if RPRID is 0, otherwise
+//  @Description  updates Rate Plan Ref RPRID. The Rentable Type rates RT and Specialty rates SP
+//  @Description  replace any existing rates. A rate with the percent flag set is a fraction
+//  @Description  of the Rentable Type's market rate. The response contains the RPRID.
+//	@Input RatePlanRefInput
+//  @Response SvcStatusResponse
+// wsdoc }
+func saveRatePlanRef(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	var (
+		funcname = "saveRatePlanRef"
+		foo      RatePlanRefInput
+	)
+
+	fmt.Printf("Entered %s\n", funcname)
+	fmt.Printf("record data = %s\n", d.data)
+
+	if err := json.Unmarshal([]byte(d.data), &foo); err != nil {
+		e := fmt.Errorf("%s: Error with json.Unmarshal:  %s", funcname, err.Error())
+		SvcGridErrorReturn(w, e, funcname)
+		return
+	}
+
+	var a rlib.RatePlanRef
+	rlib.MigrateStructVals(&foo.Record, &a) // the variables that don't need special handling
+
+	var ok bool
+	a.BID, ok = getBIDFromBUD(w, d, foo.Record.BUD, funcname)
+	if !ok {
+		return
+	}
+	a.DtStart = time.Time(foo.Record.DtStart)
+	a.DtStop = time.Time(foo.Record.DtStop)
+	a.RT = foo.Record.RT
+	a.SP = foo.Record.SP
+	if a.RPRID > 0 {
+		var b rlib.RatePlanRef
+		rlib.GetRatePlanRef(a.RPRID, &b)
+		if b.BID != a.BID {
+			e := fmt.Errorf("%s: Rate Plan Ref %d not found", funcname, a.RPRID)
+			SvcGridErrorReturn(w, e, funcname)
+			return
+		}
+		a.CreateBy = b.CreateBy
+	} else {
+		a.CreateBy = d.UID
+	}
+	a.LastModBy = d.UID
+
+	if errlist := bizlogic.SaveRatePlanRef(&a); len(errlist) > 0 {
+		SvcErrListReturn(w, errlist, funcname)
+		return
+	}
+	SvcWriteSuccessResponseWithID(w, a.RPRID)
+}
+
+// deleteRatePlanRef deletes a RatePlanRef
+// wsdoc {
+//  @Title  Delete Rate Plan Ref
+//	@URL /v1/rateplanref/:BUI/:RPRID
+//  @Method  POST
+//	@Synopsis Delete a Rate Plan Ref
+//  @Description  Deletes Rate Plan Ref :RPRID and its rates.
+//	@Input WebGridSearchRequest
+//  @Response SvcStatusResponse
+// wsdoc }
+func deleteRatePlanRef(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	var (
+		funcname = "deleteRatePlanRef"
+		a        rlib.RatePlanRef
+	)
+
+	fmt.Printf("Entered %s\n", funcname)
+	rlib.GetRatePlanRef(d.ID, &a)
+	if a.RPRID == 0 || a.BID != d.BID {
+		e := fmt.Errorf("%s: Rate Plan Ref %d not found", funcname, d.ID)
+		SvcGridErrorReturn(w, e, funcname)
+		return
+	}
+	if errlist := bizlogic.DeleteRatePlanRef(a.RPRID); len(errlist) > 0 {
+		SvcErrListReturn(w, errlist, funcname)
+		return
+	}
+	SvcWriteSuccessResponse(w)
+}
@@ -0,0 +1,114 @@
+package ws
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"rentroll/bizlogic"
+	"rentroll/rlib"
+	"time"
+)
+
+// RateQuoteForm contains the data from the Rate Quote FORM
+type RateQuoteForm struct {
+	BUD       rlib.XJSONBud
+	RPID      int64   // the Rate Plan
+	RTID      int64   // the Rentable Type
+	RSPIDs    []int64 // Specialties
+	DtStart   rlib.JSONDate
+	DtStop    rlib.JSONDate
+	Headcount int64 // number of users old enough to be counted for fees
+	PromoCode string
+}
+
+// RateQuoteInput is the input data format for a quote request
+type RateQuoteInput struct {
+	Status   string        `json:"status"`
+	Recid    int64         `json:"recid"`
+	FormName string        `json:"name"`
+	Record   RateQuoteForm `json:"record"`
+}
+
+// RateQuoteResponse is the response to a quote request
+type RateQuoteResponse struct {
+	Status string             `json:"status"`
+	Record bizlogic.RateQuote `json:"record"`
+}
+
+// SvcHandlerRateQuote prices a rental using a RatePlan.
+// For this call, we expect the URI to contain the BID:  /v1/ratequote/:BUI
+//
+// The server command can be:
+//      get
+//-----------------------------------------------------------------------------------
+func SvcHandlerRateQuote(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	var (
+		funcname = "SvcHandlerRateQuote"
+		err      error
+	)
+
+	fmt.Printf("Entered %s\n", funcname)
+	fmt.Printf("Request: %s:  BID = %d\n", d.wsSearchReq.Cmd, d.BID)
+
+	switch d.wsSearchReq.Cmd {
+	case "get":
+		getRateQuote(w, r, d)
+		break
+	default:
+		err = fmt.Errorf("Unhandled command: %s", d.wsSearchReq.Cmd)
+		SvcGridErrorReturn(w, err, funcname)
+		return
+	}
+}
+
+// getRateQuote returns an itemized quote
+// wsdoc {
+//  @Title  Rate Quote
+//	@URL /v1/ratequote/:BUI
+//  @Method  POST
+//	@Synopsis Price a rental using a Rate Plan
+//  @Description  Prices Rentable Type RTID with Specialties RSPIDs for DtStart - DtStop using
+//  @Description  the Rate Plan Ref of Rate Plan RPID in effect on DtStart. A Rate Plan Ref
+//  @Description  with a promotion code is used only when PromoCode matches it. Users beyond the
+//  @Description  Rate Plan Ref's MaxNoFeeUsers are charged its AdditionalUserFee. The response
+//  @Description  lists each charge per rent cycle and for the whole period.
+//	@Input RateQuoteInput
+//  @Response RateQuoteResponse
+// wsdoc }
+func getRateQuote(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	var (
+		funcname = "getRateQuote"
+		foo      RateQuoteInput
+		g        RateQuoteResponse
+	)
+
+	fmt.Printf("Entered %s\n", funcname)
+	fmt.Printf("record data = %s\n", d.data)
+
+	if err := json.Unmarshal([]byte(d.data), &foo); err != nil {
+		e := fmt.Errorf("%s: Error with json.Unmarshal:  %s", funcname, err.Error())
+		SvcGridErrorReturn(w, e, funcname)
+		return
+	}
+
+	var q bizlogic.RateQuoteRequest
+	rlib.MigrateStructVals(&foo.Record, &q) // the variables that don't need special handling
+
+	var ok bool
+	q.BID, ok = getBIDFromBUD(w, d, foo.Record.BUD, funcname)
+	if !ok {
+		return
+	}
+	q.RSPIDs = foo.Record.RSPIDs
+	q.DtStart = time.Time(foo.Record.DtStart)
+	q.DtStop = time.Time(foo.Record.DtStop)
+
+	rq, errlist := bizlogic.GetRateQuote(&q)
+	if len(errlist) > 0 {
+		SvcErrListReturn(w, errlist, funcname)
+		return
+	}
+	g.Record = rq
+	g.Status = "success"
+	SvcWriteResponse(&g, w)
+}
@@ -332,7 +332,11 @@ func saveReceipt(w http.ResponseWriter, r *http.Request, d *ServiceData) {
 	}
 
 	rlib.MigrateStructVals(&foo.Record, &a) // the variables that don't need special handling
+	a.LastModBy = d.UID
 	fmt.Printf("saveReceipt - first migrate: a = %#v\n", a)
+	if !svcCheckBID(w, d, a.BID, funcname) {
+		return
+	}
 
 	//------------------------------------------
 	//  Update or Insert as appropriate...
@@ -340,6 +344,7 @@ func saveReceipt(w http.ResponseWriter, r *http.Request, d *ServiceData) {
 	if a.RCPTID == 0 && d.RCPTID == 0 {
 		// This is a new Receipt
 		fmt.Printf(">>>> NEW RECEIPT IS BEING ADDED\n")
+		a.CreateBy = d.UID
 		err = bizlogic.InsertReceipt(&a)
 		if err != nil {
 			e := fmt.Errorf("%s:  Error in rlib.ProcessNewReceipt: %s", funcname, err.Error())
@@ -349,6 +354,9 @@ func saveReceipt(w http.ResponseWriter, r *http.Request, d *ServiceData) {
 		}
 	} else {
 		// update existing record
+		if old := rlib.GetReceiptNoAllocations(a.RCPTID); !svcCheckBID(w, d, old.BID, funcname) {
+			return
+		}
 		now := time.Now() // this is the time we're making the change if a reversal needs to be done
 		err = bizlogic.UpdateReceipt(&a, &now)
 	}
@@ -372,9 +380,13 @@ func saveReceipt(w http.ResponseWriter, r *http.Request, d *ServiceData) {
 //  @Response GetReceiptResponse
 // wsdoc }
 func getReceipt(w http.ResponseWriter, r *http.Request, d *ServiceData) {
-	fmt.Printf("entered getReceipt\n")
+	funcname := "getReceipt"
+	fmt.Printf("entered %s\n", funcname)
 	var g GetReceiptResponse
 	a := rlib.GetReceiptNoAllocations(d.RCPTID)
+	if a.RCPTID > 0 && !svcCheckBID(w, d, a.BID, funcname) {
+		return
+	}
 	if a.RCPTID > 0 {
 		var gg ReceiptSendForm
 		gg.BID = d.BID
@@ -432,6 +444,9 @@ func deleteReceipt(w http.ResponseWriter, r *http.Request, d *ServiceData) {
 	}
 
 	rcpt := rlib.GetReceiptNoAllocations(del.RCPTID)
+	if !svcCheckBID(w, d, rcpt.BID, funcname) {
+		return
+	}
 	dt := time.Now()
 	err := bizlogic.ReverseReceipt(&rcpt, &dt)
 	if err != nil {
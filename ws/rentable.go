@@ -82,6 +82,7 @@ type RentableDetails struct {
 	RSDtStop       rlib.JSONDate  // rentable status stop date
 	CurrentDate    rlib.JSONDate
 	AssignmentTime int64 // assignment time
+	BLDGID         int64 // Building where the Rentable is located, 0 = none
 	LastModTime    rlib.JSONDateTime
 	LastModBy      int64
 	CreateTS       rlib.JSONDateTime
@@ -555,10 +556,8 @@ func saveRentable(w http.ResponseWriter, r *http.Request, d *ServiceData) {
 	)
 
 	// checks for valid values
-	requestedBID, ok := rlib.RRdb.BUDlist[string(rfRecord.BUD)]
+	requestedBID, ok := getBIDFromBUD(w, d, rfRecord.BUD, funcname)
 	if !ok {
-		e := fmt.Errorf("Invalid Business ID found. BUD: %s", rfRecord.BUD)
-		SvcGridErrorReturn(w, e, funcname)
 		return
 	}
 	// check whether rentable type is provided or not
@@ -589,6 +588,9 @@ func saveRentable(w http.ResponseWriter, r *http.Request, d *ServiceData) {
 			SvcGridErrorReturn(w, e, funcname)
 			return
 		}
+		if !svcCheckBID(w, d, rt.BID, funcname) {
+			return
+		}
 
 		// TODO: if business value is changed then shouldn't we keep
 		// the record of tie-up of this rentable with previous business?
@@ -596,6 +598,7 @@ func saveRentable(w http.ResponseWriter, r *http.Request, d *ServiceData) {
 		rt.BID = requestedBID
 		rt.RentableName = rfRecord.RentableName
 		rt.AssignmentTime = rfRecord.AssignmentTime
+		rt.BLDGID = rfRecord.BLDGID
 		// Now just update the Rentable Record
 		err = rlib.UpdateRentable(&rt)
 		if err != nil {
@@ -680,6 +683,7 @@ func saveRentable(w http.ResponseWriter, r *http.Request, d *ServiceData) {
 		rt.BID = requestedBID
 		rt.RentableName = rfRecord.RentableName
 		rt.AssignmentTime = rfRecord.AssignmentTime
+		rt.BLDGID = rfRecord.BLDGID
 		rid, err := rlib.InsertRentable(&rt)
 		if err != nil {
 			SvcGridErrorReturn(w, err, funcname)
@@ -751,6 +755,7 @@ var rentableFormSelectFields = []string{
 	"RentableStatus.DtStart as RSDtStart",
 	"RentableStatus.DtStop as RSDtStop",
 	"Rentable.AssignmentTime",
+	"Rentable.BLDGID",
 	"Rentable.LastModTime",
 	"Rentable.LastModBy",
 	"Rentable.CreateTS",
@@ -812,7 +817,7 @@ func getRentable(w http.ResponseWriter, r *http.Request, d *ServiceData) {
 		gg.BUD = getBUDFromBIDList(gg.BID)
 
 		var rStatus int64
-		err = rows.Scan(&gg.RID, &gg.RentableName, &gg.RARID, &gg.RAID, &gg.RARDtStart, &gg.RARDtStop, &gg.RTID, &gg.RTRID, &gg.RTRefDtStart, &gg.RTRefDtStop, &gg.RentableType, &gg.RSID, &rStatus, &gg.RSDtStart, &gg.RSDtStop, &gg.AssignmentTime, &gg.LastModTime, &gg.LastModBy, &gg.CreateTS, &gg.CreateBy)
+		err = rows.Scan(&gg.RID, &gg.RentableName, &gg.RARID, &gg.RAID, &gg.RARDtStart, &gg.RARDtStop, &gg.RTID, &gg.RTRID, &gg.RTRefDtStart, &gg.RTRefDtStop, &gg.RentableType, &gg.RSID, &rStatus, &gg.RSDtStart, &gg.RSDtStop, &gg.AssignmentTime, &gg.BLDGID, &gg.LastModTime, &gg.LastModBy, &gg.CreateTS, &gg.CreateBy)
 		if err != nil {
 			SvcGridErrorReturn(w, err, funcname)
 			return
@@ -323,7 +323,7 @@ func getRentableType(w http.ResponseWriter, r *http.Request, d *ServiceData) {
 	var (
 		funcname = "getRentableType"
 		g        RentableTypeGetResponse
-		whr      = fmt.Sprintf("RentableTypes.RTID=%d", d.ID)
+		whr      = fmt.Sprintf("RentableTypes.BID=%d AND RentableTypes.RTID=%d", d.BID, d.ID)
 	)
 
 	fmt.Printf("entered %s\n", funcname)
@@ -398,6 +398,11 @@ func deleteRentableType(w http.ResponseWriter, r *http.Request, d *ServiceData)
 		return
 	}
 
+	var old rlib.RentableType
+	if rlib.GetRentableType(del.ID, &old); !svcCheckBID(w, d, old.BID, funcname) {
+		return
+	}
+
 	// DeleteRentableType is still not implemented
 	if err := rlib.DeleteRentableType(del.ID); err != nil {
 		SvcGridErrorReturn(w, err, funcname)
@@ -454,11 +459,8 @@ func saveRentableType(w http.ResponseWriter, r *http.Request, d *ServiceData) {
 	fmt.Printf("RentableMarketRate Record: %#v\n", b)
 
 	var ok bool
-	a.BID, ok = rlib.RRdb.BUDlist[string(foo.Record.BUD)]
+	a.BID, ok = getBIDFromBUD(w, d, foo.Record.BUD, funcname)
 	if !ok {
-		e := fmt.Errorf("%s: Could not map BID value: %s", funcname, foo.Record.BUD)
-		rlib.Ulog("%s", e.Error())
-		SvcGridErrorReturn(w, e, funcname)
 		return
 	}
 
@@ -484,6 +486,10 @@ func saveRentableType(w http.ResponseWriter, r *http.Request, d *ServiceData) {
 	} else {
 		// update existing record
 		fmt.Printf("Updating existing RentableType: %d\n", a.RTID)
+		var old rlib.RentableType
+		if rlib.GetRentableType(a.RTID, &old); !svcCheckBID(w, d, old.BID, funcname) {
+			return
+		}
 		err = rlib.UpdateRentableType(&a)
 		if err != nil {
 			e := fmt.Errorf("%s: unable to update RentableType (RTID=%d\n: %s", funcname, a.RTID, err.Error())
@@ -498,6 +504,11 @@ func saveRentableType(w http.ResponseWriter, r *http.Request, d *ServiceData) {
 			SvcGridErrorReturn(w, e, funcname)
 			return
 		}
+		if rmr.RTID != a.RTID {
+			e := fmt.Errorf("%s: market rate %d is not for RentableType %d", funcname, rmr.RMRID, a.RTID)
+			SvcGridErrorReturn(w, e, funcname)
+			return
+		}
 
 		// now check marketrate is changed or not
 		if rmr.MarketRate != foo.Record.MarketRate { // if it is not same, then update rentableMarketRate with new record
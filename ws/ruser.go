@@ -151,6 +151,9 @@ func saveRUser(w http.ResponseWriter, r *http.Request, d *ServiceData) {
 
 	fmt.Printf("saveRUser - first migrate: a = RID = %d, BID = %d, TCID = %d, DtStart = %s, DtStop = %s\n",
 		a.RID, a.BID, a.TCID, a.DtStart.Format(rlib.RRDATEFMT3), a.DtStop.Format(rlib.RRDATEFMT3))
+	if !svcCheckBID(w, d, a.BID, funcname) || !svcCheckRID(w, d, a.RID, funcname) {
+		return
+	}
 
 	// make sure we don't already have this user and that there's no overlap
 	// with an existing record...
@@ -212,6 +215,9 @@ func SvcUpdateRUser(w http.ResponseWriter, r *http.Request, d *ServiceData) {
 			return
 		}
 		fmt.Printf("Found ruser: %#v\n", ruser)
+		if !svcCheckRID(w, d, ruser.RID, funcname) {
+			return
+		}
 		dt := time.Time(foo.Changes[i].DtStart)
 		if dt.Year() > 1969 {
 			ruser.DtStart = dt
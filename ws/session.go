@@ -0,0 +1,196 @@
+package ws
+
+import (
+	"crypto/rand"
+	"crypto/sha512"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"rentroll/rlib"
+	"sync"
+	"time"
+)
+
+// SessionCookieName is the name of the cookie that holds the session token
+const SessionCookieName = "rrsession"
+
+// SessionTimeout is how long a session lasts without any requests
+const SessionTimeout = 8 * time.Hour
+
+// Session is a logged in user. The UID of every web service request is taken
+// from the session named by the request's cookie, never from the request data.
+type Session struct {
+	Token    string    // value of the session cookie
+	UID      int64     // phonebook UID of the user
+	Username string    // phonebook username
+	Expire   time.Time // the session ends at this time unless it is used
+}
+
+// sessions holds the active sessions by token
+var sessions = struct {
+	sync.Mutex
+	m map[string]*Session
+}{m: map[string]*Session{}}
+
+// SessionNew starts a session for phonebook user uid
+func SessionNew(uid int64, username string) (*Session, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return nil, err
+	}
+	s := Session{Token: hex.EncodeToString(b), UID: uid, Username: username, Expire: time.Now().Add(SessionTimeout)}
+	sessions.Lock()
+	sessions.m[s.Token] = &s
+	sessions.Unlock()
+	return &s, nil
+}
+
+// SessionGet returns the session named by the cookie in request r, or nil if
+// there is no cookie or the session has ended. Each use extends the session.
+func SessionGet(r *http.Request) *Session {
+	c, err := r.Cookie(SessionCookieName)
+	if err != nil {
+		return nil
+	}
+	now := time.Now()
+	sessions.Lock()
+	defer sessions.Unlock()
+	s, ok := sessions.m[c.Value]
+	if !ok {
+		return nil
+	}
+	if now.After(s.Expire) {
+		delete(sessions.m, c.Value)
+		return nil
+	}
+	s.Expire = now.Add(SessionTimeout)
+	return s
+}
+
+// SessionDelete ends the session with the supplied token
+func SessionDelete(token string) {
+	sessions.Lock()
+	delete(sessions.m, token)
+	sessions.Unlock()
+}
+
+// AuthnForm holds the credentials for a login
+type AuthnForm struct {
+	User string `json:"user"`
+	Pass string `json:"pass"`
+}
+
+// AuthnInput is the input data format for a login command
+type AuthnInput struct {
+	Cmd    string    `json:"cmd"`
+	Record AuthnForm `json:"record"`
+}
+
+// AuthnResponse is the response to a login command
+type AuthnResponse struct {
+	Status   string `json:"status"`
+	UID      int64  `json:"uid"`
+	Username string `json:"username"`
+}
+
+// SvcHandlerAuthn logs users in and out.
+// For this call, the URI does not need the BID:  /v1/authn/
+//
+// The server command can be:
+//      login
+//      logout
+//-----------------------------------------------------------------------------------
+func SvcHandlerAuthn(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	var (
+		funcname = "SvcHandlerAuthn"
+		err      error
+	)
+
+	fmt.Printf("Entered %s\n", funcname)
+	fmt.Printf("Request: %s\n", d.wsSearchReq.Cmd)
+
+	switch d.wsSearchReq.Cmd {
+	case "login":
+		authnLogin(w, r, d)
+		break
+	case "logout":
+		authnLogout(w, r, d)
+		break
+	default:
+		err = fmt.Errorf("Unhandled command: %s", d.wsSearchReq.Cmd)
+		SvcGridErrorReturn(w, err, funcname)
+		return
+	}
+}
+
+// authnLogin checks a username and password against the phonebook and starts
+// a session
+// wsdoc {
+//  @Title  Login
+//	@URL /v1/authn/
+//  @Method  POST
+//	@Synopsis Log in
+//  @Description  Checks the phonebook username and password. If they are correct a session
+//  @Description  is started and its token is returned in the rrsession cookie. Once Roles have
+//  @Description  been assigned, every other web service requires this cookie and acts on
+//  @Description  behalf of its user.
+//	@Input AuthnInput
+//  @Response AuthnResponse
+// wsdoc }
+func authnLogin(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	var (
+		funcname = "authnLogin"
+		foo      AuthnInput
+		g        AuthnResponse
+	)
+
+	fmt.Printf("Entered %s\n", funcname)
+
+	if err := json.Unmarshal([]byte(d.data), &foo); err != nil {
+		e := fmt.Errorf("%s: Error with json.Unmarshal:  %s", funcname, err.Error())
+		SvcGridErrorReturn(w, e, funcname)
+		return
+	}
+
+	uid, passhash, err := rlib.GetUserLogin(foo.Record.User)
+	sum := sha512.Sum512([]byte(foo.Record.Pass))
+	if err != nil || subtle.ConstantTimeCompare([]byte(hex.EncodeToString(sum[:])), []byte(passhash)) != 1 {
+		rlib.Ulog("%s: login failed for user %q\n", funcname, foo.Record.User)
+		SvcGridErrorReturn(w, fmt.Errorf("Invalid username or password"), funcname)
+		return
+	}
+
+	s, err := SessionNew(uid, foo.Record.User)
+	if err != nil {
+		e := fmt.Errorf("%s: Error starting session: %s", funcname, err.Error())
+		SvcGridErrorReturn(w, e, funcname)
+		return
+	}
+	http.SetCookie(w, &http.Cookie{Name: SessionCookieName, Value: s.Token, Path: "/", HttpOnly: true})
+
+	g.UID = s.UID
+	g.Username = s.Username
+	g.Status = "success"
+	w.Header().Set("Content-Type", "application/json")
+	SvcWriteResponse(&g, w)
+}
+
+// authnLogout ends the session of the requester
+// wsdoc {
+//  @Title  Logout
+//	@URL /v1/authn/
+//  @Method  POST
+//	@Synopsis Log out
+//  @Description  Ends the session named by the rrsession cookie.
+//	@Input WebGridSearchRequest
+//  @Response SvcStatusResponse
+// wsdoc }
+func authnLogout(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	if c, err := r.Cookie(SessionCookieName); err == nil {
+		SessionDelete(c.Value)
+	}
+	http.SetCookie(w, &http.Cookie{Name: SessionCookieName, Value: "", Path: "/", MaxAge: -1, HttpOnly: true})
+	SvcWriteSuccessResponse(w)
+}
@@ -65,6 +65,9 @@ func SvcGetStatementInfo(w http.ResponseWriter, r *http.Request, d *ServiceData)
 	)
 
 	rlib.Console("entered %s\n", funcname)
+	if !svcCheckRAID(w, d, d.ID, funcname) {
+		return
+	}
 
 	d1 := time.Now()
 	d2 := d1.AddDate(0, 1, 0)
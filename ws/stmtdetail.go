@@ -74,6 +74,9 @@ func SvcStatementDetail(w http.ResponseWriter, r *http.Request, sd *ServiceData)
 	//--------------------------------------------
 	d1 := sd.wsSearchReq.SearchDtStart
 	d2 := sd.wsSearchReq.SearchDtStop
+	if !svcCheckRAID(w, sd, sd.ID, funcname) {
+		return
+	}
 	m, err := rlib.GetRAIDStatementInfo(sd.ID, &d1, &d2)
 	if err != nil {
 		// e := fmt.Errorf("GetRAIDAccountBalance returned error: %s", err.Error())
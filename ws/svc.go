@@ -136,24 +136,57 @@ var Svcs = []ServiceHandler{
 	{"account", SvcFormHandlerGLAccounts, true},
 	{"accountlist", SvcAccountsList, true},
 	{"accounts", SvcSearchHandlerGLAccounts, true},
+	{"adjust", SvcHandlerAdjustment, true},
 	{"allocfunds", SvcSearchHandlerAllocFunds, true},
 	{"ar", SvcFormHandlerAR, true},
 	{"ars", SvcSearchHandlerARs, true},
 	{"asm", SvcFormHandlerAssessment, true},
 	{"asms", SvcSearchHandlerAssessments, true},
+	{"attribution", SvcHandlerAttribution, true},
+	{"authn", SvcHandlerAuthn, false},
+	{"audit", SvcHandlerAudit, true},
+	{"budget", SvcHandlerBudget, true},
+	{"budgets", SvcHandlerBudgets, true},
+	{"chgrt", SvcHandlerChangeRentableType, true},
+	{"closeperiod", SvcHandlerClosePeriod, true},
+	{"commissions", SvcHandlerCommission, true},
 	{"dep", SvcHandlerDepository, true},
+	{"deposit", SvcHandlerDeposit, true},
 	{"discon", SvcDisableConsole, false},
 	{"encon", SvcEnableConsole, false},
+	{"gas", SvcHandlerGoodsService, true},
+	{"gaslist", SvcHandlerGoodsServices, true},
+	{"gassale", SvcHandlerSale, true},
+	{"hkboard", SvcHandlerHousekeepingBoard, true},
+	{"housekeeping", SvcHandlerHousekeeping, true},
+	{"invoice", SvcHandlerInvoice, true},
+	{"latefeepolicy", SvcHandlerLateFeePolicy, true},
 	{"ledgers", getLedgerGrid, true},
+	{"maintsched", SvcHandlerMaintenanceSchedule, true},
+	{"maintscheds", SvcHandlerMaintenanceSchedules, true},
+	{"movein", SvcHandlerMoveIn, true},
+	{"moveout", SvcHandlerMoveOut, true},
+	{"notices", SvcHandlerNotices, true},
 	{"parentaccounts", SvcParentAccountsList, true},
 	{"payorfund", SvcHandlerTotalUnallocFund, true},
 	{"person", SvcFormHandlerXPerson, true},
 	{"ping", SvcHandlerPing, true},
 	{"pmts", SvcHandlerPaymentType, true},
 	{"postaccounts", SvcPostAccountsList, true},
+	{"prospect", SvcHandlerProspect, true},
+	{"prospectconvert", SvcHandlerProspectConvert, true},
+	{"prospectdeposit", SvcHandlerProspectDeposit, true},
+	{"prospectfee", SvcHandlerProspectFee, true},
+	{"prospectfollowup", SvcHandlerProspectFollowUp, true},
+	{"prospectrefund", SvcHandlerProspectRefund, true},
+	{"prospects", SvcHandlerProspects, true},
+	{"raextend", SvcHandlerRAExtend, true},
 	{"rapayor", SvcRAPayor, true},
 	{"rapets", SvcRAPets, true},
 	{"rar", SvcRARentables, true},
+	{"rateplan", SvcHandlerRatePlan, true},
+	{"rateplanref", SvcHandlerRatePlanRef, true},
+	{"ratequote", SvcHandlerRateQuote, true},
 	{"receipt", SvcFormHandlerReceipt, true},
 	{"receipts", SvcSearchHandlerReceipts, true},
 	{"rentable", SvcFormHandlerRentable, true},
@@ -162,18 +195,31 @@ var Svcs = []ServiceHandler{
 	{"rentalagr", SvcFormHandlerRentalAgreement, true},
 	{"rentalagrs", SvcSearchHandlerRentalAgr, true},
 	{"rentalagrtd", SvcRentalAgreementTypeDown, true},
+	{"role", SvcHandlerRole, false},
+	{"roles", SvcHandlerRoles, false},
 	{"rt", SvcHandlerRentableType, true},
+	{"rtbudget", SvcHandlerRentableTypeBudget, true},
+	{"rtbudgets", SvcHandlerRentableTypeBudgets, true},
 	{"rtlist", SvcRentableTypesTD, true},
 	{"ruser", SvcRUser, true},
 	{"stmt", SvcStatement, true},
 	{"stmtdetail", SvcStatementDetail, true},
 	{"stmtinfo", SvcGetStatementInfo, true},
+	{"svcreq", SvcHandlerServiceRequest, true},
+	{"svcreqcomplete", SvcHandlerServiceRequestComplete, true},
+	{"svcreqs", SvcHandlerServiceRequests, true},
+	{"svcreqstatus", SvcHandlerServiceRequestStatus, true},
+	{"tax", SvcHandlerTax, true},
+	{"taxrate", SvcHandlerTaxRate, true},
+	{"taxrates", SvcSearchHandlerTaxRates, true},
 	{"transactants", SvcSearchHandlerTransactants, true},
 	{"transactantstd", SvcTransactantTypeDown, true},
+	{"transfer", SvcHandlerTransfer, true},
 	{"tws", SvcTWS, true},
 	{"uilists", SvcUILists, false},
 	{"uival", SvcUIVal, false},
 	{"unpaidasms", SvcHandlerGetUnpaidAsms, true},
+	{"userroles", SvcHandlerUserRoles, false},
 }
 
 // V1ServiceHandler is the main dispatch point for WEB SERVICE requests
@@ -243,6 +289,9 @@ func V1ServiceHandler(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 	}
+	if s := SessionGet(r); s != nil {
+		d.UID = s.UID // the requester is whoever logged in, never what the request claims
+	}
 
 	showWebRequest(&d)
 
@@ -261,8 +310,11 @@ func V1ServiceHandler(w http.ResponseWriter, r *http.Request) {
 				fmt.Printf("***ERROR IN URL***  %s\n", e.Error())
 				SvcGridErrorReturn(w, err, funcname)
 			}
-			Svcs[i].Handler(w, r, &d)
 			found = true
+			if !svcCheckPermission(w, &d) {
+				break
+			}
+			Svcs[i].Handler(w, r, &d)
 			break
 		}
 	}
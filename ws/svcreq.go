@@ -0,0 +1,496 @@
+package ws
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"rentroll/bizlogic"
+	"rentroll/rlib"
+	"time"
+)
+
+// ServiceRequestGrid describes a service request
+type ServiceRequestGrid struct {
+	Recid           int64 `json:"recid"`
+	SRID            int64
+	BID             int64
+	BUD             rlib.XJSONBud
+	RID             int64
+	RentableName    string
+	TCID            int64
+	ReportedBy      string // name of the Transactant who reported it
+	CategorySLSID   int64
+	Category        string
+	Priority        int64
+	PriorityName    string // low, normal, high, emergency
+	Description     string
+	Assignee        int64
+	Status          int64
+	StatusName      string // open, assigned, in progress, on hold, completed, cancelled
+	DtReported      rlib.JSONDateTime
+	DtCompleted     rlib.JSONDateTime
+	CompletionNotes string
+	ASMID           int64 // the assessment billing the tenant, 0 if not billed
+	LastModTime     rlib.JSONDateTime
+	LastModBy       int64
+	CreateTS        rlib.JSONDateTime
+	CreateBy        int64
+}
+
+// ServiceRequestStatusGrid describes a change in the status of a service request
+type ServiceRequestStatusGrid struct {
+	SRSID      int64
+	Status     int64
+	StatusName string
+	Assignee   int64
+	Dt         rlib.JSONDateTime
+	Comment    string
+	CreateBy   int64
+}
+
+// ServiceRequestSearchResponse is the response to a request for a list of service requests
+type ServiceRequestSearchResponse struct {
+	Status  string               `json:"status"`
+	Total   int64                `json:"total"`
+	Records []ServiceRequestGrid `json:"records"`
+}
+
+// ServiceRequestGetResponse is the response to a request for a single service request
+type ServiceRequestGetResponse struct {
+	Status  string                     `json:"status"`
+	Record  ServiceRequestGrid         `json:"record"`
+	History []ServiceRequestStatusGrid `json:"history"`
+}
+
+// ServiceRequestForm contains the data from the Create Service Request FORM
+type ServiceRequestForm struct {
+	BUD           rlib.XJSONBud
+	SRID          int64 // 0 = new request
+	RID           int64 // the Rentable needing service
+	TCID          int64 // who reported it, 0 = staff
+	CategorySLSID int64
+	Priority      int64 // 0 = low, 1 = normal, 2 = high, 3 = emergency
+	Description   string
+	Assignee      int64 // only used for a new request
+	DtReported    rlib.JSONDateTime
+}
+
+// ServiceRequestInput is the input data format for a Save command
+type ServiceRequestInput struct {
+	Status   string             `json:"status"`
+	Recid    int64              `json:"recid"`
+	FormName string             `json:"name"`
+	Record   ServiceRequestForm `json:"record"`
+}
+
+// ServiceRequestStatusForm contains the data for a change in the status of a service request
+type ServiceRequestStatusForm struct {
+	BUD      rlib.XJSONBud
+	SRID     int64
+	Status   string // open, assigned, in progress, on hold, cancelled
+	Assignee int64  // the new assignee, 0 = unchanged
+	Dt       rlib.JSONDateTime
+	Comment  string
+}
+
+// ServiceRequestStatusInput is the input data format for a Save command
+type ServiceRequestStatusInput struct {
+	Status   string                   `json:"status"`
+	Recid    int64                    `json:"recid"`
+	FormName string                   `json:"name"`
+	Record   ServiceRequestStatusForm `json:"record"`
+}
+
+// ServiceRequestCompleteForm contains the data from the Complete Service Request FORM
+type ServiceRequestCompleteForm struct {
+	BUD        rlib.XJSONBud
+	SRID       int64
+	Dt         rlib.JSONDateTime
+	Notes      string
+	BillAmount float64 // amount to bill the tenant, 0 = do not bill
+	ARID       int64   // account rule for the assessment
+}
+
+// ServiceRequestCompleteInput is the input data format for a Save command
+type ServiceRequestCompleteInput struct {
+	Status   string                     `json:"status"`
+	Recid    int64                      `json:"recid"`
+	FormName string                     `json:"name"`
+	Record   ServiceRequestCompleteForm `json:"record"`
+}
+
+// serviceRequestGridRecord fills out a ServiceRequestGrid from service request sr
+func serviceRequestGridRecord(sr *rlib.ServiceRequest) ServiceRequestGrid {
+	var q ServiceRequestGrid
+	rlib.MigrateStructVals(sr, &q)
+	q.Recid = sr.SRID
+	q.BUD = getBUDFromBIDList(sr.BID)
+	q.RentableName = rlib.GetRentable(sr.RID).RentableName
+	if sr.TCID > 0 {
+		var t rlib.Transactant
+		rlib.GetTransactant(sr.TCID, &t)
+		q.ReportedBy = t.GetFullTransactantName()
+	}
+	if sr.CategorySLSID > 0 {
+		var s rlib.SLString
+		rlib.GetSLString(sr.CategorySLSID, &s)
+		q.Category = s.Value
+	}
+	q.PriorityName = bizlogic.SRPriorityNames[sr.Priority]
+	q.StatusName = bizlogic.SRStatusNames[sr.Status]
+	return q
+}
+
+// SvcHandlerServiceRequests lists service requests.
+// For this call, we expect the URI to contain the BID and possibly the RID:
+//       /v1/svcreqs/:BUI/[RID]
+//
+// The server command can be:
+//      get
+//      open
+//-----------------------------------------------------------------------------------
+func SvcHandlerServiceRequests(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	var (
+		funcname = "SvcHandlerServiceRequests"
+		err      error
+	)
+
+	fmt.Printf("Entered %s\n", funcname)
+	fmt.Printf("Request: %s:  BID = %d,  RID = %d\n", d.wsSearchReq.Cmd, d.BID, d.ID)
+
+	switch d.wsSearchReq.Cmd {
+	case "get":
+		getServiceRequests(w, r, d)
+		break
+	case "open":
+		getOpenServiceRequests(w, r, d)
+		break
+	default:
+		err = fmt.Errorf("Unhandled command: %s", d.wsSearchReq.Cmd)
+		SvcGridErrorReturn(w, err, funcname)
+		return
+	}
+}
+
+// writeServiceRequests writes the service requests in m that belong to business bid
+func writeServiceRequests(w http.ResponseWriter, bid int64, m []rlib.ServiceRequest) {
+	var g ServiceRequestSearchResponse
+	for i := 0; i < len(m); i++ {
+		if m[i].BID != bid {
+			continue
+		}
+		g.Records = append(g.Records, serviceRequestGridRecord(&m[i]))
+	}
+	g.Total = int64(len(g.Records))
+	g.Status = "success"
+	w.Header().Set("Content-Type", "application/json")
+	SvcWriteResponse(&g, w)
+}
+
+// getServiceRequests returns service requests
+// wsdoc {
+//  @Title  Get Service Requests
+//	@URL /v1/svcreqs/:BUI/[RID]
+//  @Method  POST
+//	@Synopsis Get the service requests for a business or a Rentable
+//  @Description  If :RID is supplied, returns all the service requests for that Rentable,
+//  @Description  most recent first. Otherwise returns the service requests of business :BUI
+//  @Description  reported between searchDtStart and searchDtStop.
+//	@Input WebGridSearchRequest
+//  @Response ServiceRequestSearchResponse
+// wsdoc }
+func getServiceRequests(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	funcname := "getServiceRequests"
+	fmt.Printf("Entered %s\n", funcname)
+	var m []rlib.ServiceRequest
+	if d.ID > 0 {
+		m = rlib.GetServiceRequestsByRentable(d.ID)
+	} else {
+		m = rlib.GetServiceRequestsInRange(d.BID, &d.wsSearchReq.SearchDtStart, &d.wsSearchReq.SearchDtStop)
+	}
+	writeServiceRequests(w, d.BID, m)
+}
+
+// getOpenServiceRequests returns the work queue
+// wsdoc {
+//  @Title  Get Open Service Requests
+//	@URL /v1/svcreqs/:BUI
+//  @Method  POST
+//	@Synopsis Get the service requests that still need work
+//  @Description  Returns the service requests of business :BUI that are neither completed
+//  @Description  nor cancelled. The highest priority is first, then the oldest.
+//	@Input WebGridSearchRequest
+//  @Response ServiceRequestSearchResponse
+// wsdoc }
+func getOpenServiceRequests(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	funcname := "getOpenServiceRequests"
+	fmt.Printf("Entered %s\n", funcname)
+	writeServiceRequests(w, d.BID, rlib.GetOpenServiceRequests(d.BID))
+}
+
+// SvcHandlerServiceRequest returns, creates and updates a service request.
+// For this call, we expect the URI to contain the BID and the SRID:
+//    /v1/svcreq/:BUI/:SRID
+//
+// The server command can be:
+//      get
+//      save
+//-----------------------------------------------------------------------------------
+func SvcHandlerServiceRequest(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	var (
+		funcname = "SvcHandlerServiceRequest"
+		err      error
+	)
+
+	fmt.Printf("Entered %s\n", funcname)
+	fmt.Printf("Request: %s:  BID = %d,  SRID = %d\n", d.wsSearchReq.Cmd, d.BID, d.ID)
+
+	switch d.wsSearchReq.Cmd {
+	case "get":
+		getServiceRequest(w, r, d)
+		break
+	case "save":
+		saveServiceRequest(w, r, d)
+		break
+	default:
+		err = fmt.Errorf("Unhandled command: %s", d.wsSearchReq.Cmd)
+		SvcGridErrorReturn(w, err, funcname)
+		return
+	}
+}
+
+// getServiceRequest returns the requested service request
+// wsdoc {
+//  @Title  Get Service Request
+//	@URL /v1/svcreq/:BUI/:SRID
+//  @Method  GET
+//	@Synopsis Get a service request and its status history
+//  @Desc  This service returns the service request with id :SRID along with every
+//  @Desc  change in its status, oldest first.
+//	@Input WebGridSearchRequest
+//  @Response ServiceRequestGetResponse
+// wsdoc }
+func getServiceRequest(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	var (
+		funcname = "getServiceRequest"
+		g        ServiceRequestGetResponse
+	)
+
+	fmt.Printf("Entered %s\n", funcname)
+	sr, err := rlib.GetServiceRequest(d.ID)
+	if err != nil || sr.BID != d.BID {
+		e := fmt.Errorf("%s: service request %d not found", funcname, d.ID)
+		SvcGridErrorReturn(w, e, funcname)
+		return
+	}
+	g.Record = serviceRequestGridRecord(&sr)
+	m := rlib.GetServiceRequestStatuses(sr.SRID)
+	for i := 0; i < len(m); i++ {
+		var q ServiceRequestStatusGrid
+		rlib.MigrateStructVals(&m[i], &q)
+		q.StatusName = bizlogic.SRStatusNames[m[i].Status]
+		g.History = append(g.History, q)
+	}
+	g.Status = "success"
+	SvcWriteResponse(&g, w)
+}
+
+// saveServiceRequest creates or updates a service request
+// wsdoc {
+//  @Title  Save Service Request
+//	@URL /v1/svcreq/:BUI/:SRID
+//  @Method  POST
+//	@Synopsis Create a service request or update its description
+//  @Description  If SRID is 0 a new service request is created for Rentable RID. It is open,
+//  @Description  or assigned if Assignee is supplied. DtReported defaults to now. Otherwise
+//  @Description  the reporter, category, priority and description of request SRID are updated.
+//  @Description  Use svcreqstatus and svcreqcomplete to change its status. The response
+//  @Description  contains the SRID.
+//	@Input ServiceRequestInput
+//  @Response SvcStatusResponse
+// wsdoc }
+func saveServiceRequest(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	var (
+		funcname = "saveServiceRequest"
+		foo      ServiceRequestInput
+	)
+
+	fmt.Printf("Entered %s\n", funcname)
+	fmt.Printf("record data = %s\n", d.data)
+
+	if err := json.Unmarshal([]byte(d.data), &foo); err != nil {
+		e := fmt.Errorf("%s: Error with json.Unmarshal:  %s", funcname, err.Error())
+		SvcGridErrorReturn(w, e, funcname)
+		return
+	}
+
+	var sr rlib.ServiceRequest
+	rlib.MigrateStructVals(&foo.Record, &sr) // the variables that don't need special handling
+
+	var ok bool
+	if sr.BID, ok = getBIDFromBUD(w, d, foo.Record.BUD, funcname); !ok {
+		return
+	}
+	if sr.SRID == 0 {
+		sr.SRID = d.ID
+	}
+	sr.DtReported = time.Time(foo.Record.DtReported)
+
+	if errlist := bizlogic.SaveServiceRequest(&sr, d.UID); len(errlist) > 0 {
+		SvcErrListReturn(w, errlist, funcname)
+		return
+	}
+	SvcWriteSuccessResponseWithID(w, sr.SRID)
+}
+
+// SvcHandlerServiceRequestStatus changes the status of a service request.
+// For this call, we expect the URI to contain the BID and the SRID:
+//    /v1/svcreqstatus/:BUI/:SRID
+//
+// The server command can be:
+//      save
+//-----------------------------------------------------------------------------------
+func SvcHandlerServiceRequestStatus(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	var (
+		funcname = "SvcHandlerServiceRequestStatus"
+		err      error
+	)
+
+	fmt.Printf("Entered %s\n", funcname)
+	fmt.Printf("Request: %s:  BID = %d,  SRID = %d\n", d.wsSearchReq.Cmd, d.BID, d.ID)
+
+	switch d.wsSearchReq.Cmd {
+	case "save":
+		saveServiceRequestStatus(w, r, d)
+		break
+	default:
+		err = fmt.Errorf("Unhandled command: %s", d.wsSearchReq.Cmd)
+		SvcGridErrorReturn(w, err, funcname)
+		return
+	}
+}
+
+// saveServiceRequestStatus changes the status of a service request
+// wsdoc {
+//  @Title  Change Service Request Status
+//	@URL /v1/svcreqstatus/:BUI/:SRID
+//  @Method  POST
+//	@Synopsis Assign, start, hold, reopen or cancel a service request
+//  @Description  Sets the status of request SRID to Status, which is one of open, assigned,
+//  @Description  in progress, on hold or cancelled, and records the change in its history.
+//  @Description  If Assignee is supplied the request is reassigned. A request must have an
+//  @Description  assignee to be assigned. Completed and cancelled requests cannot be changed.
+//	@Input ServiceRequestStatusInput
+//  @Response SvcStatusResponse
+// wsdoc }
+func saveServiceRequestStatus(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	var (
+		funcname = "saveServiceRequestStatus"
+		foo      ServiceRequestStatusInput
+	)
+
+	fmt.Printf("Entered %s\n", funcname)
+	fmt.Printf("record data = %s\n", d.data)
+
+	if err := json.Unmarshal([]byte(d.data), &foo); err != nil {
+		e := fmt.Errorf("%s: Error with json.Unmarshal:  %s", funcname, err.Error())
+		SvcGridErrorReturn(w, e, funcname)
+		return
+	}
+
+	var x bizlogic.ServiceRequestStatusChange
+	var ok bool
+	if x.BID, ok = getBIDFromBUD(w, d, foo.Record.BUD, funcname); !ok {
+		return
+	}
+	x.SRID = foo.Record.SRID
+	if x.SRID == 0 {
+		x.SRID = d.ID
+	}
+	x.Status = bizlogic.SRStatusFromName(foo.Record.Status)
+	x.Assignee = foo.Record.Assignee
+	x.Dt = time.Time(foo.Record.Dt)
+	x.Comment = foo.Record.Comment
+	x.UID = d.UID
+
+	if errlist := bizlogic.ChangeServiceRequestStatus(&x); len(errlist) > 0 {
+		SvcErrListReturn(w, errlist, funcname)
+		return
+	}
+	SvcWriteSuccessResponseWithID(w, x.SRID)
+}
+
+// SvcHandlerServiceRequestComplete completes a service request.
+// For this call, we expect the URI to contain the BID and the SRID:
+//    /v1/svcreqcomplete/:BUI/:SRID
+//
+// The server command can be:
+//      save
+//-----------------------------------------------------------------------------------
+func SvcHandlerServiceRequestComplete(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	var (
+		funcname = "SvcHandlerServiceRequestComplete"
+		err      error
+	)
+
+	fmt.Printf("Entered %s\n", funcname)
+	fmt.Printf("Request: %s:  BID = %d,  SRID = %d\n", d.wsSearchReq.Cmd, d.BID, d.ID)
+
+	switch d.wsSearchReq.Cmd {
+	case "save":
+		saveServiceRequestComplete(w, r, d)
+		break
+	default:
+		err = fmt.Errorf("Unhandled command: %s", d.wsSearchReq.Cmd)
+		SvcGridErrorReturn(w, err, funcname)
+		return
+	}
+}
+
+// saveServiceRequestComplete completes a service request
+// wsdoc {
+//  @Title  Complete Service Request
+//	@URL /v1/svcreqcomplete/:BUI/:SRID
+//  @Method  POST
+//	@Synopsis Mark a service request completed and optionally bill the tenant
+//  @Description  Marks request SRID completed on Dt, which defaults to now, with completion
+//  @Description  notes Notes. If BillAmount is greater than 0, an assessment for that amount
+//  @Description  is made using account rule ARID on the Rental Agreement holding the Rentable
+//  @Description  on Dt. The response contains the ASMID of the assessment, 0 if none was made.
+//	@Input ServiceRequestCompleteInput
+//  @Response SvcStatusResponse
+// wsdoc }
+func saveServiceRequestComplete(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	var (
+		funcname = "saveServiceRequestComplete"
+		foo      ServiceRequestCompleteInput
+	)
+
+	fmt.Printf("Entered %s\n", funcname)
+	fmt.Printf("record data = %s\n", d.data)
+
+	if err := json.Unmarshal([]byte(d.data), &foo); err != nil {
+		e := fmt.Errorf("%s: Error with json.Unmarshal:  %s", funcname, err.Error())
+		SvcGridErrorReturn(w, e, funcname)
+		return
+	}
+
+	var x bizlogic.ServiceRequestCompletion
+	rlib.MigrateStructVals(&foo.Record, &x) // the variables that don't need special handling
+	var ok bool
+	if x.BID, ok = getBIDFromBUD(w, d, foo.Record.BUD, funcname); !ok {
+		return
+	}
+	if x.SRID == 0 {
+		x.SRID = d.ID
+	}
+	x.Dt = time.Time(foo.Record.Dt)
+	x.UID = d.UID
+
+	sr, errlist := bizlogic.CompleteServiceRequest(&x)
+	if len(errlist) > 0 {
+		SvcErrListReturn(w, errlist, funcname)
+		return
+	}
+	SvcWriteSuccessResponseWithID(w, sr.ASMID)
+}
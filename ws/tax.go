@@ -0,0 +1,684 @@
+package ws
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"rentroll/rlib"
+	"strconv"
+	"strings"
+)
+
+// TaxGrid contains the data from Tax that is targeted to the UI Grid that displays
+// a list of Tax structs
+type TaxGrid struct {
+	Recid                  int64 `json:"recid"`
+	TAXID                  int64
+	BID                    int64
+	BUD                    rlib.XJSONBud
+	Name                   string
+	ARID                   int64
+	TaxingAuthority        string
+	TaxingAuthorityAddress string
+	FilingDate             rlib.JSONDate
+	FilingCycle            int64
+	Instructions           string
+	LastModTime            rlib.JSONDateTime
+	LastModBy              int64
+	CreateTS               rlib.JSONDateTime
+	CreateBy               int64
+}
+
+// TaxSearchResponse is a response string to the search request for Tax records
+type TaxSearchResponse struct {
+	Status  string    `json:"status"`
+	Total   int64     `json:"total"`
+	Records []TaxGrid `json:"records"`
+}
+
+// TaxSaveForm contains the data from the Tax FORM
+type TaxSaveForm struct {
+	Recid                  int64 `json:"recid"`
+	TAXID                  int64
+	BID                    int64
+	BUD                    rlib.XJSONBud
+	Name                   string
+	ARID                   int64
+	TaxingAuthority        string
+	TaxingAuthorityAddress string
+	FilingDate             rlib.JSONDate
+	FilingCycle            int64
+	Instructions           string
+}
+
+// TaxGridSave is the input data format for a Save command
+type TaxGridSave struct {
+	Status   string      `json:"status"`
+	Recid    int64       `json:"recid"`
+	FormName string      `json:"name"`
+	Record   TaxSaveForm `json:"record"`
+}
+
+// TaxGetResponse is the response to a GetTax request
+type TaxGetResponse struct {
+	Status string  `json:"status"`
+	Record TaxGrid `json:"record"`
+}
+
+// DeleteTaxForm used to delete a Tax or a TaxRate
+type DeleteTaxForm struct {
+	ID int64
+}
+
+// TaxRateGrid contains the data from TaxRate that is targeted to the UI Grid that displays
+// the rates for a Tax
+type TaxRateGrid struct {
+	Recid       int64 `json:"recid"`
+	TRID        int64
+	TAXID       int64
+	BID         int64
+	BUD         rlib.XJSONBud
+	DtStart     rlib.JSONDate
+	DtStop      rlib.JSONDate
+	Rate        float64
+	Fee         float64
+	Formula     string
+	LastModTime rlib.JSONDateTime
+	LastModBy   int64
+	CreateTS    rlib.JSONDateTime
+	CreateBy    int64
+}
+
+// TaxRateSearchResponse is the response to a request for the rates of a Tax
+type TaxRateSearchResponse struct {
+	Status  string        `json:"status"`
+	Total   int64         `json:"total"`
+	Records []TaxRateGrid `json:"records"`
+}
+
+// TaxRateSaveForm contains the data from the TaxRate FORM
+type TaxRateSaveForm struct {
+	Recid   int64 `json:"recid"`
+	TRID    int64
+	TAXID   int64
+	BID     int64
+	BUD     rlib.XJSONBud
+	DtStart rlib.JSONDate
+	DtStop  rlib.JSONDate
+	Rate    float64
+	Fee     float64
+	Formula string
+}
+
+// TaxRateGridSave is the input data format for a TaxRate Save command
+type TaxRateGridSave struct {
+	Status   string          `json:"status"`
+	Recid    int64           `json:"recid"`
+	FormName string          `json:"name"`
+	Record   TaxRateSaveForm `json:"record"`
+}
+
+// TaxRateGetResponse is the response to a GetTaxRate request
+type TaxRateGetResponse struct {
+	Status string      `json:"status"`
+	Record TaxRateGrid `json:"record"`
+}
+
+// SvcHandlerTax formats a complete data record for a Tax for use with the w2ui Form
+// For this call, we expect the URI to contain the BID and the TAXID as follows:
+//
+// The server command can be:
+//      get
+//      save
+//      delete
+//-----------------------------------------------------------------------------------
+func SvcHandlerTax(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	var (
+		funcname = "SvcHandlerTax"
+		err      error
+	)
+
+	fmt.Printf("Entered %s\n", funcname)
+	fmt.Printf("Request: %s:  BID = %d,  TAXID = %d\n", d.wsSearchReq.Cmd, d.BID, d.ID)
+
+	switch d.wsSearchReq.Cmd {
+	case "get":
+		if d.ID <= 0 && d.wsSearchReq.Limit > 0 {
+			SvcSearchHandlerTaxes(w, r, d) // it is a query for the grid.
+		} else {
+			if d.ID < 0 {
+				err = fmt.Errorf("TAXID is required but was not specified")
+				SvcGridErrorReturn(w, err, funcname)
+				return
+			}
+			getTax(w, r, d)
+		}
+		break
+	case "save":
+		saveTax(w, r, d)
+		break
+	case "delete":
+		deleteTax(w, r, d)
+		break
+	default:
+		err = fmt.Errorf("Unhandled command: %s", d.wsSearchReq.Cmd)
+		SvcGridErrorReturn(w, err, funcname)
+		return
+	}
+}
+
+// taxGridRowScan scans a result from sql row and dump it in a TaxGrid struct
+func taxGridRowScan(rows *sql.Rows, q TaxGrid) (TaxGrid, error) {
+	err := rows.Scan(&q.TAXID, &q.Name, &q.ARID, &q.TaxingAuthority, &q.TaxingAuthorityAddress, &q.FilingDate, &q.FilingCycle, &q.Instructions, &q.LastModTime, &q.LastModBy, &q.CreateTS, &q.CreateBy)
+	return q, err
+}
+
+var taxSearchFieldMap = selectQueryFieldMap{
+	"TAXID":                  {"Tax.TAXID"},
+	"Name":                   {"Tax.Name"},
+	"ARID":                   {"Tax.ARID"},
+	"TaxingAuthority":        {"Tax.TaxingAuthority"},
+	"TaxingAuthorityAddress": {"Tax.TaxingAuthorityAddress"},
+	"FilingDate":             {"Tax.FilingDate"},
+	"FilingCycle":            {"Tax.FilingCycle"},
+	"Instructions":           {"Tax.Instructions"},
+	"LastModTime":            {"Tax.LastModTime"},
+	"LastModBy":              {"Tax.LastModBy"},
+	"CreateTS":               {"Tax.CreateTS"},
+	"CreateBy":               {"Tax.CreateBy"},
+}
+
+// which fields needs to be fetch to satisfy the struct
+var taxSearchSelectQueryFields = selectQueryFields{
+	"Tax.TAXID",
+	"Tax.Name",
+	"Tax.ARID",
+	"Tax.TaxingAuthority",
+	"Tax.TaxingAuthorityAddress",
+	"Tax.FilingDate",
+	"Tax.FilingCycle",
+	"Tax.Instructions",
+	"Tax.LastModTime",
+	"Tax.LastModBy",
+	"Tax.CreateTS",
+	"Tax.CreateBy",
+}
+
+// SvcSearchHandlerTaxes generates a report of all Taxes defined business d.BID
+// wsdoc {
+//  @Title  Search Taxes
+//	@URL /v1/tax/:BUI
+//  @Method  POST
+//	@Synopsis Search Taxes
+//  @Descr  Search all Tax and return those that match the Search Logic.
+//	@Input WebGridSearchRequest
+//  @Response TaxSearchResponse
+// wsdoc }
+func SvcSearchHandlerTaxes(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	var (
+		funcname = "SvcSearchHandlerTaxes"
+		g        TaxSearchResponse
+		err      error
+		order    = "Tax.TAXID ASC" // default ORDER
+		whr      = fmt.Sprintf("Tax.BID=%d", d.BID)
+	)
+
+	fmt.Printf("Entered %s\n", funcname)
+
+	// get where clause and order clause for sql query
+	_, orderClause := GetSearchAndSortSQL(d, taxSearchFieldMap)
+	if len(orderClause) > 0 {
+		order = orderClause
+	}
+
+	taxQuery := `
+	SELECT
+		{{.SelectClause}}
+	FROM Tax
+	WHERE {{.WhereClause}}
+	ORDER BY {{.OrderClause}}`
+
+	qc := queryClauses{
+		"SelectClause": strings.Join(taxSearchSelectQueryFields, ","),
+		"WhereClause":  whr,
+		"OrderClause":  order,
+	}
+
+	// get TOTAL COUNT First
+	countQuery := renderSQLQuery(taxQuery, qc)
+	g.Total, err = GetQueryCount(countQuery, qc)
+	if err != nil {
+		fmt.Printf("%s: Error from GetQueryCount: %s\n", funcname, err.Error())
+		SvcGridErrorReturn(w, err, funcname)
+		return
+	}
+	fmt.Printf("g.Total = %d\n", g.Total)
+
+	// FETCH the records WITH LIMIT AND OFFSET
+	limitAndOffsetClause := `
+	LIMIT {{.LimitClause}}
+	OFFSET {{.OffsetClause}};`
+
+	taxQueryWithLimit := taxQuery + limitAndOffsetClause
+	qc["LimitClause"] = strconv.Itoa(d.wsSearchReq.Limit)
+	qc["OffsetClause"] = strconv.Itoa(d.wsSearchReq.Offset)
+
+	qry := renderSQLQuery(taxQueryWithLimit, qc)
+	fmt.Printf("db query = %s\n", qry)
+
+	rows, err := rlib.RRdb.Dbrr.Query(qry)
+	if err != nil {
+		fmt.Printf("%s: Error from DB Query: %s\n", funcname, err.Error())
+		SvcGridErrorReturn(w, err, funcname)
+		return
+	}
+	defer rows.Close()
+
+	i := int64(d.wsSearchReq.Offset)
+	count := 0
+	for rows.Next() {
+		var q TaxGrid
+		q.Recid = i
+		q.BID = d.BID
+		q.BUD = getBUDFromBIDList(q.BID)
+
+		q, err = taxGridRowScan(rows, q)
+		if err != nil {
+			SvcGridErrorReturn(w, err, funcname)
+			return
+		}
+
+		g.Records = append(g.Records, q)
+		count++ // update the count only after adding the record
+		if count >= d.wsSearchReq.Limit {
+			break // if we've added the max number requested, then exit
+		}
+		i++
+	}
+
+	err = rows.Err()
+	if err != nil {
+		SvcGridErrorReturn(w, err, funcname)
+		return
+	}
+
+	g.Status = "success"
+	w.Header().Set("Content-Type", "application/json")
+	SvcWriteResponse(&g, w)
+}
+
+// deleteTax deletes a Tax and all of its rates from the database
+// wsdoc {
+//  @Title  Delete Tax
+//	@URL /v1/tax/:BUI/:TAXID
+//  @Method  POST
+//	@Synopsis Delete a Tax
+//  @Desc  This service deletes a Tax and all of its TaxRates.
+//	@Input DeleteTaxForm
+//  @Response SvcStatusResponse
+// wsdoc }
+func deleteTax(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	var (
+		funcname = "deleteTax"
+		del      DeleteTaxForm
+	)
+
+	fmt.Printf("Entered %s\n", funcname)
+	fmt.Printf("record data = %s\n", d.data)
+
+	if err := json.Unmarshal([]byte(d.data), &del); err != nil {
+		e := fmt.Errorf("%s: Error with json.Unmarshal:  %s", funcname, err.Error())
+		SvcGridErrorReturn(w, e, funcname)
+		return
+	}
+
+	if old, _ := rlib.GetTax(del.ID); !svcCheckBID(w, d, old.BID, funcname) {
+		return
+	}
+	if err := rlib.DeleteTax(del.ID); err != nil {
+		SvcGridErrorReturn(w, err, funcname)
+		return
+	}
+
+	SvcWriteSuccessResponse(w)
+}
+
+// saveTax creates or updates a Tax
+// wsdoc {
+//  @Title  Save Tax
+//	@URL /v1/tax/:BUI/:TAXID
+//  @Method  POST
+//	@Synopsis Update the information on a Tax with the supplied data
+//  @Description  This service updates Tax :TAXID with the information supplied. All fields must be supplied.
+//  @Description  If :TAXID is 0, a new Tax is created.
+//	@Input TaxGridSave
+//  @Response SvcStatusResponse
+// wsdoc }
+func saveTax(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	var (
+		funcname = "saveTax"
+		foo      TaxGridSave
+		err      error
+	)
+
+	fmt.Printf("Entered %s\n", funcname)
+	fmt.Printf("record data = %s\n", d.data)
+
+	if err := json.Unmarshal([]byte(d.data), &foo); err != nil {
+		e := fmt.Errorf("%s: Error with json.Unmarshal:  %s", funcname, err.Error())
+		SvcGridErrorReturn(w, e, funcname)
+		return
+	}
+
+	var a rlib.Tax
+	rlib.MigrateStructVals(&foo.Record, &a) // the variables that don't need special handling
+
+	var ok bool
+	a.BID, ok = getBIDFromBUD(w, d, foo.Record.BUD, funcname)
+	if !ok {
+		return
+	}
+
+	if len(a.Name) == 0 {
+		e := fmt.Errorf("%s: Required field, Name, is blank", funcname)
+		SvcGridErrorReturn(w, e, funcname)
+		return
+	}
+
+	adup, _ := rlib.GetTaxByName(a.BID, a.Name)
+	if a.Name == adup.Name && a.TAXID != adup.TAXID {
+		e := fmt.Errorf("%s: A Tax with the name %s already exists", funcname, a.Name)
+		SvcGridErrorReturn(w, e, funcname)
+		return
+	}
+
+	ar, err := rlib.GetAR(a.ARID)
+	if err != nil || ar.BID != a.BID {
+		e := fmt.Errorf("%s: Account rule %d is not valid for this business", funcname, a.ARID)
+		SvcGridErrorReturn(w, e, funcname)
+		return
+	}
+
+	if a.TAXID == 0 && d.ID == 0 {
+		_, err = rlib.InsertTax(&a)
+	} else {
+		fmt.Printf("Updating existing Tax: %d\n", a.TAXID)
+		if old, _ := rlib.GetTax(a.TAXID); !svcCheckBID(w, d, old.BID, funcname) {
+			return
+		}
+		err = rlib.UpdateTax(&a)
+	}
+
+	if err != nil {
+		e := fmt.Errorf("%s: Error saving Tax (TAXID=%d): %s", funcname, a.TAXID, err.Error())
+		SvcGridErrorReturn(w, e, funcname)
+		return
+	}
+
+	SvcWriteSuccessResponse(w)
+}
+
+// getTax returns the requested Tax
+// wsdoc {
+//  @Title  Get Tax
+//	@URL /v1/tax/:BUI/:TAXID
+//  @Method  GET
+//	@Synopsis Get information on a Tax
+//  @Description  Return all fields for Tax :TAXID
+//	@Input WebGridSearchRequest
+//  @Response TaxGetResponse
+// wsdoc }
+func getTax(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	var (
+		funcname = "getTax"
+		g        TaxGetResponse
+	)
+
+	fmt.Printf("entered %s\n", funcname)
+	a, err := rlib.GetTax(d.ID)
+	if err != nil && !rlib.IsSQLNoResultsError(err) {
+		SvcGridErrorReturn(w, err, funcname)
+		return
+	}
+	if a.TAXID > 0 && !svcCheckBID(w, d, a.BID, funcname) {
+		return
+	}
+	if a.TAXID > 0 {
+		var gg TaxGrid
+		rlib.MigrateStructVals(&a, &gg)
+		gg.Recid = gg.TAXID
+		gg.BUD = getBUDFromBIDList(gg.BID)
+		g.Record = gg
+	}
+	g.Status = "success"
+	SvcWriteResponse(&g, w)
+}
+
+// SvcHandlerTaxRate handles the rates for a Tax. For this call, we expect the URI
+// to contain the BID and the TRID:  /v1/taxrate/:BUI/:TRID
+//
+// The server command can be:
+//      get
+//      save
+//      delete
+//-----------------------------------------------------------------------------------
+func SvcHandlerTaxRate(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	var (
+		funcname = "SvcHandlerTaxRate"
+		err      error
+	)
+
+	fmt.Printf("Entered %s\n", funcname)
+	fmt.Printf("Request: %s:  BID = %d,  TRID = %d\n", d.wsSearchReq.Cmd, d.BID, d.ID)
+
+	switch d.wsSearchReq.Cmd {
+	case "get":
+		if d.ID < 0 {
+			err = fmt.Errorf("TRID is required but was not specified")
+			SvcGridErrorReturn(w, err, funcname)
+			return
+		}
+		getTaxRate(w, r, d)
+		break
+	case "save":
+		saveTaxRate(w, r, d)
+		break
+	case "delete":
+		deleteTaxRate(w, r, d)
+		break
+	default:
+		err = fmt.Errorf("Unhandled command: %s", d.wsSearchReq.Cmd)
+		SvcGridErrorReturn(w, err, funcname)
+		return
+	}
+}
+
+// SvcSearchHandlerTaxRates returns all the rates for Tax :TAXID
+// wsdoc {
+//  @Title  Get Tax Rates
+//	@URL /v1/taxrates/:BUI/:TAXID
+//  @Method  POST
+//	@Synopsis Get the rates for a Tax
+//  @Descr  Returns every TaxRate for Tax :TAXID ordered by start date.
+//	@Input WebGridSearchRequest
+//  @Response TaxRateSearchResponse
+// wsdoc }
+func SvcSearchHandlerTaxRates(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	var (
+		funcname = "SvcSearchHandlerTaxRates"
+		g        TaxRateSearchResponse
+	)
+
+	fmt.Printf("Entered %s\n", funcname)
+	if d.ID <= 0 {
+		SvcGridErrorReturn(w, fmt.Errorf("TAXID is required but was not specified"), funcname)
+		return
+	}
+
+	if tax, _ := rlib.GetTax(d.ID); !svcCheckBID(w, d, tax.BID, funcname) {
+		return
+	}
+	m := rlib.GetTaxRates(d.ID)
+	for i := 0; i < len(m); i++ {
+		var q TaxRateGrid
+		rlib.MigrateStructVals(&m[i], &q)
+		q.Recid = q.TRID
+		q.BUD = getBUDFromBIDList(q.BID)
+		g.Records = append(g.Records, q)
+	}
+	g.Total = int64(len(g.Records))
+	g.Status = "success"
+	w.Header().Set("Content-Type", "application/json")
+	SvcWriteResponse(&g, w)
+}
+
+// deleteTaxRate deletes a TaxRate from the database
+// wsdoc {
+//  @Title  Delete Tax Rate
+//	@URL /v1/taxrate/:BUI/:TRID
+//  @Method  POST
+//	@Synopsis Delete a Tax Rate
+//  @Desc  This service deletes a TaxRate.
+//	@Input DeleteTaxForm
+//  @Response SvcStatusResponse
+// wsdoc }
+func deleteTaxRate(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	var (
+		funcname = "deleteTaxRate"
+		del      DeleteTaxForm
+	)
+
+	fmt.Printf("Entered %s\n", funcname)
+	fmt.Printf("record data = %s\n", d.data)
+
+	if err := json.Unmarshal([]byte(d.data), &del); err != nil {
+		e := fmt.Errorf("%s: Error with json.Unmarshal:  %s", funcname, err.Error())
+		SvcGridErrorReturn(w, e, funcname)
+		return
+	}
+
+	if old, _ := rlib.GetTaxRate(del.ID); !svcCheckBID(w, d, old.BID, funcname) {
+		return
+	}
+	if err := rlib.DeleteTaxRate(del.ID); err != nil {
+		SvcGridErrorReturn(w, err, funcname)
+		return
+	}
+
+	SvcWriteSuccessResponse(w)
+}
+
+// saveTaxRate creates or updates a TaxRate
+// wsdoc {
+//  @Title  Save Tax Rate
+//	@URL /v1/taxrate/:BUI/:TRID
+//  @Method  POST
+//	@Synopsis Update the information on a TaxRate with the supplied data
+//  @Description  This service updates TaxRate :TRID with the information supplied. If :TRID
+//  @Description  is 0, a new TaxRate is created. The rate may not overlap another rate for the same Tax.
+//	@Input TaxRateGridSave
+//  @Response SvcStatusResponse
+// wsdoc }
+func saveTaxRate(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	var (
+		funcname = "saveTaxRate"
+		foo      TaxRateGridSave
+		err      error
+	)
+
+	fmt.Printf("Entered %s\n", funcname)
+	fmt.Printf("record data = %s\n", d.data)
+
+	if err := json.Unmarshal([]byte(d.data), &foo); err != nil {
+		e := fmt.Errorf("%s: Error with json.Unmarshal:  %s", funcname, err.Error())
+		SvcGridErrorReturn(w, e, funcname)
+		return
+	}
+
+	var a rlib.TaxRate
+	rlib.MigrateStructVals(&foo.Record, &a) // the variables that don't need special handling
+
+	var ok bool
+	a.BID, ok = getBIDFromBUD(w, d, foo.Record.BUD, funcname)
+	if !ok {
+		return
+	}
+
+	tax, err := rlib.GetTax(a.TAXID)
+	if err != nil || tax.BID != a.BID {
+		e := fmt.Errorf("%s: Tax %d is not valid for this business", funcname, a.TAXID)
+		SvcGridErrorReturn(w, e, funcname)
+		return
+	}
+
+	if !a.DtStop.After(a.DtStart) {
+		e := fmt.Errorf("%s: DtStop must be after DtStart", funcname)
+		SvcGridErrorReturn(w, e, funcname)
+		return
+	}
+
+	m := rlib.GetTaxRates(a.TAXID)
+	for i := 0; i < len(m); i++ {
+		if m[i].TRID != a.TRID && rlib.DateRangeOverlap(&a.DtStart, &a.DtStop, &m[i].DtStart, &m[i].DtStop) {
+			e := fmt.Errorf("%s: This rate overlaps the rate in effect from %s to %s", funcname,
+				m[i].DtStart.Format(rlib.RRDATEFMT4), m[i].DtStop.Format(rlib.RRDATEFMT4))
+			SvcGridErrorReturn(w, e, funcname)
+			return
+		}
+	}
+
+	if a.TRID == 0 && d.ID == 0 {
+		_, err = rlib.InsertTaxRate(&a)
+	} else {
+		fmt.Printf("Updating existing TaxRate: %d\n", a.TRID)
+		if old, _ := rlib.GetTaxRate(a.TRID); !svcCheckBID(w, d, old.BID, funcname) {
+			return
+		}
+		err = rlib.UpdateTaxRate(&a)
+	}
+
+	if err != nil {
+		e := fmt.Errorf("%s: Error saving TaxRate (TRID=%d): %s", funcname, a.TRID, err.Error())
+		SvcGridErrorReturn(w, e, funcname)
+		return
+	}
+
+	SvcWriteSuccessResponse(w)
+}
+
+// getTaxRate returns the requested TaxRate
+// wsdoc {
+//  @Title  Get Tax Rate
+//	@URL /v1/taxrate/:BUI/:TRID
+//  @Method  GET
+//	@Synopsis Get information on a TaxRate
+//  @Description  Return all fields for TaxRate :TRID
+//	@Input WebGridSearchRequest
+//  @Response TaxRateGetResponse
+// wsdoc }
+func getTaxRate(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	var (
+		funcname = "getTaxRate"
+		g        TaxRateGetResponse
+	)
+
+	fmt.Printf("entered %s\n", funcname)
+	a, err := rlib.GetTaxRate(d.ID)
+	if err != nil && !rlib.IsSQLNoResultsError(err) {
+		SvcGridErrorReturn(w, err, funcname)
+		return
+	}
+	if a.TRID > 0 && !svcCheckBID(w, d, a.BID, funcname) {
+		return
+	}
+	if a.TRID > 0 {
+		var gg TaxRateGrid
+		rlib.MigrateStructVals(&a, &gg)
+		gg.Recid = gg.TRID
+		gg.BUD = getBUDFromBIDList(gg.BID)
+		g.Record = gg
+	}
+	g.Status = "success"
+	SvcWriteResponse(&g, w)
+}
@@ -0,0 +1,133 @@
+package ws
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"rentroll/bizlogic"
+	"rentroll/rlib"
+	"time"
+)
+
+// TransferForm contains the data from the Assign A Rentable FORM
+type TransferForm struct {
+	BUD          rlib.XJSONBud
+	RAID         int64 // the Rental Agreement
+	RID          int64 // the Rentable being vacated
+	ToRID        int64 // the Rentable being occupied
+	Dt           rlib.JSONDate
+	ContractRent float64 // rent for ToRID, 0 = keep the current rent
+	RentARID     int64   // account rule of the recurring rent
+}
+
+// TransferInput is the input data format for a Save command
+type TransferInput struct {
+	Status   string       `json:"status"`
+	Recid    int64        `json:"recid"`
+	FormName string       `json:"name"`
+	Record   TransferForm `json:"record"`
+}
+
+// TransferAsmGrid is an assessment made by a transfer
+type TransferAsmGrid struct {
+	Recid     int64 `json:"recid"`
+	ASMID     int64
+	RID       int64
+	Amount    float64
+	Start     rlib.JSONDate
+	RentCycle int64
+	Comment   string
+}
+
+// TransferResponse is the response to a Save command
+type TransferResponse struct {
+	Status      string            `json:"status"`
+	Recid       int64             `json:"recid"` // RARID of the new RentalAgreementRentable
+	Vacancy     int               `json:"vacancy"`
+	Assessments []TransferAsmGrid `json:"assessments"`
+}
+
+// SvcHandlerTransfer transfers a resident from one Rentable to another.
+// For this call, we expect the URI to contain the BID:  /v1/transfer/:BUI
+//
+// The server command can be:
+//      save
+//-----------------------------------------------------------------------------------
+func SvcHandlerTransfer(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	var (
+		funcname = "SvcHandlerTransfer"
+		err      error
+	)
+
+	fmt.Printf("Entered %s\n", funcname)
+	fmt.Printf("Request: %s:  BID = %d\n", d.wsSearchReq.Cmd, d.BID)
+
+	switch d.wsSearchReq.Cmd {
+	case "save":
+		saveTransfer(w, r, d)
+		break
+	default:
+		err = fmt.Errorf("Unhandled command: %s", d.wsSearchReq.Cmd)
+		SvcGridErrorReturn(w, err, funcname)
+		return
+	}
+}
+
+// saveTransfer moves a resident from one Rentable to another
+// wsdoc {
+//  @Title  Resident Transfer
+//	@URL /v1/transfer/:BUI
+//  @Method  POST
+//	@Synopsis Move a resident from one Rentable to another mid-lease
+//  @Description  Ends Rentable RID on Rental Agreement RAID on Dt and starts Rentable ToRID on
+//  @Description  the agreement on Dt, moving the Rentable users. The rent for the month of Dt is
+//  @Description  prorated on both Rentables and the recurring assessments are moved to ToRID from
+//  @Description  the next month on. If ContractRent is set, the recurring assessments with account
+//  @Description  rule RentARID are charged at that amount. RID is put back online and its vacancy
+//  @Description  for the month is journaled. The response contains the new RARID and the
+//  @Description  assessments that were made.
+//	@Input TransferInput
+//  @Response TransferResponse
+// wsdoc }
+func saveTransfer(w http.ResponseWriter, r *http.Request, d *ServiceData) {
+	var (
+		funcname = "saveTransfer"
+		foo      TransferInput
+		g        TransferResponse
+	)
+
+	fmt.Printf("Entered %s\n", funcname)
+	fmt.Printf("record data = %s\n", d.data)
+
+	if err := json.Unmarshal([]byte(d.data), &foo); err != nil {
+		e := fmt.Errorf("%s: Error with json.Unmarshal:  %s", funcname, err.Error())
+		SvcGridErrorReturn(w, e, funcname)
+		return
+	}
+
+	var t bizlogic.Transfer
+	rlib.MigrateStructVals(&foo.Record, &t) // the variables that don't need special handling
+
+	var ok bool
+	if t.BID, ok = getBIDFromBUD(w, d, foo.Record.BUD, funcname); !ok {
+		return
+	}
+	t.Dt = time.Time(foo.Record.Dt)
+	t.UID = d.UID
+
+	res, errlist := bizlogic.ProcessTransfer(&t)
+	if len(errlist) > 0 {
+		SvcErrListReturn(w, errlist, funcname)
+		return
+	}
+
+	for i := 0; i < len(res.Assessments); i++ {
+		a := &res.Assessments[i]
+		g.Assessments = append(g.Assessments, TransferAsmGrid{Recid: a.ASMID, ASMID: a.ASMID, RID: a.RID, Amount: a.Amount,
+			Start: rlib.JSONDate(a.Start), RentCycle: a.RentCycle, Comment: a.Comment})
+	}
+	g.Recid = res.RAR.RARID
+	g.Vacancy = res.Vacancy
+	g.Status = "success"
+	SvcWriteResponse(&g, w)
+}
@@ -1,6 +1,8 @@
 package ws
 
 import (
+	"fmt"
+	"net/http"
 	"rentroll/rlib"
 )
 
@@ -16,3 +18,46 @@ func getBUDFromBIDList(BID int64) rlib.XJSONBud {
 	}
 	return rlib.XJSONBud(BUD)
 }
+
+// getBIDFromBUD maps the BUD of a form to a BID, writing an error response if it cannot.
+// The permission check is made against the business in the URL, so a BUD that maps to
+// any other business is rejected as well.
+func getBIDFromBUD(w http.ResponseWriter, d *ServiceData, bud rlib.XJSONBud, funcname string) (int64, bool) {
+	bid, ok := rlib.RRdb.BUDlist[string(bud)]
+	if !ok {
+		e := fmt.Errorf("%s: Could not map BID value: %s", funcname, bud)
+		rlib.Ulog("%s", e.Error())
+		SvcGridErrorReturn(w, e, funcname)
+		return bid, ok
+	}
+	if !svcCheckBID(w, d, bid, funcname) {
+		return bid, false
+	}
+	return bid, ok
+}
+
+// svcCheckBID writes an error response and returns false if bid, the business of
+// a record the request reads or changes, is not the business in the URL. The
+// permission check is made against the URL's business, so records of any other
+// business are off limits.
+func svcCheckBID(w http.ResponseWriter, d *ServiceData, bid int64, funcname string) bool {
+	if bid == d.BID {
+		return true
+	}
+	e := fmt.Errorf("%s: the record does not belong to the business of the request", funcname)
+	rlib.Ulog("%s: UID = %d, BID = %d, record BID = %d\n", e.Error(), d.UID, d.BID, bid)
+	SvcGridErrorReturn(w, e, funcname)
+	return false
+}
+
+// svcCheckRAID is svcCheckBID for Rental Agreement raid
+func svcCheckRAID(w http.ResponseWriter, d *ServiceData, raid int64, funcname string) bool {
+	ra, _ := rlib.GetRentalAgreement(raid)
+	return svcCheckBID(w, d, ra.BID, funcname)
+}
+
+// svcCheckRID is svcCheckBID for Rentable rid
+func svcCheckRID(w http.ResponseWriter, d *ServiceData, rid int64, funcname string) bool {
+	r := rlib.GetRentable(rid)
+	return svcCheckBID(w, d, r.BID, funcname)
+}
@@ -55,6 +55,7 @@ type RPerson struct {
 	OutcomeSLSID              int64         // id of string from a list of outcomes. Melissa to provide reasons
 	FloatingDeposit           float64       // d $(GLCASH) _, c $(GLGENRCV) _; assign to a shell of a Rental Agreement
 	RAID                      int64         // created to hold On Account amount of Floating Deposit
+	LSID                      int64         // LeadSource that produced this Prospect
 	Points                    int64
 	DateofBirth               rlib.JSONDate
 	EmergencyContactName      string
@@ -116,6 +117,7 @@ type RPersonForm struct {
 	OutcomeSLSID              int64         // id of string from a list of outcomes. Melissa to provide reasons
 	FloatingDeposit           float64       // d $(GLCASH) _, c $(GLGENRCV) _; assign to a shell of a Rental Agreement
 	RAID                      int64         // created to hold On Account amount of Floating Deposit
+	LSID                      int64         // LeadSource that produced this Prospect
 	Points                    int64
 	DateofBirth               rlib.JSONDate
 	EmergencyContactName      string
@@ -504,6 +506,10 @@ func saveXPerson(w http.ResponseWriter, r *http.Request, d *ServiceData) {
 		return
 	}
 
+	if !svcCheckBID(w, d, xp.Trn.BID, funcname) {
+		return
+	}
+
 	//===============================================================
 	// save or update
 	if xp.Trn.TCID == 0 {
@@ -543,6 +549,10 @@ func saveXPerson(w http.ResponseWriter, r *http.Request, d *ServiceData) {
 		}
 	} else {
 		fmt.Printf("Updating Transactant record with TCID: %d\n", xp.Trn.TCID)
+		var old rlib.Transactant
+		if rlib.GetTransactant(xp.Trn.TCID, &old); !svcCheckBID(w, d, old.BID, funcname) {
+			return
+		}
 		err = rlib.UpdateTransactant(&xp.Trn)
 		if err != nil {
 			e := fmt.Errorf("%s: UpdateTransactant error:  %s", funcname, err.Error())
@@ -588,6 +598,9 @@ func getXPerson(w http.ResponseWriter, r *http.Request, d *ServiceData) {
 	var g GetTransactantResponse
 	var xp rlib.XPerson
 	rlib.GetXPerson(d.TCID, &xp)
+	if xp.Trn.TCID > 0 && !svcCheckBID(w, d, xp.Trn.BID, "getXPerson") {
+		return
+	}
 	if xp.Pay.TCID > 0 {
 		rlib.MigrateStructVals(&xp.Pay, &g.Record)
 	}
@@ -632,6 +645,11 @@ func deleteXPerson(w http.ResponseWriter, r *http.Request, d *ServiceData) {
 
 	// fmt.Printf("del = %#v\n", del)
 
+	var t rlib.Transactant
+	if rlib.GetTransactant(del.TCID, &t); !svcCheckBID(w, d, t.BID, funcname) {
+		return
+	}
+
 	// delete Prospect
 	if err := rlib.DeleteProspect(del.TCID); err != nil {
 		SvcGridErrorReturn(w, err, funcname)
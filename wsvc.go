@@ -37,21 +37,27 @@ func v1ReportHandler(reportname string, xbiz *rlib.XBusiness, ui *RRuiSupport, w
 	funcname := "v1ReportHandler"
 	fmt.Printf("%s: reportname=%s, BID=%d,  d1 = %s, d2 = %s\n", funcname, reportname, xbiz.P.BID, ui.D1.Format(rlib.RRDATEFMT4), ui.D2.Format(rlib.RRDATEFMT4))
 
-	var ri = rrpt.ReporterInfo{OutputFormat: gotable.TABLEOUTHTML, Bid: xbiz.P.BID, D1: ui.D1, D2: ui.D2, Xbiz: xbiz, BlankLineAfterRptName: true}
+	var ri = rrpt.ReporterInfo{OutputFormat: gotable.TABLEOUTHTML, Bid: xbiz.P.BID, D1: ui.D1, D2: ui.D2, ID: ui.ID, Xbiz: xbiz, BlankLineAfterRptName: true}
 	rlib.InitBizInternals(ri.Bid, xbiz)
 
 	// handler for reports which has single table
 	var wsr = []rrpt.SingleTableReportHandler{
 		{ReportNames: []string{"RPTasmrpt", "assessments"}, TableHandler: rrpt.RRAssessmentsTable},
+		{ReportNames: []string{"RPTaudit", "audit trail"}, TableHandler: rrpt.AuditTrailReportTable},
 		{ReportNames: []string{"RPTb", "business"}, TableHandler: rrpt.RRreportBusinessTable},
+		{ReportNames: []string{"RPTbudget", "budget vs actual"}, TableHandler: rrpt.BudgetVsActualReportTable},
 		{ReportNames: []string{"RPTcoa", "chart of accounts"}, TableHandler: rrpt.RRreportChartOfAccountsTable},
+		{ReportNames: []string{"RPTcommdue", "commissions due"}, TableHandler: rrpt.CommissionsDueReportTable},
 		{ReportNames: []string{"RPTc", "custom attributes"}, TableHandler: rrpt.RRreportCustomAttributesTable},
 		{ReportNames: []string{"RPTcr", "custom attribute refs"}, TableHandler: rrpt.RRreportCustomAttributeRefsTable},
 		{ReportNames: []string{"RPTdelinq", "delinquency"}, TableHandler: rrpt.DelinquencyReportTable},
+		{ReportNames: []string{"RPTdelinqaging", "delinquency aging"}, TableHandler: rrpt.DelinquencyAgingReportTable},
 		{ReportNames: []string{"RPTdpm", "deposit methods"}, TableHandler: rrpt.RRreportDepositMethodsTable},
 		{ReportNames: []string{"RPTdep", "depositories"}, TableHandler: rrpt.RRreportDepositoryTable},
 		{ReportNames: []string{"RPTgsr", "gsr"}, TableHandler: rrpt.GSRReportTable},
 		{ReportNames: []string{"RPTj", "journals"}, TableHandler: rrpt.JournalReportTable},
+		{ReportNames: []string{"RPTmaint", "maintenance due"}, TableHandler: rrpt.MaintenanceDueReportTable},
+		{ReportNames: []string{"RPTmktattr", "marketing attribution"}, TableHandler: rrpt.MarketingAttributionReportTable},
 		{ReportNames: []string{"RPTpeople", "people"}, TableHandler: rrpt.RRreportPeopleTable},
 		{ReportNames: []string{"RPTpmt", "payment types"}, TableHandler: rrpt.RRreportPaymentTypesTable},
 		{ReportNames: []string{"RPTr", "rentables"}, TableHandler: rrpt.RRreportRentablesTable},
@@ -59,17 +65,22 @@ func v1ReportHandler(reportname string, xbiz *rlib.XBusiness, ui *RRuiSupport, w
 		{ReportNames: []string{"RPTrat", "rental agreement templates"}, TableHandler: rrpt.RRreportRentalAgreementTemplatesTable},
 		{ReportNames: []string{"RPTrcpt", "receipts"}, TableHandler: rrpt.RRReceiptsTable},
 		{ReportNames: []string{"RPTrr", "rentroll"}, TableHandler: rrpt.RentRollReportTable},
+		{ReportNames: []string{"RPTri", "rent increases"}, TableHandler: rrpt.RentIncreaseReportTable},
 		{ReportNames: []string{"RPTrt", "rentable types"}, TableHandler: rrpt.RRreportRentableTypesTable},
 		{ReportNames: []string{"RPTrcbt", "rentable type counts"}, TableHandler: rrpt.RentableCountByRentableTypeReportTable},
 		{ReportNames: []string{"RPTsl", "string lists"}, TableHandler: rrpt.RRreportStringListsTable},
 		{ReportNames: []string{"RPTt", "people"}, TableHandler: rrpt.RRreportPeopleTable},
 		{ReportNames: []string{"RPTtb", "trial balance"}, TableHandler: rrpt.LedgerBalanceReportTable},
+		{ReportNames: []string{"RPTtax", "tax liability"}, TableHandler: rrpt.TaxLiabilityReportTable},
 	}
 
 	// handler for reports which has more than one table
 	var wmr = []rrpt.MultiTableReportHandler{
 		{ReportTitle: "Ledger", ReportNames: []string{"RPTl", "ledger"}, TableHandler: rrpt.LedgerReportTable},
 		{ReportTitle: "Ledger Activity", ReportNames: []string{"RPTla", "ledger activity"}, TableHandler: rrpt.LedgerActivityReportTable},
+		{ReportTitle: "Deposit Slips", ReportNames: []string{"RPTdepslip", "deposit slips"}, TableHandler: rrpt.DepositSlipsReportTable},
+		{ReportTitle: "Invoices", ReportNames: []string{"RPTinvoice", "invoices"}, TableHandler: rrpt.InvoicesReportTable},
+		{ReportTitle: "Notices", ReportNames: []string{"RPTnotices", "notices"}, TableHandler: rrpt.NoticesReportTable},
 		{ReportTitle: "Report Statements", ReportNames: []string{"RPTstatements", "report statements"}, TableHandler: rrpt.RptStatementReportTable},
 	}
 
@@ -240,10 +251,12 @@ func v1ReportHandler(reportname string, xbiz *rlib.XBusiness, ui *RRuiSupport, w
 // webServiceHandler dispatches all the web service requests
 // This service handles requests of the form:
 //    http://x.y.z/wsvc/<uid>/<BID>?[params]
+// The <uid> element is ignored, the requester is taken from the login session.
 // where params can be:
 //	  r=<reportname>
 //    dtstart=<date>
 //    dtstop=<date>
+//    id=<record id>
 //
 func webServiceHandler(w http.ResponseWriter, r *http.Request) {
 	funcname := "webServiceHandler"
@@ -258,12 +271,6 @@ func webServiceHandler(w http.ResponseWriter, r *http.Request) {
 		fmt.Printf("r.RequestURL = %s\n", r.URL.String())
 		sa := strings.Split(r.URL.Path, "/") // ["", "wsvc", "<UID>", "<BID>"]
 		fmt.Printf("sa = %#v\n", sa)
-		d.UID, err = rlib.IntFromString(sa[2], "bad request integer value")
-		if err != nil {
-			ui.ReportContent = fmt.Sprintf("Error parsing request URI: %s", err.Error())
-			SendWebSvcPage(w, r, &ui)
-			return
-		}
 		d.BID, err = rlib.IntFromString(sa[3], "bad request integer value")
 		if err != nil {
 			ui.ReportContent = fmt.Sprintf("Error parsing request URI: %s", err.Error())
@@ -290,6 +297,11 @@ func webServiceHandler(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 		reportname = x[0]
+		if err = ws.CheckReportPermission(r, d.BID, reportname); err != nil {
+			ui.ReportContent = err.Error()
+			SendWebSvcPage(w, r, &ui)
+			return
+		}
 
 		tnow := time.Now()
 		ui.D1 = time.Date(tnow.Year(), tnow.Month(), 1, 0, 0, 0, 0, time.UTC)
@@ -347,6 +359,13 @@ func webServiceHandler(w http.ResponseWriter, r *http.Request) {
 				ui.PDFPageHeight = pdfHeight
 			}
 		}
+		// record id for reports on a single record
+		x, ok = m["id"]
+		if ok && len(x[0]) > 0 {
+			if id, err := rlib.IntFromString(x[0], "bad id value"); err == nil {
+				ui.ID = id
+			}
+		}
 		// pdf page size unit, take default `inch` as of now
 		ui.PDFPageSizeUnit = "in"
 	}